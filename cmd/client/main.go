@@ -8,23 +8,39 @@ import (
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 
 	"github.com/itohin/gophkeeper/internal/client/adapters/cli"
 	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/adapters/crypto"
 	"github.com/itohin/gophkeeper/internal/client/adapters/grpc"
+	"github.com/itohin/gophkeeper/internal/client/adapters/importers"
+	"github.com/itohin/gophkeeper/internal/client/adapters/script"
+	"github.com/itohin/gophkeeper/internal/client/adapters/sshagent"
 	"github.com/itohin/gophkeeper/internal/client/adapters/storage"
 	"github.com/itohin/gophkeeper/internal/client/adapters/websocket"
 	conf "github.com/itohin/gophkeeper/internal/client/config"
 	"github.com/itohin/gophkeeper/internal/client/entities"
 	"github.com/itohin/gophkeeper/internal/client/usecases/auth"
+	"github.com/itohin/gophkeeper/internal/client/usecases/backup"
+	"github.com/itohin/gophkeeper/internal/client/usecases/imports"
+	"github.com/itohin/gophkeeper/internal/client/usecases/organizations"
+	"github.com/itohin/gophkeeper/internal/client/usecases/report"
 	"github.com/itohin/gophkeeper/internal/client/usecases/secrets"
+	"github.com/itohin/gophkeeper/pkg/hibp"
 	"github.com/itohin/gophkeeper/pkg/jwt"
 )
 
 func main() {
 	cfg := conf.ReadConfig()
 
-	fmt.Println("cfg: ", cfg.JWT.Signature)
+	if cfg.Command == conf.CommandBreachIndex {
+		err := buildBreachIndex(cfg)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	shutdownCh := make(chan struct{})
 	authCh := make(chan string, 1)
@@ -48,31 +64,47 @@ func main() {
 	defer client.Close()
 
 	memoryStorage := storage.NewMemoryStorage()
-	authUseCase := auth.NewAuth(client, authCh)
-	secretsUseCase := secrets.NewSecrets(client, memoryStorage)
+	syncState := storage.NewFileSyncState(cfg.Sync.StatePath)
+	cipher := crypto.NewCipher()
+	authUseCase := auth.NewAuth(client, cipher, authCh)
+	organizationsUseCase := organizations.NewOrganizations(client, cipher)
+	secretsUseCase := secrets.NewSecrets(client, memoryStorage, cipher, organizationsUseCase, syncState)
+	reportUseCase := report.NewReport(secretsUseCase, hibp.NewChecker(cfg.Breaches.IndexPath))
+	importsUseCase := imports.NewImports(secretsUseCase, importers.NewParser())
+	backupUseCase := backup.NewBackup(secretsUseCase, cipher)
+
+	if script.IsCommand(cfg.Command) {
+		err = runScript(cfg, authUseCase, secretsUseCase, authCh)
+		if err != nil {
+			client.Close()
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(script.ExitCode(err))
+		}
+		return
+	}
 
 	ws := websocket.NewWSListener(
 		fmt.Sprintf("wss://%s/connect", cfg.WebSocket.ServerAddress),
 		fingerPrint,
+		cfg.WebSocket.ConnectionTimeout,
 		shutdownCh,
 		errorCh,
 		memoryStorage,
 		hydrator,
+		secretsUseCase,
 	)
 
 	go func() {
 		for {
 			select {
 			case userID := <-authCh:
-				ctx, cancel := context.WithTimeout(context.Background(), cfg.WebSocket.ConnectionTimeout)
-				defer cancel()
 				go func() {
-					err := ws.Listen(ctx, userID)
+					err := ws.Listen(context.Background(), userID)
 					if err != nil {
 						errorCh <- fmt.Errorf("ws listen error: %s", err)
 					}
 				}()
-				err = secretsUseCase.SyncSecrets(context.Background())
+				err = secretsUseCase.SyncSecrets(context.Background(), userID)
 				if err != nil {
 					errorCh <- fmt.Errorf("не удалось синхронизировать данные: %v", err)
 					log.Printf("ws listen error: %v", err)
@@ -82,14 +114,59 @@ func main() {
 	}()
 
 	p := prompt.NewPrompt()
-	app := cli.NewCli(p, authUseCase, secretsUseCase, shutdownCh, errorCh)
+	app := cli.NewCli(p, authUseCase, secretsUseCase, organizationsUseCase, reportUseCase, importsUseCase, backupUseCase, shutdownCh, errorCh)
 
-	err = app.Start()
+	if cfg.Command == conf.CommandSSHAgent {
+		err = app.StartAgent(sshagent.NewAgent(secretsUseCase), cfg.SSHAgent.SocketPath)
+	} else {
+		err = app.Start()
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
 }
 
+// buildBreachIndex converts the downloaded HIBP hashes into the index used
+// by the breach check, it works offline and does not need a login
+func buildBreachIndex(cfg *conf.AppConfig) error {
+	if len(cfg.Args) != 1 {
+		return fmt.Errorf("usage: gophkeeper breach-index <file or directory of HIBP hashes>")
+	}
+	err := os.MkdirAll(filepath.Dir(cfg.Breaches.IndexPath), 0700)
+	if err != nil {
+		return err
+	}
+	n, err := hibp.BuildIndex(cfg.Args[0], cfg.Breaches.IndexPath)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Индекс утечек создан: %s, хешей: %d\n", cfg.Breaches.IndexPath, n)
+	return nil
+}
+
+// runScript runs a non-interactive command, it syncs the vault itself and
+// does not listen to the server events
+func runScript(cfg *conf.AppConfig, authUseCase script.Auth, secretsUseCase script.Secrets, authCh <-chan string) error {
+	s := script.NewScript(
+		authUseCase,
+		secretsUseCase,
+		authCh,
+		script.Credentials{
+			Login:          cfg.Credentials.Login,
+			Password:       cfg.Credentials.Password,
+			MasterPassword: cfg.Credentials.MasterPassword,
+		},
+		os.Stdin,
+		os.Stdout,
+	)
+	return s.Run(context.Background(), cfg.Command, cfg.Args, script.Options{
+		JSON:  cfg.Flags.JSON,
+		Field: cfg.Flags.Field,
+		Name:  cfg.Flags.Name,
+		Login: cfg.Flags.Login,
+	})
+}
+
 func makeFingerPrint() (string, error) {
 	var fingerPrint string
 	hostName, err := os.Hostname()
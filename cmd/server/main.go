@@ -2,19 +2,25 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/itohin/gophkeeper/internal/server/adapters/blobstore"
 	"github.com/itohin/gophkeeper/internal/server/adapters/db/hydrator"
 	"github.com/itohin/gophkeeper/internal/server/adapters/db/postgres"
 	"github.com/itohin/gophkeeper/internal/server/adapters/grpc"
+	"github.com/itohin/gophkeeper/internal/server/adapters/jobs"
 	"github.com/itohin/gophkeeper/internal/server/adapters/websocket"
 	"github.com/itohin/gophkeeper/internal/server/config"
 	"github.com/itohin/gophkeeper/internal/server/usecases/auth"
+	"github.com/itohin/gophkeeper/internal/server/usecases/organizations"
 	"github.com/itohin/gophkeeper/internal/server/usecases/secrets"
 	"github.com/itohin/gophkeeper/pkg/database"
+	"github.com/itohin/gophkeeper/pkg/envelope"
 	"github.com/itohin/gophkeeper/pkg/events"
 	"github.com/itohin/gophkeeper/pkg/hash/password"
 	"github.com/itohin/gophkeeper/pkg/jwt"
@@ -22,19 +28,46 @@ import (
 	"github.com/itohin/gophkeeper/pkg/mailer"
 	"github.com/itohin/gophkeeper/pkg/otp"
 	"github.com/itohin/gophkeeper/pkg/uuid"
+	"github.com/spf13/pflag"
 )
 
+const rotateKEKCommand = "rotate-kek"
+
+const legacyBatchSize = 500
+
 func main() {
 
 	l := logger.NewLogger()
 
 	cfg := config.ReadConfig()
 
+	keyring, err := setupKeyring(cfg.Encryption)
+	if err != nil {
+		l.Fatal(err)
+	}
+
 	db, err := database.NewPgxPoolDB(context.Background(), cfg.DB.DSN, cfg.DB.MigrationsPath)
 	if err != nil {
 		l.Fatal(err)
 	}
 	defer db.Pool.Close()
+	keysRepo := postgres.NewKeysRepository(db, keyring, cfg.Encryption.KeyCacheTTL)
+
+	if pflag.Arg(0) == rotateKEKCommand {
+		rotateKEK(keysRepo, l, cfg.Encryption)
+		return
+	}
+
+	secretsRepo := postgres.NewSecretsRepository(db, keysRepo)
+	// rows from before the encryption at rest are encrypted before the server
+	// starts serving, the reads no longer accept plain text
+	count, err := secretsRepo.EncryptLegacyRows(context.Background(), legacyBatchSize)
+	if err != nil {
+		l.Fatal(err)
+	}
+	if count > 0 {
+		l.Infof("%d unencrypted rows encrypted", count)
+	}
 
 	jwtManager, err := jwt.NewJWTGOManager(cfg.JWT.Signature, cfg.JWT.AccessTTL, cfg.JWT.RefreshTTL)
 	if err != nil {
@@ -44,11 +77,31 @@ func main() {
 	secretEventsCh := make(chan *events.SecretEvent, 10)
 	ws := websocket.NewWSNotifier(cfg.WebSocket.Address, cfg.SSL.CertPath, cfg.SSL.KeyPath, secretEventsCh)
 
-	srv, err := setupServer(db, l, jwtManager, secretEventsCh, cfg)
+	uuidGen := uuid.NewGoogleUUIDGenerator()
+	blobs, err := blobstore.NewFSBlobStore(cfg.BlobStore.Path, keysRepo)
+	if err != nil {
+		l.Fatal(err)
+	}
+	secretsUseCase := secrets.NewSecretsUseCase(
+		uuidGen,
+		secretsRepo,
+		postgres.NewUsersRepository(db),
+		postgres.NewOrganizationsRepository(db),
+		blobs,
+		database.NewPgxTransaction(db.Pool),
+		cfg.Idempotency.KeyTTL,
+		secretEventsCh,
+	)
+
+	srv, err := setupServer(db, l, jwtManager, uuidGen, secretsUseCase, cfg)
 	if err != nil {
 		l.Fatal(err)
 	}
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	trashCleaner := jobs.NewTrashCleaner(secretsUseCase, cfg.Trash.Retention, cfg.Trash.PurgePeriod, l)
+	keysCleaner := jobs.NewIdempotencyKeysCleaner(secretsUseCase, cfg.Idempotency.PurgePeriod, l)
+
 	idleConnsClosed := make(chan struct{})
 	sigint := make(chan os.Signal, 1)
 	signal.Notify(sigint, syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT)
@@ -57,11 +110,14 @@ func main() {
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
+		stopJobs()
 		srv.Stop(ctx)
 		ws.Stop(ctx)
 		close(idleConnsClosed)
 	}()
 	go ws.Run()
+	go trashCleaner.Run(jobsCtx)
+	go keysCleaner.Run(jobsCtx)
 
 	err = srv.Start()
 	if err != nil {
@@ -76,17 +132,15 @@ func main() {
 
 }
 
-func setupServer(db *database.PgxPoolDB, l logger.Logger, jm *jwt.JWTGOManager, eventCh chan *events.SecretEvent, cfg *config.AppConfig) (*grpc.Server, error) {
-	uuidGen := uuid.NewGoogleUUIDGenerator()
-	secretsRepo := postgres.NewSecretsRepository(db)
-
+func setupServer(db *database.PgxPoolDB, l logger.Logger, jm *jwt.JWTGOManager, uuidGen *uuid.GoogleUUIDGenerator, secretsUseCase *secrets.SecretsUseCase, cfg *config.AppConfig) (*grpc.Server, error) {
 	authUseCase, err := setupAuth(db, l, jm, uuidGen, cfg)
-	secretsUseCase := secrets.NewSecretsUseCase(uuidGen, secretsRepo, eventCh)
 	if err != nil {
 		return nil, err
 	}
 
-	return grpc.NewServer(authUseCase, secretsUseCase, l, jm, hydrator.NewSecretsHydrator(), cfg), nil
+	organizationsUseCase := setupOrganizations(db, l, uuidGen, cfg)
+
+	return grpc.NewServer(authUseCase, secretsUseCase, organizationsUseCase, l, jm, hydrator.NewSecretsHydrator(), cfg), nil
 }
 
 func setupAuth(db *database.PgxPoolDB, l logger.Logger, jm *jwt.JWTGOManager, uuidGen *uuid.GoogleUUIDGenerator, cfg *config.AppConfig) (*auth.AuthUseCase, error) {
@@ -99,3 +153,42 @@ func setupAuth(db *database.PgxPoolDB, l logger.Logger, jm *jwt.JWTGOManager, uu
 
 	return auth.NewAuthUseCase(passwordHash, uuidGen, otpGen, usersRepo, sessionsRepo, smtp, jm, tx), nil
 }
+
+func setupOrganizations(db *database.PgxPoolDB, l logger.Logger, uuidGen *uuid.GoogleUUIDGenerator, cfg *config.AppConfig) *organizations.OrganizationsUseCase {
+	otpGen := otp.NewGOTPGenerator(9)
+	smtp := mailer.NewSMTPMailer(cfg.Mail.Login, cfg.Mail.Password, cfg.Mail.Host, cfg.Mail.Port, l)
+
+	return organizations.NewOrganizationsUseCase(uuidGen, otpGen, postgres.NewOrganizationsRepository(db), postgres.NewUsersRepository(db), smtp)
+}
+
+func setupKeyring(cfg *config.Encryption) (*envelope.Keyring, error) {
+	keyring, err := envelope.NewKeyring(cfg.KEKID, cfg.KEK)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.RetiredKEKs == "" {
+		return keyring, nil
+	}
+	for _, pair := range strings.Split(cfg.RetiredKEKs, ",") {
+		id, key, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok {
+			return nil, fmt.Errorf("retired key encryption key must be set as id:key")
+		}
+		if keyring.Has(id) {
+			return nil, fmt.Errorf("key encryption key %s is configured twice", id)
+		}
+		err = keyring.Add(id, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keyring, nil
+}
+
+func rotateKEK(keysRepo *postgres.KeysRepository, l logger.Logger, cfg *config.Encryption) {
+	count, err := keysRepo.Rewrap(context.Background())
+	if err != nil {
+		l.Fatal(err)
+	}
+	l.Infof("%d data keys re-wrapped with key encryption key %s, the retired keys can be removed", count, cfg.KEKID)
+}
@@ -10,197 +10,245 @@ import (
 )
 
 func (c *Cli) addText() (string, error) {
-	name, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите название: "},
-		validator.ValidateStringLength(3, 25),
-	)
+	secret := c.newSecret(entities.TypeText)
+	err := c.inputText(secret)
 	if err != nil {
 		return "", err
 	}
-	text, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите текст: "},
-		validator.ValidateStringLength(3, 500),
-	)
+	err = c.secrets.CreateSecret(context.Background(), secret)
 	if err != nil {
 		return "", err
 	}
-	notes, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите примечания: "},
-		validator.ValidateStringLength(0, 500),
-	)
+	return dataMenu, nil
+}
+
+func (c *Cli) addPassword() (string, error) {
+	secret := c.newSecret(entities.TypePassword)
+	err := c.inputPassword(secret)
 	if err != nil {
 		return "", err
 	}
-	err = c.secrets.CreateSecret(
-		context.Background(),
-		&entities.Secret{
-			Name:       name,
-			Notes:      notes,
-			SecretType: entities.TypeText,
-			Data:       text,
-		},
-	)
+	err = c.secrets.CreateSecret(context.Background(), secret)
 	if err != nil {
 		return "", err
 	}
 	return dataMenu, nil
 }
 
-func (c *Cli) addPassword() (string, error) {
-	name, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите название: "},
-		validator.ValidateStringLength(3, 25),
-	)
+func (c *Cli) addBinary() (string, error) {
+	secret := c.newSecret(entities.TypeBinary)
+	path, err := c.inputBinary(secret)
 	if err != nil {
 		return "", err
 	}
-	login, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите логин: "},
-		validator.ValidateStringLength(3, 30),
-	)
+	err = c.uploadBinary(secret, path)
 	if err != nil {
 		return "", err
 	}
-	password, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите пароль: ", Mask: 42},
-		validator.ValidateStringLength(5, 30),
-	)
+	return dataMenu, nil
+}
+
+func (c *Cli) uploadBinary(secret *entities.Secret, path string) error {
+	f, err := os.Open(path)
 	if err != nil {
-		return "", err
+		return err
 	}
-	notes, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите примечания: "},
-		validator.ValidateStringLength(0, 500),
-	)
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	p := newProgress("Загрузка файла", info.Size(), os.Stdout)
+	defer p.finish()
+	return c.secrets.UploadBinary(context.Background(), secret, &progressReader{r: f, p: p})
+}
+
+func (c *Cli) addCard() (string, error) {
+	secret := c.newSecret(entities.TypeCard)
+	err := c.inputCard(secret)
 	if err != nil {
 		return "", err
 	}
-	err = c.secrets.CreateSecret(
-		context.Background(),
-		&entities.Secret{
-			Name:       name,
-			Notes:      notes,
-			SecretType: entities.TypePassword,
-			Data: &entities.Password{
-				Login:    login,
-				Password: password,
-			},
-		},
-	)
+	err = c.secrets.CreateSecret(context.Background(), secret)
 	if err != nil {
 		return "", err
 	}
 	return dataMenu, nil
 }
 
-func (c *Cli) addBinary() (string, error) {
+func (c *Cli) inputText(secret *entities.Secret) error {
+	text, _ := secret.Data.(string)
 	name, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите название: "},
+		prompt.PromptContent{Label: "Введите название: ", Default: secret.Name},
 		validator.ValidateStringLength(3, 25),
 	)
 	if err != nil {
-		return "", err
+		return err
 	}
-	path, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите путь к файлу: "},
+	text, err = c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите текст: ", Default: text},
 		validator.ValidateStringLength(3, 500),
 	)
 	if err != nil {
-		return "", err
+		return err
 	}
-	content, err := os.ReadFile(path)
+	notes, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите примечания: ", Default: secret.Notes},
+		validator.ValidateStringLength(0, 500),
+	)
 	if err != nil {
-		return "", err
+		return err
+	}
+	secret.Name = name
+	secret.Notes = notes
+	secret.Data = text
+	return nil
+}
+
+func (c *Cli) inputPassword(secret *entities.Secret) error {
+	data, ok := secret.Data.(*entities.Password)
+	if !ok {
+		data = &entities.Password{}
+	}
+	name, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите название: ", Default: secret.Name},
+		validator.ValidateStringLength(3, 25),
+	)
+	if err != nil {
+		return err
+	}
+	login, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите логин: ", Default: data.Login},
+		validator.ValidateStringLength(3, 30),
+	)
+	if err != nil {
+		return err
+	}
+	password, err := c.inputSecretPassword(data.Password)
+	if err != nil {
+		return err
 	}
 	notes, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите примечания: "},
+		prompt.PromptContent{Label: "Введите примечания: ", Default: secret.Notes},
 		validator.ValidateStringLength(0, 500),
 	)
+	if err != nil {
+		return err
+	}
+	secret.Name = name
+	secret.Notes = notes
+	changed := &entities.Password{
+		Login:    login,
+		Password: password,
+	}
+	// a zero time makes the use case stamp the change
+	if password == data.Password {
+		changed.ChangedAt = data.ChangedAt
+	}
+	secret.Data = changed
+	return nil
+}
+
+func (c *Cli) inputBinary(secret *entities.Secret) (string, error) {
+	isNew := secret.ID == ""
+	pathPrompt := prompt.PromptContent{Label: "Введите путь к файлу: "}
+	pathValidator := validator.ValidateStringLength(3, 500)
+	if !isNew {
+		pathPrompt.Label = "Введите путь к новому файлу(оставьте пустым, чтобы не менять): "
+		pathValidator = validator.ValidateStringLength(0, 500)
+	}
+
+	name, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите название: ", Default: secret.Name},
+		validator.ValidateStringLength(3, 25),
+	)
+	if err != nil {
+		return "", err
+	}
+	path, err := c.prompt.PromptGetInput(pathPrompt, pathValidator)
 	if err != nil {
 		return "", err
 	}
-	err = c.secrets.CreateSecret(
-		context.Background(),
-		&entities.Secret{
-			Name:       name,
-			Notes:      notes,
-			SecretType: entities.TypeBinary,
-			Data:       content,
-		},
+	if path != "" {
+		_, err = os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+	}
+	notes, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите примечания: ", Default: secret.Notes},
+		validator.ValidateStringLength(0, 500),
 	)
 	if err != nil {
 		return "", err
 	}
-	return dataMenu, nil
+	secret.Name = name
+	secret.Notes = notes
+	return path, nil
 }
 
-func (c *Cli) addCard() (string, error) {
+func (c *Cli) inputCard(secret *entities.Secret) error {
+	data, ok := secret.Data.(*entities.Card)
+	if !ok {
+		data = &entities.Card{}
+	}
 	name, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите название: "},
+		prompt.PromptContent{Label: "Введите название: ", Default: secret.Name},
 		validator.ValidateStringLength(3, 25),
 	)
 	if err != nil {
-		return "", err
+		return err
 	}
 	number, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите номер карты: "},
+		prompt.PromptContent{Label: "Введите номер карты: ", Default: data.Number},
 		validator.ValidateStringLength(13, 25),
 	)
 	if err != nil {
-		return "", err
+		return err
 	}
 	expiration, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите срок окончания действия карты в формате ММ/ГГГГ: "},
+		prompt.PromptContent{Label: "Введите срок окончания действия карты в формате ММ/ГГГГ: ", Default: data.Expiration},
 		validator.ValidateCardExpiration(),
 	)
 	if err != nil {
-		return "", err
+		return err
 	}
 	code, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите cvc/cvv код карты: "},
+		prompt.PromptContent{Label: "Введите cvc/cvv код карты: ", Default: data.Code},
 		validator.ValidateStringLength(0, 3),
 	)
 	if err != nil {
-		return "", err
+		return err
 	}
 	pin, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите pin код карты: "},
+		prompt.PromptContent{Label: "Введите pin код карты: ", Default: data.Pin},
 		validator.ValidateStringLength(0, 25),
 	)
 	if err != nil {
-		return "", err
+		return err
 	}
 	ownerName, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите имя владельца карты: "},
+		prompt.PromptContent{Label: "Введите имя владельца карты: ", Default: data.OwnerName},
 		validator.ValidateStringLength(0, 25),
 	)
 	if err != nil {
-		return "", err
+		return err
 	}
 	notes, err := c.prompt.PromptGetInput(
-		prompt.PromptContent{Label: "Введите примечания: "},
+		prompt.PromptContent{Label: "Введите примечания: ", Default: secret.Notes},
 		validator.ValidateStringLength(0, 500),
 	)
 	if err != nil {
-		return "", err
+		return err
 	}
-	err = c.secrets.CreateSecret(
-		context.Background(),
-		&entities.Secret{
-			Name:       name,
-			Notes:      notes,
-			SecretType: entities.TypeCard,
-			Data: &entities.Card{
-				Number:     number,
-				Expiration: expiration,
-				Code:       code,
-				Pin:        pin,
-				OwnerName:  ownerName,
-			},
-		},
-	)
-	if err != nil {
-		return "", err
+	secret.Name = name
+	secret.Notes = notes
+	secret.Data = &entities.Card{
+		Number:     number,
+		Expiration: expiration,
+		Code:       code,
+		Pin:        pin,
+		OwnerName:  ownerName,
 	}
-	return dataMenu, nil
+	return nil
 }
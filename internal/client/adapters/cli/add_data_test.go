@@ -1,8 +1,10 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"testing"
 
@@ -166,6 +168,8 @@ func TestCli_addPassword(t *testing.T) {
 	loginPrompt := prompt.PromptContent{}
 	loginPrompt.Label = "Введите логин: "
 
+	passwordSourcePrompt := prompt.PromptContent{Label: "Пароль: "}
+
 	passwordPrompt := prompt.PromptContent{
 		Label: "Введите пароль: ",
 		Mask:  42,
@@ -301,6 +305,7 @@ func TestCli_addPassword(t *testing.T) {
 
 			prompter.EXPECT().PromptGetInput(namePrompt, gomock.Any()).Return("First pass", tt.errors["namePrompt"]).Times(tt.mockTimes["namePrompt"])
 			prompter.EXPECT().PromptGetInput(loginPrompt, gomock.Any()).Return("aaa@zzz.com", tt.errors["loginPrompt"]).Times(tt.mockTimes["loginPrompt"])
+			prompter.EXPECT().PromptGetSelect(passwordSourcePrompt, gomock.Any()).Return("enter", nil).Times(tt.mockTimes["passwordPrompt"])
 			prompter.EXPECT().PromptGetInput(passwordPrompt, gomock.Any()).Return("pass@Word1", tt.errors["passwordPrompt"]).Times(tt.mockTimes["passwordPrompt"])
 			prompter.EXPECT().PromptGetInput(notesPrompt, gomock.Any()).Return("http://aaa.zzz", tt.errors["notesPrompt"]).Times(tt.mockTimes["notesPrompt"])
 			secrets.EXPECT().CreateSecret(gomock.Any(), &entities.Secret{
@@ -358,13 +363,13 @@ func TestCli_addBinary(t *testing.T) {
 				"namePrompt":   1,
 				"pathPrompt":   0,
 				"notesPrompt":  0,
-				"createSecret": 0,
+				"uploadBinary": 0,
 			},
 			errors: map[string]error{
 				"namePrompt":   errors.New("name error"),
 				"pathPrompt":   nil,
 				"notesPrompt":  nil,
-				"createSecret": nil,
+				"uploadBinary": nil,
 			},
 			wantAction: "",
 			wantErr:    assert.Error,
@@ -375,13 +380,13 @@ func TestCli_addBinary(t *testing.T) {
 				"namePrompt":   1,
 				"pathPrompt":   1,
 				"notesPrompt":  0,
-				"createSecret": 0,
+				"uploadBinary": 0,
 			},
 			errors: map[string]error{
 				"namePrompt":   nil,
 				"pathPrompt":   errors.New("path error"),
 				"notesPrompt":  nil,
-				"createSecret": nil,
+				"uploadBinary": nil,
 			},
 			wantAction: "",
 			wantErr:    assert.Error,
@@ -392,30 +397,30 @@ func TestCli_addBinary(t *testing.T) {
 				"namePrompt":   1,
 				"pathPrompt":   1,
 				"notesPrompt":  1,
-				"createSecret": 0,
+				"uploadBinary": 0,
 			},
 			errors: map[string]error{
 				"namePrompt":   nil,
 				"pathPrompt":   nil,
 				"notesPrompt":  errors.New("notes error"),
-				"createSecret": nil,
+				"uploadBinary": nil,
 			},
 			wantAction: "",
 			wantErr:    assert.Error,
 		},
 		{
-			name: "create binary error",
+			name: "upload binary error",
 			mockTimes: map[string]int{
 				"namePrompt":   1,
 				"pathPrompt":   1,
 				"notesPrompt":  1,
-				"createSecret": 1,
+				"uploadBinary": 1,
 			},
 			errors: map[string]error{
 				"namePrompt":   nil,
 				"pathPrompt":   nil,
 				"notesPrompt":  nil,
-				"createSecret": errors.New("create error"),
+				"uploadBinary": errors.New("upload error"),
 			},
 			wantAction: "",
 			wantErr:    assert.Error,
@@ -426,13 +431,13 @@ func TestCli_addBinary(t *testing.T) {
 				"namePrompt":   1,
 				"pathPrompt":   1,
 				"notesPrompt":  1,
-				"createSecret": 1,
+				"uploadBinary": 1,
 			},
 			errors: map[string]error{
 				"namePrompt":   nil,
 				"pathPrompt":   nil,
 				"notesPrompt":  nil,
-				"createSecret": nil,
+				"uploadBinary": nil,
 			},
 			wantAction: "dataMenu",
 			wantErr:    assert.NoError,
@@ -443,12 +448,16 @@ func TestCli_addBinary(t *testing.T) {
 			prompter.EXPECT().PromptGetInput(namePrompt, gomock.Any()).Return("Binary", tt.errors["namePrompt"]).Times(tt.mockTimes["namePrompt"])
 			prompter.EXPECT().PromptGetInput(pathPrompt, gomock.Any()).Return("test_data.txt", tt.errors["pathPrompt"]).Times(tt.mockTimes["pathPrompt"])
 			prompter.EXPECT().PromptGetInput(notesPrompt, gomock.Any()).Return("binary notes", tt.errors["notesPrompt"]).Times(tt.mockTimes["notesPrompt"])
-			secrets.EXPECT().CreateSecret(gomock.Any(), &entities.Secret{
+			secrets.EXPECT().UploadBinary(gomock.Any(), &entities.Secret{
 				Name:       "Binary",
 				Notes:      "binary notes",
 				SecretType: 3,
-				Data:       []byte("Lorem ipsum..."),
-			}).Return(tt.errors["createSecret"]).Times(tt.mockTimes["createSecret"])
+			}, gomock.Any()).DoAndReturn(func(ctx context.Context, secret *entities.Secret, r io.Reader) error {
+				content, err := io.ReadAll(r)
+				assert.NoError(t, err)
+				assert.Equal(t, []byte("Lorem ipsum..."), content)
+				return tt.errors["uploadBinary"]
+			}).Times(tt.mockTimes["uploadBinary"])
 
 			_, err := c.addBinary()
 			tt.wantErr(t, err, fmt.Sprintf("AddBinary()"))
@@ -52,7 +52,7 @@ func (c *Cli) login() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return dataMenu, nil
+	return unlock, nil
 }
 
 func (c *Cli) register() (string, error) {
@@ -87,7 +87,7 @@ func (c *Cli) register() (string, error) {
 		return "", err
 	}
 
-	return dataMenu, nil
+	return unlock, nil
 }
 
 func (c *Cli) verify() (string, error) {
@@ -109,7 +109,7 @@ func (c *Cli) verify() (string, error) {
 		return "", err
 	}
 
-	return dataMenu, nil
+	return unlock, nil
 }
 
 func (c *Cli) logout() (string, error) {
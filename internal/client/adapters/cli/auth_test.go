@@ -226,7 +226,7 @@ func TestCli_Register(t *testing.T) {
 				"codePrompt":     nil,
 				"auth_verify":    nil,
 			},
-			wantAction: "dataMenu",
+			wantAction: "unlock",
 			wantErr:    assert.NoError,
 		},
 	}
@@ -329,7 +329,7 @@ func TestCli_Login(t *testing.T) {
 				"passwordPrompt": nil,
 				"auth":           nil,
 			},
-			wantAction: "dataMenu",
+			wantAction: "unlock",
 			wantErr:    assert.NoError,
 		},
 	}
@@ -429,7 +429,7 @@ func TestCli_Verify(t *testing.T) {
 				"codePrompt":  nil,
 				"auth_verify": nil,
 			},
-			wantAction: "dataMenu",
+			wantAction: "unlock",
 			wantErr:    assert.NoError,
 		},
 	}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/pkg/validator"
+)
+
+var backupExtensions = map[string]string{
+	entities.BackupEncrypted: ".gkb",
+	entities.BackupJSON:      ".json",
+	entities.BackupCSV:       ".csv",
+}
+
+func (c *Cli) backupMenu() (string, error) {
+	return c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите действие: "},
+		[]prompt.SelectItem{
+			{Label: exportBackupLabel, Action: exportBackup},
+			{Label: restoreBackupLabel, Action: restoreBackup},
+			{Label: comeBackLabel, Action: dataMenu},
+		},
+	)
+}
+
+func (c *Cli) exportBackup() (string, error) {
+	format, err := c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите формат: "},
+		[]prompt.SelectItem{
+			{Label: entities.BackupEncryptedLabel, Action: entities.BackupEncrypted},
+			{Label: entities.BackupJSONLabel, Action: entities.BackupJSON},
+			{Label: entities.BackupCSVLabel, Action: entities.BackupCSV},
+			{Label: comeBackLabel, Action: backupMenu},
+		},
+	)
+	if err != nil || format == backupMenu {
+		return format, err
+	}
+	passphrase := ""
+	if format == entities.BackupEncrypted {
+		passphrase, err = c.inputBackupPassphrase()
+		if err != nil {
+			return "", err
+		}
+		if passphrase == "" {
+			fmt.Println("Пароли не совпадают")
+			return backupMenu, nil
+		}
+	} else {
+		confirm, err := c.prompt.PromptGetSelect(
+			prompt.PromptContent{Label: "Файл будет сохранен без шифрования, любой, у кого есть к нему доступ, увидит все пароли. Продолжить? "},
+			[]prompt.SelectItem{
+				{Label: noLabel, Action: no},
+				{Label: yesLabel, Action: yes},
+			},
+		)
+		if err != nil || confirm != yes {
+			return backupMenu, err
+		}
+	}
+	path, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{
+			Label:   "Введите путь к файлу: ",
+			Default: "gophkeeper-" + time.Now().Format("2006-01-02") + backupExtensions[format],
+		},
+		validator.ValidateStringLength(1, 500),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+	result, err := c.backup.Export(context.Background(), f, format, passphrase)
+	if err == nil {
+		err = f.Close()
+	} else {
+		f.Close()
+	}
+	if err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	fmt.Printf("Сохранено записей: %d, файлов: %d\n", result.Secrets, result.Binaries)
+	if result.Skipped > 0 {
+		fmt.Printf("Файлы не поддерживаются форматом и пропущены: %d\n", result.Skipped)
+	}
+	return dataMenu, nil
+}
+
+// inputBackupPassphrase returns an empty passphrase if the confirmation
+// does not match
+func (c *Cli) inputBackupPassphrase() (string, error) {
+	passphrase, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Придумайте пароль резервной копии: ", Mask: 42},
+		validator.ValidatePassword(),
+	)
+	if err != nil {
+		return "", err
+	}
+	confirm, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Повторите пароль: ", Mask: 42},
+		validator.ValidateStringLength(1, 128),
+	)
+	if err != nil {
+		return "", err
+	}
+	if confirm != passphrase {
+		return "", nil
+	}
+	return passphrase, nil
+}
+
+func (c *Cli) restoreBackup() (string, error) {
+	path, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите путь к резервной копии: "},
+		validator.ValidateStringLength(1, 500),
+	)
+	if err != nil {
+		return "", err
+	}
+	passphrase, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите пароль резервной копии: ", Mask: 42},
+		validator.ValidateStringLength(1, 128),
+	)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var p *progress
+	result, err := c.backup.Restore(context.Background(), f, passphrase, func(done, total int) {
+		if p == nil {
+			p = newProgress("Восстановление записей", int64(total), os.Stdout)
+		}
+		p.add(done - int(p.done))
+	})
+	if p != nil {
+		p.finish()
+	}
+	if errors.Is(err, entities.ErrWrongBackupPassphrase) || errors.Is(err, entities.ErrNotBackup) {
+		fmt.Println(err.Error())
+		return backupMenu, nil
+	}
+	if err != nil {
+		if result != nil {
+			return "", fmt.Errorf("восстановление прервано после %d записей: %w", result.Secrets+result.Binaries, err)
+		}
+		return "", err
+	}
+	fmt.Printf("Восстановлено записей: %d, файлов: %d\n", result.Secrets, result.Binaries)
+	return dataMenu, nil
+}
@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCli_exportBackup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	backup := mocks.NewMockBackup(ctrl)
+
+	c := &Cli{
+		prompt: prompter,
+		backup: backup,
+	}
+
+	formatPrompt := prompt.PromptContent{Label: "Выберите формат: "}
+	confirmPrompt := prompt.PromptContent{Label: "Файл будет сохранен без шифрования, любой, у кого есть к нему доступ, увидит все пароли. Продолжить? "}
+	passphrasePrompt := prompt.PromptContent{Label: "Придумайте пароль резервной копии: ", Mask: 42}
+	repeatPrompt := prompt.PromptContent{Label: "Повторите пароль: ", Mask: 42}
+
+	tests := []struct {
+		name       string
+		format     string
+		confirm    string
+		repeat     string
+		mockTimes  map[string]int
+		errors     map[string]error
+		wantFile   bool
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "come back",
+			format: "backupMenu",
+			mockTimes: map[string]int{
+				"confirm":    0,
+				"passphrase": 0,
+				"path":       0,
+				"export":     0,
+			},
+			errors:     map[string]error{},
+			wantAction: "backupMenu",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:   "passphrases mismatch",
+			format: entities.BackupEncrypted,
+			repeat: "other",
+			mockTimes: map[string]int{
+				"confirm":    0,
+				"passphrase": 1,
+				"path":       0,
+				"export":     0,
+			},
+			errors:     map[string]error{},
+			wantAction: "backupMenu",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:    "plaintext declined",
+			format:  entities.BackupJSON,
+			confirm: "no",
+			mockTimes: map[string]int{
+				"confirm":    1,
+				"passphrase": 0,
+				"path":       0,
+				"export":     0,
+			},
+			errors:     map[string]error{},
+			wantAction: "backupMenu",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:   "export error",
+			format: entities.BackupEncrypted,
+			repeat: "Passphrase1!",
+			mockTimes: map[string]int{
+				"confirm":    0,
+				"passphrase": 1,
+				"path":       1,
+				"export":     1,
+			},
+			errors: map[string]error{
+				"export": errors.New("export error"),
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:    "plaintext confirmed",
+			format:  entities.BackupCSV,
+			confirm: "yes",
+			mockTimes: map[string]int{
+				"confirm":    1,
+				"passphrase": 0,
+				"path":       1,
+				"export":     1,
+			},
+			errors:     map[string]error{},
+			wantFile:   true,
+			wantAction: "dataMenu",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "backup")
+			passphrase := ""
+			if tt.format == entities.BackupEncrypted {
+				passphrase = "Passphrase1!"
+			}
+			prompter.EXPECT().PromptGetSelect(formatPrompt, gomock.Any()).Return(tt.format, nil).Times(1)
+			prompter.EXPECT().PromptGetSelect(confirmPrompt, gomock.Any()).Return(tt.confirm, nil).Times(tt.mockTimes["confirm"])
+			prompter.EXPECT().PromptGetInput(passphrasePrompt, gomock.Any()).Return(passphrase, nil).Times(tt.mockTimes["passphrase"])
+			prompter.EXPECT().PromptGetInput(repeatPrompt, gomock.Any()).Return(tt.repeat, nil).Times(tt.mockTimes["passphrase"])
+			prompter.EXPECT().PromptGetInput(gomock.Any(), gomock.Any()).Return(path, nil).Times(tt.mockTimes["path"])
+			backup.EXPECT().Export(gomock.Any(), gomock.Any(), tt.format, passphrase).
+				DoAndReturn(func(_ interface{}, w io.Writer, _, _ string) (*entities.BackupResult, error) {
+					_, err := w.Write([]byte("data"))
+					require.NoError(t, err)
+					return &entities.BackupResult{Secrets: 1, Skipped: 1}, tt.errors["export"]
+				}).
+				Times(tt.mockTimes["export"])
+
+			action, err := c.exportBackup()
+			if !tt.wantErr(t, err, fmt.Sprintf("exportBackup()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "exportBackup()")
+			_, err = os.Stat(path)
+			assert.Equalf(t, tt.wantFile, err == nil, "exportBackup()")
+		})
+	}
+}
+
+func TestCli_restoreBackup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	backup := mocks.NewMockBackup(ctrl)
+
+	c := &Cli{
+		prompt: prompter,
+		backup: backup,
+	}
+
+	path := filepath.Join(t.TempDir(), "backup.gkb")
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0600))
+
+	pathPrompt := prompt.PromptContent{Label: "Введите путь к резервной копии: "}
+	passphrasePrompt := prompt.PromptContent{Label: "Введите пароль резервной копии: ", Mask: 42}
+
+	tests := []struct {
+		name       string
+		mockTimes  map[string]int
+		errors     map[string]error
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "wrong passphrase",
+			mockTimes: map[string]int{
+				"restore": 1,
+			},
+			errors: map[string]error{
+				"restore": entities.ErrWrongBackupPassphrase,
+			},
+			wantAction: "backupMenu",
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "not a backup",
+			mockTimes: map[string]int{
+				"restore": 1,
+			},
+			errors: map[string]error{
+				"restore": entities.ErrNotBackup,
+			},
+			wantAction: "backupMenu",
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "restore error",
+			mockTimes: map[string]int{
+				"restore": 1,
+			},
+			errors: map[string]error{
+				"restore": errors.New("restore error"),
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "success",
+			mockTimes: map[string]int{
+				"restore": 1,
+			},
+			errors:     map[string]error{},
+			wantAction: "dataMenu",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompter.EXPECT().PromptGetInput(pathPrompt, gomock.Any()).Return(path, nil).Times(1)
+			prompter.EXPECT().PromptGetInput(passphrasePrompt, gomock.Any()).Return("passphrase", nil).Times(1)
+			backup.EXPECT().Restore(gomock.Any(), gomock.Any(), "passphrase", gomock.Any()).
+				DoAndReturn(func(_ interface{}, _ io.Reader, _ string, progress func(done, total int)) (*entities.BackupResult, error) {
+					if tt.errors["restore"] != nil {
+						return nil, tt.errors["restore"]
+					}
+					progress(2, 2)
+					return &entities.BackupResult{Secrets: 1, Binaries: 1}, nil
+				}).
+				Times(tt.mockTimes["restore"])
+
+			action, err := c.restoreBackup()
+			if !tt.wantErr(t, err, fmt.Sprintf("restoreBackup()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "restoreBackup()")
+		})
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
 	"github.com/itohin/gophkeeper/internal/client/adapters/cli/router"
@@ -18,13 +20,66 @@ type Auth interface {
 	Register(ctx context.Context, login, password string) error
 	Verify(ctx context.Context, login, otp string) error
 	Logout(ctx context.Context) error
+	HasMasterPassword(ctx context.Context) (bool, error)
+	CreateMasterPassword(ctx context.Context, password string) error
+	Unlock(ctx context.Context, password string) error
 }
 
 type Secrets interface {
 	CreateSecret(ctx context.Context, secret *entities.Secret) error
 	GetSecrets(ctx context.Context) (map[string]*entities.Secret, error)
+	SearchSecrets(ctx context.Context, filter *entities.SecretsFilter, pageToken string) (*entities.SecretsPage, error)
 	GetSecret(ctx context.Context, id string) (*entities.Secret, error)
+	GetLatestSecret(ctx context.Context, id string) (*entities.Secret, error)
 	DeleteSecret(ctx context.Context, id string) error
+	DeleteSecrets(ctx context.Context, ids []string) error
+	UpdateSecret(ctx context.Context, secret *entities.Secret) error
+	GetRevisions(ctx context.Context, secretID string) ([]*entities.Revision, error)
+	RestoreRevision(ctx context.Context, secretID, revisionID string) error
+	GetDeletedSecrets(ctx context.Context) ([]*entities.DeletedSecret, error)
+	RestoreSecret(ctx context.Context, id string) error
+	PurgeSecret(ctx context.Context, id string) error
+	UploadBinary(ctx context.Context, secret *entities.Secret, r io.Reader) error
+	DownloadBinary(ctx context.Context, secret *entities.Secret, w io.Writer) error
+	ShareSecret(ctx context.Context, secretID, email string, permission uint32) error
+	RevokeShare(ctx context.Context, secretID, userID string) error
+	GetShares(ctx context.Context, secretID string) ([]*entities.Share, error)
+	CreateFolder(ctx context.Context, name, parentID string) error
+	GetFolders(ctx context.Context) ([]*entities.Folder, error)
+	DeleteFolder(ctx context.Context, id string) error
+	GetTags(ctx context.Context) ([]string, error)
+}
+
+type Organizations interface {
+	CreateOrganization(ctx context.Context, name string) error
+	GetOrganizations(ctx context.Context) ([]*entities.Organization, error)
+	InviteMember(ctx context.Context, orgID, email string, role uint32) error
+	AcceptInvitation(ctx context.Context, code string) error
+	GetMembers(ctx context.Context, orgID string) ([]*entities.Member, error)
+	SetMemberRole(ctx context.Context, orgID, userID string, role uint32) error
+	RemoveMember(ctx context.Context, orgID, userID string) error
+	CreateCollection(ctx context.Context, orgID, name string) error
+	GetCollections(ctx context.Context, orgID string) ([]*entities.Collection, error)
+	DeleteCollection(ctx context.Context, id string) error
+}
+
+type Report interface {
+	PasswordReport(ctx context.Context, maxAge time.Duration) (*entities.PasswordReport, error)
+	BreachReport(ctx context.Context) (*entities.PasswordReport, error)
+}
+
+type Imports interface {
+	Preview(ctx context.Context, format string, r io.Reader) ([]*entities.ImportItem, error)
+	Import(ctx context.Context, items []*entities.ImportItem, skipDuplicates bool, progress func(done, total int)) (*entities.ImportResult, error)
+}
+
+type Backup interface {
+	Export(ctx context.Context, w io.Writer, format, passphrase string) (*entities.BackupResult, error)
+	Restore(ctx context.Context, r io.Reader, passphrase string, progress func(done, total int)) (*entities.BackupResult, error)
+}
+
+type SSHAgent interface {
+	Serve(ctx context.Context, socketPath string) error
 }
 
 const (
@@ -35,6 +90,7 @@ const (
 	login    = "login"
 	verify   = "verify"
 	logout   = "logout"
+	unlock   = "unlock"
 
 	registerLabel = "Регистрация"
 	loginLabel    = "Вход"
@@ -44,67 +100,286 @@ const (
 	//data
 	dataMenu         = "dataMenu"
 	getData          = "getData"
+	getDataPage      = "getDataPage"
+	searchData       = "searchData"
+	filterData       = "filterData"
+	setTypeFilter    = "setTypeFilter"
+	sortData         = "sortData"
+	setSort          = "setSort"
+	resetFilter      = "resetFilter"
 	addData          = "addData"
 	deleteData       = "deleteData"
+	editData         = "editData"
+	revisions        = "revisions"
+	showRevision     = "showRevision"
+	restoreRevision  = "restoreRevision"
+	trash            = "trash"
+	trashData        = "trashData"
+	restoreData      = "restoreData"
+	purgeData        = "purgeData"
 	addText          = "addText"
 	addPassword      = "addPassword"
 	addBinary        = "addBinary"
 	addCard          = "addCard"
+	addTOTP          = "addTOTP"
+	watchCode        = "watchCode"
+	addSSHKey        = "addSSHKey"
+	sshAgent         = "sshAgent"
 	saveBinaryToDisk = "saveBinaryToDisk"
 	showData         = "showData"
+	keepMine         = "keepMine"
+	keepTheirs       = "keepTheirs"
+	mergeVersions    = "mergeVersions"
+	shares           = "shares"
+	shareData        = "shareData"
+	revokeShare      = "revokeShare"
+	folders          = "folders"
+	showFolder       = "showFolder"
+	createFolder     = "createFolder"
+	deleteFolder     = "deleteFolder"
+	tags             = "tags"
+	setTagFilter     = "setTagFilter"
+	organizeData     = "organizeData"
+	fields           = "fields"
+	field            = "field"
+	addField         = "addField"
+	editField        = "editField"
+	removeField      = "removeField"
+	revealFields     = "revealFields"
+	passwordReport   = "passwordReport"
+	breachReport     = "breachReport"
+	importData       = "importData"
+	importFile       = "importFile"
+	backupMenu       = "backupMenu"
+	exportBackup     = "exportBackup"
+	restoreBackup    = "restoreBackup"
+	selectData       = "selectData"
+	toggleSelected   = "toggleSelected"
+	deleteSelected   = "deleteSelected"
+
+	//organizations
+	organizations      = "organizations"
+	organization       = "organization"
+	createOrganization = "createOrganization"
+	acceptInvitation   = "acceptInvitation"
+	inviteMember       = "inviteMember"
+	members            = "members"
+	member             = "member"
+	setMemberRole      = "setMemberRole"
+	removeMember       = "removeMember"
+	collection         = "collection"
+	createCollection   = "createCollection"
+	deleteCollection   = "deleteCollection"
 
 	addDataLabel          = "Сохранить данные"
 	getDataLabel          = "Получить данные"
+	nextPageLabel         = "Следующая страница"
+	searchDataLabel       = "Поиск по названию"
+	filterDataLabel       = "Фильтр по типу данных"
+	allTypesLabel         = "Все типы"
+	sortDataLabel         = "Сортировка"
+	resetFilterLabel      = "Сбросить поиск и фильтры"
 	deleteDataLabel       = "Удалить данные"
+	editDataLabel         = "Редактировать данные"
+	revisionsLabel        = "История изменений"
+	restoreRevisionLabel  = "Восстановить эту версию"
+	trashLabel            = "Корзина"
+	restoreDataLabel      = "Восстановить данные"
+	purgeDataLabel        = "Удалить навсегда"
 	addTextLabel          = "Текстовые данные"
 	addBinaryLabel        = "Бинарные данные"
 	addCardLabel          = "Данные банковской карты"
+	addTOTPLabel          = "Коды двухфакторной аутентификации"
+	watchCodeLabel        = "Показать код"
+	addSSHKeyLabel        = "SSH ключ"
+	generateEd25519Label  = "Сгенерировать ключ ed25519"
+	generateRSALabel      = "Сгенерировать ключ RSA"
+	importSSHKeyLabel     = "Импортировать из файла"
 	saveBinaryToDiskLabel = "Сохранить на диске"
 	addPasswordLabel      = "Данные для входа(логин/пароль)"
+	keepMineLabel         = "Сохранить мою версию"
+	keepTheirsLabel       = "Оставить версию с сервера"
+	mergeVersionsLabel    = "Объединить версии"
+	mineFieldLabel        = "Моя версия"
+	theirsFieldLabel      = "Версия с сервера"
+	sharesLabel           = "Совместный доступ"
+	shareDataLabel        = "Поделиться"
+	ownerLabel            = "владелец"
+	foldersLabel          = "Папки"
+	showFolderLabel       = "Показать записи"
+	createFolderLabel     = "Создать папку"
+	deleteFolderLabel     = "Удалить папку"
+	rootFolderLabel       = "Без папки"
+	tagsLabel             = "Фильтр по тегу"
+	allTagsLabel          = "Все теги"
+	organizeDataLabel     = "Папка и теги"
+	fieldsLabel           = "Дополнительные поля"
+	addFieldLabel         = "Добавить поле"
+	editFieldLabel        = "Изменить поле"
+	removeFieldLabel      = "Удалить поле"
+	revealFieldsLabel     = "Показать скрытые поля"
+
+	organizationsLabel      = "Организации"
+	createOrganizationLabel = "Создать организацию"
+	acceptInvitationLabel   = "Принять приглашение"
+	inviteMemberLabel       = "Пригласить участника"
+	membersLabel            = "Участники"
+	setMemberRoleLabel      = "Изменить роль"
+	removeMemberLabel       = "Исключить из организации"
+	createCollectionLabel   = "Создать коллекцию"
+	deleteCollectionLabel   = "Удалить коллекцию"
 
 	comeBackLabel = "Вернуться назад"
+
+	enterPasswordLabel         = "Ввести пароль"
+	generatePasswordLabel      = "Сгенерировать пароль"
+	randomPasswordLabel        = "Случайный пароль"
+	pronounceablePasswordLabel = "Произносимый пароль"
+	passphrasePasswordLabel    = "Парольная фраза из слов"
+	useGeneratedLabel          = "Использовать этот пароль"
+	regenerateLabel            = "Сгенерировать другой"
+	charsetSymbolsLabel        = "Буквы, цифры и спецсимволы"
+	charsetLettersLabel        = "Буквы и цифры"
+	charsetLowerLabel          = "Строчные буквы и цифры"
+	charsetDigitsLabel         = "Только цифры"
+	yesLabel                   = "Да"
+	noLabel                    = "Нет"
+
+	passwordReportLabel = "Проверка паролей"
+	breachReportLabel   = "Проверка паролей по базе утечек"
+
+	importDataLabel     = "Импорт из других менеджеров паролей"
+	importAllLabel      = "Импортировать все"
+	skipDuplicatesLabel = "Импортировать без дубликатов"
+	cancelImportLabel   = "Отменить"
+	duplicateLabel      = "дубликат"
+	newImportLabel      = "новая"
+
+	backupLabel        = "Резервная копия"
+	exportBackupLabel  = "Создать резервную копию"
+	restoreBackupLabel = "Восстановить из резервной копии"
+
+	selectDataLabel     = "Выбрать записи для удаления"
+	deleteSelectedLabel = "Удалить выбранные"
+
+	dateTimeLayout = "02.01.2006 15:04:05"
 )
 
 type Cli struct {
-	router     *router.Router
-	prompt     prompt.Prompter
-	auth       Auth
-	secrets    Secrets
-	shutdownCh chan struct{}
-	errorCh    chan error
+	router        *router.Router
+	prompt        prompt.Prompter
+	auth          Auth
+	secrets       Secrets
+	organizations Organizations
+	report        Report
+	imports       Imports
+	backup        Backup
+	filter        entities.SecretsFilter
+	collection    *entities.Collection
+	selected      map[string]struct{}
+	agent         SSHAgent
+	agentSocket   string
+	shutdownCh    chan struct{}
+	errorCh       chan error
 }
 
 func NewCli(
 	prompt prompt.Prompter,
 	auth Auth,
 	secrets Secrets,
+	orgs Organizations,
+	report Report,
+	imports Imports,
+	backup Backup,
 	shutdownCh chan struct{},
 	errorCh chan error,
 ) *Cli {
 	cli := &Cli{
-		prompt:     prompt,
-		auth:       auth,
-		secrets:    secrets,
-		shutdownCh: shutdownCh,
-		errorCh:    errorCh,
+		prompt:        prompt,
+		auth:          auth,
+		secrets:       secrets,
+		organizations: orgs,
+		report:        report,
+		imports:       imports,
+		backup:        backup,
+		shutdownCh:    shutdownCh,
+		errorCh:       errorCh,
 	}
 
 	cli.router = router.NewRouter(
 		map[string]router.Command{
-			authMenu:    cli.authMenu,
-			register:    cli.register,
-			login:       cli.login,
-			verify:      cli.verify,
-			logout:      cli.logout,
-			dataMenu:    cli.dataMenu,
-			getData:     cli.getData,
-			addData:     cli.addData,
-			addText:     cli.addText,
-			addCard:     cli.addCard,
-			addPassword: cli.addPassword,
-			addBinary:   cli.addBinary,
-			showData:    cli.showData,
-			deleteData:  cli.deleteData,
+			authMenu:           cli.authMenu,
+			register:           cli.register,
+			login:              cli.login,
+			verify:             cli.verify,
+			logout:             cli.logout,
+			unlock:             cli.unlock,
+			dataMenu:           cli.dataMenu,
+			getData:            cli.getData,
+			getDataPage:        cli.getDataPage,
+			searchData:         cli.searchData,
+			filterData:         cli.filterData,
+			setTypeFilter:      cli.setTypeFilter,
+			sortData:           cli.sortData,
+			setSort:            cli.setSort,
+			resetFilter:        cli.resetFilter,
+			addData:            cli.addData,
+			addText:            cli.addText,
+			addCard:            cli.addCard,
+			addTOTP:            cli.addTOTP,
+			watchCode:          cli.watchCode,
+			addSSHKey:          cli.addSSHKey,
+			sshAgent:           cli.sshAgent,
+			addPassword:        cli.addPassword,
+			addBinary:          cli.addBinary,
+			showData:           cli.showData,
+			deleteData:         cli.deleteData,
+			editData:           cli.editData,
+			revisions:          cli.revisions,
+			showRevision:       cli.showRevision,
+			restoreRevision:    cli.restoreRevision,
+			trash:              cli.trash,
+			trashData:          cli.trashData,
+			restoreData:        cli.restoreData,
+			purgeData:          cli.purgeData,
+			shares:             cli.shares,
+			shareData:          cli.shareData,
+			revokeShare:        cli.revokeShare,
+			folders:            cli.folders,
+			showFolder:         cli.showFolder,
+			createFolder:       cli.createFolder,
+			deleteFolder:       cli.deleteFolder,
+			tags:               cli.tags,
+			setTagFilter:       cli.setTagFilter,
+			organizeData:       cli.organizeData,
+			fields:             cli.fields,
+			field:              cli.field,
+			addField:           cli.addField,
+			editField:          cli.editField,
+			removeField:        cli.removeField,
+			revealFields:       cli.revealFields,
+			passwordReport:     cli.passwordReport,
+			breachReport:       cli.breachReport,
+			importData:         cli.importData,
+			importFile:         cli.importFile,
+			backupMenu:         cli.backupMenu,
+			exportBackup:       cli.exportBackup,
+			restoreBackup:      cli.restoreBackup,
+			selectData:         cli.selectData,
+			toggleSelected:     cli.toggleSelected,
+			deleteSelected:     cli.deleteSelected,
+			organizations:      cli.organizationsList,
+			organization:       cli.organization,
+			createOrganization: cli.createOrganization,
+			acceptInvitation:   cli.acceptInvitation,
+			inviteMember:       cli.inviteMember,
+			members:            cli.members,
+			member:             cli.member,
+			setMemberRole:      cli.setMemberRole,
+			removeMember:       cli.removeMember,
+			collection:         cli.collectionMenu,
+			createCollection:   cli.createCollection,
+			deleteCollection:   cli.deleteCollection,
 		},
 	)
 
@@ -1,8 +1,14 @@
 package cli
 
-import "github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+import (
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
 
 func (c *Cli) dataMenu() (string, error) {
+	// the main menu works with the personal vault again
+	c.collection = nil
+	c.filter.CollectionID = ""
 	return c.prompt.PromptGetSelect(
 		prompt.PromptContent{Label: "Выберите действие: "},
 		[]prompt.SelectItem{
@@ -14,6 +20,30 @@ func (c *Cli) dataMenu() (string, error) {
 				Label:  getDataLabel,
 				Action: getData,
 			},
+			{
+				Label:  importDataLabel,
+				Action: importData,
+			},
+			{
+				Label:  backupLabel,
+				Action: backupMenu,
+			},
+			{
+				Label:  organizationsLabel,
+				Action: organizations,
+			},
+			{
+				Label:  passwordReportLabel,
+				Action: passwordReport,
+			},
+			{
+				Label:  breachReportLabel,
+				Action: breachReport,
+			},
+			{
+				Label:  trashLabel,
+				Action: trash,
+			},
 			{
 				Label:  logoutLabel,
 				Action: logout,
@@ -37,6 +67,14 @@ func (c *Cli) addData() (string, error) {
 				Label:  addCardLabel,
 				Action: addCard,
 			},
+			{
+				Label:  addTOTPLabel,
+				Action: addTOTP,
+			},
+			{
+				Label:  addSSHKeyLabel,
+				Action: addSSHKey,
+			},
 			{
 				Label:  addBinaryLabel,
 				Action: addBinary,
@@ -47,3 +85,13 @@ func (c *Cli) addData() (string, error) {
 			},
 		})
 }
+
+// newSecret targets the collection the user came from, if any
+func (c *Cli) newSecret(secretType uint32) *entities.Secret {
+	secret := &entities.Secret{SecretType: secretType}
+	if c.collection != nil {
+		secret.CollectionID = c.collection.ID
+		secret.OrganizationID = c.collection.OrganizationID
+	}
+	return secret
+}
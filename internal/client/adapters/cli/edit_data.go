@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+func (c *Cli) editData(id string) (string, error) {
+	s, err := c.secrets.GetSecret(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	secret := *s
+	switch secret.SecretType {
+	case entities.TypePassword:
+		err = c.inputPassword(&secret)
+	case entities.TypeText:
+		err = c.inputText(&secret)
+	case entities.TypeBinary:
+		return c.editBinary(&secret)
+	case entities.TypeCard:
+		err = c.inputCard(&secret)
+	case entities.TypeTOTP:
+		err = c.inputTOTP(&secret)
+	case entities.TypeSSHKey:
+		err = c.inputSSHKey(&secret)
+	default:
+		return "", fmt.Errorf("unknown secret type %v", secret.SecretType)
+	}
+	if err != nil {
+		return "", err
+	}
+	err = c.updateSecret(&secret)
+	if errors.Is(err, entities.ErrVersionConflict) {
+		return c.resolveConflict(&secret, c.updateSecret)
+	}
+	if err != nil {
+		return "", err
+	}
+	return showData + "/" + secret.ID, nil
+}
+
+func (c *Cli) editBinary(secret *entities.Secret) (string, error) {
+	path, err := c.inputBinary(secret)
+	if err != nil {
+		return "", err
+	}
+	save := c.updateSecret
+	if path != "" {
+		save = func(s *entities.Secret) error {
+			return c.uploadBinary(s, path)
+		}
+	}
+	err = save(secret)
+	if errors.Is(err, entities.ErrVersionConflict) {
+		return c.resolveConflict(secret, save)
+	}
+	if err != nil {
+		return "", err
+	}
+	return showData + "/" + secret.ID, nil
+}
+
+func (c *Cli) updateSecret(secret *entities.Secret) error {
+	return c.secrets.UpdateSecret(context.Background(), secret)
+}
+
+func (c *Cli) resolveConflict(mine *entities.Secret, save func(secret *entities.Secret) error) (string, error) {
+	for {
+		theirs, err := c.secrets.GetLatestSecret(context.Background(), mine.ID)
+		if err != nil {
+			return "", err
+		}
+		fmt.Println("\n", entities.ErrVersionConflict.Error())
+		fmt.Println("\nВерсия на сервере:")
+		err = printSecret(theirs)
+		if err != nil {
+			return "", err
+		}
+		fmt.Println("\nВаша версия:")
+		err = printSecret(mine)
+		if err != nil {
+			return "", err
+		}
+
+		choice, err := c.prompt.PromptGetSelect(
+			prompt.PromptContent{Label: "Выберите действие: "},
+			[]prompt.SelectItem{
+				{Label: keepMineLabel, Action: keepMine},
+				{Label: keepTheirsLabel, Action: keepTheirs},
+				{Label: mergeVersionsLabel, Action: mergeVersions},
+			},
+		)
+		if err != nil {
+			return "", err
+		}
+		resolved := *mine
+		switch choice {
+		case keepTheirs:
+			return showData + "/" + theirs.ID, nil
+		case mergeVersions:
+			resolved, err = c.mergeVersions(mine, theirs)
+			if err != nil {
+				return "", err
+			}
+		}
+		resolved.Version = theirs.Version
+
+		err = save(&resolved)
+		if errors.Is(err, entities.ErrVersionConflict) {
+			mine = &resolved
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return showData + "/" + theirs.ID, nil
+	}
+}
+
+func (c *Cli) mergeVersions(mine, theirs *entities.Secret) (entities.Secret, error) {
+	merged := *theirs
+	fields := []struct {
+		label string
+		equal bool
+		take  func()
+	}{
+		{"Название", mine.Name == theirs.Name, func() { merged.Name = mine.Name }},
+		{"Данные", reflect.DeepEqual(mine.Data, theirs.Data), func() { merged.Data = mine.Data }},
+		{"Примечания", mine.Notes == theirs.Notes, func() { merged.Notes = mine.Notes }},
+		{"Дополнительные поля", equalFields(mine.Fields, theirs.Fields), func() { merged.Fields = mine.Fields }},
+	}
+	for _, f := range fields {
+		if f.equal {
+			continue
+		}
+		choice, err := c.prompt.PromptGetSelect(
+			prompt.PromptContent{Label: f.label + ": "},
+			[]prompt.SelectItem{
+				{Label: mineFieldLabel, Action: keepMine},
+				{Label: theirsFieldLabel, Action: keepTheirs},
+			},
+		)
+		if err != nil {
+			return merged, err
+		}
+		if choice == keepMine {
+			f.take()
+		}
+	}
+	return merged, nil
+}
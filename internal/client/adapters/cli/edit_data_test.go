@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_editData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		prompt:  prompter,
+		secrets: secrets,
+	}
+
+	namePrompt := prompt.PromptContent{Label: "Введите название: ", Default: "First"}
+	textPrompt := prompt.PromptContent{Label: "Введите текст: ", Default: "Lorem ipsum..."}
+	notesPrompt := prompt.PromptContent{Label: "Введите примечания: ", Default: "first notes"}
+
+	tests := []struct {
+		name       string
+		mockTimes  map[string]int
+		errors     map[string]error
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "get secret error",
+			mockTimes: map[string]int{
+				"prompt":       0,
+				"updateSecret": 0,
+			},
+			errors: map[string]error{
+				"getSecret":    errors.New("get secret error"),
+				"updateSecret": nil,
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "update error",
+			mockTimes: map[string]int{
+				"prompt":       1,
+				"updateSecret": 1,
+			},
+			errors: map[string]error{
+				"getSecret":    nil,
+				"updateSecret": errors.New("update error"),
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "success",
+			mockTimes: map[string]int{
+				"prompt":       1,
+				"updateSecret": 1,
+			},
+			errors: map[string]error{
+				"getSecret":    nil,
+				"updateSecret": nil,
+			},
+			wantAction: "showData/text",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &entities.Secret{
+				ID:         "text",
+				Name:       "First",
+				SecretType: entities.TypeText,
+				Data:       "Lorem ipsum...",
+				Notes:      "first notes",
+			}
+
+			secrets.EXPECT().GetSecret(gomock.Any(), "text").Return(secret, tt.errors["getSecret"]).Times(1)
+			prompter.EXPECT().PromptGetInput(namePrompt, gomock.Any()).Return("Second", nil).Times(tt.mockTimes["prompt"])
+			prompter.EXPECT().PromptGetInput(textPrompt, gomock.Any()).Return("Dolor sit amet", nil).Times(tt.mockTimes["prompt"])
+			prompter.EXPECT().PromptGetInput(notesPrompt, gomock.Any()).Return("second notes", nil).Times(tt.mockTimes["prompt"])
+			secrets.EXPECT().UpdateSecret(gomock.Any(), &entities.Secret{
+				ID:         "text",
+				Name:       "Second",
+				SecretType: entities.TypeText,
+				Data:       "Dolor sit amet",
+				Notes:      "second notes",
+			}).Return(tt.errors["updateSecret"]).Times(tt.mockTimes["updateSecret"])
+
+			action, err := c.editData("text")
+			if !tt.wantErr(t, err, fmt.Sprintf("editData()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "editData()")
+			assert.Equal(t, "First", secret.Name)
+		})
+	}
+}
+
+func TestCli_editDataConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		prompt:  prompter,
+		secrets: secrets,
+	}
+
+	namePrompt := prompt.PromptContent{Label: "Введите название: ", Default: "First"}
+	textPrompt := prompt.PromptContent{Label: "Введите текст: ", Default: "Lorem ipsum..."}
+	notesPrompt := prompt.PromptContent{Label: "Введите примечания: ", Default: "first notes"}
+	conflictPrompt := prompt.PromptContent{Label: "Выберите действие: "}
+	conflictItems := []prompt.SelectItem{
+		{Label: keepMineLabel, Action: keepMine},
+		{Label: keepTheirsLabel, Action: keepTheirs},
+		{Label: mergeVersionsLabel, Action: mergeVersions},
+	}
+	fieldItems := []prompt.SelectItem{
+		{Label: mineFieldLabel, Action: keepMine},
+		{Label: theirsFieldLabel, Action: keepTheirs},
+	}
+
+	tests := []struct {
+		name       string
+		choice     string
+		fields     map[string]string
+		wantSaved  *entities.Secret
+		wantAction string
+	}{
+		{
+			name:       "keep server version",
+			choice:     keepTheirs,
+			wantAction: "showData/text",
+		},
+		{
+			name:   "keep my version",
+			choice: keepMine,
+			wantSaved: &entities.Secret{
+				ID:         "text",
+				Name:       "Second",
+				SecretType: entities.TypeText,
+				Data:       "Dolor sit amet",
+				Notes:      "second notes",
+				Version:    2,
+			},
+			wantAction: "showData/text",
+		},
+		{
+			name:   "merge versions",
+			choice: mergeVersions,
+			fields: map[string]string{
+				"Название":   keepMine,
+				"Данные":     keepTheirs,
+				"Примечания": keepMine,
+			},
+			wantSaved: &entities.Secret{
+				ID:         "text",
+				Name:       "Second",
+				SecretType: entities.TypeText,
+				Data:       "Server text",
+				Notes:      "second notes",
+				Version:    2,
+			},
+			wantAction: "showData/text",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &entities.Secret{
+				ID:         "text",
+				Name:       "First",
+				SecretType: entities.TypeText,
+				Data:       "Lorem ipsum...",
+				Notes:      "first notes",
+				Version:    1,
+			}
+			latest := &entities.Secret{
+				ID:         "text",
+				Name:       "Server name",
+				SecretType: entities.TypeText,
+				Data:       "Server text",
+				Notes:      "server notes",
+				Version:    2,
+			}
+
+			secrets.EXPECT().GetSecret(gomock.Any(), "text").Return(secret, nil).Times(1)
+			prompter.EXPECT().PromptGetInput(namePrompt, gomock.Any()).Return("Second", nil).Times(1)
+			prompter.EXPECT().PromptGetInput(textPrompt, gomock.Any()).Return("Dolor sit amet", nil).Times(1)
+			prompter.EXPECT().PromptGetInput(notesPrompt, gomock.Any()).Return("second notes", nil).Times(1)
+			secrets.EXPECT().UpdateSecret(gomock.Any(), &entities.Secret{
+				ID:         "text",
+				Name:       "Second",
+				SecretType: entities.TypeText,
+				Data:       "Dolor sit amet",
+				Notes:      "second notes",
+				Version:    1,
+			}).Return(entities.ErrVersionConflict).Times(1)
+			secrets.EXPECT().GetLatestSecret(gomock.Any(), "text").Return(latest, nil).Times(1)
+			prompter.EXPECT().PromptGetSelect(conflictPrompt, conflictItems).Return(tt.choice, nil).Times(1)
+			for label, choice := range tt.fields {
+				prompter.EXPECT().PromptGetSelect(prompt.PromptContent{Label: label + ": "}, fieldItems).Return(choice, nil).Times(1)
+			}
+			if tt.wantSaved != nil {
+				secrets.EXPECT().UpdateSecret(gomock.Any(), tt.wantSaved).Return(nil).Times(1)
+			}
+
+			action, err := c.editData("text")
+			assert.NoError(t, err)
+			assert.Equalf(t, tt.wantAction, action, "editData()")
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/pkg/validator"
+)
+
+func (c *Cli) fields(id string) (string, error) {
+	s, err := c.secrets.GetSecret(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	menu := make([]prompt.SelectItem, 0, len(s.Fields)+2)
+	for i, f := range s.Fields {
+		menu = append(menu, prompt.SelectItem{
+			Label:  f.Name + ": " + f.DisplayValue(false) + " (" + entities.FieldLabel(f.Type) + ")",
+			Action: field + "/" + id + "/" + strconv.Itoa(i),
+		})
+	}
+	menu = append(menu,
+		prompt.SelectItem{
+			Label:  addFieldLabel,
+			Action: addField + "/" + id,
+		},
+		prompt.SelectItem{
+			Label:  comeBackLabel,
+			Action: showData + "/" + id,
+		},
+	)
+
+	return c.prompt.PromptGetSelect(prompt.PromptContent{Label: "Выберите поле: "}, menu)
+}
+
+func (c *Cli) field(id, index string) (string, error) {
+	return c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите действие: "},
+		[]prompt.SelectItem{
+			{
+				Label:  editFieldLabel,
+				Action: editField + "/" + id + "/" + index,
+			},
+			{
+				Label:  removeFieldLabel,
+				Action: removeField + "/" + id + "/" + index,
+			},
+			{
+				Label:  comeBackLabel,
+				Action: fields + "/" + id,
+			},
+		})
+}
+
+func (c *Cli) addField(id string) (string, error) {
+	s, err := c.secrets.GetSecret(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	f, err := c.inputField(entities.CustomField{})
+	if err != nil {
+		return "", err
+	}
+	secret := *s
+	secret.Fields = append(append(make([]entities.CustomField, 0, len(s.Fields)+1), s.Fields...), f)
+	return c.saveFields(&secret)
+}
+
+func (c *Cli) editField(id, index string) (string, error) {
+	s, err := c.secrets.GetSecret(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	i, err := fieldIndex(s, index)
+	if err != nil {
+		return "", err
+	}
+	f, err := c.inputField(s.Fields[i])
+	if err != nil {
+		return "", err
+	}
+	secret := *s
+	secret.Fields = append(make([]entities.CustomField, 0, len(s.Fields)), s.Fields...)
+	secret.Fields[i] = f
+	return c.saveFields(&secret)
+}
+
+func (c *Cli) removeField(id, index string) (string, error) {
+	s, err := c.secrets.GetSecret(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	i, err := fieldIndex(s, index)
+	if err != nil {
+		return "", err
+	}
+	secret := *s
+	secret.Fields = append(append(make([]entities.CustomField, 0, len(s.Fields)-1), s.Fields[:i]...), s.Fields[i+1:]...)
+	return c.saveFields(&secret)
+}
+
+func (c *Cli) revealFields(id string) (string, error) {
+	s, err := c.secrets.GetSecret(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	printFields(s, true)
+	return c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите действие: "},
+		secretMenu(s),
+	)
+}
+
+func (c *Cli) saveFields(secret *entities.Secret) (string, error) {
+	err := c.updateSecret(secret)
+	if errors.Is(err, entities.ErrVersionConflict) {
+		return c.resolveConflict(secret, c.updateSecret)
+	}
+	if err != nil {
+		return "", err
+	}
+	return fields + "/" + secret.ID, nil
+}
+
+func (c *Cli) inputField(f entities.CustomField) (entities.CustomField, error) {
+	name, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите название поля: ", Default: f.Name},
+		validator.ValidateStringLength(1, entities.MaxFieldNameLength),
+	)
+	if err != nil {
+		return f, err
+	}
+	fieldType, err := c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите тип поля: "},
+		[]prompt.SelectItem{
+			{Label: entities.FieldTextLabel, Action: strconv.Itoa(entities.FieldText)},
+			{Label: entities.FieldHiddenLabel, Action: strconv.Itoa(entities.FieldHidden)},
+			{Label: entities.FieldURLLabel, Action: strconv.Itoa(entities.FieldURL)},
+			{Label: entities.FieldDateLabel, Action: strconv.Itoa(entities.FieldDate)},
+		},
+	)
+	if err != nil {
+		return f, err
+	}
+	t, err := strconv.ParseUint(fieldType, 10, 32)
+	if err != nil {
+		return f, err
+	}
+
+	valuePrompt := prompt.PromptContent{Label: "Введите значение: ", Default: f.Value}
+	validate := validator.ValidateStringLength(0, entities.MaxFieldLength)
+	switch t {
+	case entities.FieldHidden:
+		valuePrompt.Mask = 42
+	case entities.FieldURL:
+		validate = validator.ValidateURL()
+	case entities.FieldDate:
+		valuePrompt.Label = "Введите дату (дд.мм.гггг): "
+		validate = validator.ValidateDate(entities.FieldDateLayout)
+	}
+	value, err := c.prompt.PromptGetInput(valuePrompt, validate)
+	if err != nil {
+		return f, err
+	}
+	return entities.CustomField{Name: name, Value: value, Type: uint32(t)}, nil
+}
+
+func printFields(secret *entities.Secret, reveal bool) {
+	for _, f := range secret.Fields {
+		fmt.Println(f.Name+": ", f.DisplayValue(reveal))
+	}
+}
+
+func hasHiddenFields(secret *entities.Secret) bool {
+	for _, f := range secret.Fields {
+		if f.Type == entities.FieldHidden {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldIndex(secret *entities.Secret, index string) (int, error) {
+	i, err := strconv.Atoi(index)
+	if err != nil {
+		return 0, err
+	}
+	if i < 0 || i >= len(secret.Fields) {
+		return 0, fmt.Errorf("field %v of secret %v not found", index, secret.ID)
+	}
+	return i, nil
+}
+
+func equalFields(mine, theirs []entities.CustomField) bool {
+	return len(mine) == 0 && len(theirs) == 0 || reflect.DeepEqual(mine, theirs)
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_fields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		prompt:  prompter,
+		secrets: secrets,
+	}
+
+	secret := &entities.Secret{
+		ID: "password",
+		Fields: []entities.CustomField{
+			{Name: "Вопрос", Value: "Кличка собаки", Type: entities.FieldText},
+			{Name: "Ответ", Value: "Шарик", Type: entities.FieldHidden},
+		},
+	}
+	secrets.EXPECT().GetSecret(gomock.Any(), "password").Return(secret, nil).Times(1)
+	prompter.EXPECT().PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите поле: "},
+		[]prompt.SelectItem{
+			{Label: "Вопрос: Кличка собаки (Текст)", Action: "field/password/0"},
+			{Label: "Ответ: ******** (Скрытое поле)", Action: "field/password/1"},
+			{Label: "Добавить поле", Action: "addField/password"},
+			{Label: "Вернуться назад", Action: "showData/password"},
+		},
+	).Return("field/password/1", nil).Times(1)
+
+	action, err := c.fields("password")
+	assert.NoError(t, err)
+	assert.Equal(t, "field/password/1", action)
+}
+
+func TestCli_addField(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		prompt:  prompter,
+		secrets: secrets,
+	}
+
+	tests := []struct {
+		name       string
+		errors     map[string]error
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "update error",
+			errors: map[string]error{
+				"updateSecret": errors.New("update error"),
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:       "success",
+			errors:     map[string]error{},
+			wantAction: "fields/password",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &entities.Secret{
+				ID:     "password",
+				Fields: []entities.CustomField{{Name: "Вопрос", Value: "Кличка собаки"}},
+			}
+			secrets.EXPECT().GetSecret(gomock.Any(), "password").Return(secret, nil).Times(1)
+			prompter.EXPECT().PromptGetInput(prompt.PromptContent{Label: "Введите название поля: "}, gomock.Any()).Return("Ответ", nil).Times(1)
+			prompter.EXPECT().PromptGetSelect(prompt.PromptContent{Label: "Выберите тип поля: "}, gomock.Any()).Return("1", nil).Times(1)
+			prompter.EXPECT().PromptGetInput(prompt.PromptContent{Label: "Введите значение: ", Mask: 42}, gomock.Any()).Return("Шарик", nil).Times(1)
+			secrets.EXPECT().UpdateSecret(gomock.Any(), &entities.Secret{
+				ID: "password",
+				Fields: []entities.CustomField{
+					{Name: "Вопрос", Value: "Кличка собаки"},
+					{Name: "Ответ", Value: "Шарик", Type: entities.FieldHidden},
+				},
+			}).Return(tt.errors["updateSecret"]).Times(1)
+
+			action, err := c.addField("password")
+			if !tt.wantErr(t, err, fmt.Sprintf("addField()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "addField()")
+			assert.Len(t, secret.Fields, 1)
+		})
+	}
+}
+
+func TestCli_removeField(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		secrets: secrets,
+	}
+
+	secret := &entities.Secret{
+		ID: "password",
+		Fields: []entities.CustomField{
+			{Name: "Вопрос", Value: "Кличка собаки"},
+			{Name: "Ответ", Value: "Шарик", Type: entities.FieldHidden},
+		},
+	}
+	secrets.EXPECT().GetSecret(gomock.Any(), "password").Return(secret, nil).Times(2)
+	secrets.EXPECT().UpdateSecret(gomock.Any(), &entities.Secret{
+		ID:     "password",
+		Fields: []entities.CustomField{{Name: "Ответ", Value: "Шарик", Type: entities.FieldHidden}},
+	}).Return(nil).Times(1)
+
+	action, err := c.removeField("password", "0")
+	assert.NoError(t, err)
+	assert.Equal(t, "fields/password", action)
+	assert.Len(t, secret.Fields, 2)
+
+	_, err = c.removeField("password", "2")
+	assert.Error(t, err)
+}
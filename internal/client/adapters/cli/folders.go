@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/pkg/validator"
+)
+
+func (c *Cli) folders(parentID string) (string, error) {
+	list, err := c.secrets.GetFolders(context.Background())
+	if err != nil {
+		return "", err
+	}
+	back := getData
+	menu := make([]prompt.SelectItem, 0, len(list)+4)
+	for _, f := range list {
+		if f.ID == parentID {
+			back = folders + "/" + f.ParentID
+		}
+		if f.ParentID == parentID {
+			menu = append(menu, prompt.SelectItem{
+				Label:  f.Name + "/",
+				Action: folders + "/" + f.ID,
+			})
+		}
+	}
+	if parentID != "" {
+		menu = append(menu, prompt.SelectItem{
+			Label:  showFolderLabel,
+			Action: showFolder + "/" + parentID,
+		})
+	}
+	menu = append(menu, prompt.SelectItem{
+		Label:  createFolderLabel,
+		Action: createFolder + "/" + parentID,
+	})
+	if parentID != "" {
+		menu = append(menu, prompt.SelectItem{
+			Label:  deleteFolderLabel,
+			Action: deleteFolder + "/" + parentID,
+		})
+	}
+	menu = append(menu, prompt.SelectItem{
+		Label:  comeBackLabel,
+		Action: back,
+	})
+
+	return c.prompt.PromptGetSelect(prompt.PromptContent{Label: "Выберите папку: "}, menu)
+}
+
+func (c *Cli) showFolder(id string) (string, error) {
+	c.filter.FolderID = id
+	return getData, nil
+}
+
+func (c *Cli) createFolder(parentID string) (string, error) {
+	name, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите название папки: "},
+		validator.ValidateStringLength(1, 50),
+	)
+	if err != nil {
+		return "", err
+	}
+	err = c.secrets.CreateFolder(context.Background(), name, parentID)
+	if err != nil {
+		return "", err
+	}
+	return folders + "/" + parentID, nil
+}
+
+func (c *Cli) deleteFolder(id string) (string, error) {
+	list, err := c.secrets.GetFolders(context.Background())
+	if err != nil {
+		return "", err
+	}
+	parentID := ""
+	for _, f := range list {
+		if f.ID == id {
+			parentID = f.ParentID
+		}
+	}
+	err = c.secrets.DeleteFolder(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	if c.filter.FolderID == id {
+		c.filter.FolderID = ""
+	}
+	return folders + "/" + parentID, nil
+}
+
+func (c *Cli) tags() (string, error) {
+	list, err := c.secrets.GetTags(context.Background())
+	if err != nil {
+		return "", err
+	}
+	menu := make([]prompt.SelectItem, 0, len(list)+2)
+	for _, tag := range list {
+		menu = append(menu, prompt.SelectItem{
+			Label:  tag,
+			Action: setTagFilter + "/" + url.PathEscape(tag),
+		})
+	}
+	menu = append(menu,
+		prompt.SelectItem{
+			Label:  allTagsLabel,
+			Action: setTagFilter + "/",
+		},
+		prompt.SelectItem{
+			Label:  comeBackLabel,
+			Action: getData,
+		},
+	)
+
+	return c.prompt.PromptGetSelect(prompt.PromptContent{Label: "Выберите тег: "}, menu)
+}
+
+func (c *Cli) setTagFilter(tag string) (string, error) {
+	t, err := url.PathUnescape(tag)
+	if err != nil {
+		return "", err
+	}
+	c.filter.Tag = t
+	return getData, nil
+}
+
+func (c *Cli) organizeData(id string) (string, error) {
+	s, err := c.secrets.GetSecret(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	secret := *s
+	// folders are personal, secrets of others can only be tagged
+	if secret.CollectionID == "" && !secret.IsShared() {
+		secret.FolderID, err = c.selectFolder(secret.FolderID)
+		if err != nil {
+			return "", err
+		}
+	}
+	input, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите теги через запятую: ", Default: strings.Join(secret.Tags, ", ")},
+		validator.ValidateStringLength(0, 500),
+	)
+	if err != nil {
+		return "", err
+	}
+	secret.Tags = parseTags(input)
+
+	err = c.updateSecret(&secret)
+	if errors.Is(err, entities.ErrVersionConflict) {
+		return c.resolveConflict(&secret, c.updateSecret)
+	}
+	if err != nil {
+		return "", err
+	}
+	return showData + "/" + secret.ID, nil
+}
+
+// selectFolder offers every folder of the user labelled with its full path
+func (c *Cli) selectFolder(current string) (string, error) {
+	list, err := c.secrets.GetFolders(context.Background())
+	if err != nil {
+		return "", err
+	}
+	byID := make(map[string]*entities.Folder, len(list))
+	for _, f := range list {
+		byID[f.ID] = f
+	}
+	menu := make([]prompt.SelectItem, 0, len(list)+1)
+	menu = append(menu, prompt.SelectItem{
+		Label:  rootFolderLabel,
+		Action: "",
+	})
+	for _, f := range list {
+		label := folderPath(f, byID)
+		if f.ID == current {
+			label += " *"
+		}
+		menu = append(menu, prompt.SelectItem{
+			Label:  label,
+			Action: f.ID,
+		})
+	}
+
+	return c.prompt.PromptGetSelect(prompt.PromptContent{Label: "Выберите папку: "}, menu)
+}
+
+func folderPath(f *entities.Folder, byID map[string]*entities.Folder) string {
+	path := f.Name
+	seen := map[string]struct{}{f.ID: {}}
+	for parent, ok := byID[f.ParentID]; ok; parent, ok = byID[parent.ParentID] {
+		if _, loop := seen[parent.ID]; loop {
+			break
+		}
+		seen[parent.ID] = struct{}{}
+		path = parent.Name + "/" + path
+	}
+	return path
+}
+
+func parseTags(input string) []string {
+	result := make([]string, 0)
+	for _, tag := range strings.Split(input, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_folders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		prompt:  prompter,
+		secrets: secrets,
+	}
+
+	list := []*entities.Folder{
+		{ID: "work", Name: "Работа"},
+		{ID: "servers", ParentID: "work", Name: "Сервера"},
+		{ID: "home", Name: "Дом"},
+	}
+	menuPrompt := prompt.PromptContent{Label: "Выберите папку: "}
+
+	tests := []struct {
+		name      string
+		parentID  string
+		menuItems []prompt.SelectItem
+		mockTimes map[string]int
+		errors    map[string]error
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "get folders error",
+			mockTimes: map[string]int{
+				"promptSelect": 0,
+			},
+			errors: map[string]error{
+				"getFolders": errors.New("get folders error"),
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:     "root",
+			parentID: "",
+			menuItems: []prompt.SelectItem{
+				{Label: "Работа/", Action: "folders/work"},
+				{Label: "Дом/", Action: "folders/home"},
+				{Label: "Создать папку", Action: "createFolder/"},
+				{Label: "Вернуться назад", Action: "getData"},
+			},
+			mockTimes: map[string]int{
+				"promptSelect": 1,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "nested",
+			parentID: "work",
+			menuItems: []prompt.SelectItem{
+				{Label: "Сервера/", Action: "folders/servers"},
+				{Label: "Показать записи", Action: "showFolder/work"},
+				{Label: "Создать папку", Action: "createFolder/work"},
+				{Label: "Удалить папку", Action: "deleteFolder/work"},
+				{Label: "Вернуться назад", Action: "folders/"},
+			},
+			mockTimes: map[string]int{
+				"promptSelect": 1,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets.EXPECT().GetFolders(gomock.Any()).Return(list, tt.errors["getFolders"]).Times(1)
+			prompter.EXPECT().PromptGetSelect(menuPrompt, tt.menuItems).Return("getData", nil).Times(tt.mockTimes["promptSelect"])
+
+			_, err := c.folders(tt.parentID)
+			tt.wantErr(t, err, fmt.Sprintf("folders()"))
+		})
+	}
+}
+
+func TestCli_setTagFilter(t *testing.T) {
+	c := &Cli{}
+	action, err := c.setTagFilter("ci%2Fcd")
+	assert.NoError(t, err)
+	assert.Equal(t, "getData", action)
+	assert.Equal(t, "ci/cd", c.filter.Tag)
+
+	_, err = c.showFolder("work")
+	assert.NoError(t, err)
+	assert.Equal(t, "work", c.filter.FolderID)
+
+	_, err = c.resetFilter()
+	assert.NoError(t, err)
+	assert.True(t, c.filter.IsEmpty())
+}
+
+func TestCli_organizeData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		prompt:  prompter,
+		secrets: secrets,
+	}
+
+	list := []*entities.Folder{
+		{ID: "work", Name: "Работа"},
+		{ID: "servers", ParentID: "work", Name: "Сервера"},
+	}
+	tagsPrompt := prompt.PromptContent{Label: "Введите теги через запятую: ", Default: "old"}
+
+	tests := []struct {
+		name       string
+		secret     *entities.Secret
+		want       *entities.Secret
+		mockTimes  map[string]int
+		errors     map[string]error
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "own secret",
+			secret: &entities.Secret{ID: "text", Tags: []string{"old"}},
+			want:   &entities.Secret{ID: "text", FolderID: "servers", Tags: []string{"new", "ssh"}},
+			mockTimes: map[string]int{
+				"selectFolder": 1,
+				"updateSecret": 1,
+			},
+			errors:     map[string]error{},
+			wantAction: "showData/text",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:   "shared secret is only tagged",
+			secret: &entities.Secret{ID: "text", Tags: []string{"old"}, Owner: "owner@zzz.com", Permission: entities.PermissionReadWrite},
+			want:   &entities.Secret{ID: "text", Tags: []string{"new", "ssh"}, Owner: "owner@zzz.com", Permission: entities.PermissionReadWrite},
+			mockTimes: map[string]int{
+				"selectFolder": 0,
+				"updateSecret": 1,
+			},
+			errors:     map[string]error{},
+			wantAction: "showData/text",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:   "update error",
+			secret: &entities.Secret{ID: "text", Tags: []string{"old"}},
+			want:   &entities.Secret{ID: "text", FolderID: "servers", Tags: []string{"new", "ssh"}},
+			mockTimes: map[string]int{
+				"selectFolder": 1,
+				"updateSecret": 1,
+			},
+			errors: map[string]error{
+				"updateSecret": errors.New("update error"),
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets.EXPECT().GetSecret(gomock.Any(), "text").Return(tt.secret, nil).Times(1)
+			secrets.EXPECT().GetFolders(gomock.Any()).Return(list, nil).Times(tt.mockTimes["selectFolder"])
+			prompter.EXPECT().PromptGetSelect(
+				prompt.PromptContent{Label: "Выберите папку: "},
+				[]prompt.SelectItem{
+					{Label: "Без папки", Action: ""},
+					{Label: "Работа", Action: "work"},
+					{Label: "Работа/Сервера", Action: "servers"},
+				},
+			).Return("servers", nil).Times(tt.mockTimes["selectFolder"])
+			prompter.EXPECT().PromptGetInput(tagsPrompt, gomock.Any()).Return(" new, ,ssh ", nil).Times(1)
+			secrets.EXPECT().UpdateSecret(gomock.Any(), tt.want).Return(tt.errors["updateSecret"]).Times(tt.mockTimes["updateSecret"])
+
+			action, err := c.organizeData("text")
+			if !tt.wantErr(t, err, fmt.Sprintf("organizeData()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "organizeData()")
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/pkg/passgen"
+	"github.com/itohin/gophkeeper/pkg/validator"
+)
+
+const (
+	enterPassword    = "enter"
+	generatePassword = "generate"
+
+	randomPassword        = "random"
+	pronounceablePassword = "pronounceable"
+	passphrasePassword    = "passphrase"
+
+	useGenerated   = "use"
+	regenerate     = "regenerate"
+	yes            = "yes"
+	no             = "no"
+	charsetLetters = "letters"
+	charsetDigits  = "digits"
+	charsetSymbols = "symbols"
+	charsetLower   = "lower"
+)
+
+// inputSecretPassword lets the user type the password or generate a new one
+func (c *Cli) inputSecretPassword(current string) (string, error) {
+	source, err := c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Пароль: "},
+		[]prompt.SelectItem{
+			{Label: enterPasswordLabel, Action: enterPassword},
+			{Label: generatePasswordLabel, Action: generatePassword},
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	if source == generatePassword {
+		return c.generatePassword()
+	}
+	return c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите пароль: ", Mask: 42, Default: current},
+		validator.ValidateStringLength(5, 30),
+	)
+}
+
+func (c *Cli) generatePassword() (string, error) {
+	kind, err := c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите вид пароля: "},
+		[]prompt.SelectItem{
+			{Label: randomPasswordLabel, Action: randomPassword},
+			{Label: pronounceablePasswordLabel, Action: pronounceablePassword},
+			{Label: passphrasePasswordLabel, Action: passphrasePassword},
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	var generate func() (string, error)
+	var entropy float64
+	switch kind {
+	case randomPassword:
+		policy, err := c.inputPasswordPolicy()
+		if err != nil {
+			return "", err
+		}
+		generate = func() (string, error) { return passgen.Random(policy) }
+		entropy = passgen.RandomEntropy(policy)
+	case pronounceablePassword:
+		length, err := c.inputNumber("Введите длину пароля: ", 12, passgen.MinLength, passgen.MaxLength)
+		if err != nil {
+			return "", err
+		}
+		generate = func() (string, error) { return passgen.Pronounceable(length) }
+		entropy = passgen.PronounceableEntropy(length)
+	default:
+		words, err := c.inputNumber("Введите количество слов: ", passgen.DefaultWords, passgen.MinWords, passgen.MaxWords)
+		if err != nil {
+			return "", err
+		}
+		separator, err := c.prompt.PromptGetInput(
+			prompt.PromptContent{Label: "Введите разделитель слов: ", Default: "-"},
+			validator.ValidateStringLength(0, 3),
+		)
+		if err != nil {
+			return "", err
+		}
+		generate = func() (string, error) { return passgen.Passphrase(words, separator) }
+		entropy = passgen.PassphraseEntropy(words)
+	}
+
+	for {
+		password, err := generate()
+		if err != nil {
+			return "", err
+		}
+		fmt.Println("Сгенерированный пароль: ", password)
+		fmt.Printf("Энтропия: %.0f бит (%s)\n", entropy, strengthLabel(entropy))
+		choice, err := c.prompt.PromptGetSelect(
+			prompt.PromptContent{Label: "Выберите действие: "},
+			[]prompt.SelectItem{
+				{Label: useGeneratedLabel, Action: useGenerated},
+				{Label: regenerateLabel, Action: regenerate},
+			},
+		)
+		if err != nil {
+			return "", err
+		}
+		if choice == useGenerated {
+			return password, nil
+		}
+	}
+}
+
+func (c *Cli) inputPasswordPolicy() (passgen.Policy, error) {
+	policy := passgen.DefaultPolicy()
+	length, err := c.inputNumber("Введите длину пароля: ", policy.Length, passgen.MinLength, passgen.MaxLength)
+	if err != nil {
+		return policy, err
+	}
+	charset, err := c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите набор символов: "},
+		[]prompt.SelectItem{
+			{Label: charsetSymbolsLabel, Action: charsetSymbols},
+			{Label: charsetLettersLabel, Action: charsetLetters},
+			{Label: charsetLowerLabel, Action: charsetLower},
+			{Label: charsetDigitsLabel, Action: charsetDigits},
+		},
+	)
+	if err != nil {
+		return policy, err
+	}
+	exclude, err := c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Исключить похожие символы (I, l, 1, O, 0)? "},
+		[]prompt.SelectItem{
+			{Label: yesLabel, Action: yes},
+			{Label: noLabel, Action: no},
+		},
+	)
+	if err != nil {
+		return policy, err
+	}
+	policy = passgen.Policy{
+		Length:           length,
+		Lower:            charset != charsetDigits,
+		Upper:            charset == charsetSymbols || charset == charsetLetters,
+		Digits:           true,
+		Symbols:          charset == charsetSymbols,
+		ExcludeAmbiguous: exclude == yes,
+	}
+	return policy, nil
+}
+
+func (c *Cli) inputNumber(label string, def, min, max int) (int, error) {
+	input, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: label, Default: strconv.Itoa(def)},
+		validateUint(uint64(min), uint64(max)),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(input)
+}
+
+func strengthLabel(bits float64) string {
+	switch {
+	case bits < 40:
+		return "слабый"
+	case bits < 60:
+		return "средний"
+	case bits < 80:
+		return "надежный"
+	default:
+		return "очень надежный"
+	}
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_inputSecretPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+
+	c := &Cli{
+		prompt: prompter,
+	}
+
+	sourcePrompt := prompt.PromptContent{Label: "Пароль: "}
+	kindPrompt := prompt.PromptContent{Label: "Выберите вид пароля: "}
+	lengthPrompt := prompt.PromptContent{Label: "Введите длину пароля: ", Default: "20"}
+	charsetPrompt := prompt.PromptContent{Label: "Выберите набор символов: "}
+	ambiguousPrompt := prompt.PromptContent{Label: "Исключить похожие символы (I, l, 1, O, 0)? "}
+	wordsPrompt := prompt.PromptContent{Label: "Введите количество слов: ", Default: "6"}
+	separatorPrompt := prompt.PromptContent{Label: "Введите разделитель слов: ", Default: "-"}
+	choicePrompt := prompt.PromptContent{Label: "Выберите действие: "}
+
+	tests := []struct {
+		name      string
+		kind      string
+		mockTimes map[string]int
+		errors    map[string]error
+		check     func(t *testing.T, password string)
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "random digits",
+			kind: "random",
+			mockTimes: map[string]int{
+				"length": 1,
+				"words":  0,
+				"choice": 2,
+			},
+			errors: map[string]error{},
+			check: func(t *testing.T, password string) {
+				assert.Len(t, password, 10)
+				assert.Empty(t, strings.Trim(password, "23456789"))
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "passphrase",
+			kind: "passphrase",
+			mockTimes: map[string]int{
+				"length": 0,
+				"words":  1,
+				"choice": 2,
+			},
+			errors: map[string]error{},
+			check: func(t *testing.T, password string) {
+				assert.Len(t, strings.Split(password, "-"), 4)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "choice error",
+			kind: "passphrase",
+			mockTimes: map[string]int{
+				"length": 0,
+				"words":  1,
+				"choice": 1,
+			},
+			errors: map[string]error{
+				"choice": errors.New("choice error"),
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompter.EXPECT().PromptGetSelect(sourcePrompt, gomock.Any()).Return("generate", nil).Times(1)
+			prompter.EXPECT().PromptGetSelect(kindPrompt, gomock.Any()).Return(tt.kind, nil).Times(1)
+			prompter.EXPECT().PromptGetInput(lengthPrompt, gomock.Any()).Return("10", nil).Times(tt.mockTimes["length"])
+			prompter.EXPECT().PromptGetSelect(charsetPrompt, gomock.Any()).Return("digits", nil).Times(tt.mockTimes["length"])
+			prompter.EXPECT().PromptGetSelect(ambiguousPrompt, gomock.Any()).Return("yes", nil).Times(tt.mockTimes["length"])
+			prompter.EXPECT().PromptGetInput(wordsPrompt, gomock.Any()).Return("4", nil).Times(tt.mockTimes["words"])
+			prompter.EXPECT().PromptGetInput(separatorPrompt, gomock.Any()).Return("-", nil).Times(tt.mockTimes["words"])
+			if tt.errors["choice"] != nil {
+				prompter.EXPECT().PromptGetSelect(choicePrompt, gomock.Any()).Return("", tt.errors["choice"]).Times(1)
+			} else {
+				gomock.InOrder(
+					prompter.EXPECT().PromptGetSelect(choicePrompt, gomock.Any()).Return("regenerate", nil).Times(1),
+					prompter.EXPECT().PromptGetSelect(choicePrompt, gomock.Any()).Return("use", nil).Times(1),
+				)
+			}
+
+			password, err := c.inputSecretPassword("")
+			if !tt.wantErr(t, err, fmt.Sprintf("inputSecretPassword()")) || err != nil {
+				return
+			}
+			tt.check(t, password)
+		})
+	}
+}
+
+func TestStrengthLabel(t *testing.T) {
+	assert.Equal(t, "слабый", strengthLabel(30))
+	assert.Equal(t, "средний", strengthLabel(45))
+	assert.Equal(t, "надежный", strengthLabel(70))
+	assert.Equal(t, "очень надежный", strengthLabel(128))
+}
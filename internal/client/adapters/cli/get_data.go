@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
 	"github.com/itohin/gophkeeper/internal/client/entities"
@@ -11,21 +12,49 @@ import (
 )
 
 func (c *Cli) getData() (string, error) {
-	secrets, err := c.secrets.GetSecrets(context.Background())
+	return c.getDataPage("")
+}
+
+func (c *Cli) getDataPage(pageToken string) (string, error) {
+	c.selected = nil
+	page, err := c.secrets.SearchSecrets(context.Background(), &c.filter, pageToken)
 	if err != nil {
 		return "", err
 	}
-	menu := make([]prompt.SelectItem, 0, len(secrets))
-	for _, secret := range secrets {
+	menu := make([]prompt.SelectItem, 0, len(page.Secrets)+6)
+	for _, secret := range page.Secrets {
+		label := secret.Name + " (" + secret.GetLabel() + ")"
+		if secret.CollectionID != "" {
+			label += " [" + secret.Owner + "]"
+		} else if secret.IsShared() {
+			label += " [" + ownerLabel + ": " + secret.Owner + "]"
+		}
 		p := prompt.SelectItem{
-			Label:  secret.Name + " (" + secret.GetLabel() + ")",
+			Label:  label,
 			Action: showData + "/" + secret.ID,
 		}
 		menu = append(menu, p)
 	}
+	if page.NextPageToken != "" {
+		menu = append(menu, prompt.SelectItem{
+			Label:  nextPageLabel,
+			Action: getDataPage + "/" + page.NextPageToken,
+		})
+	}
+	if len(page.Secrets) > 0 {
+		menu = append(menu, prompt.SelectItem{
+			Label:  selectDataLabel,
+			Action: selectData + "/" + pageToken,
+		})
+	}
+	menu = append(menu, c.filterMenu()...)
+	back := dataMenu
+	if c.collection != nil {
+		back = collection + "/" + c.collection.OrganizationID + "/" + c.collection.ID
+	}
 	menu = append(menu, prompt.SelectItem{
 		Label:  comeBackLabel,
-		Action: dataMenu,
+		Action: back,
 	})
 
 	listPrompt := prompt.PromptContent{}
@@ -38,21 +67,51 @@ func (c *Cli) showData(id string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	switch s.SecretType {
+	err = printSecret(s)
+	if err != nil {
+		return "", err
+	}
+	printFields(s, false)
+	if len(s.Tags) > 0 {
+		fmt.Println("Теги: ", strings.Join(s.Tags, ", "))
+	}
+	if s.CollectionID != "" {
+		fmt.Println("Организация: ", s.Owner)
+		fmt.Println("Доступ: ", entities.PermissionLabel(s.Permission))
+	} else if s.IsShared() {
+		fmt.Println("Владелец: ", s.Owner)
+		fmt.Println("Доступ: ", entities.PermissionLabel(s.Permission))
+	}
+	if s.SecretType == entities.TypeBinary {
+		return c.showBinary(s)
+	}
+	return c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите действие: "},
+		secretMenu(s),
+	)
+}
+
+func printSecret(secret *entities.Secret) error {
+	switch secret.SecretType {
 	case entities.TypePassword:
-		return c.showPassword(s)
+		printPassword(secret)
 	case entities.TypeText:
-		return c.showText(s)
+		printText(secret)
 	case entities.TypeBinary:
-		return c.showBinary(s)
+		printBinary(secret)
 	case entities.TypeCard:
-		return c.showCard(s)
+		printCard(secret)
+	case entities.TypeTOTP:
+		printTOTP(secret)
+	case entities.TypeSSHKey:
+		printSSHKey(secret)
 	default:
-		return "", fmt.Errorf("unknown secret type %v", s.SecretType)
+		return fmt.Errorf("unknown secret type %v", secret.SecretType)
 	}
+	return nil
 }
 
-func (c *Cli) showCard(secret *entities.Secret) (string, error) {
+func printCard(secret *entities.Secret) {
 	data := secret.Data.(*entities.Card)
 	fmt.Println("Название: ", secret.Name)
 	fmt.Println("Номер: ", data.Number)
@@ -61,82 +120,45 @@ func (c *Cli) showCard(secret *entities.Secret) (string, error) {
 	fmt.Println("PIN код: ", data.Pin)
 	fmt.Println("Имя владельца: ", data.OwnerName)
 	fmt.Println("Примечания: ", secret.Notes)
-	return c.prompt.PromptGetSelect(
-		prompt.PromptContent{Label: "Выберите действие: "},
-		[]prompt.SelectItem{
-			{
-				Label:  deleteDataLabel,
-				Action: deleteData + "/" + secret.ID,
-			},
-			{
-				Label:  comeBackLabel,
-				Action: getData,
-			},
-		})
 }
 
-func (c *Cli) showPassword(secret *entities.Secret) (string, error) {
+func printPassword(secret *entities.Secret) {
 	data := secret.Data.(*entities.Password)
 	fmt.Println("Название: ", secret.Name)
 	fmt.Println("Логин: ", data.Login)
 	fmt.Println("Пароль: ", data.Password)
 	fmt.Println("Примечания: ", secret.Notes)
-	return c.prompt.PromptGetSelect(
-		prompt.PromptContent{Label: "Выберите действие: "},
-		[]prompt.SelectItem{
-			{
-				Label:  deleteDataLabel,
-				Action: deleteData + "/" + secret.ID,
-			},
-			{
-				Label:  comeBackLabel,
-				Action: getData,
-			},
-		})
 }
 
-func (c *Cli) showText(secret *entities.Secret) (string, error) {
+func printText(secret *entities.Secret) {
 	fmt.Println("Название: ", secret.Name)
 	fmt.Println("Текст: ", secret.Data.(string))
 	fmt.Println("Примечания: ", secret.Notes)
-	return c.prompt.PromptGetSelect(
-		prompt.PromptContent{Label: "Выберите действие: "},
-		[]prompt.SelectItem{
-			{
-				Label:  deleteDataLabel,
-				Action: deleteData + "/" + secret.ID,
-			},
-			{
-				Label:  comeBackLabel,
-				Action: getData,
-			},
-		})
 }
 
-func (c *Cli) showBinary(secret *entities.Secret) (string, error) {
+func printBinary(secret *entities.Secret) {
 	fmt.Println("Название: ", secret.Name)
 	fmt.Println("Примечания: ", secret.Notes)
+}
+
+func (c *Cli) showBinary(secret *entities.Secret) (string, error) {
 	action, err := c.prompt.PromptGetSelect(
 		prompt.PromptContent{Label: "Выберите действие: "},
-		[]prompt.SelectItem{
-			{
-				Label:  saveBinaryToDiskLabel,
-				Action: saveBinaryToDisk,
-			},
-			{
-				Label:  deleteDataLabel,
-				Action: deleteData + "/" + secret.ID,
-			},
-			{
-				Label:  comeBackLabel,
-				Action: getData,
+		append(
+			[]prompt.SelectItem{
+				{
+					Label:  saveBinaryToDiskLabel,
+					Action: saveBinaryToDisk,
+				},
 			},
-		})
+			secretMenu(secret)...,
+		),
+	)
 	if action != saveBinaryToDisk {
 		return action, err
 	}
 
-	err = c.saveBinary(secret.Data.([]byte))
+	err = c.saveBinary(secret)
 	if err != nil {
 		return "", err
 	}
@@ -144,7 +166,61 @@ func (c *Cli) showBinary(secret *entities.Secret) (string, error) {
 	return getData, nil
 }
 
-func (c *Cli) saveBinary(data []byte) error {
+func secretMenu(secret *entities.Secret) []prompt.SelectItem {
+	menu := make([]prompt.SelectItem, 0, 9)
+	if secret.SecretType == entities.TypeTOTP {
+		menu = append(menu, prompt.SelectItem{
+			Label:  watchCodeLabel,
+			Action: watchCode + "/" + secret.ID,
+		})
+	}
+	if secret.CanEdit() {
+		menu = append(menu,
+			prompt.SelectItem{
+				Label:  editDataLabel,
+				Action: editData + "/" + secret.ID,
+			},
+			prompt.SelectItem{
+				Label:  organizeDataLabel,
+				Action: organizeData + "/" + secret.ID,
+			},
+			prompt.SelectItem{
+				Label:  fieldsLabel,
+				Action: fields + "/" + secret.ID,
+			},
+		)
+	}
+	if !secret.IsShared() {
+		menu = append(menu,
+			prompt.SelectItem{
+				Label:  revisionsLabel,
+				Action: revisions + "/" + secret.ID,
+			},
+			prompt.SelectItem{
+				Label:  sharesLabel,
+				Action: shares + "/" + secret.ID,
+			},
+		)
+	}
+	if hasHiddenFields(secret) {
+		menu = append(menu, prompt.SelectItem{
+			Label:  revealFieldsLabel,
+			Action: revealFields + "/" + secret.ID,
+		})
+	}
+	return append(menu,
+		prompt.SelectItem{
+			Label:  deleteDataLabel,
+			Action: deleteData + "/" + secret.ID,
+		},
+		prompt.SelectItem{
+			Label:  comeBackLabel,
+			Action: getData,
+		},
+	)
+}
+
+func (c *Cli) saveBinary(secret *entities.Secret) error {
 	path, err := c.prompt.PromptGetInput(
 		prompt.PromptContent{Label: "Введите путь для сохранение(/path/to/file.ext): "},
 		validator.ValidateStringLength(3, 50),
@@ -152,7 +228,25 @@ func (c *Cli) saveBinary(data []byte) error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, data, 0755)
+	if data, ok := secret.Data.([]byte); ok && len(data) > 0 {
+		return os.WriteFile(path, data, 0755)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	p := newProgress("Сохранение файла", secret.Size, os.Stdout)
+	err = c.secrets.DownloadBinary(context.Background(), secret, &progressWriter{w: f, p: p})
+	p.finish()
+	closeErr := f.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return err
 }
 
 func (c *Cli) deleteData(id string) (string, error) {
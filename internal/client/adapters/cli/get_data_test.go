@@ -1,8 +1,10 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"testing"
 
@@ -50,7 +52,7 @@ func TestCli_showData(t *testing.T) {
 		"unknown_type": &entities.Secret{
 			ID:         "unknown_type",
 			Name:       "Unknown",
-			SecretType: 5,
+			SecretType: 99,
 			Data:       "Unknown type",
 			Notes:      "...",
 		},
@@ -111,6 +113,26 @@ func TestCli_showData(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 
 			menuItems := []prompt.SelectItem{
+				{
+					Label:  "Редактировать данные",
+					Action: "editData/" + tt.id,
+				},
+				{
+					Label:  "Папка и теги",
+					Action: "organizeData/" + tt.id,
+				},
+				{
+					Label:  "Дополнительные поля",
+					Action: "fields/" + tt.id,
+				},
+				{
+					Label:  "История изменений",
+					Action: "revisions/" + tt.id,
+				},
+				{
+					Label:  "Совместный доступ",
+					Action: "shares/" + tt.id,
+				},
 				{
 					Label:  "Удалить данные",
 					Action: "deleteData/" + tt.id,
@@ -135,26 +157,55 @@ func TestCli_saveBinary(t *testing.T) {
 	defer ctrl.Finish()
 
 	prompter := mocks.NewMockPrompter(ctrl)
-	c := &Cli{prompt: prompter}
+	secrets := mocks.NewMockSecrets(ctrl)
+	c := &Cli{prompt: prompter, secrets: secrets}
 	data := []byte("Lorem ipsum...")
 
 	tests := []struct {
-		name    string
-		errors  map[string]error
-		wantErr assert.ErrorAssertionFunc
+		name      string
+		secret    *entities.Secret
+		mockTimes map[string]int
+		errors    map[string]error
+		wantErr   assert.ErrorAssertionFunc
 	}{
 		{
-			name: "Prompt error",
+			name:   "Prompt error",
+			secret: &entities.Secret{ID: "binary", SecretType: entities.TypeBinary, Data: data},
+			mockTimes: map[string]int{
+				"download": 0,
+			},
 			errors: map[string]error{
 				"promptError": errors.New("prompt error"),
 			},
 			wantErr: assert.Error,
 		},
 		{
-			name: "success",
+			name:   "inline data",
+			secret: &entities.Secret{ID: "binary", SecretType: entities.TypeBinary, Data: data},
+			mockTimes: map[string]int{
+				"download": 0,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "download error",
+			secret: &entities.Secret{ID: "binary", SecretType: entities.TypeBinary, Size: int64(len(data))},
+			mockTimes: map[string]int{
+				"download": 1,
+			},
 			errors: map[string]error{
-				"promptError": nil,
+				"download": errors.New("download error"),
 			},
+			wantErr: assert.Error,
+		},
+		{
+			name:   "download",
+			secret: &entities.Secret{ID: "binary", SecretType: entities.TypeBinary, Size: int64(len(data))},
+			mockTimes: map[string]int{
+				"download": 1,
+			},
+			errors:  map[string]error{},
 			wantErr: assert.NoError,
 		},
 	}
@@ -164,13 +215,24 @@ func TestCli_saveBinary(t *testing.T) {
 			p := prompt.PromptContent{}
 			p.Label = "Введите путь для сохранение(/path/to/file.ext): "
 			prompter.EXPECT().PromptGetInput(p, gomock.Any()).Return("data.txt", tt.errors["promptError"]).Times(1)
+			secrets.EXPECT().DownloadBinary(gomock.Any(), tt.secret, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, secret *entities.Secret, w io.Writer) error {
+					if tt.errors["download"] != nil {
+						return tt.errors["download"]
+					}
+					_, err := w.Write(data)
+					return err
+				}).
+				Times(tt.mockTimes["download"])
 
-			tt.wantErr(t, c.saveBinary(data), fmt.Sprintf("ShowData()"))
+			tt.wantErr(t, c.saveBinary(tt.secret), fmt.Sprintf("saveBinary()"))
 
-			if tt.errors["promptError"] == nil {
-				fileData, _ := os.ReadFile("data.txt")
-				os.Remove("data.txt")
+			fileData, err := os.ReadFile("data.txt")
+			os.Remove("data.txt")
+			if tt.errors["promptError"] == nil && tt.errors["download"] == nil {
 				assert.Equal(t, fileData, []byte("Lorem ipsum..."))
+			} else {
+				assert.Error(t, err)
 			}
 		})
 	}
@@ -215,3 +277,110 @@ func TestCli_deleteData(t *testing.T) {
 		})
 	}
 }
+
+func TestCli_getDataPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	page := &entities.SecretsPage{
+		Secrets: []*entities.Secret{
+			{ID: "text", Name: "First", SecretType: entities.TypeText},
+		},
+		NextPageToken: "token2",
+	}
+	listPrompt := prompt.PromptContent{Label: "Выберите запись: "}
+
+	tests := []struct {
+		name       string
+		filter     entities.SecretsFilter
+		menuItems  []prompt.SelectItem
+		errors     map[string]error
+		mockTimes  map[string]int
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "search error",
+			filter: entities.SecretsFilter{},
+			errors: map[string]error{
+				"search":       errors.New("search error"),
+				"promptSelect": nil,
+			},
+			mockTimes: map[string]int{
+				"promptSelect": 0,
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:   "without filter",
+			filter: entities.SecretsFilter{},
+			menuItems: []prompt.SelectItem{
+				{Label: "First (Текстовые данные)", Action: "showData/text"},
+				{Label: "Следующая страница", Action: "getDataPage/token2"},
+				{Label: "Выбрать записи для удаления", Action: "selectData/token1"},
+				{Label: "Поиск по названию", Action: "searchData"},
+				{Label: "Фильтр по типу данных", Action: "filterData"},
+				{Label: "Фильтр по тегу", Action: "tags"},
+				{Label: "Папки", Action: "folders/"},
+				{Label: "Сортировка", Action: "sortData"},
+				{Label: "Вернуться назад", Action: "dataMenu"},
+			},
+			errors: map[string]error{
+				"search":       nil,
+				"promptSelect": nil,
+			},
+			mockTimes: map[string]int{
+				"promptSelect": 1,
+			},
+			wantAction: "showData/text",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:   "with filter",
+			filter: entities.SecretsFilter{Query: "fir"},
+			menuItems: []prompt.SelectItem{
+				{Label: "First (Текстовые данные)", Action: "showData/text"},
+				{Label: "Следующая страница", Action: "getDataPage/token2"},
+				{Label: "Выбрать записи для удаления", Action: "selectData/token1"},
+				{Label: "Поиск по названию", Action: "searchData"},
+				{Label: "Фильтр по типу данных", Action: "filterData"},
+				{Label: "Фильтр по тегу", Action: "tags"},
+				{Label: "Папки", Action: "folders/"},
+				{Label: "Сортировка", Action: "sortData"},
+				{Label: "Сбросить поиск и фильтры", Action: "resetFilter"},
+				{Label: "Вернуться назад", Action: "dataMenu"},
+			},
+			errors: map[string]error{
+				"search":       nil,
+				"promptSelect": nil,
+			},
+			mockTimes: map[string]int{
+				"promptSelect": 1,
+			},
+			wantAction: "showData/text",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cli{
+				prompt:  prompter,
+				secrets: secrets,
+				filter:  tt.filter,
+			}
+
+			secrets.EXPECT().SearchSecrets(gomock.Any(), &tt.filter, "token1").Return(page, tt.errors["search"]).Times(1)
+			prompter.EXPECT().PromptGetSelect(listPrompt, tt.menuItems).Return(tt.wantAction, tt.errors["promptSelect"]).Times(tt.mockTimes["promptSelect"])
+
+			action, err := c.getDataPage("token1")
+			if !tt.wantErr(t, err, fmt.Sprintf("getDataPage()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "getDataPage()")
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/pkg/validator"
+)
+
+const (
+	importAll      = "all"
+	skipDuplicates = "skip"
+)
+
+func (c *Cli) importData() (string, error) {
+	return c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите формат файла: "},
+		[]prompt.SelectItem{
+			{Label: entities.KeePassLabel, Action: importFile + "/" + entities.ImportKeePass},
+			{Label: entities.BitwardenLabel, Action: importFile + "/" + entities.ImportBitwarden},
+			{Label: entities.OnePasswordLabel, Action: importFile + "/" + entities.Import1Password},
+			{Label: entities.ChromeLabel, Action: importFile + "/" + entities.ImportChrome},
+			{Label: entities.FirefoxLabel, Action: importFile + "/" + entities.ImportFirefox},
+			{Label: comeBackLabel, Action: dataMenu},
+		},
+	)
+}
+
+// importFile shows what will be created before anything is saved
+func (c *Cli) importFile(format string) (string, error) {
+	path, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите путь к файлу экспорта: "},
+		validator.ValidateStringLength(3, 500),
+	)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	items, err := c.imports.Preview(context.Background(), format, f)
+	if err != nil {
+		return "", err
+	}
+	duplicates, err := printImportPreview(items)
+	if err != nil {
+		return "", err
+	}
+	if len(items) == 0 {
+		return dataMenu, nil
+	}
+
+	menu := []prompt.SelectItem{{Label: importAllLabel, Action: importAll}}
+	if duplicates > 0 {
+		menu = append(menu, prompt.SelectItem{Label: skipDuplicatesLabel, Action: skipDuplicates})
+	}
+	menu = append(menu, prompt.SelectItem{Label: cancelImportLabel, Action: dataMenu})
+	choice, err := c.prompt.PromptGetSelect(prompt.PromptContent{Label: "Выберите действие: "}, menu)
+	if err != nil || choice == dataMenu {
+		return choice, err
+	}
+
+	p := newProgress("Импорт записей", int64(len(items)), os.Stdout)
+	result, err := c.imports.Import(context.Background(), items, choice == skipDuplicates, func(done, total int) {
+		p.add(done - int(p.done))
+	})
+	p.finish()
+	if err != nil {
+		return "", fmt.Errorf("импорт прерван после %d записей: %w", result.Created, err)
+	}
+	fmt.Printf("Импортировано: %d, пропущено: %d\n", result.Created, result.Skipped)
+	return dataMenu, nil
+}
+
+func printImportPreview(items []*entities.ImportItem) (int, error) {
+	duplicates := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Название\tТип\tСтатус")
+	for _, item := range items {
+		status := newImportLabel
+		if item.Duplicate {
+			status = duplicateLabel
+			duplicates++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", item.Secret.Name, item.Secret.GetLabel(), status)
+	}
+	err := w.Flush()
+	if err != nil {
+		return 0, err
+	}
+	fmt.Printf("Найдено записей: %d, дубликатов: %d\n", len(items), duplicates)
+	return duplicates, nil
+}
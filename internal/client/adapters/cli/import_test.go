@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCli_importFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	imports := mocks.NewMockImports(ctrl)
+
+	c := &Cli{
+		prompt:  prompter,
+		imports: imports,
+	}
+
+	path := filepath.Join(t.TempDir(), "passwords.csv")
+	require.NoError(t, os.WriteFile(path, []byte("name,url,username,password\n"), 0600))
+
+	items := []*entities.ImportItem{
+		{Secret: &entities.Secret{Name: "Mail", SecretType: entities.TypePassword}},
+		{Secret: &entities.Secret{Name: "Shop", SecretType: entities.TypePassword}, Duplicate: true},
+	}
+	pathPrompt := prompt.PromptContent{Label: "Введите путь к файлу экспорта: "}
+	choicePrompt := prompt.PromptContent{Label: "Выберите действие: "}
+	choiceItems := []prompt.SelectItem{
+		{Label: "Импортировать все", Action: "all"},
+		{Label: "Импортировать без дубликатов", Action: "skip"},
+		{Label: "Отменить", Action: "dataMenu"},
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		choice     string
+		mockTimes  map[string]int
+		errors     map[string]error
+		wantSkip   bool
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "missing file",
+			path: filepath.Join(t.TempDir(), "missing.csv"),
+			mockTimes: map[string]int{
+				"preview": 0,
+				"choice":  0,
+				"import":  0,
+			},
+			errors:     map[string]error{},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "preview error",
+			path: path,
+			mockTimes: map[string]int{
+				"preview": 1,
+				"choice":  0,
+				"import":  0,
+			},
+			errors: map[string]error{
+				"preview": errors.New("parse error"),
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:   "cancel",
+			path:   path,
+			choice: "dataMenu",
+			mockTimes: map[string]int{
+				"preview": 1,
+				"choice":  1,
+				"import":  0,
+			},
+			errors:     map[string]error{},
+			wantAction: "dataMenu",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:   "import error",
+			path:   path,
+			choice: "all",
+			mockTimes: map[string]int{
+				"preview": 1,
+				"choice":  1,
+				"import":  1,
+			},
+			errors: map[string]error{
+				"import": errors.New("import error"),
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:   "skip duplicates",
+			path:   path,
+			choice: "skip",
+			mockTimes: map[string]int{
+				"preview": 1,
+				"choice":  1,
+				"import":  1,
+			},
+			errors:     map[string]error{},
+			wantSkip:   true,
+			wantAction: "dataMenu",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompter.EXPECT().PromptGetInput(pathPrompt, gomock.Any()).Return(tt.path, nil).Times(1)
+			imports.EXPECT().Preview(gomock.Any(), entities.ImportChrome, gomock.Any()).Return(items, tt.errors["preview"]).Times(tt.mockTimes["preview"])
+			prompter.EXPECT().PromptGetSelect(choicePrompt, choiceItems).Return(tt.choice, nil).Times(tt.mockTimes["choice"])
+			imports.EXPECT().Import(gomock.Any(), items, tt.wantSkip, gomock.Any()).
+				DoAndReturn(func(_ interface{}, items []*entities.ImportItem, _ bool, progress func(done, total int)) (*entities.ImportResult, error) {
+					progress(len(items), len(items))
+					return &entities.ImportResult{Created: 1, Skipped: 1}, tt.errors["import"]
+				}).
+				Times(tt.mockTimes["import"])
+
+			action, err := c.importFile(entities.ImportChrome)
+			if !tt.wantErr(t, err, fmt.Sprintf("importFile()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "importFile()")
+		})
+	}
+}
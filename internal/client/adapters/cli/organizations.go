@@ -0,0 +1,295 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/pkg/validator"
+)
+
+func (c *Cli) organizationsList() (string, error) {
+	list, err := c.organizations.GetOrganizations(context.Background())
+	if err != nil {
+		return "", err
+	}
+	menu := make([]prompt.SelectItem, 0, len(list)+3)
+	for _, org := range list {
+		menu = append(menu, prompt.SelectItem{
+			Label:  org.Name + " (" + entities.RoleLabel(org.Role) + ")",
+			Action: organization + "/" + org.ID,
+		})
+	}
+	menu = append(menu,
+		prompt.SelectItem{
+			Label:  createOrganizationLabel,
+			Action: createOrganization,
+		},
+		prompt.SelectItem{
+			Label:  acceptInvitationLabel,
+			Action: acceptInvitation,
+		},
+		prompt.SelectItem{
+			Label:  comeBackLabel,
+			Action: dataMenu,
+		},
+	)
+
+	return c.prompt.PromptGetSelect(prompt.PromptContent{Label: "Выберите организацию: "}, menu)
+}
+
+func (c *Cli) organization(orgID string) (string, error) {
+	org, err := c.getOrganization(orgID)
+	if err != nil {
+		return "", err
+	}
+	list, err := c.organizations.GetCollections(context.Background(), orgID)
+	if err != nil {
+		return "", err
+	}
+	menu := make([]prompt.SelectItem, 0, len(list)+4)
+	for _, col := range list {
+		menu = append(menu, prompt.SelectItem{
+			Label:  col.Name,
+			Action: collection + "/" + orgID + "/" + col.ID,
+		})
+	}
+	if org.CanManage() {
+		menu = append(menu,
+			prompt.SelectItem{
+				Label:  createCollectionLabel,
+				Action: createCollection + "/" + orgID,
+			},
+			prompt.SelectItem{
+				Label:  inviteMemberLabel,
+				Action: inviteMember + "/" + orgID,
+			},
+		)
+	}
+	menu = append(menu,
+		prompt.SelectItem{
+			Label:  membersLabel,
+			Action: members + "/" + orgID,
+		},
+		prompt.SelectItem{
+			Label:  comeBackLabel,
+			Action: organizations,
+		},
+	)
+
+	return c.prompt.PromptGetSelect(prompt.PromptContent{Label: "Выберите коллекцию: "}, menu)
+}
+
+func (c *Cli) createOrganization() (string, error) {
+	name, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите название организации: "},
+		validator.ValidateStringLength(3, 25),
+	)
+	if err != nil {
+		return "", err
+	}
+	err = c.organizations.CreateOrganization(context.Background(), name)
+	if err != nil {
+		return "", err
+	}
+	return organizations, nil
+}
+
+func (c *Cli) acceptInvitation() (string, error) {
+	code, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите код приглашения: "},
+		validator.ValidateStringLength(1, 255),
+	)
+	if err != nil {
+		return "", err
+	}
+	err = c.organizations.AcceptInvitation(context.Background(), code)
+	if err != nil {
+		return "", err
+	}
+	return organizations, nil
+}
+
+func (c *Cli) inviteMember(orgID string) (string, error) {
+	email, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите email пользователя: "},
+		validator.ValidateEmail(),
+	)
+	if err != nil {
+		return "", err
+	}
+	role, err := c.selectRole()
+	if err != nil {
+		return "", err
+	}
+	err = c.organizations.InviteMember(context.Background(), orgID, email, role)
+	if err != nil {
+		return "", err
+	}
+	return organization + "/" + orgID, nil
+}
+
+func (c *Cli) members(orgID string) (string, error) {
+	org, err := c.getOrganization(orgID)
+	if err != nil {
+		return "", err
+	}
+	list, err := c.organizations.GetMembers(context.Background(), orgID)
+	if err != nil {
+		return "", err
+	}
+	menu := make([]prompt.SelectItem, 0, len(list)+1)
+	for _, m := range list {
+		item := prompt.SelectItem{
+			Label:  m.Email + " (" + entities.RoleLabel(m.Role) + ")",
+			Action: members + "/" + orgID,
+		}
+		if org.CanManage() && m.Role != entities.RoleOwner {
+			item.Action = member + "/" + orgID + "/" + m.UserID
+		}
+		menu = append(menu, item)
+	}
+	menu = append(menu, prompt.SelectItem{
+		Label:  comeBackLabel,
+		Action: organization + "/" + orgID,
+	})
+
+	return c.prompt.PromptGetSelect(prompt.PromptContent{Label: "Выберите участника: "}, menu)
+}
+
+func (c *Cli) member(orgID, userID string) (string, error) {
+	return c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите действие: "},
+		[]prompt.SelectItem{
+			{
+				Label:  setMemberRoleLabel,
+				Action: setMemberRole + "/" + orgID + "/" + userID,
+			},
+			{
+				Label:  removeMemberLabel,
+				Action: removeMember + "/" + orgID + "/" + userID,
+			},
+			{
+				Label:  comeBackLabel,
+				Action: members + "/" + orgID,
+			},
+		})
+}
+
+func (c *Cli) setMemberRole(orgID, userID string) (string, error) {
+	role, err := c.selectRole()
+	if err != nil {
+		return "", err
+	}
+	err = c.organizations.SetMemberRole(context.Background(), orgID, userID, role)
+	if err != nil {
+		return "", err
+	}
+	return members + "/" + orgID, nil
+}
+
+func (c *Cli) removeMember(orgID, userID string) (string, error) {
+	err := c.organizations.RemoveMember(context.Background(), orgID, userID)
+	if err != nil {
+		return "", err
+	}
+	return members + "/" + orgID, nil
+}
+
+func (c *Cli) collectionMenu(orgID, collectionID string) (string, error) {
+	org, err := c.getOrganization(orgID)
+	if err != nil {
+		return "", err
+	}
+	c.collection = &entities.Collection{ID: collectionID, OrganizationID: orgID}
+	c.filter = entities.SecretsFilter{CollectionID: collectionID}
+
+	menu := make([]prompt.SelectItem, 0, 4)
+	if org.CanWrite() {
+		menu = append(menu, prompt.SelectItem{
+			Label:  addDataLabel,
+			Action: addData,
+		})
+	}
+	menu = append(menu, prompt.SelectItem{
+		Label:  getDataLabel,
+		Action: getData,
+	})
+	if org.CanManage() {
+		menu = append(menu, prompt.SelectItem{
+			Label:  deleteCollectionLabel,
+			Action: deleteCollection + "/" + orgID + "/" + collectionID,
+		})
+	}
+	menu = append(menu, prompt.SelectItem{
+		Label:  comeBackLabel,
+		Action: organization + "/" + orgID,
+	})
+
+	return c.prompt.PromptGetSelect(prompt.PromptContent{Label: "Выберите действие: "}, menu)
+}
+
+func (c *Cli) createCollection(orgID string) (string, error) {
+	name, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите название коллекции: "},
+		validator.ValidateStringLength(3, 25),
+	)
+	if err != nil {
+		return "", err
+	}
+	err = c.organizations.CreateCollection(context.Background(), orgID, name)
+	if err != nil {
+		return "", err
+	}
+	return organization + "/" + orgID, nil
+}
+
+func (c *Cli) deleteCollection(orgID, collectionID string) (string, error) {
+	err := c.organizations.DeleteCollection(context.Background(), collectionID)
+	if err != nil {
+		return "", err
+	}
+	return organization + "/" + orgID, nil
+}
+
+func (c *Cli) getOrganization(orgID string) (*entities.Organization, error) {
+	list, err := c.organizations.GetOrganizations(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for _, org := range list {
+		if org.ID == orgID {
+			return org, nil
+		}
+	}
+	return nil, entities.ErrOrganizationNotFound
+}
+
+func (c *Cli) selectRole() (uint32, error) {
+	role, err := c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите роль: "},
+		[]prompt.SelectItem{
+			{
+				Label:  entities.AdminRoleLabel,
+				Action: strconv.Itoa(entities.RoleAdmin),
+			},
+			{
+				Label:  entities.MemberRoleLabel,
+				Action: strconv.Itoa(entities.RoleMember),
+			},
+			{
+				Label:  entities.ViewerRoleLabel,
+				Action: strconv.Itoa(entities.RoleViewer),
+			},
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+	r, err := strconv.ParseUint(role, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(r), nil
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_organization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	organizations := mocks.NewMockOrganizations(ctrl)
+
+	c := &Cli{
+		prompt:        prompter,
+		organizations: organizations,
+	}
+
+	collections := []*entities.Collection{
+		{ID: "col1", OrganizationID: "org", Name: "Сервера"},
+	}
+	menuPrompt := prompt.PromptContent{Label: "Выберите коллекцию: "}
+
+	tests := []struct {
+		name      string
+		role      uint32
+		menuItems []prompt.SelectItem
+		mockTimes map[string]int
+		errors    map[string]error
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "get collections error",
+			role: entities.RoleAdmin,
+			mockTimes: map[string]int{
+				"promptSelect": 0,
+			},
+			errors: map[string]error{
+				"getCollections": errors.New("get collections error"),
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "admin",
+			role: entities.RoleAdmin,
+			menuItems: []prompt.SelectItem{
+				{Label: "Сервера", Action: "collection/org/col1"},
+				{Label: "Создать коллекцию", Action: "createCollection/org"},
+				{Label: "Пригласить участника", Action: "inviteMember/org"},
+				{Label: "Участники", Action: "members/org"},
+				{Label: "Вернуться назад", Action: "organizations"},
+			},
+			mockTimes: map[string]int{
+				"promptSelect": 1,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "viewer",
+			role: entities.RoleViewer,
+			menuItems: []prompt.SelectItem{
+				{Label: "Сервера", Action: "collection/org/col1"},
+				{Label: "Участники", Action: "members/org"},
+				{Label: "Вернуться назад", Action: "organizations"},
+			},
+			mockTimes: map[string]int{
+				"promptSelect": 1,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgs := []*entities.Organization{{ID: "org", Name: "Команда", Role: tt.role}}
+			organizations.EXPECT().GetOrganizations(gomock.Any()).Return(orgs, nil).Times(1)
+			organizations.EXPECT().GetCollections(gomock.Any(), "org").Return(collections, tt.errors["getCollections"]).Times(1)
+			prompter.EXPECT().PromptGetSelect(menuPrompt, tt.menuItems).Return("members/org", nil).Times(tt.mockTimes["promptSelect"])
+
+			_, err := c.organization("org")
+			tt.wantErr(t, err, fmt.Sprintf("organization()"))
+		})
+	}
+}
+
+func TestCli_collectionMenu(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	organizations := mocks.NewMockOrganizations(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		prompt:        prompter,
+		organizations: organizations,
+		secrets:       secrets,
+	}
+
+	orgs := []*entities.Organization{{ID: "org", Name: "Команда", Role: entities.RoleMember}}
+	organizations.EXPECT().GetOrganizations(gomock.Any()).Return(orgs, nil).Times(1)
+	prompter.EXPECT().PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите действие: "},
+		[]prompt.SelectItem{
+			{Label: "Сохранить данные", Action: "addData"},
+			{Label: "Получить данные", Action: "getData"},
+			{Label: "Вернуться назад", Action: "organization/org"},
+		},
+	).Return("addData", nil).Times(1)
+
+	action, err := c.collectionMenu("org", "col1")
+	assert.NoError(t, err)
+	assert.Equal(t, "addData", action)
+	assert.Equal(t, "col1", c.filter.CollectionID)
+
+	secret := c.newSecret(entities.TypeText)
+	assert.Equal(t, &entities.Secret{SecretType: entities.TypeText, CollectionID: "col1", OrganizationID: "org"}, secret)
+
+	prompter.EXPECT().PromptGetSelect(gomock.Any(), gomock.Any()).Return("getData", nil).Times(1)
+	_, err = c.dataMenu()
+	assert.NoError(t, err)
+	assert.Nil(t, c.collection)
+	assert.Equal(t, "", c.filter.CollectionID)
+	assert.Equal(t, &entities.Secret{SecretType: entities.TypeText}, c.newSecret(entities.TypeText))
+}
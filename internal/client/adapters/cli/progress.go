@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+)
+
+type progress struct {
+	label   string
+	total   int64
+	done    int64
+	percent int64
+	out     io.Writer
+}
+
+func newProgress(label string, total int64, out io.Writer) *progress {
+	return &progress{label: label, total: total, percent: -1, out: out}
+}
+
+func (p *progress) add(n int) {
+	p.done += int64(n)
+	percent := int64(100)
+	if p.total > 0 && p.done < p.total {
+		percent = p.done * 100 / p.total
+	}
+	if percent == p.percent {
+		return
+	}
+	p.percent = percent
+	fmt.Fprintf(p.out, "\r%s: %d%%", p.label, percent)
+}
+
+func (p *progress) finish() {
+	fmt.Fprintln(p.out)
+}
+
+type progressReader struct {
+	r io.Reader
+	p *progress
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	r.p.add(n)
+	return n, err
+}
+
+type progressWriter struct {
+	w io.Writer
+	p *progress
+}
+
+func (w *progressWriter) Write(b []byte) (int, error) {
+	n, err := w.w.Write(b)
+	w.p.add(n)
+	return n, err
+}
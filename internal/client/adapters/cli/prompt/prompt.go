@@ -19,8 +19,9 @@ func NewPrompt() *Prompt {
 }
 
 type PromptContent struct {
-	Label string
-	Mask  rune
+	Label   string
+	Mask    rune
+	Default string
 }
 
 type SelectItem struct {
@@ -41,6 +42,8 @@ func (p *Prompt) PromptGetInput(pc PromptContent, validate func(input string) er
 		Templates: templates,
 		Validate:  validate,
 		Mask:      pc.Mask,
+		Default:   pc.Default,
+		AllowEdit: pc.Default != "",
 	}
 
 	result, err := prompt.Run()
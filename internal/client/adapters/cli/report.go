@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+const month = 30 * 24 * time.Hour
+
+func (c *Cli) passwordReport() (string, error) {
+	months, err := c.inputNumber("Пароли старше (месяцев): ", 12, 1, 120)
+	if err != nil {
+		return "", err
+	}
+	report, err := c.report.PasswordReport(context.Background(), time.Duration(months)*month)
+	if err != nil {
+		return "", err
+	}
+	err = printPasswordReport(report)
+	if err != nil {
+		return "", err
+	}
+	return c.reportMenu(report)
+}
+
+func (c *Cli) breachReport() (string, error) {
+	report, err := c.report.BreachReport(context.Background())
+	if errors.Is(err, entities.ErrNoBreachIndex) {
+		fmt.Println(err.Error())
+		return dataMenu, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	err = printBreachReport(report)
+	if err != nil {
+		return "", err
+	}
+	return c.reportMenu(report)
+}
+
+func (c *Cli) reportMenu(report *entities.PasswordReport) (string, error) {
+	menu := make([]prompt.SelectItem, 0, len(report.Entries)+1)
+	for _, entry := range report.Entries {
+		menu = append(menu, prompt.SelectItem{
+			Label:  entry.Secret.Name + " (" + entry.IssueLabels() + ")",
+			Action: showData + "/" + entry.Secret.ID,
+		})
+	}
+	menu = append(menu, prompt.SelectItem{
+		Label:  comeBackLabel,
+		Action: dataMenu,
+	})
+	return c.prompt.PromptGetSelect(prompt.PromptContent{Label: "Выберите запись: "}, menu)
+}
+
+func printPasswordReport(report *entities.PasswordReport) error {
+	fmt.Printf("Проверено паролей: %d, с проблемами: %d\n", report.Total, len(report.Entries))
+	if len(report.Entries) == 0 {
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Название\tЛогин\tИзменен\tПроблемы")
+	for _, entry := range report.Entries {
+		updated := "-"
+		if !entry.Secret.UpdatedAt.IsZero() {
+			updated = entry.Secret.UpdatedAt.Local().Format(dateTimeLayout)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", entry.Secret.Name, reportLogin(entry), updated, entry.IssueLabels())
+	}
+	return w.Flush()
+}
+
+func printBreachReport(report *entities.PasswordReport) error {
+	fmt.Printf("Проверено паролей: %d, найдено в утечках: %d\n", report.Total, len(report.Entries))
+	if len(report.Entries) == 0 {
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Название\tЛогин\tВстречается в утечках")
+	for _, entry := range report.Entries {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", entry.Secret.Name, reportLogin(entry), entry.Breaches)
+	}
+	return w.Flush()
+}
+
+func reportLogin(entry *entities.PasswordReportEntry) string {
+	if p, ok := entry.Secret.Data.(*entities.Password); ok {
+		return p.Login
+	}
+	return ""
+}
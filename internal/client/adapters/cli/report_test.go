@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_passwordReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	report := mocks.NewMockReport(ctrl)
+
+	c := &Cli{
+		prompt: prompter,
+		report: report,
+	}
+
+	result := &entities.PasswordReport{
+		Total: 3,
+		Entries: []*entities.PasswordReportEntry{
+			{
+				Secret: &entities.Secret{
+					ID:         "mail",
+					Name:       "Mail",
+					SecretType: entities.TypePassword,
+					Data:       &entities.Password{Login: "ivan", Password: "qwerty"},
+					UpdatedAt:  time.Date(2022, 1, 10, 9, 0, 0, 0, time.Local),
+				},
+				Issues: entities.IssueWeak | entities.IssueOld,
+			},
+		},
+	}
+	monthsPrompt := prompt.PromptContent{Label: "Пароли старше (месяцев): ", Default: "12"}
+	menuPrompt := prompt.PromptContent{Label: "Выберите запись: "}
+	menuItems := []prompt.SelectItem{
+		{
+			Label:  "Mail (слабый пароль, давно не менялся)",
+			Action: "showData/mail",
+		},
+		{
+			Label:  "Вернуться назад",
+			Action: "dataMenu",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		mockTimes  map[string]int
+		errors     map[string]error
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "months prompt error",
+			mockTimes: map[string]int{
+				"report":       0,
+				"promptSelect": 0,
+			},
+			errors: map[string]error{
+				"promptInput": errors.New("prompt error"),
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "report error",
+			mockTimes: map[string]int{
+				"report":       1,
+				"promptSelect": 0,
+			},
+			errors: map[string]error{
+				"report": errors.New("report error"),
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "success",
+			mockTimes: map[string]int{
+				"report":       1,
+				"promptSelect": 1,
+			},
+			errors:     map[string]error{},
+			wantAction: "showData/mail",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompter.EXPECT().PromptGetInput(monthsPrompt, gomock.Any()).Return("6", tt.errors["promptInput"]).Times(1)
+			report.EXPECT().PasswordReport(gomock.Any(), 6*month).Return(result, tt.errors["report"]).Times(tt.mockTimes["report"])
+			prompter.EXPECT().PromptGetSelect(menuPrompt, menuItems).Return(tt.wantAction, nil).Times(tt.mockTimes["promptSelect"])
+
+			action, err := c.passwordReport()
+			if !tt.wantErr(t, err, fmt.Sprintf("passwordReport()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "passwordReport()")
+		})
+	}
+}
+
+func TestCli_breachReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	report := mocks.NewMockReport(ctrl)
+
+	c := &Cli{
+		prompt: prompter,
+		report: report,
+	}
+
+	result := &entities.PasswordReport{
+		Total: 2,
+		Entries: []*entities.PasswordReportEntry{
+			{
+				Secret: &entities.Secret{
+					ID:         "mail",
+					Name:       "Mail",
+					SecretType: entities.TypePassword,
+					Data:       &entities.Password{Login: "ivan", Password: "123456"},
+				},
+				Issues:   entities.IssueBreached,
+				Breaches: 37359195,
+			},
+		},
+	}
+	menuPrompt := prompt.PromptContent{Label: "Выберите запись: "}
+	menuItems := []prompt.SelectItem{
+		{
+			Label:  "Mail (найден в утечках)",
+			Action: "showData/mail",
+		},
+		{
+			Label:  "Вернуться назад",
+			Action: "dataMenu",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		reportErr  error
+		selectTime int
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "no index",
+			reportErr:  entities.ErrNoBreachIndex,
+			selectTime: 0,
+			wantAction: "dataMenu",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "report error",
+			reportErr:  errors.New("report error"),
+			selectTime: 0,
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "success",
+			selectTime: 1,
+			wantAction: "showData/mail",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report.EXPECT().BreachReport(gomock.Any()).Return(result, tt.reportErr).Times(1)
+			prompter.EXPECT().PromptGetSelect(menuPrompt, menuItems).Return(tt.wantAction, nil).Times(tt.selectTime)
+
+			action, err := c.breachReport()
+			if !tt.wantErr(t, err, fmt.Sprintf("breachReport()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "breachReport()")
+		})
+	}
+}
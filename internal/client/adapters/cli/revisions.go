@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+)
+
+func (c *Cli) revisions(secretID string) (string, error) {
+	list, err := c.secrets.GetRevisions(context.Background(), secretID)
+	if err != nil {
+		return "", err
+	}
+	menu := make([]prompt.SelectItem, 0, len(list)+1)
+	for _, revision := range list {
+		menu = append(menu, prompt.SelectItem{
+			Label:  revision.CreatedAt.Local().Format(dateTimeLayout) + " " + revision.Secret.Name,
+			Action: showRevision + "/" + secretID + "/" + revision.ID,
+		})
+	}
+	menu = append(menu, prompt.SelectItem{
+		Label:  comeBackLabel,
+		Action: showData + "/" + secretID,
+	})
+
+	return c.prompt.PromptGetSelect(prompt.PromptContent{Label: "Выберите версию: "}, menu)
+}
+
+func (c *Cli) showRevision(secretID, revisionID string) (string, error) {
+	list, err := c.secrets.GetRevisions(context.Background(), secretID)
+	if err != nil {
+		return "", err
+	}
+	for _, revision := range list {
+		if revision.ID != revisionID {
+			continue
+		}
+		fmt.Println("Версия от: ", revision.CreatedAt.Local().Format(dateTimeLayout))
+		err = printSecret(revision.Secret)
+		if err != nil {
+			return "", err
+		}
+		return c.prompt.PromptGetSelect(
+			prompt.PromptContent{Label: "Выберите действие: "},
+			[]prompt.SelectItem{
+				{
+					Label:  restoreRevisionLabel,
+					Action: restoreRevision + "/" + secretID + "/" + revisionID,
+				},
+				{
+					Label:  comeBackLabel,
+					Action: revisions + "/" + secretID,
+				},
+			})
+	}
+	return "", fmt.Errorf("revision %v not found", revisionID)
+}
+
+func (c *Cli) restoreRevision(secretID, revisionID string) (string, error) {
+	err := c.secrets.RestoreRevision(context.Background(), secretID, revisionID)
+	if err != nil {
+		return "", err
+	}
+	return showData + "/" + secretID, nil
+}
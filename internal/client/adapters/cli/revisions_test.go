@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_revisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		prompt:  prompter,
+		secrets: secrets,
+	}
+
+	createdAt := time.Date(2024, 6, 10, 9, 0, 0, 0, time.Local)
+	list := []*entities.Revision{
+		{
+			ID:        "rev1",
+			CreatedAt: createdAt,
+			Secret:    &entities.Secret{ID: "text", Name: "First", SecretType: entities.TypeText, Data: "Lorem ipsum..."},
+		},
+	}
+	menuPrompt := prompt.PromptContent{Label: "Выберите версию: "}
+	menuItems := []prompt.SelectItem{
+		{
+			Label:  "10.06.2024 09:00:00 First",
+			Action: "showRevision/text/rev1",
+		},
+		{
+			Label:  "Вернуться назад",
+			Action: "showData/text",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		mockTimes  map[string]int
+		errors     map[string]error
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "get revisions error",
+			mockTimes: map[string]int{
+				"promptSelect": 0,
+			},
+			errors: map[string]error{
+				"getRevisions": errors.New("get revisions error"),
+				"promptSelect": nil,
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "prompt error",
+			mockTimes: map[string]int{
+				"promptSelect": 1,
+			},
+			errors: map[string]error{
+				"getRevisions": nil,
+				"promptSelect": errors.New("prompt error"),
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "success",
+			mockTimes: map[string]int{
+				"promptSelect": 1,
+			},
+			errors: map[string]error{
+				"getRevisions": nil,
+				"promptSelect": nil,
+			},
+			wantAction: "showRevision/text/rev1",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets.EXPECT().GetRevisions(gomock.Any(), "text").Return(list, tt.errors["getRevisions"]).Times(1)
+			prompter.EXPECT().PromptGetSelect(menuPrompt, menuItems).Return(tt.wantAction, tt.errors["promptSelect"]).Times(tt.mockTimes["promptSelect"])
+
+			action, err := c.revisions("text")
+			if !tt.wantErr(t, err, fmt.Sprintf("revisions()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "revisions()")
+		})
+	}
+}
+
+func TestCli_restoreRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		secrets: secrets,
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "restore error",
+			err:        errors.New("restore error"),
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "success",
+			err:        nil,
+			wantAction: "showData/text",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets.EXPECT().RestoreRevision(gomock.Any(), "text", "rev1").Return(tt.err).Times(1)
+
+			action, err := c.restoreRevision("text", "rev1")
+			if !tt.wantErr(t, err, fmt.Sprintf("restoreRevision()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "restoreRevision()")
+		})
+	}
+}
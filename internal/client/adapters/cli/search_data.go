@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"strconv"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/pkg/validator"
+)
+
+func (c *Cli) filterMenu() []prompt.SelectItem {
+	menu := []prompt.SelectItem{
+		{
+			Label:  searchDataLabel,
+			Action: searchData,
+		},
+		{
+			Label:  filterDataLabel,
+			Action: filterData,
+		},
+		{
+			Label:  tagsLabel,
+			Action: tags,
+		},
+	}
+	if c.collection == nil {
+		menu = append(menu, prompt.SelectItem{
+			Label:  foldersLabel,
+			Action: folders + "/",
+		})
+	}
+	menu = append(menu, prompt.SelectItem{
+		Label:  sortDataLabel,
+		Action: sortData,
+	})
+	if !c.filter.IsEmpty() {
+		menu = append(menu, prompt.SelectItem{
+			Label:  resetFilterLabel,
+			Action: resetFilter,
+		})
+	}
+	return menu
+}
+
+func (c *Cli) searchData() (string, error) {
+	query, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите часть названия: ", Default: c.filter.Query},
+		validator.ValidateStringLength(0, 100),
+	)
+	if err != nil {
+		return "", err
+	}
+	c.filter.Query = query
+	return getData, nil
+}
+
+func (c *Cli) filterData() (string, error) {
+	return c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите тип данных: "},
+		[]prompt.SelectItem{
+			{
+				Label:  allTypesLabel,
+				Action: setTypeFilter + "/0",
+			},
+			{
+				Label:  entities.TextLabel,
+				Action: setTypeFilter + "/" + strconv.Itoa(entities.TypeText),
+			},
+			{
+				Label:  entities.PasswordLabel,
+				Action: setTypeFilter + "/" + strconv.Itoa(entities.TypePassword),
+			},
+			{
+				Label:  entities.CardLabel,
+				Action: setTypeFilter + "/" + strconv.Itoa(entities.TypeCard),
+			},
+			{
+				Label:  entities.TOTPLabel,
+				Action: setTypeFilter + "/" + strconv.Itoa(entities.TypeTOTP),
+			},
+			{
+				Label:  entities.SSHKeyLabel,
+				Action: setTypeFilter + "/" + strconv.Itoa(entities.TypeSSHKey),
+			},
+			{
+				Label:  entities.BinaryLabel,
+				Action: setTypeFilter + "/" + strconv.Itoa(entities.TypeBinary),
+			},
+			{
+				Label:  comeBackLabel,
+				Action: getData,
+			},
+		})
+}
+
+func (c *Cli) setTypeFilter(secretType string) (string, error) {
+	t, err := strconv.ParseUint(secretType, 10, 32)
+	if err != nil {
+		return "", err
+	}
+	c.filter.SecretType = uint32(t)
+	return getData, nil
+}
+
+func (c *Cli) sortData() (string, error) {
+	return c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите порядок сортировки: "},
+		[]prompt.SelectItem{
+			{
+				Label:  entities.SortNameAscLabel,
+				Action: setSort + "/" + strconv.Itoa(entities.SortNameAsc),
+			},
+			{
+				Label:  entities.SortNameDescLabel,
+				Action: setSort + "/" + strconv.Itoa(entities.SortNameDesc),
+			},
+			{
+				Label:  entities.SortUpdatedDescLabel,
+				Action: setSort + "/" + strconv.Itoa(entities.SortUpdatedDesc),
+			},
+			{
+				Label:  entities.SortUpdatedAscLabel,
+				Action: setSort + "/" + strconv.Itoa(entities.SortUpdatedAsc),
+			},
+			{
+				Label:  comeBackLabel,
+				Action: getData,
+			},
+		})
+}
+
+func (c *Cli) setSort(sort string) (string, error) {
+	s, err := strconv.Atoi(sort)
+	if err != nil {
+		return "", err
+	}
+	c.filter.Sort = s
+	return getData, nil
+}
+
+func (c *Cli) resetFilter() (string, error) {
+	c.filter = entities.SecretsFilter{CollectionID: c.filter.CollectionID}
+	return getData, nil
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_searchData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantQuery  string
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "prompt error",
+			err:        errors.New("prompt error"),
+			wantQuery:  "old",
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "success",
+			err:        nil,
+			wantQuery:  "new",
+			wantAction: "getData",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cli{
+				prompt: prompter,
+				filter: entities.SecretsFilter{Query: "old"},
+			}
+			p := prompt.PromptContent{Label: "Введите часть названия: ", Default: "old"}
+			prompter.EXPECT().PromptGetInput(p, gomock.Any()).Return("new", tt.err).Times(1)
+
+			action, err := c.searchData()
+			tt.wantErr(t, err, fmt.Sprintf("searchData()"))
+			assert.Equalf(t, tt.wantAction, action, "searchData()")
+			assert.Equal(t, tt.wantQuery, c.filter.Query)
+		})
+	}
+}
+
+func TestCli_setTypeFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		secretType string
+		want       uint32
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "wrong type",
+			secretType: "text",
+			want:       0,
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "success",
+			secretType: "2",
+			want:       entities.TypePassword,
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cli{}
+			_, err := c.setTypeFilter(tt.secretType)
+			tt.wantErr(t, err, fmt.Sprintf("setTypeFilter()"))
+			assert.Equal(t, tt.want, c.filter.SecretType)
+		})
+	}
+}
+
+func TestCli_setSort(t *testing.T) {
+	c := &Cli{}
+	action, err := c.setSort("2")
+	assert.NoError(t, err)
+	assert.Equal(t, "getData", action)
+	assert.Equal(t, entities.SortUpdatedDesc, c.filter.Sort)
+
+	_, err = c.setSort("name")
+	assert.Error(t, err)
+}
+
+func TestCli_resetFilter(t *testing.T) {
+	c := &Cli{filter: entities.SecretsFilter{Query: "first", SecretType: entities.TypeText, Sort: entities.SortNameDesc}}
+	action, err := c.resetFilter()
+	assert.NoError(t, err)
+	assert.Equal(t, "getData", action)
+	assert.True(t, c.filter.IsEmpty())
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+// selectData lists the page with checkboxes, the selection is kept across
+// pages until the secrets are deleted or the list is opened again
+func (c *Cli) selectData(pageToken string) (string, error) {
+	page, err := c.secrets.SearchSecrets(context.Background(), &c.filter, pageToken)
+	if err != nil {
+		return "", err
+	}
+	menu := make([]prompt.SelectItem, 0, len(page.Secrets)+3)
+	for _, secret := range page.Secrets {
+		// the server deletes the whole batch or nothing
+		if secret.CollectionID != "" && !secret.CanEdit() {
+			continue
+		}
+		mark := "[ ] "
+		if _, ok := c.selected[secret.ID]; ok {
+			mark = "[x] "
+		}
+		menu = append(menu, prompt.SelectItem{
+			Label:  mark + secret.Name + " (" + secret.GetLabel() + ")",
+			Action: toggleSelected + "/" + pageToken + "/" + secret.ID,
+		})
+	}
+	if page.NextPageToken != "" {
+		menu = append(menu, prompt.SelectItem{
+			Label:  nextPageLabel,
+			Action: selectData + "/" + page.NextPageToken,
+		})
+	}
+	if len(c.selected) > 0 {
+		menu = append(menu, prompt.SelectItem{
+			Label:  fmt.Sprintf("%s (%d)", deleteSelectedLabel, len(c.selected)),
+			Action: deleteSelected,
+		})
+	}
+	menu = append(menu, prompt.SelectItem{
+		Label:  comeBackLabel,
+		Action: getDataPage + "/" + pageToken,
+	})
+	return c.prompt.PromptGetSelect(prompt.PromptContent{Label: "Отметьте записи: "}, menu)
+}
+
+func (c *Cli) toggleSelected(pageToken, id string) (string, error) {
+	if c.selected == nil {
+		c.selected = make(map[string]struct{})
+	}
+	if _, ok := c.selected[id]; ok {
+		delete(c.selected, id)
+	} else {
+		c.selected[id] = struct{}{}
+	}
+	return selectData + "/" + pageToken, nil
+}
+
+func (c *Cli) deleteSelected() (string, error) {
+	if len(c.selected) == 0 {
+		return getData, nil
+	}
+	if len(c.selected) > entities.MaxBatchSize {
+		fmt.Printf("За один раз можно удалить не больше %d записей\n", entities.MaxBatchSize)
+		return selectData + "/", nil
+	}
+	confirm, err := c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: fmt.Sprintf("Удалить выбранные записи (%d)? ", len(c.selected))},
+		[]prompt.SelectItem{
+			{Label: noLabel, Action: no},
+			{Label: yesLabel, Action: yes},
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	if confirm != yes {
+		return selectData + "/", nil
+	}
+	ids := make([]string, 0, len(c.selected))
+	for id := range c.selected {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	err = c.secrets.DeleteSecrets(context.Background(), ids)
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("Удалено записей: %d\n", len(ids))
+	c.selected = nil
+	return getData, nil
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_selectData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	page := &entities.SecretsPage{
+		Secrets: []*entities.Secret{
+			{ID: "text", Name: "First", SecretType: entities.TypeText},
+			{ID: "org", Name: "Org", SecretType: entities.TypeText, CollectionID: "col", Permission: entities.PermissionRead},
+			{ID: "password", Name: "Second", SecretType: entities.TypePassword},
+		},
+		NextPageToken: "token2",
+	}
+	selectPrompt := prompt.PromptContent{Label: "Отметьте записи: "}
+
+	tests := []struct {
+		name      string
+		selected  map[string]struct{}
+		menuItems []prompt.SelectItem
+	}{
+		{
+			name: "nothing selected",
+			menuItems: []prompt.SelectItem{
+				{Label: "[ ] First (Текстовые данные)", Action: "toggleSelected/token1/text"},
+				{Label: "[ ] Second (Данные для входа(логин/пароль))", Action: "toggleSelected/token1/password"},
+				{Label: "Следующая страница", Action: "selectData/token2"},
+				{Label: "Вернуться назад", Action: "getDataPage/token1"},
+			},
+		},
+		{
+			name:     "selected on other page",
+			selected: map[string]struct{}{"text": {}, "other": {}},
+			menuItems: []prompt.SelectItem{
+				{Label: "[x] First (Текстовые данные)", Action: "toggleSelected/token1/text"},
+				{Label: "[ ] Second (Данные для входа(логин/пароль))", Action: "toggleSelected/token1/password"},
+				{Label: "Следующая страница", Action: "selectData/token2"},
+				{Label: "Удалить выбранные (2)", Action: "deleteSelected"},
+				{Label: "Вернуться назад", Action: "getDataPage/token1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cli{
+				prompt:   prompter,
+				secrets:  secrets,
+				selected: tt.selected,
+			}
+
+			secrets.EXPECT().SearchSecrets(gomock.Any(), gomock.Any(), "token1").Return(page, nil).Times(1)
+			prompter.EXPECT().PromptGetSelect(selectPrompt, tt.menuItems).Return("deleteSelected", nil).Times(1)
+
+			action, err := c.selectData("token1")
+			if !assert.NoError(t, err, fmt.Sprintf("selectData()")) {
+				return
+			}
+			assert.Equalf(t, "deleteSelected", action, "selectData()")
+		})
+	}
+}
+
+func TestCli_toggleSelected(t *testing.T) {
+	c := &Cli{}
+
+	action, err := c.toggleSelected("token1", "text")
+	assert.NoError(t, err)
+	assert.Equal(t, "selectData/token1", action)
+	assert.Equal(t, map[string]struct{}{"text": {}}, c.selected)
+
+	_, err = c.toggleSelected("token1", "text")
+	assert.NoError(t, err)
+	assert.Empty(t, c.selected)
+}
+
+func TestCli_deleteSelected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	confirmPrompt := prompt.PromptContent{Label: "Удалить выбранные записи (2)? "}
+
+	tests := []struct {
+		name         string
+		confirm      string
+		mockTimes    map[string]int
+		errors       map[string]error
+		wantSelected int
+		wantAction   string
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "declined",
+			confirm: "no",
+			mockTimes: map[string]int{
+				"delete": 0,
+			},
+			errors:       map[string]error{},
+			wantSelected: 2,
+			wantAction:   "selectData/",
+			wantErr:      assert.NoError,
+		},
+		{
+			name:    "delete error",
+			confirm: "yes",
+			mockTimes: map[string]int{
+				"delete": 1,
+			},
+			errors: map[string]error{
+				"delete": errors.New("delete error"),
+			},
+			wantSelected: 2,
+			wantAction:   "",
+			wantErr:      assert.Error,
+		},
+		{
+			name:    "success",
+			confirm: "yes",
+			mockTimes: map[string]int{
+				"delete": 1,
+			},
+			errors:       map[string]error{},
+			wantSelected: 0,
+			wantAction:   "getData",
+			wantErr:      assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cli{
+				prompt:   prompter,
+				secrets:  secrets,
+				selected: map[string]struct{}{"b": {}, "a": {}},
+			}
+
+			prompter.EXPECT().PromptGetSelect(confirmPrompt, gomock.Any()).Return(tt.confirm, nil).Times(1)
+			secrets.EXPECT().DeleteSecrets(gomock.Any(), []string{"a", "b"}).Return(tt.errors["delete"]).Times(tt.mockTimes["delete"])
+
+			action, err := c.deleteSelected()
+			assert.Len(t, c.selected, tt.wantSelected)
+			if !tt.wantErr(t, err, fmt.Sprintf("deleteSelected()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "deleteSelected()")
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/pkg/validator"
+)
+
+func (c *Cli) shares(secretID string) (string, error) {
+	list, err := c.secrets.GetShares(context.Background(), secretID)
+	if err != nil {
+		return "", err
+	}
+	menu := make([]prompt.SelectItem, 0, len(list)+2)
+	for _, share := range list {
+		menu = append(menu, prompt.SelectItem{
+			Label:  share.Email + " (" + entities.PermissionLabel(share.Permission) + ")",
+			Action: revokeShare + "/" + secretID + "/" + share.UserID,
+		})
+	}
+	menu = append(menu,
+		prompt.SelectItem{
+			Label:  shareDataLabel,
+			Action: shareData + "/" + secretID,
+		},
+		prompt.SelectItem{
+			Label:  comeBackLabel,
+			Action: showData + "/" + secretID,
+		},
+	)
+
+	return c.prompt.PromptGetSelect(prompt.PromptContent{Label: "Выберите пользователя, чтобы закрыть доступ: "}, menu)
+}
+
+func (c *Cli) shareData(secretID string) (string, error) {
+	email, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите email пользователя: "},
+		validator.ValidateEmail(),
+	)
+	if err != nil {
+		return "", err
+	}
+	permission, err := c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите уровень доступа: "},
+		[]prompt.SelectItem{
+			{
+				Label:  entities.ReadLabel,
+				Action: strconv.Itoa(entities.PermissionRead),
+			},
+			{
+				Label:  entities.ReadWriteLabel,
+				Action: strconv.Itoa(entities.PermissionReadWrite),
+			},
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	p, err := strconv.ParseUint(permission, 10, 32)
+	if err != nil {
+		return "", err
+	}
+	err = c.secrets.ShareSecret(context.Background(), secretID, email, uint32(p))
+	if err != nil {
+		return "", err
+	}
+	return shares + "/" + secretID, nil
+}
+
+func (c *Cli) revokeShare(secretID, userID string) (string, error) {
+	err := c.secrets.RevokeShare(context.Background(), secretID, userID)
+	if err != nil {
+		return "", err
+	}
+	return shares + "/" + secretID, nil
+}
@@ -0,0 +1,245 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_secretMenu(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret *entities.Secret
+		want   []string
+	}{
+		{
+			name:   "own secret",
+			secret: &entities.Secret{ID: "text"},
+			want:   []string{"editData/text", "organizeData/text", "fields/text", "revisions/text", "shares/text", "deleteData/text", "getData"},
+		},
+		{
+			name:   "shared read-write",
+			secret: &entities.Secret{ID: "text", Owner: "owner@zzz.com", Permission: entities.PermissionReadWrite},
+			want:   []string{"editData/text", "organizeData/text", "fields/text", "deleteData/text", "getData"},
+		},
+		{
+			name:   "shared read-only",
+			secret: &entities.Secret{ID: "text", Owner: "owner@zzz.com", Permission: entities.PermissionRead},
+			want:   []string{"deleteData/text", "getData"},
+		},
+		{
+			name: "shared read-only with hidden fields",
+			secret: &entities.Secret{
+				ID:         "text",
+				Owner:      "owner@zzz.com",
+				Permission: entities.PermissionRead,
+				Fields:     []entities.CustomField{{Name: "Ответ", Value: "Шарик", Type: entities.FieldHidden}},
+			},
+			want: []string{"revealFields/text", "deleteData/text", "getData"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actions := make([]string, 0)
+			for _, item := range secretMenu(tt.secret) {
+				actions = append(actions, item.Action)
+			}
+			assert.Equal(t, tt.want, actions)
+		})
+	}
+}
+
+func TestCli_shares(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		prompt:  prompter,
+		secrets: secrets,
+	}
+
+	list := []*entities.Share{
+		{UserID: "user1", Email: "aaa@zzz.com", Permission: entities.PermissionRead},
+	}
+	menuPrompt := prompt.PromptContent{Label: "Выберите пользователя, чтобы закрыть доступ: "}
+	menuItems := []prompt.SelectItem{
+		{
+			Label:  "aaa@zzz.com (Только чтение)",
+			Action: "revokeShare/text/user1",
+		},
+		{
+			Label:  "Поделиться",
+			Action: "shareData/text",
+		},
+		{
+			Label:  "Вернуться назад",
+			Action: "showData/text",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		mockTimes  map[string]int
+		errors     map[string]error
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "get shares error",
+			mockTimes: map[string]int{
+				"promptSelect": 0,
+			},
+			errors: map[string]error{
+				"getShares":    errors.New("get shares error"),
+				"promptSelect": nil,
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "success",
+			mockTimes: map[string]int{
+				"promptSelect": 1,
+			},
+			errors: map[string]error{
+				"getShares":    nil,
+				"promptSelect": nil,
+			},
+			wantAction: "revokeShare/text/user1",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets.EXPECT().GetShares(gomock.Any(), "text").Return(list, tt.errors["getShares"]).Times(1)
+			prompter.EXPECT().PromptGetSelect(menuPrompt, menuItems).Return(tt.wantAction, tt.errors["promptSelect"]).Times(tt.mockTimes["promptSelect"])
+
+			action, err := c.shares("text")
+			if !tt.wantErr(t, err, fmt.Sprintf("shares()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "shares()")
+		})
+	}
+}
+
+func TestCli_shareData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		prompt:  prompter,
+		secrets: secrets,
+	}
+
+	tests := []struct {
+		name       string
+		mockTimes  map[string]int
+		errors     map[string]error
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "email prompt error",
+			mockTimes: map[string]int{
+				"promptSelect": 0,
+				"share":        0,
+			},
+			errors: map[string]error{
+				"promptInput": errors.New("prompt error"),
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "share error",
+			mockTimes: map[string]int{
+				"promptSelect": 1,
+				"share":        1,
+			},
+			errors: map[string]error{
+				"share": errors.New("share error"),
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "success",
+			mockTimes: map[string]int{
+				"promptSelect": 1,
+				"share":        1,
+			},
+			errors:     map[string]error{},
+			wantAction: "shares/text",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompter.EXPECT().PromptGetInput(gomock.Any(), gomock.Any()).Return("aaa@zzz.com", tt.errors["promptInput"]).Times(1)
+			prompter.EXPECT().PromptGetSelect(gomock.Any(), gomock.Any()).Return("2", nil).Times(tt.mockTimes["promptSelect"])
+			secrets.EXPECT().ShareSecret(gomock.Any(), "text", "aaa@zzz.com", uint32(entities.PermissionReadWrite)).
+				Return(tt.errors["share"]).Times(tt.mockTimes["share"])
+
+			action, err := c.shareData("text")
+			if !tt.wantErr(t, err, fmt.Sprintf("shareData()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "shareData()")
+		})
+	}
+}
+
+func TestCli_revokeShare(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		secrets: secrets,
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "revoke error",
+			err:        errors.New("revoke error"),
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "success",
+			err:        nil,
+			wantAction: "shares/text",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets.EXPECT().RevokeShare(gomock.Any(), "text", "user1").Return(tt.err).Times(1)
+
+			action, err := c.revokeShare("text", "user1")
+			if !tt.wantErr(t, err, fmt.Sprintf("revokeShare()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "revokeShare()")
+		})
+	}
+}
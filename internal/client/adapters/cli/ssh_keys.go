@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/pkg/sshkey"
+	"github.com/itohin/gophkeeper/pkg/validator"
+	"golang.org/x/crypto/ssh"
+)
+
+const importSSHKey = "import"
+
+func (c *Cli) addSSHKey() (string, error) {
+	secret := c.newSecret(entities.TypeSSHKey)
+	err := c.inputSSHKey(secret)
+	if err != nil {
+		return "", err
+	}
+	err = c.secrets.CreateSecret(context.Background(), secret)
+	if err != nil {
+		return "", err
+	}
+	return dataMenu, nil
+}
+
+// inputSSHKey creates the key pair for a new secret, existing keys only get
+// their description changed
+func (c *Cli) inputSSHKey(secret *entities.Secret) error {
+	data, ok := secret.Data.(*entities.SSHKey)
+	isNew := !ok
+	if isNew {
+		data = &entities.SSHKey{}
+	}
+	name, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите название: ", Default: secret.Name},
+		validator.ValidateStringLength(3, 25),
+	)
+	if err != nil {
+		return err
+	}
+	source := ""
+	if isNew {
+		source, err = c.prompt.PromptGetSelect(
+			prompt.PromptContent{Label: "Выберите способ добавления ключа: "},
+			[]prompt.SelectItem{
+				{Label: generateEd25519Label, Action: sshkey.TypeEd25519},
+				{Label: generateRSALabel, Action: sshkey.TypeRSA},
+				{Label: importSSHKeyLabel, Action: importSSHKey},
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	comment, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите комментарий к ключу: ", Default: data.Comment},
+		validator.ValidateStringLength(0, 100),
+	)
+	if err != nil {
+		return err
+	}
+	key := &sshkey.Key{PrivateKey: data.PrivateKey, PublicKey: data.PublicKey}
+	passphrase := data.Passphrase
+	switch source {
+	case "":
+		key.PublicKey, err = withComment(data.PublicKey, comment)
+	case importSSHKey:
+		key, passphrase, err = c.importSSHKey(comment)
+	default:
+		passphrase, err = c.inputPassphrase()
+		if err != nil {
+			return err
+		}
+		key, err = sshkey.Generate(source, comment, passphrase)
+	}
+	if err != nil {
+		return err
+	}
+	notes, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите примечания: ", Default: secret.Notes},
+		validator.ValidateStringLength(0, 500),
+	)
+	if err != nil {
+		return err
+	}
+	secret.Name = name
+	secret.Notes = notes
+	secret.Data = &entities.SSHKey{
+		PrivateKey: key.PrivateKey,
+		PublicKey:  key.PublicKey,
+		Comment:    comment,
+		Passphrase: passphrase,
+	}
+	return nil
+}
+
+func (c *Cli) importSSHKey(comment string) (*sshkey.Key, string, error) {
+	path, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите путь к приватному ключу: "},
+		validator.ValidateStringLength(1, 500),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	private, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	passphrase, err := c.inputPassphrase()
+	if err != nil {
+		return nil, "", err
+	}
+	signer, err := sshkey.NewSigner(string(private), passphrase)
+	if err != nil {
+		return nil, "", err
+	}
+	return &sshkey.Key{
+		PrivateKey: string(private),
+		PublicKey:  sshkey.AuthorizedKey(signer.PublicKey(), comment),
+	}, passphrase, nil
+}
+
+func (c *Cli) inputPassphrase() (string, error) {
+	return c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите парольную фразу ключа(оставьте пустым, если ее нет): ", Mask: 42},
+		validator.ValidateStringLength(0, 128),
+	)
+}
+
+func withComment(publicKey, comment string) (string, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return "", err
+	}
+	return sshkey.AuthorizedKey(key, comment), nil
+}
+
+func printSSHKey(secret *entities.Secret) {
+	data := secret.Data.(*entities.SSHKey)
+	fmt.Println("Название: ", secret.Name)
+	fmt.Println("Публичный ключ: ", data.PublicKey)
+	fmt.Println("Отпечаток: ", sshkey.Fingerprint(data.PublicKey))
+	fmt.Println("Комментарий: ", data.Comment)
+	fmt.Println("Примечания: ", secret.Notes)
+}
+
+// StartAgent logs the user in and serves the SSH keys of the vault until
+// the process is interrupted
+func (c *Cli) StartAgent(agent SSHAgent, socketPath string) error {
+	c.agent = agent
+	c.agentSocket = socketPath
+	return c.Start()
+}
+
+func (c *Cli) sshAgent() (string, error) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		select {
+		case <-c.shutdownCh:
+			stop()
+		case <-ctx.Done():
+		}
+	}()
+
+	socket, err := filepath.Abs(c.agentSocket)
+	if err != nil {
+		return "", err
+	}
+	fmt.Println("ssh-agent запущен, для использования ключей выполните:")
+	fmt.Printf("export SSH_AUTH_SOCK=%s\n", socket)
+	fmt.Println("Для завершения нажмите Ctrl+C")
+	err = c.agent.Serve(ctx, socket)
+	if err != nil {
+		return "", err
+	}
+	return logout, nil
+}
+
+// home is where the user lands after unlocking the vault
+func (c *Cli) home() string {
+	if c.agent != nil {
+		return sshAgent
+	}
+	return dataMenu
+}
@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/itohin/gophkeeper/pkg/sshkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCli_addSSHKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		prompt:  prompter,
+		secrets: secrets,
+	}
+
+	imported, err := sshkey.Generate(sshkey.TypeEd25519, "", "secret phrase")
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "id_ed25519")
+	require.NoError(t, os.WriteFile(path, []byte(imported.PrivateKey), 0600))
+
+	sourcePrompt := prompt.PromptContent{Label: "Выберите способ добавления ключа: "}
+	passphrasePrompt := prompt.PromptContent{Label: "Введите парольную фразу ключа(оставьте пустым, если ее нет): ", Mask: 42}
+
+	tests := []struct {
+		name       string
+		source     string
+		passphrase string
+		mockTimes  map[string]int
+		errors     map[string]error
+		wantPublic string
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "generate",
+			source: sshkey.TypeEd25519,
+			mockTimes: map[string]int{
+				"pathPrompt":   0,
+				"notesPrompt":  1,
+				"createSecret": 1,
+			},
+			errors:     map[string]error{},
+			wantAction: "dataMenu",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "import",
+			source:     "import",
+			passphrase: "secret phrase",
+			mockTimes: map[string]int{
+				"pathPrompt":   1,
+				"notesPrompt":  1,
+				"createSecret": 1,
+			},
+			errors:     map[string]error{},
+			wantPublic: imported.PublicKey + " john@zzz.com",
+			wantAction: "dataMenu",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "import with wrong passphrase",
+			source:     "import",
+			passphrase: "wrong",
+			mockTimes: map[string]int{
+				"pathPrompt":   1,
+				"notesPrompt":  0,
+				"createSecret": 0,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.Error,
+		},
+		{
+			name:   "create secret error",
+			source: sshkey.TypeEd25519,
+			mockTimes: map[string]int{
+				"pathPrompt":   0,
+				"notesPrompt":  1,
+				"createSecret": 1,
+			},
+			errors: map[string]error{
+				"createSecret": errors.New("create secret error"),
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompter.EXPECT().PromptGetInput(prompt.PromptContent{Label: "Введите название: "}, gomock.Any()).Return("Work", nil).Times(1)
+			prompter.EXPECT().PromptGetSelect(sourcePrompt, gomock.Any()).Return(tt.source, nil).Times(1)
+			prompter.EXPECT().PromptGetInput(prompt.PromptContent{Label: "Введите комментарий к ключу: "}, gomock.Any()).Return("john@zzz.com", nil).Times(1)
+			prompter.EXPECT().PromptGetInput(prompt.PromptContent{Label: "Введите путь к приватному ключу: "}, gomock.Any()).Return(path, nil).Times(tt.mockTimes["pathPrompt"])
+			prompter.EXPECT().PromptGetInput(passphrasePrompt, gomock.Any()).Return(tt.passphrase, nil).Times(1)
+			prompter.EXPECT().PromptGetInput(prompt.PromptContent{Label: "Введите примечания: "}, gomock.Any()).Return("notes", nil).Times(tt.mockTimes["notesPrompt"])
+
+			var created *entities.Secret
+			secrets.EXPECT().CreateSecret(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ interface{}, s *entities.Secret) error {
+					created = s
+					return tt.errors["createSecret"]
+				},
+			).Times(tt.mockTimes["createSecret"])
+
+			action, err := c.addSSHKey()
+			if !tt.wantErr(t, err, fmt.Sprintf("addSSHKey()")) || err != nil {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "addSSHKey()")
+
+			data := created.Data.(*entities.SSHKey)
+			assert.Equal(t, entities.TypeSSHKey, int(created.SecretType))
+			assert.Equal(t, "john@zzz.com", data.Comment)
+			assert.Equal(t, tt.passphrase, data.Passphrase)
+			if tt.wantPublic != "" {
+				assert.Equal(t, tt.wantPublic, data.PublicKey)
+			}
+			signer, err := sshkey.NewSigner(data.PrivateKey, data.Passphrase)
+			require.NoError(t, err)
+			assert.Equal(t, data.PublicKey, sshkey.AuthorizedKey(signer.PublicKey(), data.Comment))
+		})
+	}
+}
+
+func TestCli_inputSSHKey_edit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	c := &Cli{prompt: prompter}
+
+	key, err := sshkey.Generate(sshkey.TypeEd25519, "old", "")
+	require.NoError(t, err)
+	secret := &entities.Secret{
+		ID:         "ssh",
+		Name:       "Work",
+		SecretType: entities.TypeSSHKey,
+		Data:       &entities.SSHKey{PrivateKey: key.PrivateKey, PublicKey: key.PublicKey, Comment: "old"},
+	}
+
+	prompter.EXPECT().PromptGetInput(prompt.PromptContent{Label: "Введите название: ", Default: "Work"}, gomock.Any()).Return("Work", nil).Times(1)
+	prompter.EXPECT().PromptGetInput(prompt.PromptContent{Label: "Введите комментарий к ключу: ", Default: "old"}, gomock.Any()).Return("new", nil).Times(1)
+	prompter.EXPECT().PromptGetInput(prompt.PromptContent{Label: "Введите примечания: "}, gomock.Any()).Return("", nil).Times(1)
+
+	err = c.inputSSHKey(secret)
+	require.NoError(t, err)
+	data := secret.Data.(*entities.SSHKey)
+	assert.Equal(t, key.PrivateKey, data.PrivateKey)
+	assert.Equal(t, strings.TrimSuffix(key.PublicKey, "old")+"new", data.PublicKey)
+	assert.Equal(t, "new", data.Comment)
+}
+
+func TestCli_home(t *testing.T) {
+	c := &Cli{}
+	assert.Equal(t, "dataMenu", c.home())
+
+	c.agent = mocks.NewMockSSHAgent(gomock.NewController(t))
+	assert.Equal(t, "sshAgent", c.home())
+}
@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/pkg/otp"
+	"github.com/itohin/gophkeeper/pkg/validator"
+)
+
+func (c *Cli) addTOTP() (string, error) {
+	secret := c.newSecret(entities.TypeTOTP)
+	err := c.inputTOTP(secret)
+	if err != nil {
+		return "", err
+	}
+	err = c.secrets.CreateSecret(context.Background(), secret)
+	if err != nil {
+		return "", err
+	}
+	return dataMenu, nil
+}
+
+func (c *Cli) inputTOTP(secret *entities.Secret) error {
+	data, ok := secret.Data.(*entities.TOTP)
+	if !ok {
+		data = &entities.TOTP{Digits: otp.DefaultDigits, Period: otp.DefaultPeriod}
+	}
+	uri, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите ссылку otpauth:// (оставьте пустым, чтобы ввести параметры вручную): "},
+		validateOTPURI(),
+	)
+	if err != nil {
+		return err
+	}
+	if uri != "" {
+		params, _ := otp.ParseURI(uri)
+		*data = entities.TOTP(*params)
+	}
+	name := secret.Name
+	if name == "" {
+		name = data.Issuer
+	}
+	name, err = c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите название: ", Default: name},
+		validator.ValidateStringLength(3, 25),
+	)
+	if err != nil {
+		return err
+	}
+	if uri == "" {
+		err = c.inputTOTPParams(data)
+		if err != nil {
+			return err
+		}
+	}
+	notes, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите примечания: ", Default: secret.Notes},
+		validator.ValidateStringLength(0, 500),
+	)
+	if err != nil {
+		return err
+	}
+	secret.Name = name
+	secret.Notes = notes
+	secret.Data = data
+	return nil
+}
+
+func (c *Cli) inputTOTPParams(data *entities.TOTP) error {
+	seed, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите секретный ключ: ", Mask: 42, Default: data.Secret},
+		otp.ValidateSecret,
+	)
+	if err != nil {
+		return err
+	}
+	issuer, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите название сервиса: ", Default: data.Issuer},
+		validator.ValidateStringLength(0, 50),
+	)
+	if err != nil {
+		return err
+	}
+	account, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите имя аккаунта: ", Default: data.Account},
+		validator.ValidateStringLength(0, 50),
+	)
+	if err != nil {
+		return err
+	}
+	digits, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите количество цифр кода: ", Default: strconv.Itoa(int(data.Digits))},
+		validateUint(otp.MinDigits, otp.MaxDigits),
+	)
+	if err != nil {
+		return err
+	}
+	period, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите период действия кода в секундах: ", Default: strconv.Itoa(int(data.Period))},
+		validateUint(1, otp.MaxPeriod),
+	)
+	if err != nil {
+		return err
+	}
+	algorithm, err := c.prompt.PromptGetSelect(
+		prompt.PromptContent{Label: "Выберите алгоритм: "},
+		[]prompt.SelectItem{
+			{Label: otp.AlgorithmName(otp.AlgorithmSHA1), Action: strconv.Itoa(otp.AlgorithmSHA1)},
+			{Label: otp.AlgorithmName(otp.AlgorithmSHA256), Action: strconv.Itoa(otp.AlgorithmSHA256)},
+			{Label: otp.AlgorithmName(otp.AlgorithmSHA512), Action: strconv.Itoa(otp.AlgorithmSHA512)},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	d, _ := strconv.ParseUint(digits, 10, 32)
+	p, _ := strconv.ParseUint(period, 10, 32)
+	a, err := strconv.ParseUint(algorithm, 10, 32)
+	if err != nil {
+		return err
+	}
+	*data = entities.TOTP{
+		Secret:    otp.NormalizeSecret(seed),
+		Issuer:    issuer,
+		Account:   account,
+		Digits:    uint32(d),
+		Period:    uint32(p),
+		Algorithm: uint32(a),
+	}
+	return nil
+}
+
+func printTOTP(secret *entities.Secret) {
+	data := secret.Data.(*entities.TOTP)
+	fmt.Println("Название: ", secret.Name)
+	fmt.Println("Сервис: ", data.Issuer)
+	fmt.Println("Аккаунт: ", data.Account)
+	fmt.Println("Код: ", totpCode(data, time.Now()))
+	fmt.Println("Примечания: ", secret.Notes)
+}
+
+// watchCode keeps the current code on screen until the user presses Enter
+func (c *Cli) watchCode(id string) (string, error) {
+	secret, err := c.secrets.GetSecret(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	data, ok := secret.Data.(*entities.TOTP)
+	if !ok {
+		return "", fmt.Errorf("unknown secret type %v", secret.SecretType)
+	}
+	done := make(chan struct{})
+	go func() {
+		bufio.NewReader(os.Stdin).ReadString('\n')
+		close(done)
+	}()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	fmt.Println("Нажмите Enter, чтобы вернуться")
+	for {
+		fmt.Printf("\rКод: %s    ", totpCode(data, time.Now()))
+		select {
+		case <-done:
+			return showData + "/" + id, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func totpCode(data *entities.TOTP, now time.Time) string {
+	params := otp.TOTP(*data)
+	code, left, err := params.Code(now)
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("%s (осталось %d сек.)", code, int(left.Seconds()))
+}
+
+func validateOTPURI() func(string) error {
+	return func(input string) error {
+		if input == "" {
+			return nil
+		}
+		_, err := otp.ParseURI(input)
+		return err
+	}
+}
+
+func validateUint(min, max uint64) func(string) error {
+	return func(input string) error {
+		v, err := strconv.ParseUint(input, 10, 32)
+		if err != nil || v < min || v > max {
+			return fmt.Errorf("введите число от %d до %d", min, max)
+		}
+		return nil
+	}
+}
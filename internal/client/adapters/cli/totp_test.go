@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_addTOTP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		prompt:  prompter,
+		secrets: secrets,
+	}
+
+	uriPrompt := prompt.PromptContent{Label: "Введите ссылку otpauth:// (оставьте пустым, чтобы ввести параметры вручную): "}
+	notesPrompt := prompt.PromptContent{Label: "Введите примечания: "}
+
+	tests := []struct {
+		name       string
+		uri        string
+		mockTimes  map[string]int
+		errors     map[string]error
+		want       *entities.Secret
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "from uri",
+			uri:  "otpauth://totp/GitHub:john?secret=JBSWY3DPEHPK3PXP&issuer=GitHub",
+			mockTimes: map[string]int{
+				"manual":       0,
+				"createSecret": 1,
+			},
+			errors: map[string]error{},
+			want: &entities.Secret{
+				Name:       "GitHub",
+				SecretType: entities.TypeTOTP,
+				Notes:      "notes",
+				Data: &entities.TOTP{
+					Secret:  "JBSWY3DPEHPK3PXP",
+					Issuer:  "GitHub",
+					Account: "john",
+					Digits:  6,
+					Period:  30,
+				},
+			},
+			wantAction: "dataMenu",
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "manual",
+			uri:  "",
+			mockTimes: map[string]int{
+				"manual":       1,
+				"createSecret": 1,
+			},
+			errors: map[string]error{},
+			want: &entities.Secret{
+				Name:       "GitHub",
+				SecretType: entities.TypeTOTP,
+				Notes:      "notes",
+				Data: &entities.TOTP{
+					Secret:    "JBSWY3DPEHPK3PXP",
+					Issuer:    "GitHub",
+					Account:   "john",
+					Digits:    8,
+					Period:    60,
+					Algorithm: 1,
+				},
+			},
+			wantAction: "dataMenu",
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "create secret error",
+			uri:  "otpauth://totp/GitHub:john?secret=JBSWY3DPEHPK3PXP&issuer=GitHub",
+			mockTimes: map[string]int{
+				"manual":       0,
+				"createSecret": 1,
+			},
+			errors: map[string]error{
+				"createSecret": errors.New("create secret error"),
+			},
+			want: &entities.Secret{
+				Name:       "GitHub",
+				SecretType: entities.TypeTOTP,
+				Notes:      "notes",
+				Data: &entities.TOTP{
+					Secret:  "JBSWY3DPEHPK3PXP",
+					Issuer:  "GitHub",
+					Account: "john",
+					Digits:  6,
+					Period:  30,
+				},
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := "GitHub"
+			if tt.uri == "" {
+				name = ""
+			}
+			prompter.EXPECT().PromptGetInput(uriPrompt, gomock.Any()).Return(tt.uri, nil).Times(1)
+			prompter.EXPECT().PromptGetInput(prompt.PromptContent{Label: "Введите название: ", Default: name}, gomock.Any()).Return("GitHub", nil).Times(1)
+			prompter.EXPECT().PromptGetInput(prompt.PromptContent{Label: "Введите секретный ключ: ", Mask: 42}, gomock.Any()).Return("jbsw y3dp ehpk 3pxp", nil).Times(tt.mockTimes["manual"])
+			prompter.EXPECT().PromptGetInput(prompt.PromptContent{Label: "Введите название сервиса: "}, gomock.Any()).Return("GitHub", nil).Times(tt.mockTimes["manual"])
+			prompter.EXPECT().PromptGetInput(prompt.PromptContent{Label: "Введите имя аккаунта: "}, gomock.Any()).Return("john", nil).Times(tt.mockTimes["manual"])
+			prompter.EXPECT().PromptGetInput(prompt.PromptContent{Label: "Введите количество цифр кода: ", Default: "6"}, gomock.Any()).Return("8", nil).Times(tt.mockTimes["manual"])
+			prompter.EXPECT().PromptGetInput(prompt.PromptContent{Label: "Введите период действия кода в секундах: ", Default: "30"}, gomock.Any()).Return("60", nil).Times(tt.mockTimes["manual"])
+			prompter.EXPECT().PromptGetSelect(prompt.PromptContent{Label: "Выберите алгоритм: "}, gomock.Any()).Return("1", nil).Times(tt.mockTimes["manual"])
+			prompter.EXPECT().PromptGetInput(notesPrompt, gomock.Any()).Return("notes", nil).Times(1)
+			secrets.EXPECT().CreateSecret(gomock.Any(), tt.want).Return(tt.errors["createSecret"]).Times(tt.mockTimes["createSecret"])
+
+			action, err := c.addTOTP()
+			if !tt.wantErr(t, err, fmt.Sprintf("addTOTP()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "addTOTP()")
+		})
+	}
+}
+
+func TestTotpCode(t *testing.T) {
+	data := &entities.TOTP{Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", Digits: 8, Period: 30}
+	assert.Equal(t, "94287082 (осталось 1 сек.)", totpCode(data, time.Unix(59, 0)))
+
+	data.Secret = "invalid!"
+	assert.NotContains(t, totpCode(data, time.Unix(59, 0)), "осталось")
+}
+
+func TestSecretMenu_totp(t *testing.T) {
+	menu := secretMenu(&entities.Secret{ID: "otp", SecretType: entities.TypeTOTP, Owner: "owner@zzz.com", Permission: entities.PermissionRead})
+	assert.Equal(t, []prompt.SelectItem{
+		{Label: "Показать код", Action: "watchCode/otp"},
+		{Label: "Удалить данные", Action: "deleteData/otp"},
+		{Label: "Вернуться назад", Action: "getData"},
+	}, menu)
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+)
+
+func (c *Cli) trash() (string, error) {
+	list, err := c.secrets.GetDeletedSecrets(context.Background())
+	if err != nil {
+		return "", err
+	}
+	menu := make([]prompt.SelectItem, 0, len(list)+1)
+	for _, deleted := range list {
+		menu = append(menu, prompt.SelectItem{
+			Label:  deleted.Secret.Name + " (" + deleted.Secret.GetLabel() + ", удалено " + deleted.DeletedAt.Local().Format(dateTimeLayout) + ")",
+			Action: trashData + "/" + deleted.Secret.ID,
+		})
+	}
+	menu = append(menu, prompt.SelectItem{
+		Label:  comeBackLabel,
+		Action: dataMenu,
+	})
+
+	return c.prompt.PromptGetSelect(prompt.PromptContent{Label: "Выберите запись: "}, menu)
+}
+
+func (c *Cli) trashData(id string) (string, error) {
+	list, err := c.secrets.GetDeletedSecrets(context.Background())
+	if err != nil {
+		return "", err
+	}
+	for _, deleted := range list {
+		if deleted.Secret.ID != id {
+			continue
+		}
+		fmt.Println("Удалено: ", deleted.DeletedAt.Local().Format(dateTimeLayout))
+		err = printSecret(deleted.Secret)
+		if err != nil {
+			return "", err
+		}
+		return c.prompt.PromptGetSelect(
+			prompt.PromptContent{Label: "Выберите действие: "},
+			[]prompt.SelectItem{
+				{
+					Label:  restoreDataLabel,
+					Action: restoreData + "/" + id,
+				},
+				{
+					Label:  purgeDataLabel,
+					Action: purgeData + "/" + id,
+				},
+				{
+					Label:  comeBackLabel,
+					Action: trash,
+				},
+			})
+	}
+	return "", fmt.Errorf("secret %v not found in trash", id)
+}
+
+func (c *Cli) restoreData(id string) (string, error) {
+	err := c.secrets.RestoreSecret(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	return showData + "/" + id, nil
+}
+
+func (c *Cli) purgeData(id string) (string, error) {
+	err := c.secrets.PurgeSecret(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	return trash, nil
+}
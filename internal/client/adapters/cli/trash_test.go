@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_trash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		prompt:  prompter,
+		secrets: secrets,
+	}
+
+	list := []*entities.DeletedSecret{
+		{
+			DeletedAt: time.Date(2024, 6, 10, 9, 0, 0, 0, time.Local),
+			Secret:    &entities.Secret{ID: "text", Name: "First", SecretType: entities.TypeText, Data: "Lorem ipsum..."},
+		},
+	}
+	menuPrompt := prompt.PromptContent{Label: "Выберите запись: "}
+	menuItems := []prompt.SelectItem{
+		{
+			Label:  "First (Текстовые данные, удалено 10.06.2024 09:00:00)",
+			Action: "trashData/text",
+		},
+		{
+			Label:  "Вернуться назад",
+			Action: "dataMenu",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		mockTimes  map[string]int
+		errors     map[string]error
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "get deleted secrets error",
+			mockTimes: map[string]int{
+				"promptSelect": 0,
+			},
+			errors: map[string]error{
+				"getDeleted":   errors.New("get deleted secrets error"),
+				"promptSelect": nil,
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "prompt error",
+			mockTimes: map[string]int{
+				"promptSelect": 1,
+			},
+			errors: map[string]error{
+				"getDeleted":   nil,
+				"promptSelect": errors.New("prompt error"),
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "success",
+			mockTimes: map[string]int{
+				"promptSelect": 1,
+			},
+			errors: map[string]error{
+				"getDeleted":   nil,
+				"promptSelect": nil,
+			},
+			wantAction: "trashData/text",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets.EXPECT().GetDeletedSecrets(gomock.Any()).Return(list, tt.errors["getDeleted"]).Times(1)
+			prompter.EXPECT().PromptGetSelect(menuPrompt, menuItems).Return(tt.wantAction, tt.errors["promptSelect"]).Times(tt.mockTimes["promptSelect"])
+
+			action, err := c.trash()
+			if !tt.wantErr(t, err, fmt.Sprintf("trash()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "trash()")
+		})
+	}
+}
+
+func TestCli_restoreData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		secrets: secrets,
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "restore error",
+			err:        errors.New("restore error"),
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "success",
+			err:        nil,
+			wantAction: "showData/text",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets.EXPECT().RestoreSecret(gomock.Any(), "text").Return(tt.err).Times(1)
+
+			action, err := c.restoreData("text")
+			if !tt.wantErr(t, err, fmt.Sprintf("restoreData()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "restoreData()")
+		})
+	}
+}
+
+func TestCli_purgeData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secrets := mocks.NewMockSecrets(ctrl)
+
+	c := &Cli{
+		secrets: secrets,
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "purge error",
+			err:        errors.New("purge error"),
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "success",
+			err:        nil,
+			wantAction: "trash",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets.EXPECT().PurgeSecret(gomock.Any(), "text").Return(tt.err).Times(1)
+
+			action, err := c.purgeData("text")
+			if !tt.wantErr(t, err, fmt.Sprintf("purgeData()")) {
+				return
+			}
+			assert.Equalf(t, tt.wantAction, action, "purgeData()")
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/pkg/validator"
+)
+
+func (c *Cli) unlock() (string, error) {
+	exists, err := c.auth.HasMasterPassword(context.Background())
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return c.createMasterPassword()
+	}
+
+	password, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Введите мастер-пароль: ", Mask: 42},
+		validator.ValidateStringLength(1, 128),
+	)
+	if err != nil {
+		return "", err
+	}
+	err = c.auth.Unlock(context.Background(), password)
+	if errors.Is(err, entities.ErrWrongMasterPassword) {
+		fmt.Println(err.Error())
+		return unlock, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return c.home(), nil
+}
+
+func (c *Cli) createMasterPassword() (string, error) {
+	password, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{
+			Label: "Придумайте мастер-пароль для шифрования данных(не менее 8 символов в разном регистре: буквы, цифры, спецсимволы.). Восстановить его невозможно: ",
+			Mask:  42,
+		},
+		validator.ValidatePassword(),
+	)
+	if err != nil {
+		return "", err
+	}
+	confirmation, err := c.prompt.PromptGetInput(
+		prompt.PromptContent{Label: "Повторите мастер-пароль: ", Mask: 42},
+		validator.ValidatePassword(),
+	)
+	if err != nil {
+		return "", err
+	}
+	if password != confirmation {
+		fmt.Println("Пароли не совпадают")
+		return unlock, nil
+	}
+	err = c.auth.CreateMasterPassword(context.Background(), password)
+	if err != nil {
+		return "", err
+	}
+	return c.home(), nil
+}
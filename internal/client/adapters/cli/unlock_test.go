@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/adapters/cli/prompt"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCli_unlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	auth := mocks.NewMockAuth(ctrl)
+
+	c := &Cli{
+		prompt: prompter,
+		auth:   auth,
+	}
+
+	passwordPrompt := prompt.PromptContent{Label: "Введите мастер-пароль: ", Mask: 42}
+
+	tests := []struct {
+		name       string
+		mockTimes  map[string]int
+		errors     map[string]error
+		wantAction string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "key params error",
+			mockTimes: map[string]int{
+				"prompt": 0,
+				"unlock": 0,
+			},
+			errors: map[string]error{
+				"hasMaster": errors.New("key params error"),
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "prompt error",
+			mockTimes: map[string]int{
+				"prompt": 1,
+				"unlock": 0,
+			},
+			errors: map[string]error{
+				"prompt": errors.New("prompt error"),
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "wrong master password",
+			mockTimes: map[string]int{
+				"prompt": 1,
+				"unlock": 1,
+			},
+			errors: map[string]error{
+				"unlock": entities.ErrWrongMasterPassword,
+			},
+			wantAction: "unlock",
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "unlock error",
+			mockTimes: map[string]int{
+				"prompt": 1,
+				"unlock": 1,
+			},
+			errors: map[string]error{
+				"unlock": errors.New("unlock error"),
+			},
+			wantAction: "",
+			wantErr:    assert.Error,
+		},
+		{
+			name: "success",
+			mockTimes: map[string]int{
+				"prompt": 1,
+				"unlock": 1,
+			},
+			errors:     map[string]error{},
+			wantAction: "dataMenu",
+			wantErr:    assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth.EXPECT().HasMasterPassword(gomock.Any()).Return(true, tt.errors["hasMaster"]).Times(1)
+			prompter.EXPECT().PromptGetInput(passwordPrompt, gomock.Any()).Return("master", tt.errors["prompt"]).Times(tt.mockTimes["prompt"])
+			auth.EXPECT().Unlock(gomock.Any(), "master").Return(tt.errors["unlock"]).Times(tt.mockTimes["unlock"])
+
+			action, err := c.unlock()
+			assert.Equal(t, tt.wantAction, action)
+			tt.wantErr(t, err, fmt.Sprintf("unlock()"))
+		})
+	}
+}
+
+func TestCli_createMasterPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prompter := mocks.NewMockPrompter(ctrl)
+	auth := mocks.NewMockAuth(ctrl)
+
+	c := &Cli{
+		prompt: prompter,
+		auth:   auth,
+	}
+
+	confirmPrompt := prompt.PromptContent{Label: "Повторите мастер-пароль: ", Mask: 42}
+
+	tests := []struct {
+		name         string
+		confirmation string
+		createTimes  int
+		createErr    error
+		wantAction   string
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name:         "passwords mismatch",
+			confirmation: "Other@pass1",
+			createTimes:  0,
+			wantAction:   "unlock",
+			wantErr:      assert.NoError,
+		},
+		{
+			name:         "create error",
+			confirmation: "Master@pass1",
+			createTimes:  1,
+			createErr:    errors.New("create error"),
+			wantAction:   "",
+			wantErr:      assert.Error,
+		},
+		{
+			name:         "success",
+			confirmation: "Master@pass1",
+			createTimes:  1,
+			wantAction:   "dataMenu",
+			wantErr:      assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompter.EXPECT().PromptGetInput(gomock.Not(confirmPrompt), gomock.Any()).Return("Master@pass1", nil).Times(1)
+			prompter.EXPECT().PromptGetInput(confirmPrompt, gomock.Any()).Return(tt.confirmation, nil).Times(1)
+			auth.EXPECT().CreateMasterPassword(gomock.Any(), "Master@pass1").Return(tt.createErr).Times(tt.createTimes)
+
+			action, err := c.createMasterPassword()
+			assert.Equal(t, tt.wantAction, action)
+			tt.wantErr(t, err, fmt.Sprintf("createMasterPassword()"))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package crypto
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"io"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+// A backup archive is sealed with a key derived from its own passphrase, not
+// from the master password, so it can be restored into any account. The
+// header holds the magic, the salt and a sealed key check, the rest is
+// a stream of segments as for binary secrets.
+var archiveMagic = []byte("GKBACKUP")
+
+func (c *Cipher) SealArchive(passphrase string, r io.Reader) (io.Reader, error) {
+	salt := make([]byte, saltSize)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := deriveAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	keyCheck, err := seal(aead, keyCheckPlaintext, archiveMagic)
+	if err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, streamPrefixSize)
+	_, err = rand.Read(prefix)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, 0, len(archiveMagic)+saltSize+len(keyCheck))
+	header = append(header, archiveMagic...)
+	header = append(header, salt...)
+	header = append(header, keyCheck...)
+	return io.MultiReader(
+		bytes.NewReader(header),
+		&sealReader{
+			aead:   aead,
+			src:    bufio.NewReaderSize(r, streamSegmentSize),
+			prefix: prefix,
+			ad:     archiveMagic,
+			out:    append([]byte{streamVersion}, prefix...),
+			seg:    make([]byte, streamSegmentSize),
+		},
+	), nil
+}
+
+// OpenArchive checks the passphrase right away and decrypts the rest of
+// the archive while it is read, the reader must be closed
+func (c *Cipher) OpenArchive(passphrase string, r io.Reader) (io.ReadCloser, error) {
+	header := make([]byte, len(archiveMagic)+saltSize)
+	_, err := io.ReadFull(r, header)
+	if err != nil || !bytes.Equal(header[:len(archiveMagic)], archiveMagic) {
+		return nil, entities.ErrNotBackup
+	}
+	aead, err := deriveAEAD(passphrase, header[len(archiveMagic):])
+	if err != nil {
+		return nil, err
+	}
+	keyCheck := make([]byte, 1+aead.NonceSize()+len(keyCheckPlaintext)+aead.Overhead())
+	_, err = io.ReadFull(r, keyCheck)
+	if err != nil {
+		return nil, entities.ErrNotBackup
+	}
+	_, err = open(aead, keyCheck, archiveMagic)
+	if err != nil {
+		return nil, entities.ErrWrongBackupPassphrase
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		w := &openWriter{aead: aead, dst: pw, ad: archiveMagic}
+		_, err := io.Copy(w, r)
+		if err == nil {
+			err = w.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
@@ -0,0 +1,42 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCipher_SealOpenArchive(t *testing.T) {
+	c := NewCipher()
+	content := make([]byte, 2*streamSegmentSize+5)
+	_, _ = rand.Read(content)
+
+	r, err := c.SealArchive("backup passphrase", bytes.NewReader(content))
+	require.NoError(t, err)
+	sealed, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	opened, err := c.OpenArchive("backup passphrase", bytes.NewReader(sealed))
+	require.NoError(t, err)
+	got, err := io.ReadAll(opened)
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+	assert.NoError(t, opened.Close())
+
+	_, err = c.OpenArchive("wrong passphrase", bytes.NewReader(sealed))
+	assert.ErrorIs(t, err, entities.ErrWrongBackupPassphrase)
+
+	_, err = c.OpenArchive("backup passphrase", bytes.NewReader([]byte("not a backup")))
+	assert.ErrorIs(t, err, entities.ErrNotBackup)
+
+	opened, err = c.OpenArchive("backup passphrase", bytes.NewReader(sealed[:len(sealed)-1]))
+	require.NoError(t, err)
+	_, err = io.ReadAll(opened)
+	assert.Error(t, err)
+	assert.NoError(t, opened.Close())
+}
@@ -0,0 +1,250 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	saltSize     = 16
+	keySize      = 32
+	argonTime    = 3
+	argonMemory  = 64 * 1024
+	argonThreads = 4
+
+	sealVersion byte = 1
+	keyIDSize        = 8
+)
+
+var keyCheckPlaintext = []byte("gophkeeper key check")
+
+type payload struct {
+	Data   json.RawMessage
+	Notes  string
+	Fields []entities.CustomField `json:",omitempty"`
+}
+
+type Cipher struct {
+	mx      sync.RWMutex
+	aead    cipher.AEAD
+	private *ecdh.PrivateKey
+	orgKeys map[string][]byte
+}
+
+func NewCipher() *Cipher {
+	return &Cipher{}
+}
+
+func (c *Cipher) NewKeyParams(password string) (*entities.KeyParams, error) {
+	salt := make([]byte, saltSize)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := deriveAEAD(password, salt)
+	if err != nil {
+		return nil, err
+	}
+	keyCheck, err := seal(aead, keyCheckPlaintext, nil)
+	if err != nil {
+		return nil, err
+	}
+	private, sealedPrivate, err := newKeyPair(aead)
+	if err != nil {
+		return nil, err
+	}
+	c.setKeys(aead, private)
+	return &entities.KeyParams{
+		Salt:             salt,
+		KeyCheck:         keyCheck,
+		PublicKey:        private.PublicKey().Bytes(),
+		SealedPrivateKey: sealedPrivate,
+	}, nil
+}
+
+func (c *Cipher) Unlock(password string, params *entities.KeyParams) error {
+	aead, err := deriveAEAD(password, params.Salt)
+	if err != nil {
+		return err
+	}
+	_, err = open(aead, params.KeyCheck, nil)
+	if err != nil {
+		return entities.ErrWrongMasterPassword
+	}
+	var private *ecdh.PrivateKey
+	if len(params.SealedPrivateKey) > 0 {
+		private, err = openPrivateKey(aead, params.SealedPrivateKey)
+		if err != nil {
+			return err
+		}
+	}
+	c.setKeys(aead, private)
+	return nil
+}
+
+func (c *Cipher) Lock() {
+	c.setKeys(nil, nil)
+}
+
+func (c *Cipher) Seal(secret *entities.Secret) (*entities.Secret, error) {
+	aead, itemKey, err := c.secretAEAD(secret)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(secret.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal secret data: %v", err)
+	}
+	plaintext, err := json.Marshal(payload{Data: data, Notes: secret.Notes, Fields: secret.Fields})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal secret payload: %v", err)
+	}
+	sealed, err := seal(aead, plaintext, additionalData(secret, itemKey))
+	if err != nil {
+		return nil, err
+	}
+	s := *secret
+	s.Notes = ""
+	s.Fields = nil
+	s.Data = entities.Sealed(sealed)
+	return &s, nil
+}
+
+func (c *Cipher) Open(secret *entities.Secret) (*entities.Secret, error) {
+	sealed, ok := secret.Data.(entities.Sealed)
+	if !ok {
+		return secret, nil
+	}
+	aead, itemKey, err := c.secretAEAD(secret)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := open(aead, sealed, additionalData(secret, itemKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret %v: %v", secret.ID, err)
+	}
+	var p payload
+	err = json.Unmarshal(plaintext, &p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal secret payload: %v", err)
+	}
+	data, err := unmarshalData(secret.SecretType, p.Data)
+	if err != nil {
+		return nil, err
+	}
+	s := *secret
+	s.Notes = p.Notes
+	s.Fields = p.Fields
+	s.Data = data
+	s.ItemKey = itemKey
+	return &s, nil
+}
+
+func (c *Cipher) setKeys(aead cipher.AEAD, private *ecdh.PrivateKey) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.aead = aead
+	c.private = private
+	c.orgKeys = make(map[string][]byte)
+}
+
+func (c *Cipher) getAEAD() cipher.AEAD {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+	return c.aead
+}
+
+func deriveAEAD(password string, salt []byte) (cipher.AEAD, error) {
+	if len(salt) < saltSize {
+		return nil, errors.New("invalid key salt")
+	}
+	key := argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, keySize)
+	return newAEAD(key)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, plaintext, ad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, 1+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, sealVersion)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, ad), nil
+}
+
+func open(aead cipher.AEAD, sealed, ad []byte) ([]byte, error) {
+	if len(sealed) < 1+aead.NonceSize() || sealed[0] != sealVersion {
+		return nil, errors.New("malformed ciphertext")
+	}
+	nonce := sealed[1 : 1+aead.NonceSize()]
+	return aead.Open(nil, nonce, sealed[1+aead.NonceSize():], ad)
+}
+
+// additionalData binds the ciphertext to the secret and to its key, so that
+// the server can not hand out the data of one secret as another one
+func additionalData(secret *entities.Secret, itemKey []byte) []byte {
+	ad := append([]byte{byte(secret.SecretType)}, keyID(itemKey)...)
+	return append(ad, secret.ID...)
+}
+
+// keyID identifies the item key without revealing it, secrets sealed with
+// the master key get zeros
+func keyID(itemKey []byte) []byte {
+	if len(itemKey) == 0 {
+		return make([]byte, keyIDSize)
+	}
+	sum := sha256.Sum256(append([]byte("gophkeeper item key id"), itemKey...))
+	return sum[:keyIDSize]
+}
+
+func unmarshalData(secretType uint32, data []byte) (interface{}, error) {
+	var err error
+	switch secretType {
+	case entities.TypeText:
+		var t string
+		err = json.Unmarshal(data, &t)
+		return t, err
+	case entities.TypePassword:
+		var p entities.Password
+		err = json.Unmarshal(data, &p)
+		return &p, err
+	case entities.TypeCard:
+		var c entities.Card
+		err = json.Unmarshal(data, &c)
+		return &c, err
+	case entities.TypeTOTP:
+		var o entities.TOTP
+		err = json.Unmarshal(data, &o)
+		return &o, err
+	case entities.TypeSSHKey:
+		var k entities.SSHKey
+		err = json.Unmarshal(data, &k)
+		return &k, err
+	case entities.TypeBinary:
+		var b []byte
+		err = json.Unmarshal(data, &b)
+		return b, err
+	default:
+		return nil, fmt.Errorf("unknown secret type %v", secretType)
+	}
+}
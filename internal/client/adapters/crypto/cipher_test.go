@@ -0,0 +1,94 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCipher_SealOpen(t *testing.T) {
+	c := NewCipher()
+	params, err := c.NewKeyParams("Master@pass1")
+	assert.NoError(t, err)
+
+	secret := &entities.Secret{
+		ID:         "password",
+		Name:       "Password",
+		SecretType: entities.TypePassword,
+		Notes:      "http://aaa.zzz",
+		Data:       &entities.Password{Login: "aaa@zzz.com", Password: "pass@Word1"},
+		Fields: []entities.CustomField{
+			{Name: "Секретный вопрос", Value: "Кличка собаки", Type: entities.FieldText},
+			{Name: "Ответ", Value: "Шарик", Type: entities.FieldHidden},
+		},
+	}
+	sealed, err := c.Seal(secret)
+	assert.NoError(t, err)
+	assert.Equal(t, "", sealed.Notes)
+	assert.Nil(t, sealed.Fields)
+	assert.IsType(t, entities.Sealed{}, sealed.Data)
+
+	c.Lock()
+	_, err = c.Open(sealed)
+	assert.ErrorIs(t, err, entities.ErrVaultLocked)
+
+	err = c.Unlock("Wrong@pass1", params)
+	assert.ErrorIs(t, err, entities.ErrWrongMasterPassword)
+
+	err = c.Unlock("Master@pass1", params)
+	assert.NoError(t, err)
+	opened, err := c.Open(sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, secret, opened)
+
+	sealed.SecretType = entities.TypeText
+	_, err = c.Open(sealed)
+	assert.Error(t, err)
+}
+
+func TestCipher_OpenSwapped(t *testing.T) {
+	c := NewCipher()
+	_, err := c.NewKeyParams("Master@pass1")
+	assert.NoError(t, err)
+
+	itemKey, _, err := c.NewItemKey()
+	assert.NoError(t, err)
+	secret := &entities.Secret{ID: "first", SecretType: entities.TypeText, Data: "Lorem ipsum...", ItemKey: itemKey}
+	sealed, err := c.Seal(secret)
+	assert.NoError(t, err)
+
+	moved := *sealed
+	moved.ID = "second"
+	_, err = c.Open(&moved)
+	assert.Error(t, err, "data of a secret can not be opened as another one")
+
+	otherKey, _, err := c.NewItemKey()
+	assert.NoError(t, err)
+	rekeyed := *sealed
+	rekeyed.ItemKey = otherKey
+	_, err = c.Open(&rekeyed)
+	assert.Error(t, err)
+}
+
+func TestCipher_OpenTypeBoundOnly(t *testing.T) {
+	c := NewCipher()
+	_, err := c.NewKeyParams("Master@pass1")
+	assert.NoError(t, err)
+
+	secret := &entities.Secret{ID: "id", SecretType: entities.TypeText}
+	sealed, err := seal(c.getAEAD(), []byte(`{"Data":"Lorem ipsum..."}`), []byte{byte(secret.SecretType)})
+	assert.NoError(t, err)
+	secret.Data = entities.Sealed(sealed)
+
+	_, err = c.Open(secret)
+	assert.Error(t, err, "data not bound to the secret id is rejected")
+}
+
+func TestCipher_OpenPlain(t *testing.T) {
+	c := NewCipher()
+	secret := &entities.Secret{ID: "text", SecretType: entities.TypeText, Data: "Lorem ipsum..."}
+	opened, err := c.Open(secret)
+	assert.NoError(t, err)
+	assert.Equal(t, secret, opened)
+}
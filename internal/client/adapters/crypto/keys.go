@@ -0,0 +1,180 @@
+package crypto
+
+import (
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Shareable secrets are sealed with their own random item key. The owner keeps
+// the item key sealed with the master key, every recipient gets a copy sealed
+// to their X25519 public key with an ephemeral key agreement. Secrets of an
+// organization keep the item key sealed with the organization key instead.
+const (
+	keyWrapVersion byte = 3
+	orgWrapVersion byte = 4
+	publicKeySize       = 32
+)
+
+var (
+	privateKeyAD = []byte("gophkeeper private key")
+	itemKeyAD    = []byte("gophkeeper item key")
+
+	errMalformedKey = errors.New("malformed wrapped key")
+)
+
+func (c *Cipher) NewKeyPair() ([]byte, []byte, error) {
+	aead := c.getAEAD()
+	if aead == nil {
+		return nil, nil, entities.ErrVaultLocked
+	}
+	private, sealedPrivate, err := newKeyPair(aead)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.setKeys(aead, private)
+	return private.PublicKey().Bytes(), sealedPrivate, nil
+}
+
+// NewItemKey returns a random item key and its copy sealed with the master key
+func (c *Cipher) NewItemKey() ([]byte, []byte, error) {
+	aead := c.getAEAD()
+	if aead == nil {
+		return nil, nil, entities.ErrVaultLocked
+	}
+	itemKey := make([]byte, keySize)
+	_, err := rand.Read(itemKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	wrapped, err := seal(aead, itemKey, itemKeyAD)
+	if err != nil {
+		return nil, nil, err
+	}
+	return itemKey, wrapped, nil
+}
+
+func (c *Cipher) WrapKeyFor(itemKey, publicKey []byte) ([]byte, error) {
+	recipient, err := ecdh.X25519().NewPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %v", err)
+	}
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := ephemeral.ECDH(recipient)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := wrapAEAD(shared, ephemeral.PublicKey().Bytes(), publicKey)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := seal(aead, itemKey, itemKeyAD)
+	if err != nil {
+		return nil, err
+	}
+	out := append([]byte{keyWrapVersion}, ephemeral.PublicKey().Bytes()...)
+	return append(out, sealed...), nil
+}
+
+func (c *Cipher) unwrapKey(wrapped []byte, orgID string) ([]byte, error) {
+	c.mx.RLock()
+	aead, private := c.aead, c.private
+	c.mx.RUnlock()
+	if aead == nil {
+		return nil, entities.ErrVaultLocked
+	}
+	if len(wrapped) == 0 {
+		return nil, errMalformedKey
+	}
+	if wrapped[0] == sealVersion {
+		return open(aead, wrapped, itemKeyAD)
+	}
+	if wrapped[0] == orgWrapVersion {
+		return c.unwrapOrganizationKey(wrapped, orgID)
+	}
+
+	if wrapped[0] != keyWrapVersion || len(wrapped) < 1+publicKeySize {
+		return nil, errMalformedKey
+	}
+	if private == nil {
+		return nil, errors.New("no key pair to open a shared secret")
+	}
+	ephemeral, err := ecdh.X25519().NewPublicKey(wrapped[1 : 1+publicKeySize])
+	if err != nil {
+		return nil, errMalformedKey
+	}
+	shared, err := private.ECDH(ephemeral)
+	if err != nil {
+		return nil, err
+	}
+	aead, err = wrapAEAD(shared, ephemeral.Bytes(), private.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return open(aead, wrapped[1+publicKeySize:], itemKeyAD)
+}
+
+// secretAEAD picks the item key of the secret if it has one, secrets created
+// before sharing are sealed with the master key directly
+func (c *Cipher) secretAEAD(secret *entities.Secret) (cipher.AEAD, []byte, error) {
+	master := c.getAEAD()
+	if master == nil {
+		return nil, nil, entities.ErrVaultLocked
+	}
+	itemKey := secret.ItemKey
+	if len(itemKey) == 0 && len(secret.WrappedKey) > 0 {
+		var err error
+		itemKey, err = c.unwrapKey(secret.WrappedKey, secret.OrganizationID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to unwrap key of secret %v: %w", secret.ID, err)
+		}
+	}
+	if len(itemKey) == 0 {
+		return master, nil, nil
+	}
+	aead, err := newAEAD(itemKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return aead, itemKey, nil
+}
+
+func newKeyPair(aead cipher.AEAD) (*ecdh.PrivateKey, []byte, error) {
+	private, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	sealed, err := seal(aead, private.Bytes(), privateKeyAD)
+	if err != nil {
+		return nil, nil, err
+	}
+	return private, sealed, nil
+}
+
+func openPrivateKey(aead cipher.AEAD, sealed []byte) (*ecdh.PrivateKey, error) {
+	raw, err := open(aead, sealed, privateKeyAD)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open private key: %v", err)
+	}
+	return ecdh.X25519().NewPrivateKey(raw)
+}
+
+func wrapAEAD(shared, ephemeral, recipient []byte) (cipher.AEAD, error) {
+	info := append(append([]byte{}, ephemeral...), recipient...)
+	key := make([]byte, keySize)
+	_, err := io.ReadFull(hkdf.New(sha256.New, shared, info, itemKeyAD), key)
+	if err != nil {
+		return nil, err
+	}
+	return newAEAD(key)
+}
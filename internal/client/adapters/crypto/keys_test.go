@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCipher_ShareItemKey(t *testing.T) {
+	owner := NewCipher()
+	ownerParams, err := owner.NewKeyParams("Owner@pass1")
+	assert.NoError(t, err)
+	recipient := NewCipher()
+	recipientParams, err := recipient.NewKeyParams("Recipient@pass1")
+	assert.NoError(t, err)
+	stranger := NewCipher()
+	_, err = stranger.NewKeyParams("Stranger@pass1")
+	assert.NoError(t, err)
+
+	itemKey, wrapped, err := owner.NewItemKey()
+	assert.NoError(t, err)
+	secret := &entities.Secret{
+		ID:         "text",
+		SecretType: entities.TypeText,
+		Data:       "Lorem ipsum...",
+		ItemKey:    itemKey,
+		WrappedKey: wrapped,
+	}
+	sealed, err := owner.Seal(secret)
+	assert.NoError(t, err)
+	sealed.ItemKey = nil
+
+	owner.Lock()
+	err = owner.Unlock("Owner@pass1", ownerParams)
+	assert.NoError(t, err)
+	opened, err := owner.Open(sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, secret, opened)
+
+	shared := *sealed
+	shared.WrappedKey, err = owner.WrapKeyFor(itemKey, recipientParams.PublicKey)
+	assert.NoError(t, err)
+
+	recipient.Lock()
+	err = recipient.Unlock("Recipient@pass1", recipientParams)
+	assert.NoError(t, err)
+	opened, err = recipient.Open(&shared)
+	assert.NoError(t, err)
+	assert.Equal(t, "Lorem ipsum...", opened.Data)
+	assert.Equal(t, itemKey, opened.ItemKey)
+
+	_, err = stranger.Open(&shared)
+	assert.Error(t, err)
+	_, err = recipient.Open(sealed)
+	assert.Error(t, err)
+}
+
+func TestCipher_NewKeyPair(t *testing.T) {
+	c := NewCipher()
+	_, _, err := c.NewKeyPair()
+	assert.ErrorIs(t, err, entities.ErrVaultLocked)
+
+	params, err := c.NewKeyParams("Master@pass1")
+	assert.NoError(t, err)
+	params.PublicKey, params.SealedPrivateKey, err = c.NewKeyPair()
+	assert.NoError(t, err)
+
+	c.Lock()
+	err = c.Unlock("Master@pass1", params)
+	assert.NoError(t, err)
+	itemKey, _, err := c.NewItemKey()
+	assert.NoError(t, err)
+	wrapped, err := c.WrapKeyFor(itemKey, params.PublicKey)
+	assert.NoError(t, err)
+	unwrapped, err := c.unwrapKey(wrapped, "")
+	assert.NoError(t, err)
+	assert.Equal(t, itemKey, unwrapped)
+}
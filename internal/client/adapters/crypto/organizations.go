@@ -0,0 +1,111 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"errors"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+var orgKeyAD = []byte("gophkeeper organization key")
+
+// NewOrganizationKey returns a random organization key and its copy sealed
+// to the user's own public key
+func (c *Cipher) NewOrganizationKey() ([]byte, []byte, error) {
+	public, err := c.publicKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	orgKey := make([]byte, keySize)
+	_, err = rand.Read(orgKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	wrapped, err := c.WrapKeyFor(orgKey, public)
+	if err != nil {
+		return nil, nil, err
+	}
+	return orgKey, wrapped, nil
+}
+
+// AddOrganizationKey opens the organization key sealed to the user and keeps
+// it until the vault is locked
+func (c *Cipher) AddOrganizationKey(orgID string, wrapped []byte) error {
+	if len(wrapped) == 0 || wrapped[0] != keyWrapVersion {
+		return errMalformedKey
+	}
+	orgKey, err := c.unwrapKey(wrapped, "")
+	if err != nil {
+		return err
+	}
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	if c.aead == nil {
+		return entities.ErrVaultLocked
+	}
+	c.orgKeys[orgID] = orgKey
+	return nil
+}
+
+// WrapOrganizationKeyFor seals the organization key to the public key of a new member
+func (c *Cipher) WrapOrganizationKeyFor(orgID string, publicKey []byte) ([]byte, error) {
+	orgKey, err := c.organizationKey(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return c.WrapKeyFor(orgKey, publicKey)
+}
+
+// WrapKeyForOrganization seals the item key of a secret with the organization key
+func (c *Cipher) WrapKeyForOrganization(itemKey []byte, orgID string) ([]byte, error) {
+	orgKey, err := c.organizationKey(orgID)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(orgKey)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := seal(aead, itemKey, orgKeyAD)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{orgWrapVersion}, sealed...), nil
+}
+
+func (c *Cipher) unwrapOrganizationKey(wrapped []byte, orgID string) ([]byte, error) {
+	orgKey, err := c.organizationKey(orgID)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(orgKey)
+	if err != nil {
+		return nil, err
+	}
+	return open(aead, wrapped[1:], orgKeyAD)
+}
+
+func (c *Cipher) organizationKey(orgID string) ([]byte, error) {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+	if c.aead == nil {
+		return nil, entities.ErrVaultLocked
+	}
+	orgKey, ok := c.orgKeys[orgID]
+	if !ok {
+		return nil, entities.ErrOrgKeyMissing
+	}
+	return orgKey, nil
+}
+
+func (c *Cipher) publicKey() ([]byte, error) {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+	if c.aead == nil {
+		return nil, entities.ErrVaultLocked
+	}
+	if c.private == nil {
+		return nil, errors.New("no key pair to create an organization")
+	}
+	return c.private.PublicKey().Bytes(), nil
+}
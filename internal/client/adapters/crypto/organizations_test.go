@@ -0,0 +1,59 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCipher_OrganizationKey(t *testing.T) {
+	owner := NewCipher()
+	_, err := owner.NewKeyParams("Owner@pass1")
+	assert.NoError(t, err)
+	member := NewCipher()
+	memberParams, err := member.NewKeyParams("Member@pass1")
+	assert.NoError(t, err)
+	stranger := NewCipher()
+	_, err = stranger.NewKeyParams("Stranger@pass1")
+	assert.NoError(t, err)
+
+	_, ownerWrapped, err := owner.NewOrganizationKey()
+	assert.NoError(t, err)
+	err = owner.AddOrganizationKey("org", ownerWrapped)
+	assert.NoError(t, err)
+	memberWrapped, err := owner.WrapOrganizationKeyFor("org", memberParams.PublicKey)
+	assert.NoError(t, err)
+
+	itemKey, _, err := owner.NewItemKey()
+	assert.NoError(t, err)
+	wrapped, err := owner.WrapKeyForOrganization(itemKey, "org")
+	assert.NoError(t, err)
+	sealed, err := owner.Seal(&entities.Secret{
+		ID:             "text",
+		SecretType:     entities.TypeText,
+		Data:           "Lorem ipsum...",
+		ItemKey:        itemKey,
+		WrappedKey:     wrapped,
+		OrganizationID: "org",
+	})
+	assert.NoError(t, err)
+	sealed.ItemKey = nil
+
+	_, err = member.Open(sealed)
+	assert.ErrorIs(t, err, entities.ErrOrgKeyMissing)
+
+	err = member.AddOrganizationKey("org", memberWrapped)
+	assert.NoError(t, err)
+	opened, err := member.Open(sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, "Lorem ipsum...", opened.Data)
+	assert.Equal(t, itemKey, opened.ItemKey)
+
+	err = stranger.AddOrganizationKey("org", memberWrapped)
+	assert.Error(t, err)
+
+	member.Lock()
+	_, err = member.WrapKeyForOrganization(itemKey, "org")
+	assert.ErrorIs(t, err, entities.ErrVaultLocked)
+}
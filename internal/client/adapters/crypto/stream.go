@@ -0,0 +1,165 @@
+package crypto
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+// Binary content is sealed in fixed-size segments so that it can be streamed
+// without holding the whole file in memory. Every segment nonce is built from
+// a random per-stream prefix, the segment counter and a flag marking the last
+// segment, so reordered, dropped or truncated segments fail to open.
+const (
+	streamVersion     byte = 2
+	streamPrefixSize       = 7
+	streamSegmentSize      = 64 * 1024
+)
+
+var errMalformedStream = errors.New("malformed encrypted stream")
+
+func (c *Cipher) SealStream(secret *entities.Secret, r io.Reader) (io.Reader, error) {
+	aead, itemKey, err := c.secretAEAD(secret)
+	if err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, streamPrefixSize)
+	_, err = rand.Read(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return &sealReader{
+		aead:   aead,
+		src:    bufio.NewReaderSize(r, streamSegmentSize),
+		prefix: prefix,
+		ad:     additionalData(secret, itemKey),
+		out:    append([]byte{streamVersion}, prefix...),
+		seg:    make([]byte, streamSegmentSize),
+	}, nil
+}
+
+func (c *Cipher) OpenStream(secret *entities.Secret, w io.Writer) (io.WriteCloser, error) {
+	aead, itemKey, err := c.secretAEAD(secret)
+	if err != nil {
+		return nil, err
+	}
+	return &openWriter{
+		aead: aead,
+		dst:  w,
+		ad:   additionalData(secret, itemKey),
+	}, nil
+}
+
+type sealReader struct {
+	aead    cipher.AEAD
+	src     *bufio.Reader
+	prefix  []byte
+	ad      []byte
+	counter uint32
+	seg     []byte
+	out     []byte
+	done    bool
+}
+
+func (s *sealReader) Read(p []byte) (int, error) {
+	for len(s.out) == 0 {
+		if s.done {
+			return 0, io.EOF
+		}
+		err := s.sealSegment()
+		if err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, s.out)
+	s.out = s.out[n:]
+	return n, nil
+}
+
+func (s *sealReader) sealSegment() error {
+	n, err := io.ReadFull(s.src, s.seg)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+	last := err != nil
+	if !last {
+		_, err = s.src.Peek(1)
+		if errors.Is(err, io.EOF) {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+	if s.counter == math.MaxUint32 {
+		return errors.New("stream is too long")
+	}
+	s.out = s.aead.Seal(nil, streamNonce(s.prefix, s.counter, last), s.seg[:n], s.ad)
+	s.counter++
+	s.done = last
+	return nil
+}
+
+type openWriter struct {
+	aead    cipher.AEAD
+	dst     io.Writer
+	prefix  []byte
+	ad      []byte
+	counter uint32
+	buf     []byte
+}
+
+func (w *openWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	if w.prefix == nil {
+		if len(w.buf) < 1+streamPrefixSize {
+			return len(p), nil
+		}
+		if w.buf[0] != streamVersion {
+			return 0, errMalformedStream
+		}
+		w.prefix = append([]byte(nil), w.buf[1:1+streamPrefixSize]...)
+		w.buf = append(w.buf[:0], w.buf[1+streamPrefixSize:]...)
+	}
+	frameSize := streamSegmentSize + w.aead.Overhead()
+	for len(w.buf) > frameSize {
+		err := w.openSegment(w.buf[:frameSize], false)
+		if err != nil {
+			return 0, err
+		}
+		w.buf = append(w.buf[:0], w.buf[frameSize:]...)
+	}
+	return len(p), nil
+}
+
+func (w *openWriter) Close() error {
+	if w.prefix == nil {
+		return errMalformedStream
+	}
+	return w.openSegment(w.buf, true)
+}
+
+func (w *openWriter) openSegment(frame []byte, last bool) error {
+	plaintext, err := w.aead.Open(nil, streamNonce(w.prefix, w.counter, last), frame, w.ad)
+	if err != nil {
+		return errMalformedStream
+	}
+	w.counter++
+	_, err = w.dst.Write(plaintext)
+	return err
+}
+
+func streamNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, 0, len(prefix)+5)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, counter)
+	if last {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
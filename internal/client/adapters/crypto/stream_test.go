@@ -0,0 +1,91 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCipher_SealOpenStream(t *testing.T) {
+	c := NewCipher()
+	_, err := c.NewKeyParams("Master@pass1")
+	assert.NoError(t, err)
+	secret := &entities.Secret{ID: "binary", SecretType: entities.TypeBinary}
+
+	tests := []struct {
+		name string
+		size int
+	}{
+		{name: "empty", size: 0},
+		{name: "small", size: 100},
+		{name: "one segment", size: streamSegmentSize},
+		{name: "several segments", size: 3*streamSegmentSize + 17},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := make([]byte, tt.size)
+			_, _ = rand.Read(content)
+
+			r, err := c.SealStream(secret, bytes.NewReader(content))
+			assert.NoError(t, err)
+			sealed, err := io.ReadAll(r)
+			assert.NoError(t, err)
+
+			var out bytes.Buffer
+			w, err := c.OpenStream(secret, &out)
+			assert.NoError(t, err)
+			_, err = io.Copy(w, bytes.NewReader(sealed))
+			assert.NoError(t, err)
+			assert.NoError(t, w.Close())
+			assert.Equal(t, content, append([]byte{}, out.Bytes()...))
+
+			var truncated bytes.Buffer
+			w, err = c.OpenStream(secret, &truncated)
+			assert.NoError(t, err)
+			_, _ = w.Write(sealed[:len(sealed)-1])
+			assert.Error(t, w.Close())
+		})
+	}
+}
+
+func TestCipher_OpenStreamBinding(t *testing.T) {
+	c := NewCipher()
+	_, err := c.NewKeyParams("Master@pass1")
+	assert.NoError(t, err)
+	secret := &entities.Secret{ID: "binary", SecretType: entities.TypeBinary}
+	content := []byte("Lorem ipsum...")
+
+	r, err := c.SealStream(secret, bytes.NewReader(content))
+	assert.NoError(t, err)
+	sealed, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	w, err := c.OpenStream(&entities.Secret{ID: "other", SecretType: entities.TypeBinary}, io.Discard)
+	assert.NoError(t, err)
+	_, _ = w.Write(sealed)
+	assert.Error(t, w.Close(), "content of a secret can not be opened as another one")
+
+	prefix := make([]byte, streamPrefixSize)
+	typeBound := append([]byte{streamVersion}, prefix...)
+	typeBound = c.getAEAD().Seal(typeBound, streamNonce(prefix, 0, true), content, []byte{byte(secret.SecretType)})
+	w, err = c.OpenStream(secret, io.Discard)
+	assert.NoError(t, err)
+	_, _ = w.Write(typeBound)
+	assert.Error(t, w.Close(), "content not bound to the secret id is rejected")
+
+	w, err = c.OpenStream(secret, io.Discard)
+	assert.NoError(t, err)
+	_, err = w.Write(append([]byte{streamVersion + 1}, sealed[1:]...))
+	assert.Error(t, err)
+}
+
+func TestCipher_SealStreamLocked(t *testing.T) {
+	c := NewCipher()
+	_, err := c.SealStream(&entities.Secret{SecretType: entities.TypeBinary}, bytes.NewReader(nil))
+	assert.ErrorIs(t, err, entities.ErrVaultLocked)
+	_, err = c.OpenStream(&entities.Secret{SecretType: entities.TypeBinary}, io.Discard)
+	assert.ErrorIs(t, err, entities.ErrVaultLocked)
+}
@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 
+	"github.com/itohin/gophkeeper/internal/client/entities"
 	pb "github.com/itohin/gophkeeper/proto"
 )
 
@@ -64,3 +65,52 @@ func (c *Client) Logout(ctx context.Context) error {
 	close(c.shutdownCh)
 	return nil
 }
+
+func (c *Client) GetKeyParams(ctx context.Context) (*entities.KeyParams, error) {
+	r, err := c.auth.GetKeyParams(ctx, &pb.GetKeyParamsRequest{})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &entities.KeyParams{
+		Salt:             r.Params.GetSalt(),
+		KeyCheck:         r.Params.GetKeyCheck(),
+		PublicKey:        r.Params.GetPublicKey(),
+		SealedPrivateKey: r.Params.GetSealedPrivateKey(),
+	}, nil
+}
+
+func (c *Client) SetKeyParams(ctx context.Context, params *entities.KeyParams) error {
+	_, err := c.auth.SetKeyParams(ctx, &pb.SetKeyParamsRequest{
+		Params: &pb.KeyParams{
+			Salt:             params.Salt,
+			KeyCheck:         params.KeyCheck,
+			PublicKey:        params.PublicKey,
+			SealedPrivateKey: params.SealedPrivateKey,
+		},
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (c *Client) SetKeyPair(ctx context.Context, publicKey, sealedPrivateKey []byte) error {
+	_, err := c.auth.SetKeyPair(ctx, &pb.SetKeyPairRequest{
+		PublicKey:        publicKey,
+		SealedPrivateKey: sealedPrivateKey,
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (c *Client) GetPublicKey(ctx context.Context, email string) (string, []byte, error) {
+	r, err := c.auth.GetPublicKey(ctx, &pb.GetPublicKeyRequest{
+		Email: email,
+	})
+	if err != nil {
+		return "", nil, handleError(err)
+	}
+	return r.UserId, r.PublicKey, nil
+}
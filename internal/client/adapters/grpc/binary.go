@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	errors2 "github.com/itohin/gophkeeper/pkg/errors"
+	pb "github.com/itohin/gophkeeper/proto"
+)
+
+const binaryChunkSize = 64 * 1024
+
+func (c *Client) UploadBinary(ctx context.Context, secret *entities.Secret, r io.Reader) (string, error) {
+	ps, err := c.secretsHydrator.ToProto(secret)
+	if err != nil {
+		return "", fmt.Errorf("failed convert secret to proto: %v", err)
+	}
+	// cancelling the stream on any error unblocks the server, otherwise it
+	// waits for the next chunk until the connection drops
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.secrets.UploadBinary(ctx)
+	if err != nil {
+		return "", handleError(err)
+	}
+	err = stream.Send(&pb.UploadBinaryRequest{
+		Payload: &pb.UploadBinaryRequest_Secret{Secret: ps},
+	})
+	if err != nil {
+		return "", closeUpload(stream)
+	}
+
+	hash := sha256.New()
+	buf := make([]byte, binaryChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			hash.Write(buf[:n])
+			sendErr := stream.Send(&pb.UploadBinaryRequest{
+				Payload: &pb.UploadBinaryRequest_Chunk{
+					Chunk: &pb.BinaryChunk{
+						Data:  buf[:n],
+						Crc32: crc32.ChecksumIEEE(buf[:n]),
+					},
+				},
+			})
+			if sendErr != nil {
+				return "", closeUpload(stream)
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = stream.Send(&pb.UploadBinaryRequest{
+		Payload: &pb.UploadBinaryRequest_Sha256{Sha256: hash.Sum(nil)},
+	})
+	if err != nil {
+		return "", closeUpload(stream)
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return "", handleError(err)
+	}
+	return resp.Id, nil
+}
+
+func (c *Client) DownloadBinary(ctx context.Context, id string, w io.Writer) error {
+	stream, err := c.secrets.DownloadBinary(ctx, &pb.DownloadBinaryRequest{
+		Id: id,
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return handleError(err)
+	}
+	info := resp.GetInfo()
+	if info == nil {
+		return errors2.NewDomainError(entities.ErrChecksumMismatch)
+	}
+
+	hash := sha256.New()
+	var size uint64
+	for {
+		resp, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return handleError(err)
+		}
+		chunk := resp.GetChunk()
+		if chunk == nil || crc32.ChecksumIEEE(chunk.Data) != chunk.Crc32 {
+			return errors2.NewDomainError(entities.ErrChecksumMismatch)
+		}
+		hash.Write(chunk.Data)
+		size += uint64(len(chunk.Data))
+		_, err = w.Write(chunk.Data)
+		if err != nil {
+			return err
+		}
+	}
+	if size != info.Size || !bytes.Equal(hash.Sum(nil), info.Sha256) {
+		return errors2.NewDomainError(entities.ErrChecksumMismatch)
+	}
+	return nil
+}
+
+func closeUpload(stream pb.Secrets_UploadBinaryClient) error {
+	_, err := stream.CloseAndRecv()
+	if err == nil {
+		err = io.ErrUnexpectedEOF
+	}
+	return handleError(err)
+}
@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	pb "github.com/itohin/gophkeeper/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type uploadServer struct {
+	pb.UnimplementedSecretsServer
+	done chan error
+}
+
+func (s *uploadServer) UploadBinary(stream pb.Secrets_UploadBinaryServer) error {
+	for {
+		_, err := stream.Recv()
+		if err != nil {
+			s.done <- err
+			return err
+		}
+	}
+}
+
+type protoHydrator struct{}
+
+func (protoHydrator) FromProto(v *pb.Secret) (*entities.Secret, error) {
+	return &entities.Secret{ID: v.Id}, nil
+}
+
+func (protoHydrator) ToProto(s *entities.Secret) (*pb.Secret, error) {
+	return &pb.Secret{Id: s.ID}, nil
+}
+
+type failingReader struct {
+	sent bool
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.sent {
+		return 0, errors.New("read error")
+	}
+	r.sent = true
+	return copy(p, make([]byte, binaryChunkSize)), nil
+}
+
+func TestClient_UploadBinary_readError(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	server := &uploadServer{done: make(chan error, 1)}
+	pb.RegisterSecretsServer(srv, server)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	c := &Client{
+		secrets:         pb.NewSecretsClient(conn),
+		secretsHydrator: protoHydrator{},
+	}
+	_, err = c.UploadBinary(context.Background(), &entities.Secret{ID: "id"}, &failingReader{})
+	assert.EqualError(t, err, "read error")
+
+	select {
+	case err = <-server.done:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server is still waiting for chunks")
+	}
+}
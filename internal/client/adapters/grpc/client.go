@@ -22,6 +22,7 @@ type Client struct {
 	conn            *grpc.ClientConn
 	auth            pb.AuthClient
 	secrets         pb.SecretsClient
+	organizations   pb.OrganizationsClient
 	shutdownCh      chan struct{}
 	token           *entities.Token
 	fingerPrint     string
@@ -42,6 +43,9 @@ func NewClient(
 		grpc.WithChainUnaryInterceptor(
 			ji.UnaryClientInterceptor(token, fingerPrint),
 		),
+		grpc.WithChainStreamInterceptor(
+			ji.StreamClientInterceptor(token, fingerPrint),
+		),
 	)
 	if err != nil {
 		return nil, err
@@ -52,6 +56,7 @@ func NewClient(
 		conn:            conn,
 		auth:            auth,
 		secrets:         pb.NewSecretsClient(conn),
+		organizations:   pb.NewOrganizationsClient(conn),
 		shutdownCh:      shutdownCh,
 		token:           token,
 		fingerPrint:     fingerPrint,
@@ -70,6 +75,14 @@ func handleError(err error) error {
 			fmt.Errorf("input error: %v", e.Message()),
 		)
 	}
+	if ok && e.Code() == codes.Aborted {
+		return errors.NewDomainError(entities.ErrVersionConflict)
+	}
+	if ok && e.Code() == codes.PermissionDenied {
+		return errors.NewDomainError(
+			fmt.Errorf("access denied: %v", e.Message()),
+		)
+	}
 	return errors.NewDomainError(
 		fmt.Errorf("internal error: please try again later"),
 	)
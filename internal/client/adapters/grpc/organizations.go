@@ -0,0 +1,139 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	pb "github.com/itohin/gophkeeper/proto"
+)
+
+func (c *Client) CreateOrganization(ctx context.Context, name string, wrappedKey []byte) (string, error) {
+	r, err := c.organizations.CreateOrganization(ctx, &pb.CreateOrganizationRequest{
+		Name:       name,
+		WrappedKey: wrappedKey,
+	})
+	if err != nil {
+		return "", handleError(err)
+	}
+	return r.Id, nil
+}
+
+func (c *Client) GetOrganizations(ctx context.Context) ([]*entities.Organization, error) {
+	r, err := c.organizations.ListOrganizations(ctx, &pb.ListOrganizationsRequest{})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	orgs := make([]*entities.Organization, 0, len(r.Organizations))
+	for _, v := range r.Organizations {
+		orgs = append(orgs, &entities.Organization{
+			ID:         v.Id,
+			Name:       v.Name,
+			Role:       uint32(v.Role),
+			WrappedKey: v.WrappedKey,
+		})
+	}
+	return orgs, nil
+}
+
+func (c *Client) InviteMember(ctx context.Context, orgID, email string, role uint32, wrappedKey []byte) error {
+	_, err := c.organizations.InviteMember(ctx, &pb.InviteMemberRequest{
+		OrganizationId: orgID,
+		Email:          email,
+		Role:           pb.Role(role),
+		WrappedKey:     wrappedKey,
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (c *Client) AcceptInvitation(ctx context.Context, code string) (string, error) {
+	r, err := c.organizations.AcceptInvitation(ctx, &pb.AcceptInvitationRequest{
+		Code: code,
+	})
+	if err != nil {
+		return "", handleError(err)
+	}
+	return r.OrganizationId, nil
+}
+
+func (c *Client) GetMembers(ctx context.Context, orgID string) ([]*entities.Member, error) {
+	r, err := c.organizations.ListMembers(ctx, &pb.ListMembersRequest{
+		OrganizationId: orgID,
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	members := make([]*entities.Member, 0, len(r.Members))
+	for _, v := range r.Members {
+		members = append(members, &entities.Member{
+			UserID: v.UserId,
+			Email:  v.Email,
+			Role:   uint32(v.Role),
+		})
+	}
+	return members, nil
+}
+
+func (c *Client) SetMemberRole(ctx context.Context, orgID, userID string, role uint32) error {
+	_, err := c.organizations.SetMemberRole(ctx, &pb.SetMemberRoleRequest{
+		OrganizationId: orgID,
+		UserId:         userID,
+		Role:           pb.Role(role),
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (c *Client) RemoveMember(ctx context.Context, orgID, userID string) error {
+	_, err := c.organizations.RemoveMember(ctx, &pb.RemoveMemberRequest{
+		OrganizationId: orgID,
+		UserId:         userID,
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (c *Client) CreateCollection(ctx context.Context, orgID, name string) (string, error) {
+	r, err := c.organizations.CreateCollection(ctx, &pb.CreateCollectionRequest{
+		OrganizationId: orgID,
+		Name:           name,
+	})
+	if err != nil {
+		return "", handleError(err)
+	}
+	return r.Id, nil
+}
+
+func (c *Client) GetCollections(ctx context.Context, orgID string) ([]*entities.Collection, error) {
+	r, err := c.organizations.ListCollections(ctx, &pb.ListCollectionsRequest{
+		OrganizationId: orgID,
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	collections := make([]*entities.Collection, 0, len(r.Collections))
+	for _, v := range r.Collections {
+		collections = append(collections, &entities.Collection{
+			ID:             v.Id,
+			OrganizationID: v.OrganizationId,
+			Name:           v.Name,
+		})
+	}
+	return collections, nil
+}
+
+func (c *Client) DeleteCollection(ctx context.Context, id string) error {
+	_, err := c.organizations.DeleteCollection(ctx, &pb.DeleteCollectionRequest{
+		Id: id,
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
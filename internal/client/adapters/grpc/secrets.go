@@ -3,9 +3,21 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/itohin/gophkeeper/internal/client/entities"
 	pb "github.com/itohin/gophkeeper/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	createAttempts = 3
+	createBackoff  = 500 * time.Millisecond
+	// createTimeout bounds a single attempt, a hung attempt leaves time for
+	// the next ones
+	createTimeout = 10 * time.Second
 )
 
 func (c *Client) GetSecret(ctx context.Context, id string) (*entities.Secret, error) {
@@ -22,20 +34,32 @@ func (c *Client) GetSecret(ctx context.Context, id string) (*entities.Secret, er
 	return secret, nil
 }
 
-func (c *Client) SearchSecrets(ctx context.Context) (map[string]*entities.Secret, error) {
-	s, err := c.secrets.Search(ctx, &pb.SearchRequest{})
+func (c *Client) SearchSecrets(ctx context.Context, filter *entities.SecretsFilter, pageToken string, pageSize uint32) (*entities.SecretsPage, error) {
+	s, err := c.secrets.Search(ctx, &pb.SearchRequest{
+		Query:        filter.Query,
+		SecretType:   filter.SecretType,
+		Sort:         pb.SortOrder(filter.Sort),
+		PageToken:    pageToken,
+		PageSize:     pageSize,
+		CollectionId: filter.CollectionID,
+		FolderId:     filter.FolderID,
+		Tag:          filter.Tag,
+	})
 	if err != nil {
 		return nil, handleError(err)
 	}
-	secrets := make(map[string]*entities.Secret, len(s.Secrets))
+	page := &entities.SecretsPage{
+		Secrets:       make([]*entities.Secret, 0, len(s.Secrets)),
+		NextPageToken: s.NextPageToken,
+	}
 	for _, v := range s.Secrets {
 		secret, err := c.secretsHydrator.FromProto(v)
 		if err != nil {
 			return nil, handleError(err)
 		}
-		secrets[v.Id] = secret
+		page.Secrets = append(page.Secrets, secret)
 	}
-	return secrets, nil
+	return page, nil
 }
 
 func (c *Client) CreateSecret(ctx context.Context, secret *entities.Secret) error {
@@ -43,8 +67,69 @@ func (c *Client) CreateSecret(ctx context.Context, secret *entities.Secret) erro
 	if err != nil {
 		return fmt.Errorf("failed convert secret to proto: %v", err)
 	}
-	_, err = c.secrets.Create(ctx, &pb.CreateRequest{
-		Secret: ps,
+	// the same key is sent on every attempt, so the server creates the
+	// secret once even if a response was lost
+	in := &pb.CreateRequest{
+		Secret:         ps,
+		IdempotencyKey: uuid.NewString(),
+	}
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, createTimeout)
+		_, err = c.secrets.Create(attemptCtx, in)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if attempt == createAttempts || !retryable(ctx, err) {
+			return handleError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return handleError(err)
+		case <-time.After(createBackoff * time.Duration(attempt)):
+		}
+	}
+}
+
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	code := status.Code(err)
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
+
+// CreateSecrets creates all the secrets or none of them
+func (c *Client) CreateSecrets(ctx context.Context, secrets []*entities.Secret) error {
+	in := &pb.BatchCreateRequest{Secrets: make([]*pb.Secret, 0, len(secrets))}
+	for _, secret := range secrets {
+		ps, err := c.secretsHydrator.ToProto(secret)
+		if err != nil {
+			return fmt.Errorf("failed convert secret to proto: %v", err)
+		}
+		in.Secrets = append(in.Secrets, ps)
+	}
+	_, err := c.secrets.BatchCreate(ctx, in)
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+// DeleteSecrets deletes all the secrets or none of them
+func (c *Client) DeleteSecrets(ctx context.Context, ids []string) error {
+	_, err := c.secrets.BatchDelete(ctx, &pb.BatchDeleteRequest{
+		Ids: ids,
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (c *Client) DeleteSecret(ctx context.Context, id string) error {
+	_, err := c.secrets.Delete(ctx, &pb.DeleteRequest{
+		Id: id,
 	})
 	if err != nil {
 		return handleError(err)
@@ -52,16 +137,200 @@ func (c *Client) CreateSecret(ctx context.Context, secret *entities.Secret) erro
 	return nil
 }
 
-func (c *Client) DeleteSecret(ctx context.Context, secret *entities.Secret) error {
+func (c *Client) UpdateSecret(ctx context.Context, secret *entities.Secret) (uint64, error) {
 	ps, err := c.secretsHydrator.ToProto(secret)
 	if err != nil {
-		return fmt.Errorf("failed convert secret to proto: %v", err)
+		return 0, fmt.Errorf("failed convert secret to proto: %v", err)
 	}
-	_, err = c.secrets.Delete(ctx, &pb.DeleteRequest{
+	r, err := c.secrets.Update(ctx, &pb.UpdateRequest{
 		Secret: ps,
 	})
+	if err != nil {
+		return 0, handleError(err)
+	}
+	return r.Version, nil
+}
+
+func (c *Client) GetRevisions(ctx context.Context, secretID string) ([]*entities.Revision, error) {
+	r, err := c.secrets.ListRevisions(ctx, &pb.ListRevisionsRequest{
+		SecretId: secretID,
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	revisions := make([]*entities.Revision, 0, len(r.Revisions))
+	for _, v := range r.Revisions {
+		secret, err := c.secretsHydrator.FromProto(v.Secret)
+		if err != nil {
+			return nil, handleError(err)
+		}
+		revisions = append(revisions, &entities.Revision{
+			ID:        v.Id,
+			CreatedAt: v.CreatedAt.AsTime(),
+			Secret:    secret,
+		})
+	}
+	return revisions, nil
+}
+
+func (c *Client) RestoreRevision(ctx context.Context, secretID, revisionID string) error {
+	_, err := c.secrets.RestoreRevision(ctx, &pb.RestoreRevisionRequest{
+		SecretId:   secretID,
+		RevisionId: revisionID,
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (c *Client) GetDeletedSecrets(ctx context.Context) ([]*entities.DeletedSecret, error) {
+	r, err := c.secrets.ListDeleted(ctx, &pb.ListDeletedRequest{})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	deleted := make([]*entities.DeletedSecret, 0, len(r.Secrets))
+	for _, v := range r.Secrets {
+		secret, err := c.secretsHydrator.FromProto(v.Secret)
+		if err != nil {
+			return nil, handleError(err)
+		}
+		deleted = append(deleted, &entities.DeletedSecret{
+			DeletedAt: v.DeletedAt.AsTime(),
+			Secret:    secret,
+		})
+	}
+	return deleted, nil
+}
+
+func (c *Client) RestoreSecret(ctx context.Context, id string) error {
+	_, err := c.secrets.Restore(ctx, &pb.RestoreRequest{
+		Id: id,
+	})
 	if err != nil {
 		return handleError(err)
 	}
 	return nil
 }
+
+func (c *Client) PurgeSecret(ctx context.Context, id string) error {
+	_, err := c.secrets.Purge(ctx, &pb.PurgeRequest{
+		Id: id,
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (c *Client) SyncSince(ctx context.Context, cursor uint64, pageSize uint32) (*entities.ChangesPage, error) {
+	s, err := c.secrets.SyncSince(ctx, &pb.SyncSinceRequest{
+		Cursor:   cursor,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	page := &entities.ChangesPage{
+		Changes: make([]*entities.SecretChange, 0, len(s.Changes)),
+		Cursor:  s.Cursor,
+		HasMore: s.HasMore,
+		Resync:  s.Resync,
+	}
+	for _, v := range s.Changes {
+		secret, err := c.secretsHydrator.FromProto(v.Secret)
+		if err != nil {
+			return nil, handleError(err)
+		}
+		page.Changes = append(page.Changes, &entities.SecretChange{Secret: secret, Deleted: v.Deleted})
+	}
+	return page, nil
+}
+
+func (c *Client) ShareSecret(ctx context.Context, secretID, email string, permission uint32, wrappedKey []byte) error {
+	_, err := c.secrets.Share(ctx, &pb.ShareRequest{
+		SecretId:   secretID,
+		Email:      email,
+		Permission: pb.Permission(permission),
+		WrappedKey: wrappedKey,
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (c *Client) RevokeShare(ctx context.Context, secretID, userID string) error {
+	_, err := c.secrets.RevokeShare(ctx, &pb.RevokeShareRequest{
+		SecretId: secretID,
+		UserId:   userID,
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (c *Client) GetShares(ctx context.Context, secretID string) ([]*entities.Share, error) {
+	r, err := c.secrets.ListShares(ctx, &pb.ListSharesRequest{
+		SecretId: secretID,
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	shares := make([]*entities.Share, 0, len(r.Shares))
+	for _, v := range r.Shares {
+		shares = append(shares, &entities.Share{
+			UserID:     v.UserId,
+			Email:      v.Email,
+			Permission: uint32(v.Permission),
+			CreatedAt:  v.CreatedAt.AsTime(),
+		})
+	}
+	return shares, nil
+}
+
+func (c *Client) CreateFolder(ctx context.Context, name, parentID string) (string, error) {
+	r, err := c.secrets.CreateFolder(ctx, &pb.CreateFolderRequest{
+		Name:     name,
+		ParentId: parentID,
+	})
+	if err != nil {
+		return "", handleError(err)
+	}
+	return r.Id, nil
+}
+
+func (c *Client) GetFolders(ctx context.Context) ([]*entities.Folder, error) {
+	r, err := c.secrets.ListFolders(ctx, &pb.ListFoldersRequest{})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	folders := make([]*entities.Folder, 0, len(r.Folders))
+	for _, v := range r.Folders {
+		folders = append(folders, &entities.Folder{
+			ID:       v.Id,
+			ParentID: v.ParentId,
+			Name:     v.Name,
+		})
+	}
+	return folders, nil
+}
+
+func (c *Client) DeleteFolder(ctx context.Context, id string) error {
+	_, err := c.secrets.DeleteFolder(ctx, &pb.DeleteFolderRequest{
+		Id: id,
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	return nil
+}
+
+func (c *Client) GetTags(ctx context.Context) ([]string, error) {
+	r, err := c.secrets.ListTags(ctx, &pb.ListTagsRequest{})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return r.Tags, nil
+}
@@ -0,0 +1,142 @@
+package importers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+const (
+	bitwardenLogin = iota + 1
+	bitwardenNote
+	bitwardenCard
+	bitwardenIdentity
+
+	bitwardenHiddenField = 1
+)
+
+var ErrEncryptedExport = errors.New("зашифрованный экспорт Bitwarden не поддерживается, выгрузите JSON без шифрования")
+
+type bitwardenExport struct {
+	Encrypted bool `json:"encrypted"`
+	Folders   []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"folders"`
+	Items []bitwardenItem `json:"items"`
+}
+
+type bitwardenItem struct {
+	Type     int    `json:"type"`
+	Name     string `json:"name"`
+	Notes    string `json:"notes"`
+	FolderID string `json:"folderId"`
+	Login    *struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		TOTP     string `json:"totp"`
+		URIs     []struct {
+			URI string `json:"uri"`
+		} `json:"uris"`
+	} `json:"login"`
+	Card *struct {
+		CardholderName string `json:"cardholderName"`
+		Number         string `json:"number"`
+		ExpMonth       string `json:"expMonth"`
+		ExpYear        string `json:"expYear"`
+		Code           string `json:"code"`
+	} `json:"card"`
+	Identity map[string]interface{} `json:"identity"`
+	Fields   []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+		Type  int    `json:"type"`
+	} `json:"fields"`
+}
+
+// parseBitwarden reads the unencrypted JSON export, folders become tags
+// and identities become text secrets
+func parseBitwarden(r io.Reader) ([]*entities.Secret, error) {
+	var export bitwardenExport
+	err := json.NewDecoder(r).Decode(&export)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать JSON Bitwarden: %v", err)
+	}
+	if export.Encrypted {
+		return nil, ErrEncryptedExport
+	}
+	folders := make(map[string]string, len(export.Folders))
+	for _, f := range export.Folders {
+		folders[f.ID] = f.Name
+	}
+
+	secrets := make([]*entities.Secret, 0, len(export.Items))
+	for _, item := range export.Items {
+		var secret *entities.Secret
+		var fields []entities.CustomField
+		switch {
+		case item.Type == bitwardenLogin && item.Login != nil:
+			secret = newPassword(item.Name, item.Login.Username, item.Login.Password, item.Notes)
+			for k, u := range item.Login.URIs {
+				name := "URL"
+				if k > 0 {
+					name = fmt.Sprintf("URL %d", k+1)
+				}
+				fields = append(fields, urlField(name, u.URI))
+			}
+			if item.Login.TOTP != "" {
+				fields = append(fields, entities.CustomField{Name: "TOTP", Value: item.Login.TOTP, Type: entities.FieldHidden})
+			}
+		case item.Type == bitwardenCard && item.Card != nil:
+			secret = &entities.Secret{
+				Name:       item.Name,
+				SecretType: entities.TypeCard,
+				Notes:      item.Notes,
+				Data: &entities.Card{
+					Number:     item.Card.Number,
+					Expiration: expiration(item.Card.ExpMonth, item.Card.ExpYear),
+					Code:       item.Card.Code,
+					OwnerName:  item.Card.CardholderName,
+				},
+			}
+		case item.Type == bitwardenIdentity:
+			secret = newText(item.Name, identityText(item.Identity))
+			secret.Notes = item.Notes
+		default:
+			secret = newText(item.Name, item.Notes)
+		}
+		for _, f := range item.Fields {
+			field := entities.CustomField{Name: f.Name, Value: f.Value, Type: entities.FieldText}
+			if f.Type == bitwardenHiddenField {
+				field.Type = entities.FieldHidden
+			}
+			fields = append(fields, field)
+		}
+		secret.Fields = fields
+		if folder, ok := folders[item.FolderID]; ok {
+			secret.Tags = []string{folder}
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+func identityText(identity map[string]interface{}) string {
+	keys := make([]string, 0, len(identity))
+	for k, v := range identity {
+		if s, ok := v.(string); ok && s != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	lines := make([]string, 0, len(keys))
+	for _, k := range keys {
+		lines = append(lines, k+": "+identity[k].(string))
+	}
+	return strings.Join(lines, "\n")
+}
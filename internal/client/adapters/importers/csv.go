@@ -0,0 +1,154 @@
+package importers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+// csvTable gives access to the columns of a CSV export by header name
+type csvTable struct {
+	columns map[string]int
+	rows    [][]string
+}
+
+func readCSV(r io.Reader) (*csvTable, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать CSV: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("файл CSV пустой")
+	}
+	t := &csvTable{columns: make(map[string]int, len(records[0])), rows: records[1:]}
+	for k, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		t.columns[name] = k
+	}
+	return t, nil
+}
+
+func (t *csvTable) has(names ...string) bool {
+	for _, name := range names {
+		if _, ok := t.columns[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// get returns the first non-empty column of the row out of names
+func (t *csvTable) get(row []string, names ...string) string {
+	for _, name := range names {
+		k, ok := t.columns[name]
+		if ok && k < len(row) && strings.TrimSpace(row[k]) != "" {
+			return strings.TrimSpace(row[k])
+		}
+	}
+	return ""
+}
+
+// parseOnePassword reads the CSV export of 1Password 7 and 8, rows with
+// a card number become cards and archived rows are skipped
+func parseOnePassword(r io.Reader) ([]*entities.Secret, error) {
+	t, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	if !t.has("title") {
+		return nil, fmt.Errorf("в файле нет колонки Title, это не экспорт 1Password")
+	}
+	secrets := make([]*entities.Secret, 0, len(t.rows))
+	for _, row := range t.rows {
+		if strings.EqualFold(t.get(row, "archived"), "true") {
+			continue
+		}
+		name := t.get(row, "title")
+		notes := t.get(row, "notes", "notesplain")
+		var secret *entities.Secret
+		if number := t.get(row, "number", "card number"); number != "" {
+			secret = &entities.Secret{
+				Name:       name,
+				SecretType: entities.TypeCard,
+				Notes:      notes,
+				Data: &entities.Card{
+					Number:     number,
+					Expiration: t.get(row, "expiry date", "expiry", "expires"),
+					Code:       t.get(row, "verification number", "cvv"),
+					Pin:        t.get(row, "pin"),
+					OwnerName:  t.get(row, "cardholder name", "cardholder"),
+				},
+			}
+		} else if login, password := t.get(row, "username"), t.get(row, "password"); login != "" || password != "" {
+			secret = newPassword(name, login, password, notes)
+		} else {
+			secret = newText(name, notes)
+		}
+		if u := t.get(row, "url", "website", "urls"); u != "" {
+			secret.Fields = append(secret.Fields, urlField("URL", u))
+		}
+		if otp := t.get(row, "otpauth", "one-time password"); otp != "" {
+			secret.Fields = append(secret.Fields, entities.CustomField{Name: "TOTP", Value: otp, Type: entities.FieldHidden})
+		}
+		secret.Tags = splitTags(t.get(row, "tags"))
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+// parseChrome reads the passwords CSV of Chrome and other Chromium browsers
+func parseChrome(r io.Reader) ([]*entities.Secret, error) {
+	t, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	if !t.has("name", "url") || !t.has("password") {
+		return nil, fmt.Errorf("в файле нет колонок name, url и password, это не экспорт Chrome")
+	}
+	secrets := make([]*entities.Secret, 0, len(t.rows))
+	for _, row := range t.rows {
+		u := t.get(row, "url")
+		name := t.get(row, "name")
+		if name == "" {
+			name = nameFromURL(u)
+		}
+		secret := newPassword(name, t.get(row, "username"), t.get(row, "password"), t.get(row, "note"))
+		if u != "" {
+			secret.Fields = []entities.CustomField{urlField("URL", u)}
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+// parseFirefox reads the logins CSV of Firefox, which has no entry names,
+// so the site host is used instead
+func parseFirefox(r io.Reader) ([]*entities.Secret, error) {
+	t, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	if !t.has("url") || !t.has("password") {
+		return nil, fmt.Errorf("в файле нет колонок url и password, это не экспорт Firefox")
+	}
+	secrets := make([]*entities.Secret, 0, len(t.rows))
+	for _, row := range t.rows {
+		u := t.get(row, "url")
+		// Firefox keeps its own account among the logins
+		if parsed, err := url.Parse(u); err == nil && parsed.Scheme == "chrome" {
+			continue
+		}
+		secret := newPassword(nameFromURL(u), t.get(row, "username"), t.get(row, "password"), "")
+		if u != "" {
+			secret.Fields = []entities.CustomField{urlField("URL", u)}
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
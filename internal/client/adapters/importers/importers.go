@@ -0,0 +1,105 @@
+package importers
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+var ErrUnknownFormat = errors.New("неизвестный формат импорта")
+
+type Parser struct {
+}
+
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// Parse reads the export of another password manager into secrets ready to
+// be created, binary secrets carry the file content in Data
+func (p *Parser) Parse(format string, r io.Reader) ([]*entities.Secret, error) {
+	switch format {
+	case entities.ImportKeePass:
+		return parseKeePass(r)
+	case entities.ImportBitwarden:
+		return parseBitwarden(r)
+	case entities.Import1Password:
+		return parseOnePassword(r)
+	case entities.ImportChrome:
+		return parseChrome(r)
+	case entities.ImportFirefox:
+		return parseFirefox(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func newPassword(name, login, password, notes string) *entities.Secret {
+	return &entities.Secret{
+		Name:       name,
+		SecretType: entities.TypePassword,
+		Notes:      notes,
+		Data:       &entities.Password{Login: login, Password: password},
+	}
+}
+
+func newText(name, text string) *entities.Secret {
+	return &entities.Secret{
+		Name:       name,
+		SecretType: entities.TypeText,
+		Data:       text,
+	}
+}
+
+func newBinary(name string, content []byte) *entities.Secret {
+	return &entities.Secret{
+		Name:       name,
+		SecretType: entities.TypeBinary,
+		Data:       content,
+		Size:       int64(len(content)),
+	}
+}
+
+// urlField keeps values that are not valid links as plain text, otherwise
+// entities.ValidateFields rejects the whole secret
+func urlField(name, value string) entities.CustomField {
+	field := entities.CustomField{Name: name, Value: value, Type: entities.FieldURL}
+	if _, err := url.ParseRequestURI(value); err != nil {
+		field.Type = entities.FieldText
+	}
+	return field
+}
+
+func nameFromURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.Hostname() == "" {
+		return value
+	}
+	return strings.TrimPrefix(u.Hostname(), "www.")
+}
+
+// expiration formats the card expiration the way the card form expects it
+func expiration(month, year string) string {
+	month = strings.TrimSpace(month)
+	year = strings.TrimSpace(year)
+	if month == "" || year == "" {
+		return ""
+	}
+	if len(month) == 1 {
+		month = "0" + month
+	}
+	return month + "/" + year
+}
+
+func splitTags(value string) []string {
+	var tags []string
+	for _, t := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
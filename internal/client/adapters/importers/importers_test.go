@@ -0,0 +1,220 @@
+package importers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const keePassXML = `<?xml version="1.0" encoding="utf-8" standalone="yes"?>
+<KeePassFile>
+	<Meta>
+		<RecycleBinUUID>bin</RecycleBinUUID>
+		<Binaries>
+			<Binary ID="0" Compressed="True">ATT</Binary>
+			<Binary ID="1">PLAIN</Binary>
+		</Binaries>
+	</Meta>
+	<Root>
+		<Group>
+			<UUID>root</UUID>
+			<Name>Database</Name>
+			<Entry>
+				<String><Key>Title</Key><Value>Mail</Value></String>
+				<String><Key>UserName</Key><Value>ivan</Value></String>
+				<String><Key>Password</Key><Value ProtectInMemory="True">secret</Value></String>
+				<String><Key>URL</Key><Value>https://mail.example.com</Value></String>
+				<String><Key>Notes</Key><Value>work</Value></String>
+				<String><Key>Recovery</Key><Value ProtectInMemory="True">code</Value></String>
+				<Binary><Key>config</Key><Value Ref="0" /></Binary>
+			</Entry>
+			<Group>
+				<UUID>servers</UUID>
+				<Name>Servers</Name>
+				<Entry>
+					<Tags>prod;linux</Tags>
+					<String><Key>Title</Key><Value>Readme</Value></String>
+					<String><Key>Notes</Key><Value>ssh only</Value></String>
+					<Binary><Key>hello.txt</Key><Value Ref="1" /></Binary>
+				</Entry>
+			</Group>
+			<Group>
+				<UUID>bin</UUID>
+				<Name>Recycle Bin</Name>
+				<Entry>
+					<String><Key>Title</Key><Value>Deleted</Value></String>
+				</Entry>
+			</Group>
+		</Group>
+	</Root>
+</KeePassFile>`
+
+const bitwardenJSON = `{
+	"encrypted": false,
+	"folders": [{"id": "f1", "name": "Work"}],
+	"items": [
+		{
+			"type": 1, "name": "GitHub", "notes": "2fa", "folderId": "f1",
+			"login": {"username": "ivan", "password": "pass", "totp": "JBSWY3DPEHPK3PXP", "uris": [{"uri": "https://github.com"}, {"uri": "github.io"}]},
+			"fields": [{"name": "PIN", "value": "1234", "type": 1}]
+		},
+		{"type": 2, "name": "Wifi", "notes": "password123", "folderId": null},
+		{
+			"type": 3, "name": "Visa",
+			"card": {"cardholderName": "IVAN IVANOV", "number": "4111111111111111", "expMonth": "7", "expYear": "2027", "code": "123"}
+		},
+		{"type": 4, "name": "Passport", "identity": {"firstName": "Ivan", "lastName": "Ivanov", "ssn": null}}
+	]
+}`
+
+func TestParse_keePass(t *testing.T) {
+	xml := strings.NewReplacer("ATT", "H4sIAHqw1GoC/ysuzlBIzs9Ly0wHALClIZ4KAAAA", "PLAIN", "aGVsbG8=").Replace(keePassXML)
+	secrets, err := NewParser().Parse(entities.ImportKeePass, strings.NewReader(xml))
+	require.NoError(t, err)
+	require.Len(t, secrets, 4)
+
+	mail := secrets[0]
+	assert.Equal(t, "Mail", mail.Name)
+	assert.Equal(t, &entities.Password{Login: "ivan", Password: "secret"}, mail.Data)
+	assert.Equal(t, "work", mail.Notes)
+	assert.Empty(t, mail.Tags)
+	assert.Equal(t, []entities.CustomField{
+		{Name: "URL", Value: "https://mail.example.com", Type: entities.FieldURL},
+		{Name: "Recovery", Value: "code", Type: entities.FieldHidden},
+	}, mail.Fields)
+
+	assert.Equal(t, "Mail - config", secrets[1].Name)
+	assert.Equal(t, uint32(entities.TypeBinary), secrets[1].SecretType)
+	assert.Equal(t, []byte("ssh config"), secrets[1].Data)
+	assert.Equal(t, int64(10), secrets[1].Size)
+
+	assert.Equal(t, "Readme", secrets[2].Name)
+	assert.Equal(t, "ssh only", secrets[2].Data)
+	assert.Equal(t, []string{"prod", "linux", "Servers"}, secrets[2].Tags)
+	assert.Equal(t, []byte("hello"), secrets[3].Data)
+}
+
+func TestParse_bitwarden(t *testing.T) {
+	secrets, err := NewParser().Parse(entities.ImportBitwarden, strings.NewReader(bitwardenJSON))
+	require.NoError(t, err)
+	require.Len(t, secrets, 4)
+
+	assert.Equal(t, &entities.Password{Login: "ivan", Password: "pass"}, secrets[0].Data)
+	assert.Equal(t, []string{"Work"}, secrets[0].Tags)
+	assert.Equal(t, []entities.CustomField{
+		{Name: "URL", Value: "https://github.com", Type: entities.FieldURL},
+		{Name: "URL 2", Value: "github.io", Type: entities.FieldText},
+		{Name: "TOTP", Value: "JBSWY3DPEHPK3PXP", Type: entities.FieldHidden},
+		{Name: "PIN", Value: "1234", Type: entities.FieldHidden},
+	}, secrets[0].Fields)
+
+	assert.Equal(t, "password123", secrets[1].Data)
+	assert.Empty(t, secrets[1].Tags)
+	assert.Equal(t, &entities.Card{
+		Number:     "4111111111111111",
+		Expiration: "07/2027",
+		Code:       "123",
+		OwnerName:  "IVAN IVANOV",
+	}, secrets[2].Data)
+	assert.Equal(t, "firstName: Ivan\nlastName: Ivanov", secrets[3].Data)
+
+	_, err = NewParser().Parse(entities.ImportBitwarden, strings.NewReader(`{"encrypted": true, "items": []}`))
+	assert.ErrorIs(t, err, ErrEncryptedExport)
+}
+
+func TestParse_csv(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		input   string
+		want    []*entities.Secret
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "1password",
+			format: entities.Import1Password,
+			input: "\ufeffTitle,Url,Username,Password,OTPAuth,Favorite,Archived,Tags,Notes\n" +
+				"Mail,https://mail.example.com,ivan,secret,,false,false,\"work,mail\",note\n" +
+				"Old,,ivan,old,,false,true,,\n",
+			want: []*entities.Secret{
+				{
+					Name:       "Mail",
+					SecretType: entities.TypePassword,
+					Notes:      "note",
+					Data:       &entities.Password{Login: "ivan", Password: "secret"},
+					Fields:     []entities.CustomField{{Name: "URL", Value: "https://mail.example.com", Type: entities.FieldURL}},
+					Tags:       []string{"work", "mail"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "1password card",
+			format: entities.Import1Password,
+			input: "title,cardholder name,number,expiry date,verification number\n" +
+				"Visa,IVAN IVANOV,4111111111111111,07/27,123\n",
+			want: []*entities.Secret{
+				{
+					Name:       "Visa",
+					SecretType: entities.TypeCard,
+					Data:       &entities.Card{Number: "4111111111111111", Expiration: "07/27", Code: "123", OwnerName: "IVAN IVANOV"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "chrome",
+			format: entities.ImportChrome,
+			input: "name,url,username,password,note\n" +
+				",https://www.example.com/login,ivan,secret,\n",
+			want: []*entities.Secret{
+				{
+					Name:       "example.com",
+					SecretType: entities.TypePassword,
+					Data:       &entities.Password{Login: "ivan", Password: "secret"},
+					Fields:     []entities.CustomField{{Name: "URL", Value: "https://www.example.com/login", Type: entities.FieldURL}},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "firefox",
+			format: entities.ImportFirefox,
+			input: `"url","username","password","httpRealm","formActionOrigin","guid","timeCreated","timeLastUsed","timePasswordChanged"` + "\n" +
+				`"https://shop.example.com","ivan","secret",,"https://shop.example.com","{1}","1","1","1"` + "\n" +
+				`"chrome://FirefoxAccounts","ivan","token",,,"{2}","1","1","1"` + "\n",
+			want: []*entities.Secret{
+				{
+					Name:       "shop.example.com",
+					SecretType: entities.TypePassword,
+					Data:       &entities.Password{Login: "ivan", Password: "secret"},
+					Fields:     []entities.CustomField{{Name: "URL", Value: "https://shop.example.com", Type: entities.FieldURL}},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "wrong columns",
+			format:  entities.ImportFirefox,
+			input:   "title,login\nMail,ivan\n",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "unknown format",
+			format:  "lastpass",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParser().Parse(tt.format, strings.NewReader(tt.input))
+			if !tt.wantErr(t, err, "Parse()") || err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
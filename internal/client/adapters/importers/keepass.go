@@ -0,0 +1,170 @@
+package importers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+type keePassFile struct {
+	Meta struct {
+		RecycleBinUUID string          `xml:"RecycleBinUUID"`
+		Binaries       []keePassBinary `xml:"Binaries>Binary"`
+	} `xml:"Meta"`
+	Root struct {
+		Groups []keePassGroup `xml:"Group"`
+	} `xml:"Root"`
+}
+
+type keePassBinary struct {
+	ID         string `xml:"ID,attr"`
+	Compressed bool   `xml:"Compressed,attr"`
+	Content    string `xml:",chardata"`
+}
+
+type keePassGroup struct {
+	UUID    string         `xml:"UUID"`
+	Name    string         `xml:"Name"`
+	Entries []keePassEntry `xml:"Entry"`
+	Groups  []keePassGroup `xml:"Group"`
+}
+
+type keePassEntry struct {
+	Tags    string `xml:"Tags"`
+	Strings []struct {
+		Key   string `xml:"Key"`
+		Value struct {
+			Value   string `xml:",chardata"`
+			Protect bool   `xml:"ProtectInMemory,attr"`
+		} `xml:"Value"`
+	} `xml:"String"`
+	Attachments []struct {
+		Key   string `xml:"Key"`
+		Value struct {
+			Ref string `xml:"Ref,attr"`
+		} `xml:"Value"`
+	} `xml:"Binary"`
+}
+
+// parseKeePass reads the KeePass 2 XML export, groups become tags and
+// attachments become binary secrets
+func parseKeePass(r io.Reader) ([]*entities.Secret, error) {
+	var file keePassFile
+	err := xml.NewDecoder(r).Decode(&file)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать XML KeePass: %v", err)
+	}
+	binaries := make(map[string][]byte, len(file.Meta.Binaries))
+	for _, b := range file.Meta.Binaries {
+		content, err := decodeKeePassBinary(b)
+		if err != nil {
+			return nil, err
+		}
+		binaries[b.ID] = content
+	}
+
+	var secrets []*entities.Secret
+	var walk func(g keePassGroup, path []string) error
+	walk = func(g keePassGroup, path []string) error {
+		if g.UUID != "" && g.UUID == file.Meta.RecycleBinUUID {
+			return nil
+		}
+		for _, e := range g.Entries {
+			parsed, err := keePassSecrets(e, path, binaries)
+			if err != nil {
+				return err
+			}
+			secrets = append(secrets, parsed...)
+		}
+		for _, child := range g.Groups {
+			err := walk(child, append(path[:len(path):len(path)], child.Name))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	// the root group is the database itself and is not a tag
+	for _, root := range file.Root.Groups {
+		err = walk(root, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return secrets, nil
+}
+
+func keePassSecrets(e keePassEntry, path []string, binaries map[string][]byte) ([]*entities.Secret, error) {
+	var title, login, password, notes string
+	var fields []entities.CustomField
+	for _, s := range e.Strings {
+		value := s.Value.Value
+		switch s.Key {
+		case "Title":
+			title = value
+		case "UserName":
+			login = value
+		case "Password":
+			password = value
+		case "Notes":
+			notes = value
+		case "URL":
+			if value != "" {
+				fields = append(fields, urlField("URL", value))
+			}
+		default:
+			if value == "" {
+				continue
+			}
+			field := entities.CustomField{Name: s.Key, Value: value, Type: entities.FieldText}
+			if s.Value.Protect {
+				field.Type = entities.FieldHidden
+			}
+			fields = append(fields, field)
+		}
+	}
+	tags := append(splitTags(e.Tags), path...)
+
+	var secret *entities.Secret
+	if login != "" || password != "" {
+		secret = newPassword(title, login, password, notes)
+	} else {
+		secret = newText(title, notes)
+	}
+	secret.Fields = fields
+	secret.Tags = tags
+	secrets := []*entities.Secret{secret}
+
+	for _, a := range e.Attachments {
+		content, ok := binaries[a.Value.Ref]
+		if !ok {
+			return nil, fmt.Errorf("вложение %s записи %s не найдено в файле", a.Key, title)
+		}
+		binary := newBinary(title+" - "+a.Key, content)
+		binary.Tags = tags
+		secrets = append(secrets, binary)
+	}
+	return secrets, nil
+}
+
+func decodeKeePassBinary(b keePassBinary) ([]byte, error) {
+	content, err := base64.StdEncoding.DecodeString(strings.TrimSpace(b.Content))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать вложение %s: %v", b.ID, err)
+	}
+	if !b.Compressed {
+		return content, nil
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("не удалось распаковать вложение %s: %v", b.ID, err)
+	}
+	defer gz.Close()
+	return io.ReadAll(gz)
+}
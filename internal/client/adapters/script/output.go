@@ -0,0 +1,126 @@
+package script
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+var typeNames = map[uint32]string{
+	entities.TypeText:     "text",
+	entities.TypePassword: "password",
+	entities.TypeBinary:   "binary",
+	entities.TypeCard:     "card",
+	entities.TypeTOTP:     "totp",
+	entities.TypeSSHKey:   "ssh-key",
+}
+
+type field struct {
+	key   string
+	value string
+}
+
+type fieldList []field
+
+// get matches the key case-insensitively, custom fields can not shadow the
+// fields of the secret itself
+func (l fieldList) get(key string) (string, bool) {
+	for _, f := range l {
+		if strings.EqualFold(f.key, key) {
+			return f.value, true
+		}
+	}
+	return "", false
+}
+
+// secretFields lists the data of the secret, the content of binaries is
+// never printed
+func secretFields(secret *entities.Secret) fieldList {
+	fields := fieldList{{"name", secret.Name}}
+	switch d := secret.Data.(type) {
+	case string:
+		fields = append(fields, field{"text", d})
+	case *entities.Password:
+		fields = append(fields,
+			field{"login", d.Login},
+			field{"password", d.Password},
+		)
+	case *entities.Card:
+		fields = append(fields,
+			field{"number", d.Number},
+			field{"expiration", d.Expiration},
+			field{"code", d.Code},
+			field{"pin", d.Pin},
+			field{"owner", d.OwnerName},
+		)
+	case *entities.TOTP:
+		fields = append(fields,
+			field{"secret", d.Secret},
+			field{"issuer", d.Issuer},
+			field{"account", d.Account},
+		)
+	case *entities.SSHKey:
+		fields = append(fields,
+			field{"private_key", d.PrivateKey},
+			field{"public_key", d.PublicKey},
+			field{"comment", d.Comment},
+			field{"passphrase", d.Passphrase},
+		)
+	}
+	if secret.SecretType == entities.TypeBinary {
+		fields = append(fields, field{"size", strconv.FormatInt(secret.Size, 10)})
+	}
+	fields = append(fields, field{"notes", secret.Notes})
+	for _, f := range secret.Fields {
+		fields = append(fields, field{f.Name, f.Value})
+	}
+	return fields
+}
+
+type listItem struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	FolderID  string    `json:"folder_id,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	Shared    bool      `json:"shared,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newListItem(secret *entities.Secret) listItem {
+	return listItem{
+		ID:        secret.ID,
+		Name:      secret.Name,
+		Type:      typeNames[secret.SecretType],
+		FolderID:  secret.FolderID,
+		Tags:      secret.Tags,
+		Shared:    secret.IsShared(),
+		UpdatedAt: secret.UpdatedAt,
+	}
+}
+
+type secretView struct {
+	ID     string            `json:"id"`
+	Type   string            `json:"type"`
+	Fields map[string]string `json:"fields"`
+}
+
+func newSecretView(secret *entities.Secret, fields fieldList) secretView {
+	view := secretView{
+		ID:     secret.ID,
+		Type:   typeNames[secret.SecretType],
+		Fields: make(map[string]string, len(fields)),
+	}
+	for _, f := range fields {
+		if _, ok := view.Fields[f.key]; !ok {
+			view.Fields[f.key] = f.value
+		}
+	}
+	return view
+}
+
+type syncResult struct {
+	Secrets int `json:"secrets"`
+}
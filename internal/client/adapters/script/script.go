@@ -0,0 +1,398 @@
+package script
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/pkg/validator"
+)
+
+const (
+	CommandList = "list"
+	CommandGet  = "get"
+	CommandAdd  = "add"
+	CommandRm   = "rm"
+	CommandSync = "sync"
+)
+
+const (
+	ExitFailure = iota + 1
+	ExitUsage
+	ExitAuth
+	ExitNotFound
+)
+
+const usage = `использование:
+  gophkeeper list [--json]
+  gophkeeper get <имя или id> [--field <поле>] [--json]
+  gophkeeper add password --name <имя> --login <логин> < пароль
+  gophkeeper add text --name <имя> < текст
+  gophkeeper rm <имя или id>...
+  gophkeeper sync [--json]`
+
+type Auth interface {
+	Login(ctx context.Context, login, password string) error
+	Logout(ctx context.Context) error
+	HasMasterPassword(ctx context.Context) (bool, error)
+	Unlock(ctx context.Context, password string) error
+}
+
+type Secrets interface {
+	SyncSecrets(ctx context.Context, userID string) error
+	GetSecrets(ctx context.Context) (map[string]*entities.Secret, error)
+	GetSecret(ctx context.Context, id string) (*entities.Secret, error)
+	CreateSecret(ctx context.Context, secret *entities.Secret) error
+	DeleteSecrets(ctx context.Context, ids []string) error
+}
+
+// Credentials are taken from the environment, so that they do not show up
+// in the process list or the shell history
+type Credentials struct {
+	Login          string
+	Password       string
+	MasterPassword string
+}
+
+type Options struct {
+	JSON  bool
+	Field string
+	Name  string
+	Login string
+}
+
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+func withCode(code int, err error) error {
+	return &exitError{code: code, err: err}
+}
+
+// ExitCode returns the process exit code for the error returned by Run
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var e *exitError
+	if errors.As(err, &e) {
+		return e.code
+	}
+	return ExitFailure
+}
+
+func IsCommand(name string) bool {
+	switch name {
+	case CommandList, CommandGet, CommandAdd, CommandRm, CommandSync:
+		return true
+	}
+	return false
+}
+
+// Script runs a single command without prompts, the result goes to out and
+// the errors are left to the caller
+type Script struct {
+	auth    Auth
+	secrets Secrets
+	authCh  <-chan string
+	creds   Credentials
+	in      io.Reader
+	out     io.Writer
+}
+
+func NewScript(auth Auth, secrets Secrets, authCh <-chan string, creds Credentials, in io.Reader, out io.Writer) *Script {
+	return &Script{
+		auth:    auth,
+		secrets: secrets,
+		authCh:  authCh,
+		creds:   creds,
+		in:      in,
+		out:     out,
+	}
+}
+
+func (s *Script) Run(ctx context.Context, command string, args []string, opts Options) error {
+	err := validate(command, args, opts)
+	if err != nil {
+		return withCode(ExitUsage, fmt.Errorf("%v\n%s", err, usage))
+	}
+	var secret *entities.Secret
+	if command == CommandAdd {
+		// stdin is read before the login, a script piping nothing fails fast
+		secret, err = s.readSecret(args[0], opts)
+		if err != nil {
+			return withCode(ExitUsage, err)
+		}
+	}
+
+	userID, err := s.login(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.auth.Logout(ctx)
+	err = s.unlock(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case CommandList:
+		return s.list(ctx, opts)
+	case CommandGet:
+		return s.get(ctx, args[0], opts)
+	case CommandAdd:
+		return s.secrets.CreateSecret(ctx, secret)
+	case CommandRm:
+		return s.rm(ctx, args)
+	default:
+		return s.sync(ctx, opts)
+	}
+}
+
+func validate(command string, args []string, opts Options) error {
+	switch command {
+	case CommandList, CommandSync:
+		if len(args) > 0 {
+			return fmt.Errorf("команда %s не принимает аргументов", command)
+		}
+	case CommandGet:
+		if len(args) != 1 {
+			return fmt.Errorf("укажите одну запись")
+		}
+	case CommandRm:
+		if len(args) == 0 {
+			return fmt.Errorf("укажите записи для удаления")
+		}
+		if len(args) > entities.MaxBatchSize {
+			return fmt.Errorf("за один раз можно удалить не больше %d записей", entities.MaxBatchSize)
+		}
+	case CommandAdd:
+		if len(args) != 1 || (args[0] != "password" && args[0] != "text") {
+			return fmt.Errorf("можно добавить только password или text")
+		}
+		err := validator.ValidateStringLength(3, 25)(opts.Name)
+		if err != nil {
+			return fmt.Errorf("--name: %v", err)
+		}
+		if args[0] == "password" {
+			err = validator.ValidateStringLength(3, 30)(opts.Login)
+			if err != nil {
+				return fmt.Errorf("--login: %v", err)
+			}
+		}
+	default:
+		return fmt.Errorf("неизвестная команда %s", command)
+	}
+	return nil
+}
+
+func (s *Script) login(ctx context.Context) (string, error) {
+	if s.creds.Login == "" || s.creds.Password == "" || s.creds.MasterPassword == "" {
+		return "", withCode(ExitAuth, fmt.Errorf("задайте GOPHKEEPER_LOGIN, GOPHKEEPER_PASSWORD и GOPHKEEPER_MASTER_PASSWORD"))
+	}
+	err := s.auth.Login(ctx, s.creds.Login, s.creds.Password)
+	if err != nil {
+		return "", withCode(ExitAuth, fmt.Errorf("не удалось выполнить вход: %w", err))
+	}
+	select {
+	case userID := <-s.authCh:
+		return userID, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// unlock opens the vault and syncs it, so that the command sees the secrets
+// created on other devices
+func (s *Script) unlock(ctx context.Context, userID string) error {
+	exists, err := s.auth.HasMasterPassword(ctx)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return withCode(ExitAuth, fmt.Errorf("мастер-пароль не создан, войдите в интерактивном режиме"))
+	}
+	err = s.auth.Unlock(ctx, s.creds.MasterPassword)
+	if errors.Is(err, entities.ErrWrongMasterPassword) {
+		return withCode(ExitAuth, err)
+	}
+	if err != nil {
+		return err
+	}
+	err = s.secrets.SyncSecrets(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("не удалось синхронизировать данные: %w", err)
+	}
+	return nil
+}
+
+func (s *Script) readSecret(secretType string, opts Options) (*entities.Secret, error) {
+	if secretType == "password" {
+		password, err := bufio.NewReader(s.in).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		password = strings.TrimRight(password, "\r\n")
+		if password == "" {
+			return nil, fmt.Errorf("передайте пароль через stdin")
+		}
+		return &entities.Secret{
+			Name:       opts.Name,
+			SecretType: entities.TypePassword,
+			Data:       &entities.Password{Login: opts.Login, Password: password},
+		}, nil
+	}
+
+	content, err := io.ReadAll(s.in)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimRight(string(content), "\r\n")
+	err = validator.ValidateStringLength(3, 500)(text)
+	if err != nil {
+		return nil, fmt.Errorf("текст из stdin: %v", err)
+	}
+	return &entities.Secret{
+		Name:       opts.Name,
+		SecretType: entities.TypeText,
+		Data:       text,
+	}, nil
+}
+
+func (s *Script) list(ctx context.Context, opts Options) error {
+	stored, err := s.secrets.GetSecrets(ctx)
+	if err != nil {
+		return err
+	}
+	secrets := make([]*entities.Secret, 0, len(stored))
+	for _, secret := range stored {
+		secrets = append(secrets, secret)
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		if secrets[i].Name == secrets[j].Name {
+			return secrets[i].ID < secrets[j].ID
+		}
+		return secrets[i].Name < secrets[j].Name
+	})
+
+	if opts.JSON {
+		items := make([]listItem, 0, len(secrets))
+		for _, secret := range secrets {
+			items = append(items, newListItem(secret))
+		}
+		return s.writeJSON(items)
+	}
+	for _, secret := range secrets {
+		_, err = fmt.Fprintf(s.out, "%s\t%s\t%s\n", secret.ID, typeNames[secret.SecretType], secret.Name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Script) get(ctx context.Context, ref string, opts Options) error {
+	id, err := s.resolve(ctx, ref)
+	if err != nil {
+		return err
+	}
+	secret, err := s.secrets.GetSecret(ctx, id)
+	if err != nil {
+		return err
+	}
+	fields := secretFields(secret)
+
+	if opts.Field != "" {
+		value, ok := fields.get(opts.Field)
+		if !ok {
+			return withCode(ExitNotFound, fmt.Errorf("поле %s не найдено", opts.Field))
+		}
+		if opts.JSON {
+			return s.writeJSON(value)
+		}
+		_, err = fmt.Fprintln(s.out, value)
+		return err
+	}
+	if opts.JSON {
+		return s.writeJSON(newSecretView(secret, fields))
+	}
+	for _, f := range fields {
+		_, err = fmt.Fprintf(s.out, "%s: %s\n", f.key, f.value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Script) rm(ctx context.Context, refs []string) error {
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		id, err := s.resolve(ctx, ref)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	return s.secrets.DeleteSecrets(ctx, ids)
+}
+
+func (s *Script) sync(ctx context.Context, opts Options) error {
+	secrets, err := s.secrets.GetSecrets(ctx)
+	if err != nil {
+		return err
+	}
+	if opts.JSON {
+		return s.writeJSON(syncResult{Secrets: len(secrets)})
+	}
+	_, err = fmt.Fprintf(s.out, "Синхронизировано записей: %d\n", len(secrets))
+	return err
+}
+
+// resolve finds the secret by id or by its name, a name shared by several
+// secrets has to be replaced with the id
+func (s *Script) resolve(ctx context.Context, ref string) (string, error) {
+	secrets, err := s.secrets.GetSecrets(ctx)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := secrets[ref]; ok {
+		return ref, nil
+	}
+	var found []string
+	for id, secret := range secrets {
+		if secret.Name == ref {
+			found = append(found, id)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return "", withCode(ExitNotFound, fmt.Errorf("запись %s не найдена", ref))
+	case 1:
+		return found[0], nil
+	default:
+		sort.Strings(found)
+		return "", withCode(ExitUsage, fmt.Errorf("записей с именем %s несколько, укажите id: %s", ref, strings.Join(found, ", ")))
+	}
+}
+
+func (s *Script) writeJSON(v interface{}) error {
+	encoder := json.NewEncoder(s.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
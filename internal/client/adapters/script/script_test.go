@@ -0,0 +1,240 @@
+package script
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScript_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	auth := mocks.NewMockScriptAuth(ctrl)
+	secrets := mocks.NewMockScriptSecrets(ctrl)
+
+	stored := map[string]*entities.Secret{
+		"1": {ID: "1", Name: "github", SecretType: entities.TypePassword},
+		"2": {ID: "2", Name: "note", SecretType: entities.TypeText},
+		"3": {ID: "3", Name: "note", SecretType: entities.TypeText},
+	}
+	github := &entities.Secret{
+		ID:         "1",
+		Name:       "github",
+		SecretType: entities.TypePassword,
+		Data:       &entities.Password{Login: "octocat", Password: "Secret1!"},
+		Fields:     []entities.CustomField{{Name: "url", Value: "https://github.com"}},
+	}
+	creds := Credentials{Login: "user@example.com", Password: "Password1!", MasterPassword: "Master1!"}
+
+	tests := []struct {
+		name      string
+		command   string
+		args      []string
+		opts      Options
+		stdin     string
+		creds     Credentials
+		mockTimes map[string]int
+		errors    map[string]error
+		wantOut   string
+		wantCode  int
+	}{
+		{
+			name:      "unknown command",
+			command:   "edit",
+			creds:     creds,
+			mockTimes: map[string]int{},
+			errors:    map[string]error{},
+			wantCode:  ExitUsage,
+		},
+		{
+			name:      "no credentials",
+			command:   CommandList,
+			mockTimes: map[string]int{},
+			errors:    map[string]error{},
+			wantCode:  ExitAuth,
+		},
+		{
+			name:    "wrong master password",
+			command: CommandList,
+			creds:   creds,
+			mockTimes: map[string]int{
+				"login":  1,
+				"unlock": 1,
+				"logout": 1,
+			},
+			errors: map[string]error{
+				"unlock": entities.ErrWrongMasterPassword,
+			},
+			wantCode: ExitAuth,
+		},
+		{
+			name:    "list",
+			command: CommandList,
+			creds:   creds,
+			mockTimes: map[string]int{
+				"login":  1,
+				"unlock": 1,
+				"sync":   1,
+				"logout": 1,
+			},
+			errors:  map[string]error{},
+			wantOut: "1\tpassword\tgithub\n2\ttext\tnote\n3\ttext\tnote\n",
+		},
+		{
+			name:    "get field",
+			command: CommandGet,
+			args:    []string{"github"},
+			opts:    Options{Field: "password"},
+			creds:   creds,
+			mockTimes: map[string]int{
+				"login":  1,
+				"unlock": 1,
+				"sync":   1,
+				"get":    1,
+				"logout": 1,
+			},
+			errors:  map[string]error{},
+			wantOut: "Secret1!\n",
+		},
+		{
+			name:    "get custom field as json",
+			command: CommandGet,
+			args:    []string{"1"},
+			opts:    Options{Field: "URL", JSON: true},
+			creds:   creds,
+			mockTimes: map[string]int{
+				"login":  1,
+				"unlock": 1,
+				"sync":   1,
+				"get":    1,
+				"logout": 1,
+			},
+			errors:  map[string]error{},
+			wantOut: "\"https://github.com\"\n",
+		},
+		{
+			name:    "get ambiguous name",
+			command: CommandGet,
+			args:    []string{"note"},
+			creds:   creds,
+			mockTimes: map[string]int{
+				"login":  1,
+				"unlock": 1,
+				"sync":   1,
+				"logout": 1,
+			},
+			errors:   map[string]error{},
+			wantCode: ExitUsage,
+		},
+		{
+			name:    "get not found",
+			command: CommandGet,
+			args:    []string{"gitlab"},
+			creds:   creds,
+			mockTimes: map[string]int{
+				"login":  1,
+				"unlock": 1,
+				"sync":   1,
+				"logout": 1,
+			},
+			errors:   map[string]error{},
+			wantCode: ExitNotFound,
+		},
+		{
+			name:      "add password without stdin",
+			command:   CommandAdd,
+			args:      []string{"password"},
+			opts:      Options{Name: "gitlab", Login: "octocat"},
+			creds:     creds,
+			mockTimes: map[string]int{},
+			errors:    map[string]error{},
+			wantCode:  ExitUsage,
+		},
+		{
+			name:    "add password",
+			command: CommandAdd,
+			args:    []string{"password"},
+			opts:    Options{Name: "gitlab", Login: "octocat"},
+			stdin:   "Secret1!\n",
+			creds:   creds,
+			mockTimes: map[string]int{
+				"login":  1,
+				"unlock": 1,
+				"sync":   1,
+				"create": 1,
+				"logout": 1,
+			},
+			errors: map[string]error{},
+		},
+		{
+			name:    "rm error",
+			command: CommandRm,
+			args:    []string{"github", "2"},
+			creds:   creds,
+			mockTimes: map[string]int{
+				"login":  1,
+				"unlock": 1,
+				"sync":   1,
+				"delete": 1,
+				"logout": 1,
+			},
+			errors: map[string]error{
+				"delete": errors.New("delete error"),
+			},
+			wantCode: ExitFailure,
+		},
+		{
+			name:    "sync as json",
+			command: CommandSync,
+			opts:    Options{JSON: true},
+			creds:   creds,
+			mockTimes: map[string]int{
+				"login":  1,
+				"unlock": 1,
+				"sync":   1,
+				"logout": 1,
+			},
+			errors:  map[string]error{},
+			wantOut: "{\n  \"secrets\": 3\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authCh := make(chan string, 1)
+			out := &bytes.Buffer{}
+			s := NewScript(auth, secrets, authCh, tt.creds, strings.NewReader(tt.stdin), out)
+
+			auth.EXPECT().Login(gomock.Any(), creds.Login, creds.Password).
+				DoAndReturn(func(_ context.Context, _, _ string) error {
+					authCh <- "user"
+					return nil
+				}).
+				Times(tt.mockTimes["login"])
+			auth.EXPECT().HasMasterPassword(gomock.Any()).Return(true, nil).Times(tt.mockTimes["unlock"])
+			auth.EXPECT().Unlock(gomock.Any(), creds.MasterPassword).Return(tt.errors["unlock"]).Times(tt.mockTimes["unlock"])
+			auth.EXPECT().Logout(gomock.Any()).Return(nil).Times(tt.mockTimes["logout"])
+			secrets.EXPECT().SyncSecrets(gomock.Any(), "user").Return(nil).Times(tt.mockTimes["sync"])
+			secrets.EXPECT().GetSecrets(gomock.Any()).Return(stored, nil).AnyTimes()
+			secrets.EXPECT().GetSecret(gomock.Any(), "1").Return(github, nil).Times(tt.mockTimes["get"])
+			secrets.EXPECT().CreateSecret(gomock.Any(), &entities.Secret{
+				Name:       "gitlab",
+				SecretType: entities.TypePassword,
+				Data:       &entities.Password{Login: "octocat", Password: "Secret1!"},
+			}).Return(nil).Times(tt.mockTimes["create"])
+			secrets.EXPECT().DeleteSecrets(gomock.Any(), []string{"1", "2"}).Return(tt.errors["delete"]).Times(tt.mockTimes["delete"])
+
+			err := s.Run(context.Background(), tt.command, tt.args, tt.opts)
+			assert.Equalf(t, tt.wantCode, ExitCode(err), fmt.Sprintf("Run() error = %v", err))
+			assert.Equalf(t, tt.wantOut, out.String(), "Run()")
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package sshagent
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/pkg/sshkey"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+var ErrReadOnly = errors.New("ключи хранятся в gophkeeper, изменить их через ssh-agent нельзя")
+
+type Secrets interface {
+	GetSecrets(ctx context.Context) (map[string]*entities.Secret, error)
+	GetSecret(ctx context.Context, id string) (*entities.Secret, error)
+}
+
+// Agent serves the SSH keys of the vault, keys are kept only in memory and
+// are decrypted again once the sync changes the SSH keys of the local store
+type Agent struct {
+	secrets  Secrets
+	mu       sync.Mutex
+	state    string
+	signers  []ssh.Signer
+	comments []string
+}
+
+func NewAgent(secrets Secrets) *Agent {
+	return &Agent{
+		secrets: secrets,
+	}
+}
+
+// Serve accepts ssh-agent connections on the unix socket until ctx is done,
+// the socket is created in a directory only the user can access, so that
+// nobody can connect to it before its permissions are set
+func (a *Agent) Serve(ctx context.Context, socketPath string) error {
+	dir := filepath.Dir(socketPath)
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return err
+	}
+	err = checkPrivateDir(dir)
+	if err != nil {
+		return err
+	}
+	err = removeStaleSocket(socketPath)
+	if err != nil {
+		return err
+	}
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", socketPath, err)
+	}
+	defer os.Remove(socketPath)
+	err = os.Chmod(socketPath, 0600)
+	if err != nil {
+		l.Close()
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			err := agent.ServeAgent(a, conn)
+			if err != nil && !errors.Is(err, io.EOF) {
+				log.Printf("ssh-agent connection error: %v", err)
+			}
+		}()
+	}
+}
+
+func (a *Agent) List() ([]*agent.Key, error) {
+	signers, comments, err := a.load()
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*agent.Key, 0, len(signers))
+	for i, s := range signers {
+		pub := s.PublicKey()
+		keys = append(keys, &agent.Key{
+			Format:  pub.Type(),
+			Blob:    pub.Marshal(),
+			Comment: comments[i],
+		})
+	}
+	return keys, nil
+}
+
+func (a *Agent) Sign(key ssh.PublicKey, data []byte) (*ssh.Signature, error) {
+	return a.SignWithFlags(key, data, 0)
+}
+
+func (a *Agent) SignWithFlags(key ssh.PublicKey, data []byte, flags agent.SignatureFlags) (*ssh.Signature, error) {
+	signers, _, err := a.load()
+	if err != nil {
+		return nil, err
+	}
+	wanted := key.Marshal()
+	for _, s := range signers {
+		if !bytes.Equal(s.PublicKey().Marshal(), wanted) {
+			continue
+		}
+		algorithmSigner, ok := s.(ssh.AlgorithmSigner)
+		if !ok || flags == 0 {
+			return s.Sign(rand.Reader, data)
+		}
+		switch {
+		case flags&agent.SignatureFlagRsaSha256 != 0:
+			return algorithmSigner.SignWithAlgorithm(rand.Reader, data, ssh.KeyAlgoRSASHA256)
+		case flags&agent.SignatureFlagRsaSha512 != 0:
+			return algorithmSigner.SignWithAlgorithm(rand.Reader, data, ssh.KeyAlgoRSASHA512)
+		default:
+			return s.Sign(rand.Reader, data)
+		}
+	}
+	return nil, errors.New("ключ не найден")
+}
+
+func (a *Agent) Signers() ([]ssh.Signer, error) {
+	signers, _, err := a.load()
+	return signers, err
+}
+
+func (a *Agent) Add(key agent.AddedKey) error {
+	return ErrReadOnly
+}
+
+func (a *Agent) Remove(key ssh.PublicKey) error {
+	return ErrReadOnly
+}
+
+func (a *Agent) RemoveAll() error {
+	return ErrReadOnly
+}
+
+func (a *Agent) Lock(passphrase []byte) error {
+	return ErrReadOnly
+}
+
+func (a *Agent) Unlock(passphrase []byte) error {
+	return ErrReadOnly
+}
+
+func (a *Agent) Extension(extensionType string, contents []byte) ([]byte, error) {
+	return nil, agent.ErrExtensionUnsupported
+}
+
+// load returns the cached keys while the SSH keys of the local store, which
+// the sync keeps up to date, stay the same. Otherwise every key is decrypted
+// again, broken keys are skipped so that a single bad entry does not lock the
+// user out of the rest
+func (a *Agent) load() ([]ssh.Signer, []string, error) {
+	ctx := context.Background()
+	stored, err := a.secrets.GetSecrets(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	keys := make([]*entities.Secret, 0)
+	for _, secret := range stored {
+		if secret.SecretType == entities.TypeSSHKey {
+			keys = append(keys, secret)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Name == keys[j].Name {
+			return keys[i].ID < keys[j].ID
+		}
+		return keys[i].Name < keys[j].Name
+	})
+	var state strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&state, "%s/%d/%d;", key.ID, key.Version, key.UpdatedAt.UnixNano())
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.signers != nil && a.state == state.String() {
+		return a.signers, a.comments, nil
+	}
+	signers := make([]ssh.Signer, 0, len(keys))
+	comments := make([]string, 0, len(keys))
+	for _, key := range keys {
+		secret, err := a.secrets.GetSecret(ctx, key.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, ok := secret.Data.(*entities.SSHKey)
+		if !ok {
+			continue
+		}
+		signer, err := sshkey.NewSigner(data.PrivateKey, data.Passphrase)
+		if err != nil {
+			log.Printf("ssh-agent: skip key %s: %v", secret.Name, err)
+			continue
+		}
+		comment := data.Comment
+		if comment == "" {
+			comment = secret.Name
+		}
+		signers = append(signers, signer)
+		comments = append(comments, comment)
+	}
+	a.state, a.signers, a.comments = state.String(), signers, comments
+	return signers, comments, nil
+}
+
+// checkPrivateDir refuses directories other users can enter, windows has no
+// such permission bits
+func checkPrivateDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		return fmt.Errorf("каталог %s доступен другим пользователям, для сокета нужен каталог с правами 0700", dir)
+	}
+	return nil
+}
+
+// removeStaleSocket removes the socket left by an agent that was not shut
+// down, a running agent or any other file at the path is kept
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s уже существует и не является сокетом", path)
+	}
+	conn, err := net.Dial("unix", path)
+	if err == nil {
+		conn.Close()
+		return fmt.Errorf("ssh-agent уже запущен на %s", path)
+	}
+	return os.Remove(path)
+}
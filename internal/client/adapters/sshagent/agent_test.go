@@ -0,0 +1,164 @@
+package sshagent
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/itohin/gophkeeper/pkg/sshkey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func TestAgent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secrets := mocks.NewMockAgentSecrets(ctrl)
+
+	plain, err := sshkey.Generate(sshkey.TypeEd25519, "work", "")
+	require.NoError(t, err)
+	encrypted, err := sshkey.Generate(sshkey.TypeEd25519, "", "secret phrase")
+	require.NoError(t, err)
+
+	stored := map[string]*entities.Secret{
+		"work": {ID: "work", Name: "Work", SecretType: entities.TypeSSHKey, Data: &entities.SSHKey{
+			PrivateKey: plain.PrivateKey, PublicKey: plain.PublicKey, Comment: "work",
+		}},
+		"home": {ID: "home", Name: "Home", SecretType: entities.TypeSSHKey, Data: &entities.SSHKey{
+			PrivateKey: encrypted.PrivateKey, PublicKey: encrypted.PublicKey, Passphrase: "secret phrase",
+		}},
+		"broken": {ID: "broken", Name: "Broken", SecretType: entities.TypeSSHKey, Data: &entities.SSHKey{
+			PrivateKey: encrypted.PrivateKey, Passphrase: "wrong",
+		}},
+	}
+	secrets.EXPECT().GetSecrets(gomock.Any()).Return(map[string]*entities.Secret{
+		"work":   {ID: "work", Name: "Work", SecretType: entities.TypeSSHKey},
+		"home":   {ID: "home", Name: "Home", SecretType: entities.TypeSSHKey},
+		"broken": {ID: "broken", Name: "Broken", SecretType: entities.TypeSSHKey},
+		"note":   {ID: "note", Name: "Note", SecretType: entities.TypeText},
+	}, nil).AnyTimes()
+	secrets.EXPECT().GetSecret(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, id string) (*entities.Secret, error) {
+			return stored[id], nil
+		},
+	).AnyTimes()
+
+	serverConn, clientConn := net.Pipe()
+	defer clientConn.Close()
+	go func() {
+		defer serverConn.Close()
+		_ = agent.ServeAgent(NewAgent(secrets), serverConn)
+	}()
+	client := agent.NewClient(clientConn)
+
+	keys, err := client.List()
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "Home", keys[0].Comment)
+	assert.Equal(t, "work", keys[1].Comment)
+
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(encrypted.PublicKey))
+	require.NoError(t, err)
+	sig, err := client.Sign(pub, []byte("payload"))
+	require.NoError(t, err)
+	assert.NoError(t, pub.Verify([]byte("payload"), sig))
+
+	other, err := sshkey.Generate(sshkey.TypeEd25519, "", "")
+	require.NoError(t, err)
+	otherPub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(other.PublicKey))
+	require.NoError(t, err)
+	_, err = client.Sign(otherPub, []byte("payload"))
+	assert.Error(t, err)
+
+	assert.Error(t, client.RemoveAll())
+}
+
+func TestAgent_storeError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secrets := mocks.NewMockAgentSecrets(ctrl)
+	secrets.EXPECT().GetSecrets(gomock.Any()).Return(nil, errors.New("store error")).Times(1)
+
+	_, err := NewAgent(secrets).List()
+	assert.Error(t, err)
+}
+
+func TestAgent_cache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secrets := mocks.NewMockAgentSecrets(ctrl)
+
+	key, err := sshkey.Generate(sshkey.TypeEd25519, "work", "")
+	require.NoError(t, err)
+	secret := &entities.Secret{ID: "work", Name: "Work", SecretType: entities.TypeSSHKey, Version: 1, Data: &entities.SSHKey{
+		PrivateKey: key.PrivateKey, PublicKey: key.PublicKey, Comment: "work",
+	}}
+	meta := &entities.Secret{ID: "work", Name: "Work", SecretType: entities.TypeSSHKey, Version: 1}
+	updated := &entities.Secret{ID: "work", Name: "Work", SecretType: entities.TypeSSHKey, Version: 2}
+
+	gomock.InOrder(
+		secrets.EXPECT().GetSecrets(gomock.Any()).Return(map[string]*entities.Secret{"work": meta}, nil).Times(2),
+		secrets.EXPECT().GetSecrets(gomock.Any()).Return(map[string]*entities.Secret{"work": updated}, nil).Times(1),
+		secrets.EXPECT().GetSecrets(gomock.Any()).Return(map[string]*entities.Secret{}, nil).Times(1),
+	)
+	secrets.EXPECT().GetSecret(gomock.Any(), "work").Return(secret, nil).Times(2)
+
+	a := NewAgent(secrets)
+	for i := 0; i < 3; i++ {
+		keys, err := a.List()
+		require.NoError(t, err)
+		assert.Len(t, keys, 1)
+	}
+	keys, err := a.List()
+	require.NoError(t, err)
+	assert.Len(t, keys, 0)
+}
+
+func TestRemoveStaleSocket(t *testing.T) {
+	dir := t.TempDir()
+
+	stale := filepath.Join(dir, "stale.sock")
+	l, err := net.Listen("unix", stale)
+	require.NoError(t, err)
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, l.Close())
+	assert.NoError(t, removeStaleSocket(stale))
+	_, err = os.Lstat(stale)
+	assert.True(t, os.IsNotExist(err))
+
+	live := filepath.Join(dir, "live.sock")
+	l, err = net.Listen("unix", live)
+	require.NoError(t, err)
+	defer l.Close()
+	assert.Error(t, removeStaleSocket(live))
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, []byte("data"), 0600))
+	assert.Error(t, removeStaleSocket(file))
+	_, err = os.Stat(file)
+	assert.NoError(t, err)
+
+	assert.NoError(t, removeStaleSocket(filepath.Join(dir, "missing.sock")))
+}
+
+func TestCheckPrivateDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no unix permissions")
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.Chmod(dir, 0700))
+	assert.NoError(t, checkPrivateDir(dir))
+	require.NoError(t, os.Chmod(dir, 0755))
+	assert.Error(t, checkPrivateDir(dir))
+}
@@ -18,10 +18,15 @@ func NewSecretsHydrator() *SecretsHydrator {
 
 func (h *SecretsHydrator) ToProto(s *entities.Secret) (*pb.Secret, error) {
 	ps := &pb.Secret{
-		Id:         s.ID,
-		Name:       s.Name,
-		SecretType: s.SecretType,
-		Notes:      s.Notes,
+		Id:           s.ID,
+		Name:         s.Name,
+		SecretType:   s.SecretType,
+		Notes:        s.Notes,
+		Version:      s.Version,
+		WrappedKey:   s.WrappedKey,
+		CollectionId: s.CollectionID,
+		FolderId:     s.FolderID,
+		Tags:         s.Tags,
 	}
 	switch d := s.Data.(type) {
 	case *entities.Password:
@@ -49,6 +54,10 @@ func (h *SecretsHydrator) ToProto(s *entities.Secret) (*pb.Secret, error) {
 		ps.Data = &pb.Secret_Binary{
 			Binary: d,
 		}
+	case entities.Sealed:
+		ps.Data = &pb.Secret_Sealed{
+			Sealed: d,
+		}
 	default:
 		return nil, fmt.Errorf("unknown secret data type: %v", d)
 	}
@@ -58,12 +67,26 @@ func (h *SecretsHydrator) ToProto(s *entities.Secret) (*pb.Secret, error) {
 
 func (h *SecretsHydrator) FromProto(v *pb.Secret) (*entities.Secret, error) {
 	secret := &entities.Secret{
-		ID:         v.Id,
-		Name:       v.Name,
-		SecretType: v.SecretType,
-		Notes:      v.Notes,
+		ID:             v.Id,
+		Name:           v.Name,
+		SecretType:     v.SecretType,
+		Notes:          v.Notes,
+		Size:           int64(v.Size),
+		Version:        v.Version,
+		Owner:          v.Owner,
+		Permission:     uint32(v.Permission),
+		WrappedKey:     v.WrappedKey,
+		CollectionID:   v.CollectionId,
+		OrganizationID: v.OrganizationId,
+		FolderID:       v.FolderId,
+		Tags:           v.Tags,
+	}
+	if v.UpdatedAt != nil {
+		secret.UpdatedAt = v.UpdatedAt.AsTime()
 	}
 	switch d := v.Data.(type) {
+	case nil:
+		// only metadata was sent, payload is fetched on demand
 	case *pb.Secret_Password:
 		secret.Data = &entities.Password{
 			Login:    d.Password.Login,
@@ -81,6 +104,8 @@ func (h *SecretsHydrator) FromProto(v *pb.Secret) (*entities.Secret, error) {
 		secret.Data = d.Text
 	case *pb.Secret_Binary:
 		secret.Data = d.Binary
+	case *pb.Secret_Sealed:
+		secret.Data = entities.Sealed(d.Sealed)
 	default:
 		return nil, fmt.Errorf("unknown secret data type")
 	}
@@ -93,6 +118,18 @@ func (h *SecretsHydrator) FromSecretEvent(event *events.SecretEvent) (*entities.
 	s.Name = event.Secret.Name
 	s.SecretType = event.Secret.SecretType
 	s.Notes = event.Secret.Notes
+	s.Size = event.Secret.Size
+	s.Version = uint64(event.Secret.Version)
+	s.Owner = event.Secret.Owner
+	s.Permission = event.Secret.Permission
+	s.CollectionID = event.Secret.CollectionID
+	s.OrganizationID = event.Secret.OrganizationID
+	s.FolderID = event.Secret.FolderID
+	s.Tags = event.Secret.Tags
+	s.UpdatedAt = event.Secret.UpdatedAt
+	if event.FetchHint {
+		return &s, nil
+	}
 
 	var t entities.Text
 	var p entities.Password
@@ -112,8 +149,9 @@ func (h *SecretsHydrator) FromSecretEvent(event *events.SecretEvent) (*entities.
 			return nil, fmt.Errorf("failed to transform secret event: %v", err)
 		}
 		s.Data = &entities.Password{
-			Login:    p.Login,
-			Password: p.Password,
+			Login:     p.Login,
+			Password:  p.Password,
+			ChangedAt: p.ChangedAt,
 		}
 	case entities.TypeCard:
 		err := json.Unmarshal(event.Secret.Data, &c)
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/pkg/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretsHydrator_FromSecretEvent(t *testing.T) {
+	updatedAt := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		event    *events.SecretEvent
+		wantData interface{}
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "fetch hint",
+			event: &events.SecretEvent{
+				Secret:    &events.SecretDTO{ID: "text", Name: "Text", SecretType: entities.TypeText, Version: 2, UpdatedAt: updatedAt},
+				FetchHint: true,
+			},
+			wantData: nil,
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "fetch hint ignores the data",
+			event: &events.SecretEvent{
+				Secret:    &events.SecretDTO{ID: "text", Name: "Text", SecretType: entities.TypeText, Version: 2, UpdatedAt: updatedAt, Data: []byte("not json")},
+				FetchHint: true,
+			},
+			wantData: nil,
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "data",
+			event: &events.SecretEvent{
+				Secret: &events.SecretDTO{ID: "text", Name: "Text", SecretType: entities.TypeText, Version: 2, UpdatedAt: updatedAt, Data: []byte(`{"Text":"Lorem ipsum..."}`)},
+			},
+			wantData: "Lorem ipsum...",
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "broken data",
+			event: &events.SecretEvent{
+				Secret: &events.SecretDTO{ID: "text", Name: "Text", SecretType: entities.TypeText, Version: 2, UpdatedAt: updatedAt, Data: []byte("not json")},
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret, err := NewSecretsHydrator().FromSecretEvent(tt.event)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+			assert.Equal(t, "text", secret.ID)
+			assert.Equal(t, "Text", secret.Name)
+			assert.Equal(t, uint64(2), secret.Version)
+			assert.Equal(t, updatedAt, secret.UpdatedAt)
+			assert.Equal(t, tt.wantData, secret.Data)
+		})
+	}
+}
@@ -61,8 +61,8 @@ func (m *MemoryStorage) GetSecret(ctx context.Context, id string) (*entities.Sec
 }
 
 func (m *MemoryStorage) DeleteSecret(ctx context.Context, id string) error {
-	m.mx.RLock()
-	defer m.mx.RUnlock()
+	m.mx.Lock()
+	defer m.mx.Unlock()
 
 	delete(m.secrets, id)
 
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+type FileSyncState struct {
+	dir string
+}
+
+func NewFileSyncState(dir string) *FileSyncState {
+	return &FileSyncState{dir: dir}
+}
+
+func (f *FileSyncState) Load(ctx context.Context, userID string) (*entities.SyncState, error) {
+	state := &entities.SyncState{Secrets: make(map[string]*entities.Secret)}
+	data, err := os.ReadFile(f.path(userID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state: %v", err)
+	}
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sync state: %v", err)
+	}
+	if state.Secrets == nil {
+		state.Secrets = make(map[string]*entities.Secret)
+	}
+	return state, nil
+}
+
+func (f *FileSyncState) Save(ctx context.Context, userID string, state *entities.SyncState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync state: %v", err)
+	}
+	err = os.MkdirAll(f.dir, 0700)
+	if err != nil {
+		return fmt.Errorf("failed to create sync state directory: %v", err)
+	}
+	tmp, err := os.CreateTemp(f.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create sync state file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write sync state: %v", err)
+	}
+	err = tmp.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write sync state: %v", err)
+	}
+	err = os.Rename(tmp.Name(), f.path(userID))
+	if err != nil {
+		return fmt.Errorf("failed to replace sync state: %v", err)
+	}
+	return nil
+}
+
+func (f *FileSyncState) path(userID string) string {
+	return filepath.Join(f.dir, filepath.Base(userID)+".json")
+}
@@ -7,7 +7,8 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"sync"
+	"net"
+	"time"
 
 	"github.com/gobwas/ws"
 	"github.com/gobwas/ws/wsutil"
@@ -15,6 +16,11 @@ import (
 	"github.com/itohin/gophkeeper/pkg/events"
 )
 
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = time.Minute
+)
+
 type SecretsHolder interface {
 	SaveSecret(ctx context.Context, secret *entities.Secret) error
 	DeleteSecret(ctx context.Context, id string) error
@@ -24,65 +30,117 @@ type SecretHydrator interface {
 	FromSecretEvent(event *events.SecretEvent) (*entities.Secret, error)
 }
 
+type Syncer interface {
+	SyncSecrets(ctx context.Context, userID string) error
+}
+
 type WSListener struct {
 	url           string
 	fingerPrint   string
+	dialTimeout   time.Duration
 	shutdownCh    chan struct{}
 	errorCh       chan error
 	secretsHolder SecretsHolder
 	hydrator      SecretHydrator
+	syncer        Syncer
 }
 
 func NewWSListener(
 	url, fingerPrint string,
+	dialTimeout time.Duration,
 	shutdownCh chan struct{},
 	errorCh chan error,
 	secretsHolder SecretsHolder,
 	hydrator SecretHydrator,
+	syncer Syncer,
 ) *WSListener {
 	return &WSListener{
 		url:           url,
 		fingerPrint:   fingerPrint,
+		dialTimeout:   dialTimeout,
 		shutdownCh:    shutdownCh,
 		errorCh:       errorCh,
 		secretsHolder: secretsHolder,
 		hydrator:      hydrator,
+		syncer:        syncer,
 	}
 }
 
 func (w *WSListener) Listen(ctx context.Context, userID string) error {
+	conn, err := w.dial(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to dial the websocket server: %v", err)
+	}
+
+	delay := minReconnectDelay
+	for {
+		if conn != nil {
+			delay = minReconnectDelay
+			if w.serve(ctx, conn) {
+				return nil
+			}
+		}
+
+		select {
+		case <-w.shutdownCh:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+
+		conn, err = w.dial(ctx, userID)
+		if err != nil {
+			log.Printf("failed to reconnect to the websocket server: %v", err)
+			continue
+		}
+		// events sent while the connection was down are only visible in the change log
+		err = w.syncer.SyncSecrets(ctx, userID)
+		if err != nil {
+			w.errorCh <- fmt.Errorf("не удалось синхронизировать данные: %v", err)
+		}
+	}
+}
+
+func (w *WSListener) dial(ctx context.Context, userID string) (net.Conn, error) {
 	dialer := ws.Dialer{
 		TLSConfig: &tls.Config{
 			InsecureSkipVerify: true,
 		},
 	}
+	ctx, cancel := context.WithTimeout(ctx, w.dialTimeout)
+	defer cancel()
 
 	conn, _, _, err := dialer.Dial(ctx, fmt.Sprintf("%s?finger_print=%s&user_id=%s", w.url, w.fingerPrint, userID))
-	if err != nil {
-		return fmt.Errorf("failed to dial the websocket server: %v", err)
-	}
-	defer conn.Close()
-
-	wg := &sync.WaitGroup{}
-	wg.Add(1)
+	return conn, err
+}
 
-	go w.receiveMsg(ctx, wg, conn)
+// serve reports whether the listener was shut down, otherwise the connection was lost
+func (w *WSListener) serve(ctx context.Context, conn net.Conn) bool {
+	defer conn.Close()
 
-	<-w.shutdownCh
+	done := make(chan struct{})
+	go func() {
+		w.receiveMsg(ctx, conn)
+		close(done)
+	}()
 
-	if err := closeConnection(conn); err != nil {
-		return fmt.Errorf("failed to close ws connection: %v", err)
+	select {
+	case <-w.shutdownCh:
+		if err := closeConnection(conn); err != nil {
+			log.Printf("failed to close ws connection: %v", err)
+		}
+		<-done
+		log.Println("connection closed")
+		return true
+	case <-done:
+		log.Println("connection lost")
+		return false
 	}
-	wg.Wait()
-
-	log.Println("connection closed")
-
-	return nil
 }
 
-func (w *WSListener) receiveMsg(ctx context.Context, wg *sync.WaitGroup, conn io.ReadWriteCloser) {
-	defer wg.Done()
-
+func (w *WSListener) receiveMsg(ctx context.Context, conn io.ReadWriteCloser) {
 	for {
 		select {
 		case <-w.shutdownCh:
@@ -93,7 +151,7 @@ func (w *WSListener) receiveMsg(ctx context.Context, wg *sync.WaitGroup, conn io
 		h, r, err := wsutil.NextReader(conn, ws.StateClientSide)
 		if err != nil {
 			log.Printf("failed to read a frame: %v", err)
-			continue
+			return
 		}
 		switch h.OpCode {
 		case ws.OpClose:
@@ -122,21 +180,31 @@ func (w *WSListener) receiveMsg(ctx context.Context, wg *sync.WaitGroup, conn io
 }
 
 func (w *WSListener) handleEvent(ctx context.Context, event events.SecretEvent) error {
-	if event.EventType == events.TypeDeleted {
-		err := w.secretsHolder.DeleteSecret(ctx, event.Secret.ID)
-		if err != nil {
-			return err
+	if len(event.Secrets) > 0 {
+		for _, secret := range event.Secrets {
+			single := event
+			single.Secret = secret
+			single.Secrets = nil
+			err := w.handleEvent(ctx, single)
+			if err != nil {
+				return err
+			}
 		}
-
 		return nil
 	}
+	switch event.EventType {
+	case events.TypeCreated, events.TypeUpdated:
+		secret, err := w.hydrator.FromSecretEvent(&event)
+		if err != nil {
+			return err
+		}
 
-	secret, err := w.hydrator.FromSecretEvent(&event)
-	if err != nil {
-		return err
+		return w.secretsHolder.SaveSecret(ctx, secret)
+	case events.TypeDeleted:
+		return w.secretsHolder.DeleteSecret(ctx, event.Secret.ID)
+	default:
+		return fmt.Errorf("unknown secret event type %v", event.EventType)
 	}
-
-	return w.secretsHolder.SaveSecret(ctx, secret)
 }
 
 func closeConnection(conn io.ReadWriteCloser) error {
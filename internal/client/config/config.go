@@ -15,6 +15,19 @@ const (
 	WebSocketAddress           = "WebSocketAddress"
 	WebSocketConnectionTimeout = "WebSocketConnectionTimeout"
 	GRPCAddress                = "GrpcAddress"
+	SyncStatePath              = "SyncStatePath"
+	SSHAgentSocket             = "SSHAgentSocket"
+	BreachIndexPath            = "BreachIndexPath"
+	AccountLogin               = "AccountLogin"
+	AccountPassword            = "AccountPassword"
+	MasterPassword             = "MasterPassword"
+	OutputJSON                 = "OutputJson"
+	SecretField                = "SecretField"
+	SecretName                 = "SecretName"
+	SecretLogin                = "SecretLogin"
+
+	CommandSSHAgent    = "ssh-agent"
+	CommandBreachIndex = "breach-index"
 )
 
 type JWT struct {
@@ -32,10 +45,47 @@ type GRPC struct {
 	ServerAddress string
 }
 
+type Sync struct {
+	StatePath string
+}
+
+type SSHAgent struct {
+	SocketPath string
+}
+
+type Breaches struct {
+	IndexPath string
+}
+
+// Credentials log in the non-interactive commands, they are read only from
+// the environment
+type Credentials struct {
+	Login          string
+	Password       string
+	MasterPassword string
+}
+
+// Flags are the options of the non-interactive commands
+type Flags struct {
+	JSON  bool
+	Field string
+	Name  string
+	Login string
+}
+
 type AppConfig struct {
-	JWT       *JWT
-	WebSocket *WebSocket
-	GRPC      *GRPC
+	JWT         *JWT
+	WebSocket   *WebSocket
+	GRPC        *GRPC
+	Sync        *Sync
+	SSHAgent    *SSHAgent
+	Breaches    *Breaches
+	Credentials *Credentials
+	Flags       *Flags
+	// Command is the first positional argument, e.g. ssh-agent
+	Command string
+	// Args are the positional arguments of the command
+	Args []string
 }
 
 func ReadConfig() *AppConfig {
@@ -56,7 +106,36 @@ func ReadConfig() *AppConfig {
 		GRPC: &GRPC{
 			ServerAddress: viper.GetString(GRPCAddress),
 		},
+		Sync: &Sync{
+			StatePath: viper.GetString(SyncStatePath),
+		},
+		SSHAgent: &SSHAgent{
+			SocketPath: viper.GetString(SSHAgentSocket),
+		},
+		Breaches: &Breaches{
+			IndexPath: viper.GetString(BreachIndexPath),
+		},
+		Credentials: &Credentials{
+			Login:          viper.GetString(AccountLogin),
+			Password:       viper.GetString(AccountPassword),
+			MasterPassword: viper.GetString(MasterPassword),
+		},
+		Flags: &Flags{
+			JSON:  viper.GetBool(OutputJSON),
+			Field: viper.GetString(SecretField),
+			Name:  viper.GetString(SecretName),
+			Login: viper.GetString(SecretLogin),
+		},
+		Command: pflag.Arg(0),
+		Args:    commandArgs(),
+	}
+}
+
+func commandArgs() []string {
+	if pflag.NArg() < 2 {
+		return nil
 	}
+	return pflag.Args()[1:]
 }
 
 func readEnv() {
@@ -66,6 +145,12 @@ func readEnv() {
 	_ = viper.BindEnv(WebSocketAddress, "WEBSOCKET_ADDRESS")
 	_ = viper.BindEnv(WebSocketConnectionTimeout, "WEBSOCKET_CONNECTION_TIMEOUT")
 	_ = viper.BindEnv(GRPCAddress, "GRPC_ADDRESS")
+	_ = viper.BindEnv(SyncStatePath, "SYNC_STATE_PATH")
+	_ = viper.BindEnv(SSHAgentSocket, "SSH_AGENT_SOCKET")
+	_ = viper.BindEnv(BreachIndexPath, "BREACH_INDEX_PATH")
+	_ = viper.BindEnv(AccountLogin, "GOPHKEEPER_LOGIN")
+	_ = viper.BindEnv(AccountPassword, "GOPHKEEPER_PASSWORD")
+	_ = viper.BindEnv(MasterPassword, "GOPHKEEPER_MASTER_PASSWORD")
 }
 
 func readFlags() {
@@ -78,6 +163,13 @@ func readFlags() {
 	pflag.String("ws-addr", "", "Websocket server address")
 	pflag.Duration("ws-ttl", 100*time.Millisecond, "Timeout to connect to websocket server")
 	pflag.String("grpc-addr", "", "GRPC server address")
+	pflag.String("sync-path", "", "Directory to keep the sync cursor and cached metadata")
+	pflag.String("ssh-sock", "", "Unix socket the ssh-agent mode listens on")
+	pflag.String("breach-index", "", "Index of breached password hashes built by the breach-index command")
+	pflag.Bool("json", false, "Print the result of a command as JSON")
+	pflag.String("field", "", "Field of the secret printed by the get command")
+	pflag.String("name", "", "Name of the secret created by the add command")
+	pflag.String("login", "", "Login of the password created by the add command")
 
 	pflag.Parse()
 
@@ -87,6 +179,13 @@ func readFlags() {
 	_ = viper.BindPFlag(WebSocketAddress, pflag.Lookup("ws-addr"))
 	_ = viper.BindPFlag(WebSocketConnectionTimeout, pflag.Lookup("ws-ttl"))
 	_ = viper.BindPFlag(GRPCAddress, pflag.Lookup("grpc-addr"))
+	_ = viper.BindPFlag(SyncStatePath, pflag.Lookup("sync-path"))
+	_ = viper.BindPFlag(SSHAgentSocket, pflag.Lookup("ssh-sock"))
+	_ = viper.BindPFlag(BreachIndexPath, pflag.Lookup("breach-index"))
+	_ = viper.BindPFlag(OutputJSON, pflag.Lookup("json"))
+	_ = viper.BindPFlag(SecretField, pflag.Lookup("field"))
+	_ = viper.BindPFlag(SecretName, pflag.Lookup("name"))
+	_ = viper.BindPFlag(SecretLogin, pflag.Lookup("login"))
 }
 
 func setDefaults() {
@@ -96,4 +195,7 @@ func setDefaults() {
 	viper.SetDefault(WebSocketAddress, ":7777")
 	viper.SetDefault(WebSocketConnectionTimeout, 100*time.Millisecond)
 	viper.SetDefault(GRPCAddress, ":3200")
+	viper.SetDefault(SyncStatePath, ".gophkeeper/sync")
+	viper.SetDefault(SSHAgentSocket, ".gophkeeper/agent.sock")
+	viper.SetDefault(BreachIndexPath, ".gophkeeper/breaches.idx")
 }
@@ -38,6 +38,19 @@ func TestReadConfig(t *testing.T) {
 				&GRPC{
 					ServerAddress: ":3200",
 				},
+				&Sync{
+					StatePath: ".gophkeeper/sync",
+				},
+				&SSHAgent{
+					SocketPath: ".gophkeeper/agent.sock",
+				},
+				&Breaches{
+					IndexPath: ".gophkeeper/breaches.idx",
+				},
+				&Credentials{},
+				&Flags{},
+				"",
+				nil,
 			},
 		},
 		{
@@ -53,6 +66,12 @@ func TestReadConfig(t *testing.T) {
 					"WEBSOCKET_ADDRESS":            ":9999",
 					"WEBSOCKET_CONNECTION_TIMEOUT": "300ms",
 					"GRPC_ADDRESS":                 ":3400",
+					"SYNC_STATE_PATH":              "/tmp/envsync",
+					"SSH_AGENT_SOCKET":             "/tmp/env.sock",
+					"BREACH_INDEX_PATH":            "/tmp/env.idx",
+					"GOPHKEEPER_LOGIN":             "user@example.com",
+					"GOPHKEEPER_PASSWORD":          "Password1!",
+					"GOPHKEEPER_MASTER_PASSWORD":   "Master1!",
 				},
 			},
 			want: &AppConfig{
@@ -68,6 +87,23 @@ func TestReadConfig(t *testing.T) {
 				&GRPC{
 					ServerAddress: ":3400",
 				},
+				&Sync{
+					StatePath: "/tmp/envsync",
+				},
+				&SSHAgent{
+					SocketPath: "/tmp/env.sock",
+				},
+				&Breaches{
+					IndexPath: "/tmp/env.idx",
+				},
+				&Credentials{
+					Login:          "user@example.com",
+					Password:       "Password1!",
+					MasterPassword: "Master1!",
+				},
+				&Flags{},
+				"",
+				nil,
 			},
 		},
 		{
@@ -81,6 +117,15 @@ func TestReadConfig(t *testing.T) {
 					"--ws-addr=:8888",
 					"--ws-ttl=200ms",
 					"--grpc-addr=:3300",
+					"--sync-path=/tmp/flagsync",
+					"--ssh-sock=/tmp/flag.sock",
+					"--breach-index=/tmp/flag.idx",
+					"--json",
+					"--field=password",
+					"--name=github",
+					"--login=octocat",
+					"breach-index",
+					"/tmp/pwned",
 				},
 				env: map[string]string{},
 			},
@@ -97,6 +142,28 @@ func TestReadConfig(t *testing.T) {
 				&GRPC{
 					ServerAddress: ":3300",
 				},
+				&Sync{
+					StatePath: "/tmp/flagsync",
+				},
+				&SSHAgent{
+					SocketPath: "/tmp/flag.sock",
+				},
+				&Breaches{
+					IndexPath: "/tmp/flag.idx",
+				},
+				&Credentials{
+					Login:          "user@example.com",
+					Password:       "Password1!",
+					MasterPassword: "Master1!",
+				},
+				&Flags{
+					JSON:  true,
+					Field: "password",
+					Name:  "github",
+					Login: "octocat",
+				},
+				"breach-index",
+				[]string{"/tmp/pwned"},
 			},
 		},
 	}
@@ -0,0 +1,18 @@
+package entities
+
+const (
+	BackupEncrypted = "encrypted"
+	BackupJSON      = "json"
+	BackupCSV       = "csv"
+
+	BackupEncryptedLabel = "Зашифрованный архив"
+	BackupJSONLabel      = "JSON без шифрования"
+	BackupCSVLabel       = "CSV без шифрования (без файлов)"
+)
+
+type BackupResult struct {
+	Secrets  int
+	Binaries int
+	// Skipped are binaries that the format can not hold
+	Skipped int
+}
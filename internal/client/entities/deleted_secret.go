@@ -0,0 +1,8 @@
+package entities
+
+import "time"
+
+type DeletedSecret struct {
+	DeletedAt time.Time
+	Secret    *Secret
+}
@@ -0,0 +1,15 @@
+package entities
+
+import "errors"
+
+var (
+	ErrWrongMasterPassword   = errors.New("неверный мастер-пароль")
+	ErrVaultLocked           = errors.New("хранилище заблокировано, введите мастер-пароль")
+	ErrChecksumMismatch      = errors.New("контрольная сумма файла не совпадает, попробуйте еще раз")
+	ErrVersionConflict       = errors.New("запись была изменена на другом устройстве")
+	ErrOrgKeyMissing         = errors.New("ключ организации недоступен, обновите список организаций")
+	ErrOrganizationNotFound  = errors.New("организация не найдена")
+	ErrNotBackup             = errors.New("файл не является резервной копией gophkeeper или поврежден")
+	ErrWrongBackupPassphrase = errors.New("неверный пароль резервной копии")
+	ErrNoBreachIndex         = errors.New("индекс утечек не найден, создайте его командой gophkeeper breach-index <путь к файлам HIBP>")
+)
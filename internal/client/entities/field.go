@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/itohin/gophkeeper/pkg/validator"
+)
+
+const (
+	FieldText = iota
+	FieldHidden
+	FieldURL
+	FieldDate
+
+	FieldTextLabel   = "Текст"
+	FieldHiddenLabel = "Скрытое поле"
+	FieldURLLabel    = "Ссылка"
+	FieldDateLabel   = "Дата"
+
+	FieldDateLayout = "02.01.2006"
+	HiddenMask      = "********"
+
+	MaxFields          = 50
+	MaxFieldNameLength = 100
+	MaxFieldLength     = 4096
+)
+
+type CustomField struct {
+	Name  string
+	Value string
+	Type  uint32
+}
+
+func FieldLabel(fieldType uint32) string {
+	switch fieldType {
+	case FieldHidden:
+		return FieldHiddenLabel
+	case FieldURL:
+		return FieldURLLabel
+	case FieldDate:
+		return FieldDateLabel
+	default:
+		return FieldTextLabel
+	}
+}
+
+// DisplayValue masks hidden fields unless they are revealed explicitly
+func (f CustomField) DisplayValue(reveal bool) string {
+	if f.Type == FieldHidden && !reveal {
+		return HiddenMask
+	}
+	return f.Value
+}
+
+// ValidateFields checks the custom fields before they are sealed, the server
+// only sees the ciphertext and can not validate them
+func ValidateFields(fields []CustomField) error {
+	if len(fields) > MaxFields {
+		return fmt.Errorf("у записи может быть не больше %d дополнительных полей", MaxFields)
+	}
+	for _, f := range fields {
+		err := validator.ValidateStringLength(1, MaxFieldNameLength)(f.Name)
+		if err != nil {
+			return fmt.Errorf("название поля %q: %v", f.Name, err)
+		}
+		validate := validator.ValidateStringLength(0, MaxFieldLength)
+		switch f.Type {
+		case FieldText, FieldHidden:
+		case FieldURL:
+			validate = validator.ValidateURL()
+		case FieldDate:
+			validate = validator.ValidateDate(FieldDateLayout)
+		default:
+			return fmt.Errorf("неизвестный тип %v поля %q", f.Type, f.Name)
+		}
+		err = validate(f.Value)
+		if err != nil {
+			return fmt.Errorf("поле %q: %v", f.Name, err)
+		}
+	}
+	return nil
+}
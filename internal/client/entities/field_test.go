@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []CustomField
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "empty",
+			fields:  nil,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "valid",
+			fields: []CustomField{
+				{Name: "Секретный вопрос", Value: "Девичья фамилия матери", Type: FieldText},
+				{Name: "Ответ", Value: "Иванова", Type: FieldHidden},
+				{Name: "Восстановление", Value: "https://example.com/recover", Type: FieldURL},
+				{Name: "Открыт", Value: "03.07.2024", Type: FieldDate},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "without name",
+			fields:  []CustomField{{Value: "value"}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "too long",
+			fields:  []CustomField{{Name: "Поле", Value: strings.Repeat("a", MaxFieldLength+1)}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "invalid url",
+			fields:  []CustomField{{Name: "Сайт", Value: "example", Type: FieldURL}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "invalid date",
+			fields:  []CustomField{{Name: "Открыт", Value: "2024-07-03", Type: FieldDate}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "unknown type",
+			fields:  []CustomField{{Name: "Поле", Value: "value", Type: 7}},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, ValidateFields(tt.fields))
+		})
+	}
+}
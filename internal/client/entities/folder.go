@@ -0,0 +1,7 @@
+package entities
+
+type Folder struct {
+	ID       string
+	ParentID string
+	Name     string
+}
@@ -0,0 +1,25 @@
+package entities
+
+const (
+	ImportKeePass    = "keepass"
+	ImportBitwarden  = "bitwarden"
+	Import1Password  = "1password"
+	ImportChrome     = "chrome"
+	ImportFirefox    = "firefox"
+	KeePassLabel     = "KeePass (XML)"
+	BitwardenLabel   = "Bitwarden (JSON)"
+	OnePasswordLabel = "1Password (CSV)"
+	ChromeLabel      = "Chrome (CSV)"
+	FirefoxLabel     = "Firefox (CSV)"
+)
+
+type ImportItem struct {
+	Secret *Secret
+	// Duplicate is set when the name is taken in the vault or earlier in the file
+	Duplicate bool
+}
+
+type ImportResult struct {
+	Created int
+	Skipped int
+}
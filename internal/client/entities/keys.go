@@ -0,0 +1,8 @@
+package entities
+
+type KeyParams struct {
+	Salt             []byte
+	KeyCheck         []byte
+	PublicKey        []byte
+	SealedPrivateKey []byte
+}
@@ -0,0 +1,55 @@
+package entities
+
+const (
+	RoleOwner = iota
+	RoleAdmin
+	RoleMember
+	RoleViewer
+
+	OwnerRoleLabel  = "Владелец"
+	AdminRoleLabel  = "Администратор"
+	MemberRoleLabel = "Участник"
+	ViewerRoleLabel = "Наблюдатель"
+)
+
+type Organization struct {
+	ID         string
+	Name       string
+	Role       uint32
+	WrappedKey []byte
+}
+
+type Member struct {
+	UserID string
+	Email  string
+	Role   uint32
+}
+
+type Collection struct {
+	ID             string
+	OrganizationID string
+	Name           string
+}
+
+func RoleLabel(role uint32) string {
+	switch role {
+	case RoleOwner:
+		return OwnerRoleLabel
+	case RoleAdmin:
+		return AdminRoleLabel
+	case RoleMember:
+		return MemberRoleLabel
+	case RoleViewer:
+		return ViewerRoleLabel
+	default:
+		return ""
+	}
+}
+
+func (o *Organization) CanManage() bool {
+	return o.Role <= RoleAdmin
+}
+
+func (o *Organization) CanWrite() bool {
+	return o.Role <= RoleMember
+}
@@ -0,0 +1,52 @@
+package entities
+
+import "strings"
+
+const (
+	IssueReused = 1 << iota
+	IssueDuplicate
+	IssueWeak
+	IssueOld
+	IssueBreached
+
+	ReusedLabel    = "пароль используется в других записях"
+	DuplicateLabel = "дубликат записи"
+	WeakLabel      = "слабый пароль"
+	OldLabel       = "давно не менялся"
+	BreachedLabel  = "найден в утечках"
+)
+
+type PasswordReport struct {
+	Total   int
+	Entries []*PasswordReportEntry
+}
+
+type PasswordReportEntry struct {
+	Secret   *Secret
+	Issues   int
+	Score    int
+	Breaches uint32
+}
+
+func (e *PasswordReportEntry) Has(issue int) bool {
+	return e.Issues&issue != 0
+}
+
+func (e *PasswordReportEntry) IssueLabels() string {
+	labels := make([]string, 0, 5)
+	for _, i := range []struct {
+		issue int
+		label string
+	}{
+		{IssueReused, ReusedLabel},
+		{IssueDuplicate, DuplicateLabel},
+		{IssueWeak, WeakLabel},
+		{IssueOld, OldLabel},
+		{IssueBreached, BreachedLabel},
+	} {
+		if e.Has(i.issue) {
+			labels = append(labels, i.label)
+		}
+	}
+	return strings.Join(labels, ", ")
+}
@@ -0,0 +1,9 @@
+package entities
+
+import "time"
+
+type Revision struct {
+	ID        string
+	CreatedAt time.Time
+	Secret    *Secret
+}
@@ -0,0 +1,32 @@
+package entities
+
+const (
+	SortNameAsc = iota
+	SortNameDesc
+	SortUpdatedDesc
+	SortUpdatedAsc
+
+	SortNameAscLabel     = "По названию (А-Я)"
+	SortNameDescLabel    = "По названию (Я-А)"
+	SortUpdatedDescLabel = "Сначала новые"
+	SortUpdatedAscLabel  = "Сначала старые"
+)
+
+type SecretsFilter struct {
+	Query        string
+	SecretType   uint32
+	Sort         int
+	CollectionID string
+	FolderID     string
+	Tag          string
+}
+
+type SecretsPage struct {
+	Secrets       []*Secret
+	NextPageToken string
+}
+
+func (f *SecretsFilter) IsEmpty() bool {
+	return f.Query == "" && f.SecretType == 0 && f.Sort == SortNameAsc && f.CollectionID == "" &&
+		f.FolderID == "" && f.Tag == ""
+}
@@ -1,28 +1,63 @@
 package entities
 
+import "time"
+
 const (
 	TypeText = iota + 1
 	TypePassword
 	TypeBinary
 	TypeCard
+	TypeTOTP
+	TypeSSHKey
 
 	TextLabel     = "Текстовые данные"
 	PasswordLabel = "Данные для входа(логин/пароль)"
 	BinaryLabel   = "Бинарные данные"
 	CardLabel     = "Данные банковских карт"
+	TOTPLabel     = "Коды двухфакторной аутентификации"
+	SSHKeyLabel   = "SSH ключи"
+)
+
+const (
+	PermissionOwner = iota
+	PermissionRead
+	PermissionReadWrite
+
+	ReadLabel      = "Только чтение"
+	ReadWriteLabel = "Чтение и изменение"
 )
 
+// MaxBatchSize is the number of secrets the server creates or deletes at once
+const MaxBatchSize = 100
+
 type Secret struct {
-	ID         string
-	Name       string
-	SecretType uint32
-	Notes      string
-	Data       interface{}
+	ID             string
+	Name           string
+	SecretType     uint32
+	Notes          string
+	Data           interface{}
+	Size           int64
+	Version        uint64
+	Owner          string
+	Permission     uint32
+	WrappedKey     []byte
+	ItemKey        []byte
+	CollectionID   string
+	OrganizationID string
+	FolderID       string
+	Tags           []string
+	Fields         []CustomField
+	UpdatedAt      time.Time
 }
 
+type Sealed []byte
+
+// Password keeps the time of the last password change, editing the login or
+// the notes does not make the password any younger
 type Password struct {
-	Login    string
-	Password string
+	Login     string
+	Password  string
+	ChangedAt time.Time
 }
 
 type Text struct {
@@ -41,6 +76,22 @@ type Card struct {
 	OwnerName  string
 }
 
+type TOTP struct {
+	Secret    string
+	Issuer    string
+	Account   string
+	Digits    uint32
+	Period    uint32
+	Algorithm uint32
+}
+
+type SSHKey struct {
+	PrivateKey string
+	PublicKey  string
+	Comment    string
+	Passphrase string
+}
+
 func (s *Secret) GetLabel() string {
 	switch s.SecretType {
 	case TypeText:
@@ -51,7 +102,19 @@ func (s *Secret) GetLabel() string {
 		return BinaryLabel
 	case TypeCard:
 		return CardLabel
+	case TypeTOTP:
+		return TOTPLabel
+	case TypeSSHKey:
+		return SSHKeyLabel
 	default:
 		return ""
 	}
 }
+
+func (s *Secret) IsShared() bool {
+	return s.Owner != ""
+}
+
+func (s *Secret) CanEdit() bool {
+	return s.Permission != PermissionRead
+}
@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+type Share struct {
+	UserID     string
+	Email      string
+	Permission uint32
+	CreatedAt  time.Time
+}
+
+func PermissionLabel(permission uint32) string {
+	switch permission {
+	case PermissionRead:
+		return ReadLabel
+	case PermissionReadWrite:
+		return ReadWriteLabel
+	default:
+		return ""
+	}
+}
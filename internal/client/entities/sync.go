@@ -0,0 +1,18 @@
+package entities
+
+type SecretChange struct {
+	Secret  *Secret
+	Deleted bool
+}
+
+type ChangesPage struct {
+	Changes []*SecretChange
+	Cursor  uint64
+	HasMore bool
+	Resync  bool
+}
+
+type SyncState struct {
+	Cursor  uint64
+	Secrets map[string]*Secret
+}
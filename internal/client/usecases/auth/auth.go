@@ -2,6 +2,8 @@ package auth
 
 import (
 	"context"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
 )
 
 type Client interface {
@@ -9,6 +11,16 @@ type Client interface {
 	Verify(ctx context.Context, email, otp string) (string, error)
 	Login(ctx context.Context, email, password string) (string, error)
 	Logout(ctx context.Context) error
+	GetKeyParams(ctx context.Context) (*entities.KeyParams, error)
+	SetKeyParams(ctx context.Context, params *entities.KeyParams) error
+	SetKeyPair(ctx context.Context, publicKey, sealedPrivateKey []byte) error
+}
+
+type KeyStore interface {
+	NewKeyParams(password string) (*entities.KeyParams, error)
+	NewKeyPair() ([]byte, []byte, error)
+	Unlock(password string, params *entities.KeyParams) error
+	Lock()
 }
 
 type ServerListener interface {
@@ -17,12 +29,14 @@ type ServerListener interface {
 
 type AuthUseCase struct {
 	client Client
+	keys   KeyStore
 	authCh chan string
 }
 
-func NewAuth(client Client, authCh chan string) *AuthUseCase {
+func NewAuth(client Client, keys KeyStore, authCh chan string) *AuthUseCase {
 	return &AuthUseCase{
 		client: client,
+		keys:   keys,
 		authCh: authCh,
 	}
 }
@@ -52,5 +66,48 @@ func (a *AuthUseCase) Login(ctx context.Context, login, password string) error {
 }
 
 func (a *AuthUseCase) Logout(ctx context.Context) error {
+	a.keys.Lock()
 	return a.client.Logout(ctx)
 }
+
+func (a *AuthUseCase) HasMasterPassword(ctx context.Context) (bool, error) {
+	params, err := a.client.GetKeyParams(ctx)
+	if err != nil {
+		return false, err
+	}
+	return len(params.Salt) > 0, nil
+}
+
+func (a *AuthUseCase) CreateMasterPassword(ctx context.Context, password string) error {
+	params, err := a.keys.NewKeyParams(password)
+	if err != nil {
+		return err
+	}
+	err = a.client.SetKeyParams(ctx, params)
+	if err != nil {
+		a.keys.Lock()
+		return err
+	}
+	return nil
+}
+
+func (a *AuthUseCase) Unlock(ctx context.Context, password string) error {
+	params, err := a.client.GetKeyParams(ctx)
+	if err != nil {
+		return err
+	}
+	err = a.keys.Unlock(password, params)
+	if err != nil {
+		return err
+	}
+	if len(params.PublicKey) > 0 {
+		return nil
+	}
+
+	// accounts created before sharing get their key pair on the first unlock
+	publicKey, sealedPrivateKey, err := a.keys.NewKeyPair()
+	if err != nil {
+		return err
+	}
+	return a.client.SetKeyPair(ctx, publicKey, sealedPrivateKey)
+}
@@ -0,0 +1,184 @@
+package backup
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+// The decrypted archive is a length-prefixed manifest frame followed by the
+// content of every binary secret in manifest order, each as a sequence of
+// length-prefixed chunks ended by an empty one, so binaries are streamed
+// without knowing their size up front
+const (
+	ManifestVersion = 1
+
+	maxManifestSize = 256 << 20
+)
+
+type manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Secrets   []*record `json:"secrets"`
+}
+
+type record struct {
+	Name     string                 `json:"name"`
+	Type     uint32                 `json:"type"`
+	Notes    string                 `json:"notes,omitempty"`
+	Tags     []string               `json:"tags,omitempty"`
+	Fields   []entities.CustomField `json:"fields,omitempty"`
+	Text     *string                `json:"text,omitempty"`
+	Password *entities.Password     `json:"password,omitempty"`
+	Card     *entities.Card         `json:"card,omitempty"`
+	TOTP     *entities.TOTP         `json:"totp,omitempty"`
+	SSHKey   *entities.SSHKey       `json:"ssh_key,omitempty"`
+	Size     int64                  `json:"size,omitempty"`
+	// Content is only set by the plain JSON export, the archive streams
+	// binaries after the manifest
+	Content []byte `json:"content,omitempty"`
+}
+
+func newRecord(s *entities.Secret) *record {
+	r := &record{
+		Name:   s.Name,
+		Type:   s.SecretType,
+		Notes:  s.Notes,
+		Tags:   s.Tags,
+		Fields: s.Fields,
+		Size:   s.Size,
+	}
+	switch d := s.Data.(type) {
+	case string:
+		r.Text = &d
+	case *entities.Password:
+		r.Password = d
+	case *entities.Card:
+		r.Card = d
+	case *entities.TOTP:
+		r.TOTP = d
+	case *entities.SSHKey:
+		r.SSHKey = d
+	}
+	return r
+}
+
+func (r *record) secret() (*entities.Secret, error) {
+	s := &entities.Secret{
+		Name:       r.Name,
+		SecretType: r.Type,
+		Notes:      r.Notes,
+		Tags:       r.Tags,
+		Fields:     r.Fields,
+		Size:       r.Size,
+	}
+	switch {
+	case r.Type == entities.TypeText && r.Text != nil:
+		s.Data = *r.Text
+	case r.Type == entities.TypePassword && r.Password != nil:
+		s.Data = r.Password
+	case r.Type == entities.TypeCard && r.Card != nil:
+		s.Data = r.Card
+	case r.Type == entities.TypeTOTP && r.TOTP != nil:
+		s.Data = r.TOTP
+	case r.Type == entities.TypeSSHKey && r.SSHKey != nil:
+		s.Data = r.SSHKey
+	case r.Type == entities.TypeBinary:
+	default:
+		return nil, fmt.Errorf("запись %q резервной копии повреждена", r.Name)
+	}
+	return s, nil
+}
+
+func writeManifest(w io.Writer, m *manifest) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return writeFrame(w, data)
+}
+
+func readManifest(r io.Reader) (*manifest, error) {
+	var size uint32
+	err := binary.Read(r, binary.BigEndian, &size)
+	if err != nil || size > maxManifestSize {
+		return nil, entities.ErrNotBackup
+	}
+	data := make([]byte, size)
+	_, err = io.ReadFull(r, data)
+	if err != nil {
+		return nil, entities.ErrNotBackup
+	}
+	var m manifest
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, entities.ErrNotBackup
+	}
+	if m.Version > ManifestVersion {
+		return nil, fmt.Errorf("резервная копия версии %d создана более новой версией gophkeeper", m.Version)
+	}
+	return &m, nil
+}
+
+func writeFrame(w io.Writer, data []byte) error {
+	err := binary.Write(w, binary.BigEndian, uint32(len(data)))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+type chunkWriter struct {
+	w io.Writer
+}
+
+func (c *chunkWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	err := writeFrame(c.w, p)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (c *chunkWriter) Close() error {
+	return writeFrame(c.w, nil)
+}
+
+type chunkReader struct {
+	r    io.Reader
+	left uint32
+	done bool
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	if c.done {
+		return 0, io.EOF
+	}
+	if c.left == 0 {
+		err := binary.Read(c.r, binary.BigEndian, &c.left)
+		if err != nil {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if c.left == 0 {
+			c.done = true
+			return 0, io.EOF
+		}
+	}
+	if uint32(len(p)) > c.left {
+		p = p[:c.left]
+	}
+	n, err := c.r.Read(p)
+	c.left -= uint32(n)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
@@ -0,0 +1,229 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+type Secrets interface {
+	GetSecrets(ctx context.Context) (map[string]*entities.Secret, error)
+	GetSecret(ctx context.Context, id string) (*entities.Secret, error)
+	CreateSecret(ctx context.Context, secret *entities.Secret) error
+	UploadBinary(ctx context.Context, secret *entities.Secret, r io.Reader) error
+	DownloadBinary(ctx context.Context, secret *entities.Secret, w io.Writer) error
+}
+
+type Archiver interface {
+	SealArchive(passphrase string, r io.Reader) (io.Reader, error)
+	OpenArchive(passphrase string, r io.Reader) (io.ReadCloser, error)
+}
+
+type BackupUseCase struct {
+	secrets  Secrets
+	archiver Archiver
+}
+
+func NewBackup(secrets Secrets, archiver Archiver) *BackupUseCase {
+	return &BackupUseCase{
+		secrets:  secrets,
+		archiver: archiver,
+	}
+}
+
+// Export writes the secrets owned by the user, shared and organization
+// secrets belong to someone else and are left out
+func (b *BackupUseCase) Export(ctx context.Context, w io.Writer, format, passphrase string) (*entities.BackupResult, error) {
+	secrets, err := b.ownSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case entities.BackupEncrypted:
+		return b.exportArchive(ctx, w, secrets, passphrase)
+	case entities.BackupJSON:
+		return b.exportJSON(ctx, w, secrets)
+	case entities.BackupCSV:
+		return exportCSV(w, secrets)
+	default:
+		return nil, fmt.Errorf("unknown backup format %v", format)
+	}
+}
+
+// Restore creates the secrets of an encrypted archive in the current account,
+// binaries are uploaded while the archive is read
+func (b *BackupUseCase) Restore(ctx context.Context, r io.Reader, passphrase string, progress func(done, total int)) (*entities.BackupResult, error) {
+	plain, err := b.archiver.OpenArchive(passphrase, r)
+	if err != nil {
+		return nil, err
+	}
+	defer plain.Close()
+	m, err := readManifest(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entities.BackupResult{}
+	for k, rec := range m.Secrets {
+		secret, err := rec.secret()
+		if err != nil {
+			return result, err
+		}
+		if secret.SecretType == entities.TypeBinary {
+			content := &chunkReader{r: plain}
+			err = b.secrets.UploadBinary(ctx, secret, content)
+			if err == nil {
+				_, err = io.Copy(io.Discard, content)
+			}
+			result.Binaries++
+		} else {
+			err = b.secrets.CreateSecret(ctx, secret)
+			result.Secrets++
+		}
+		if err != nil {
+			return result, err
+		}
+		if progress != nil {
+			progress(k+1, len(m.Secrets))
+		}
+	}
+	return result, nil
+}
+
+func (b *BackupUseCase) ownSecrets(ctx context.Context) ([]*entities.Secret, error) {
+	stored, err := b.secrets.GetSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secrets := make([]*entities.Secret, 0, len(stored))
+	for id, s := range stored {
+		if s.IsShared() || s.CollectionID != "" {
+			continue
+		}
+		s, err = b.secrets.GetSecret(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, s)
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+	return secrets, nil
+}
+
+func (b *BackupUseCase) exportArchive(ctx context.Context, w io.Writer, secrets []*entities.Secret, passphrase string) (*entities.BackupResult, error) {
+	result := &entities.BackupResult{}
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(b.writeArchive(ctx, pw, secrets, result))
+	}()
+	sealed, err := b.archiver.SealArchive(passphrase, pr)
+	if err != nil {
+		pr.CloseWithError(err)
+		return nil, err
+	}
+	_, err = io.Copy(w, sealed)
+	if err != nil {
+		pr.CloseWithError(err)
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b *BackupUseCase) writeArchive(ctx context.Context, w io.Writer, secrets []*entities.Secret, result *entities.BackupResult) error {
+	m := &manifest{Version: ManifestVersion, CreatedAt: time.Now().UTC()}
+	for _, s := range secrets {
+		m.Secrets = append(m.Secrets, newRecord(s))
+	}
+	err := writeManifest(w, m)
+	if err != nil {
+		return err
+	}
+	for _, s := range secrets {
+		if s.SecretType != entities.TypeBinary {
+			result.Secrets++
+			continue
+		}
+		content := &chunkWriter{w: w}
+		err = b.secrets.DownloadBinary(ctx, s, content)
+		if err != nil {
+			return err
+		}
+		err = content.Close()
+		if err != nil {
+			return err
+		}
+		result.Binaries++
+	}
+	return nil
+}
+
+func (b *BackupUseCase) exportJSON(ctx context.Context, w io.Writer, secrets []*entities.Secret) (*entities.BackupResult, error) {
+	result := &entities.BackupResult{}
+	m := &manifest{Version: ManifestVersion, CreatedAt: time.Now().UTC()}
+	for _, s := range secrets {
+		rec := newRecord(s)
+		if s.SecretType == entities.TypeBinary {
+			var content bytes.Buffer
+			err := b.secrets.DownloadBinary(ctx, s, &content)
+			if err != nil {
+				return nil, err
+			}
+			rec.Content = content.Bytes()
+			result.Binaries++
+		} else {
+			result.Secrets++
+		}
+		m.Secrets = append(m.Secrets, rec)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return result, encoder.Encode(m)
+}
+
+// exportCSV writes one row per secret, the data of cards, TOTP and SSH keys
+// goes to the data column as JSON and binaries are skipped
+func exportCSV(w io.Writer, secrets []*entities.Secret) (*entities.BackupResult, error) {
+	result := &entities.BackupResult{}
+	cw := csv.NewWriter(w)
+	err := cw.Write([]string{"name", "type", "login", "password", "text", "notes", "tags", "data"})
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range secrets {
+		row := []string{s.Name, strconv.Itoa(int(s.SecretType)), "", "", "", s.Notes, strings.Join(s.Tags, ","), ""}
+		switch d := s.Data.(type) {
+		case string:
+			row[4] = d
+		case *entities.Password:
+			row[2] = d.Login
+			row[3] = d.Password
+		case *entities.Card, *entities.TOTP, *entities.SSHKey:
+			data, err := json.Marshal(d)
+			if err != nil {
+				return nil, err
+			}
+			row[7] = string(data)
+		default:
+			result.Skipped++
+			continue
+		}
+		err = cw.Write(row)
+		if err != nil {
+			return nil, err
+		}
+		result.Secrets++
+	}
+	cw.Flush()
+	return result, cw.Error()
+}
@@ -0,0 +1,127 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func backupSecrets() map[string]*entities.Secret {
+	return map[string]*entities.Secret{
+		"text": {ID: "text", Name: "Note", SecretType: entities.TypeText, Data: "Lorem ipsum", Tags: []string{"home"}},
+		"mail": {
+			ID:         "mail",
+			Name:       "Mail",
+			SecretType: entities.TypePassword,
+			Notes:      "work",
+			Data:       &entities.Password{Login: "ivan", Password: "secret"},
+			Fields:     []entities.CustomField{{Name: "PIN", Value: "1234", Type: entities.FieldHidden}},
+		},
+		"file":   {ID: "file", Name: "File", SecretType: entities.TypeBinary, Size: 12},
+		"shared": {ID: "shared", Name: "Shared", SecretType: entities.TypeText, Data: "foreign", Owner: "boss@example.com"},
+	}
+}
+
+func TestBackupUseCase_ExportRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secrets := mocks.NewMockBackupSecrets(ctrl)
+	archiver := mocks.NewMockArchiver(ctrl)
+	b := NewBackup(secrets, archiver)
+	stored := backupSecrets()
+
+	secrets.EXPECT().GetSecrets(gomock.Any()).Return(stored, nil).Times(1)
+	secrets.EXPECT().GetSecret(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) (*entities.Secret, error) {
+		return stored[id], nil
+	}).Times(3)
+	secrets.EXPECT().DownloadBinary(gomock.Any(), stored["file"], gomock.Any()).DoAndReturn(func(_ context.Context, _ *entities.Secret, w io.Writer) error {
+		_, err := w.Write([]byte("file "))
+		if err != nil {
+			return err
+		}
+		_, err = w.Write([]byte("content"))
+		return err
+	}).Times(1)
+	archiver.EXPECT().SealArchive("passphrase", gomock.Any()).DoAndReturn(func(_ string, r io.Reader) (io.Reader, error) {
+		return r, nil
+	}).Times(1)
+
+	var archive bytes.Buffer
+	result, err := b.Export(context.Background(), &archive, entities.BackupEncrypted, "passphrase")
+	require.NoError(t, err)
+	assert.Equal(t, &entities.BackupResult{Secrets: 2, Binaries: 1}, result)
+
+	archiver.EXPECT().OpenArchive("passphrase", gomock.Any()).DoAndReturn(func(_ string, r io.Reader) (io.ReadCloser, error) {
+		return io.NopCloser(r), nil
+	}).Times(1)
+	var created []*entities.Secret
+	secrets.EXPECT().CreateSecret(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *entities.Secret) error {
+		created = append(created, s)
+		return nil
+	}).Times(2)
+	secrets.EXPECT().UploadBinary(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *entities.Secret, r io.Reader) error {
+		assert.Equal(t, "File", s.Name)
+		content, err := io.ReadAll(r)
+		assert.Equal(t, "file content", string(content))
+		return err
+	}).Times(1)
+
+	var done []int
+	result, err = b.Restore(context.Background(), &archive, "passphrase", func(d, total int) {
+		done = append(done, d)
+		assert.Equal(t, 3, total)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &entities.BackupResult{Secrets: 2, Binaries: 1}, result)
+	assert.Equal(t, []int{1, 2, 3}, done)
+	require.Len(t, created, 2)
+	assert.Equal(t, &entities.Password{Login: "ivan", Password: "secret"}, created[0].Data)
+	assert.Equal(t, stored["mail"].Fields, created[0].Fields)
+	assert.Equal(t, "work", created[0].Notes)
+	assert.Equal(t, "Lorem ipsum", created[1].Data)
+	assert.Equal(t, []string{"home"}, created[1].Tags)
+}
+
+func TestBackupUseCase_ExportCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secrets := mocks.NewMockBackupSecrets(ctrl)
+	b := NewBackup(secrets, mocks.NewMockArchiver(ctrl))
+	stored := backupSecrets()
+
+	secrets.EXPECT().GetSecrets(gomock.Any()).Return(stored, nil).Times(1)
+	secrets.EXPECT().GetSecret(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) (*entities.Secret, error) {
+		return stored[id], nil
+	}).Times(3)
+
+	var out bytes.Buffer
+	result, err := b.Export(context.Background(), &out, entities.BackupCSV, "")
+	require.NoError(t, err)
+	assert.Equal(t, &entities.BackupResult{Secrets: 2, Skipped: 1}, result)
+	assert.Equal(t, "name,type,login,password,text,notes,tags,data\n"+
+		"Mail,2,ivan,secret,,work,,\n"+
+		"Note,1,,,Lorem ipsum,,home,\n", out.String())
+}
+
+func TestBackupUseCase_RestoreNotBackup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	archiver := mocks.NewMockArchiver(ctrl)
+	b := NewBackup(mocks.NewMockBackupSecrets(ctrl), archiver)
+
+	archiver.EXPECT().OpenArchive("passphrase", gomock.Any()).DoAndReturn(func(_ string, r io.Reader) (io.ReadCloser, error) {
+		return io.NopCloser(r), nil
+	}).Times(1)
+	_, err := b.Restore(context.Background(), bytes.NewReader([]byte{0, 0, 0, 3, 'b', 'a', 'd'}), "passphrase", nil)
+	assert.ErrorIs(t, err, entities.ErrNotBackup)
+}
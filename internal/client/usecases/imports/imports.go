@@ -0,0 +1,110 @@
+package imports
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+const batchSize = 20
+
+type Secrets interface {
+	GetSecrets(ctx context.Context) (map[string]*entities.Secret, error)
+	CreateSecrets(ctx context.Context, secrets []*entities.Secret) error
+	UploadBinary(ctx context.Context, secret *entities.Secret, r io.Reader) error
+}
+
+type Parser interface {
+	Parse(format string, r io.Reader) ([]*entities.Secret, error)
+}
+
+type ImportsUseCase struct {
+	secrets Secrets
+	parser  Parser
+}
+
+func NewImports(secrets Secrets, parser Parser) *ImportsUseCase {
+	return &ImportsUseCase{
+		secrets: secrets,
+		parser:  parser,
+	}
+}
+
+// Preview parses the export without saving anything and marks the secrets
+// whose name is taken in the vault or by an earlier secret of the file,
+// names are compared case-insensitively
+func (i *ImportsUseCase) Preview(ctx context.Context, format string, r io.Reader) ([]*entities.ImportItem, error) {
+	secrets, err := i.parser.Parse(format, r)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := i.secrets.GetSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]struct{}, len(stored)+len(secrets))
+	for _, s := range stored {
+		names[strings.ToLower(s.Name)] = struct{}{}
+	}
+	items := make([]*entities.ImportItem, 0, len(secrets))
+	for _, s := range secrets {
+		name := strings.ToLower(s.Name)
+		_, duplicate := names[name]
+		names[name] = struct{}{}
+		items = append(items, &entities.ImportItem{Secret: s, Duplicate: duplicate})
+	}
+	return items, nil
+}
+
+// Import creates the secrets batch by batch and reports the progress after
+// every batch, a batch is saved as a whole, on error the result holds what
+// was created before it
+func (i *ImportsUseCase) Import(ctx context.Context, items []*entities.ImportItem, skipDuplicates bool, progress func(done, total int)) (*entities.ImportResult, error) {
+	result := &entities.ImportResult{}
+	batch := make([]*entities.Secret, 0, batchSize)
+	flush := func() error {
+		secrets := make([]*entities.Secret, 0, len(batch))
+		for _, s := range batch {
+			if s.SecretType != entities.TypeBinary {
+				secrets = append(secrets, s)
+				continue
+			}
+			content, _ := s.Data.([]byte)
+			err := i.secrets.UploadBinary(ctx, s, bytes.NewReader(content))
+			if err != nil {
+				return err
+			}
+			result.Created++
+		}
+		if len(secrets) > 0 {
+			err := i.secrets.CreateSecrets(ctx, secrets)
+			if err != nil {
+				return err
+			}
+			result.Created += len(secrets)
+		}
+		batch = batch[:0]
+		if progress != nil {
+			progress(result.Created+result.Skipped, len(items))
+		}
+		return nil
+	}
+	for _, item := range items {
+		if item.Duplicate && skipDuplicates {
+			result.Skipped++
+			continue
+		}
+		batch = append(batch, item.Secret)
+		if len(batch) < batchSize {
+			continue
+		}
+		err := flush()
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, flush()
+}
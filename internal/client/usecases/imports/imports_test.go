@@ -0,0 +1,93 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportsUseCase_Preview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secrets := mocks.NewMockImportSecrets(ctrl)
+	parser := mocks.NewMockImportParser(ctrl)
+	i := NewImports(secrets, parser)
+
+	r := strings.NewReader("export")
+	parser.EXPECT().Parse(entities.ImportChrome, r).Return([]*entities.Secret{
+		{Name: "mail"},
+		{Name: "Shop"},
+		{Name: "SHOP"},
+	}, nil).Times(1)
+	secrets.EXPECT().GetSecrets(gomock.Any()).Return(map[string]*entities.Secret{
+		"1": {ID: "1", Name: "Mail"},
+	}, nil).Times(1)
+
+	items, err := i.Preview(context.Background(), entities.ImportChrome, r)
+	require.NoError(t, err)
+	got := make([]bool, 0, len(items))
+	for _, item := range items {
+		got = append(got, item.Duplicate)
+	}
+	assert.Equal(t, []bool{true, false, true}, got)
+}
+
+func TestImportsUseCase_Import(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secrets := mocks.NewMockImportSecrets(ctrl)
+	i := NewImports(secrets, mocks.NewMockImportParser(ctrl))
+
+	items := make([]*entities.ImportItem, 0, batchSize+3)
+	for k := 0; k < batchSize+2; k++ {
+		items = append(items, &entities.ImportItem{
+			Secret: &entities.Secret{Name: fmt.Sprintf("secret %d", k), SecretType: entities.TypeText, Data: "text"},
+		})
+	}
+	items = append(items,
+		&entities.ImportItem{Secret: &entities.Secret{Name: "file", SecretType: entities.TypeBinary, Data: []byte("content")}},
+	)
+	items[0].Duplicate = true
+
+	t.Run("skip duplicates", func(t *testing.T) {
+		first := make([]*entities.Secret, 0, batchSize)
+		for _, item := range items[1 : batchSize+1] {
+			first = append(first, item.Secret)
+		}
+		gomock.InOrder(
+			secrets.EXPECT().CreateSecrets(gomock.Any(), first).Return(nil).Times(1),
+			secrets.EXPECT().CreateSecrets(gomock.Any(), gomock.Len(1)).Return(nil).Times(1),
+		)
+		secrets.EXPECT().UploadBinary(gomock.Any(), items[len(items)-1].Secret, gomock.Any()).Return(nil).Times(1)
+
+		var reported [][2]int
+		result, err := i.Import(context.Background(), items, true, func(done, total int) {
+			reported = append(reported, [2]int{done, total})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &entities.ImportResult{Created: batchSize + 2, Skipped: 1}, result)
+		assert.Equal(t, [][2]int{{batchSize + 1, len(items)}, {len(items), len(items)}}, reported)
+	})
+
+	t.Run("create error", func(t *testing.T) {
+		gomock.InOrder(
+			secrets.EXPECT().CreateSecrets(gomock.Any(), gomock.Len(batchSize)).Return(nil).Times(1),
+			secrets.EXPECT().CreateSecrets(gomock.Any(), gomock.Any()).Return(errors.New("create error")).Times(1),
+		)
+		secrets.EXPECT().UploadBinary(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		result, err := i.Import(context.Background(), items, false, nil)
+		assert.Error(t, err)
+		assert.Equal(t, batchSize+1, result.Created)
+	})
+}
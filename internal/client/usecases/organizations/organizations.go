@@ -0,0 +1,125 @@
+package organizations
+
+import (
+	"context"
+	"errors"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+type Client interface {
+	CreateOrganization(ctx context.Context, name string, wrappedKey []byte) (string, error)
+	GetOrganizations(ctx context.Context) ([]*entities.Organization, error)
+	InviteMember(ctx context.Context, orgID, email string, role uint32, wrappedKey []byte) error
+	AcceptInvitation(ctx context.Context, code string) (string, error)
+	GetMembers(ctx context.Context, orgID string) ([]*entities.Member, error)
+	SetMemberRole(ctx context.Context, orgID, userID string, role uint32) error
+	RemoveMember(ctx context.Context, orgID, userID string) error
+	CreateCollection(ctx context.Context, orgID, name string) (string, error)
+	GetCollections(ctx context.Context, orgID string) ([]*entities.Collection, error)
+	DeleteCollection(ctx context.Context, id string) error
+	GetPublicKey(ctx context.Context, email string) (string, []byte, error)
+}
+
+type Cipher interface {
+	NewOrganizationKey() ([]byte, []byte, error)
+	AddOrganizationKey(orgID string, wrapped []byte) error
+	WrapOrganizationKeyFor(orgID string, publicKey []byte) ([]byte, error)
+}
+
+type OrganizationsUseCase struct {
+	client Client
+	cipher Cipher
+}
+
+func NewOrganizations(client Client, cipher Cipher) *OrganizationsUseCase {
+	return &OrganizationsUseCase{
+		client: client,
+		cipher: cipher,
+	}
+}
+
+func (o *OrganizationsUseCase) CreateOrganization(ctx context.Context, name string) error {
+	_, wrappedKey, err := o.cipher.NewOrganizationKey()
+	if err != nil {
+		return err
+	}
+	orgID, err := o.client.CreateOrganization(ctx, name, wrappedKey)
+	if err != nil {
+		return err
+	}
+	return o.cipher.AddOrganizationKey(orgID, wrappedKey)
+}
+
+// GetOrganizations also opens the organization keys, so that secrets of
+// organizations joined since the vault was unlocked become readable
+func (o *OrganizationsUseCase) GetOrganizations(ctx context.Context) ([]*entities.Organization, error) {
+	orgs, err := o.client.GetOrganizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, org := range orgs {
+		err = o.cipher.AddOrganizationKey(org.ID, org.WrappedKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return orgs, nil
+}
+
+func (o *OrganizationsUseCase) LoadOrganizationKeys(ctx context.Context) error {
+	_, err := o.GetOrganizations(ctx)
+	return err
+}
+
+func (o *OrganizationsUseCase) InviteMember(ctx context.Context, orgID, email string, role uint32) error {
+	_, publicKey, err := o.client.GetPublicKey(ctx, email)
+	if err != nil {
+		return err
+	}
+	wrappedKey, err := o.cipher.WrapOrganizationKeyFor(orgID, publicKey)
+	if errors.Is(err, entities.ErrOrgKeyMissing) {
+		err = o.LoadOrganizationKeys(ctx)
+		if err != nil {
+			return err
+		}
+		wrappedKey, err = o.cipher.WrapOrganizationKeyFor(orgID, publicKey)
+	}
+	if err != nil {
+		return err
+	}
+	return o.client.InviteMember(ctx, orgID, email, role, wrappedKey)
+}
+
+func (o *OrganizationsUseCase) AcceptInvitation(ctx context.Context, code string) error {
+	_, err := o.client.AcceptInvitation(ctx, code)
+	if err != nil {
+		return err
+	}
+	return o.LoadOrganizationKeys(ctx)
+}
+
+func (o *OrganizationsUseCase) GetMembers(ctx context.Context, orgID string) ([]*entities.Member, error) {
+	return o.client.GetMembers(ctx, orgID)
+}
+
+func (o *OrganizationsUseCase) SetMemberRole(ctx context.Context, orgID, userID string, role uint32) error {
+	return o.client.SetMemberRole(ctx, orgID, userID, role)
+}
+
+func (o *OrganizationsUseCase) RemoveMember(ctx context.Context, orgID, userID string) error {
+	return o.client.RemoveMember(ctx, orgID, userID)
+}
+
+func (o *OrganizationsUseCase) CreateCollection(ctx context.Context, orgID, name string) error {
+	_, err := o.client.CreateCollection(ctx, orgID, name)
+	return err
+}
+
+func (o *OrganizationsUseCase) GetCollections(ctx context.Context, orgID string) ([]*entities.Collection, error) {
+	return o.client.GetCollections(ctx, orgID)
+}
+
+func (o *OrganizationsUseCase) DeleteCollection(ctx context.Context, id string) error {
+	return o.client.DeleteCollection(ctx, id)
+}
@@ -0,0 +1,176 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/pkg/passgen"
+)
+
+type Secrets interface {
+	GetSecrets(ctx context.Context) (map[string]*entities.Secret, error)
+	GetSecret(ctx context.Context, id string) (*entities.Secret, error)
+}
+
+type Breaches interface {
+	Check(passwords []string) ([]uint32, error)
+}
+
+type ReportUseCase struct {
+	secrets  Secrets
+	breaches Breaches
+}
+
+func NewReport(secrets Secrets, breaches Breaches) *ReportUseCase {
+	return &ReportUseCase{
+		secrets:  secrets,
+		breaches: breaches,
+	}
+}
+
+func (r *ReportUseCase) PasswordReport(ctx context.Context, maxAge time.Duration) (*entities.PasswordReport, error) {
+	passwords, err := r.passwords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return Analyse(passwords, time.Now(), maxAge), nil
+}
+
+// BreachReport checks the passwords against the local index of breached
+// hashes, the passwords never leave the device
+func (r *ReportUseCase) BreachReport(ctx context.Context) (*entities.PasswordReport, error) {
+	secrets, err := r.passwords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	passwords := make([]string, len(secrets))
+	for k, s := range secrets {
+		passwords[k] = s.Data.(*entities.Password).Password
+	}
+	counts, err := r.breaches.Check(passwords)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, entities.ErrNoBreachIndex
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.PasswordReport{Total: len(secrets)}
+	for k, s := range secrets {
+		if counts[k] == 0 {
+			continue
+		}
+		report.Entries = append(report.Entries, &entities.PasswordReportEntry{
+			Secret:   s,
+			Issues:   entities.IssueBreached,
+			Breaches: counts[k],
+		})
+	}
+	sortEntries(report.Entries)
+	return report, nil
+}
+
+// passwords loads the passwords of the local store, entries without
+// a payload are decrypted on the way
+func (r *ReportUseCase) passwords(ctx context.Context) ([]*entities.Secret, error) {
+	stored, err := r.secrets.GetSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	passwords := make([]*entities.Secret, 0, len(stored))
+	for id, secret := range stored {
+		if secret.SecretType != entities.TypePassword {
+			continue
+		}
+		if secret.Data == nil {
+			secret, err = r.secrets.GetSecret(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if _, ok := secret.Data.(*entities.Password); ok {
+			passwords = append(passwords, secret)
+		}
+	}
+	return passwords, nil
+}
+
+// Analyse returns the entries with at least one issue sorted by name,
+// maxAge of zero disables the check of old passwords. An entry duplicates
+// another one only if both have the same login for the same site, the same
+// password anywhere else is reused. Passwords saved before the time of the
+// change was kept are never reported as old
+func Analyse(secrets []*entities.Secret, now time.Time, maxAge time.Duration) *entities.PasswordReport {
+	report := &entities.PasswordReport{}
+	byPassword := make(map[string][]*entities.Secret)
+	for _, s := range secrets {
+		p, ok := s.Data.(*entities.Password)
+		if !ok {
+			continue
+		}
+		byPassword[p.Password] = append(byPassword[p.Password], s)
+	}
+
+	for _, s := range secrets {
+		p, ok := s.Data.(*entities.Password)
+		if !ok {
+			continue
+		}
+		report.Total++
+		entry := &entities.PasswordReportEntry{Secret: s, Score: passgen.Score(p.Password)}
+		for _, other := range byPassword[p.Password] {
+			if other == s {
+				continue
+			}
+			if isDuplicate(s, other) {
+				entry.Issues |= entities.IssueDuplicate
+			} else {
+				entry.Issues |= entities.IssueReused
+			}
+		}
+		if entry.Score < passgen.ScoreStrong {
+			entry.Issues |= entities.IssueWeak
+		}
+		if maxAge > 0 && !p.ChangedAt.IsZero() && now.Sub(p.ChangedAt) > maxAge {
+			entry.Issues |= entities.IssueOld
+		}
+		if entry.Issues != 0 {
+			report.Entries = append(report.Entries, entry)
+		}
+	}
+	sortEntries(report.Entries)
+	return report
+}
+
+func isDuplicate(a, b *entities.Secret) bool {
+	site := siteOf(a)
+	return site != "" && site == siteOf(b) &&
+		strings.EqualFold(a.Data.(*entities.Password).Login, b.Data.(*entities.Password).Login)
+}
+
+// siteOf returns the host of the first link of the secret
+func siteOf(s *entities.Secret) string {
+	for _, f := range s.Fields {
+		if f.Type != entities.FieldURL {
+			continue
+		}
+		u, err := url.Parse(f.Value)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	}
+	return ""
+}
+
+func sortEntries(entries []*entities.PasswordReportEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Secret.Name < entries[j].Secret.Name
+	})
+}
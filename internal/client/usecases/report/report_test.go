@@ -0,0 +1,146 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyse(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	strong := "Xv7#pQ2!mZ9$kL4w"
+	secret := func(name, site, login, password string, changedAt time.Time) *entities.Secret {
+		s := &entities.Secret{
+			ID:         name,
+			Name:       name,
+			SecretType: entities.TypePassword,
+			Data:       &entities.Password{Login: login, Password: password, ChangedAt: changedAt},
+			UpdatedAt:  now,
+		}
+		if site != "" {
+			s.Fields = []entities.CustomField{{Name: "url", Value: site, Type: entities.FieldURL}}
+		}
+		return s
+	}
+
+	report := Analyse([]*entities.Secret{
+		secret("mail", "https://mail.example.com", "ivan", strong, now),
+		secret("mail copy", "https://www.mail.example.com/login", "Ivan", strong, now),
+		secret("chat", "https://chat.example.com", "ivan", strong, now),
+		secret("shop", "", "ivan@example.com", strong, now),
+		secret("forum", "", "ivan", "qwerty123", now),
+		secret("bank", "", "ivan", "Hq8@tN3%wR6^yB1&", now.AddDate(-2, 0, 0)),
+		secret("game", "", "ivan", "Lm4*zS7(dF2)gH5!", time.Time{}),
+		{ID: "note", Name: "note", SecretType: entities.TypeText, Data: "text"},
+	}, now, 365*24*time.Hour)
+
+	assert.Equal(t, 7, report.Total)
+	got := make(map[string]int, len(report.Entries))
+	names := make([]string, 0, len(report.Entries))
+	for _, e := range report.Entries {
+		got[e.Secret.Name] = e.Issues
+		names = append(names, e.Secret.Name)
+	}
+	assert.Equal(t, []string{"bank", "chat", "forum", "mail", "mail copy", "shop"}, names)
+	assert.Equal(t, entities.IssueReused|entities.IssueDuplicate, got["mail"])
+	assert.Equal(t, entities.IssueReused|entities.IssueDuplicate, got["mail copy"])
+	assert.Equal(t, entities.IssueReused, got["chat"])
+	assert.Equal(t, entities.IssueReused, got["shop"])
+	assert.Equal(t, entities.IssueWeak, got["forum"])
+	assert.Equal(t, entities.IssueOld, got["bank"])
+
+	report = Analyse([]*entities.Secret{secret("bank", "", "ivan", "Hq8@tN3%wR6^yB1&", now.AddDate(-2, 0, 0))}, now, 0)
+	assert.Empty(t, report.Entries)
+
+	// editing the entry does not reset the age of the password
+	edited := secret("bank", "", "ivan", "Hq8@tN3%wR6^yB1&", now.AddDate(-2, 0, 0))
+	edited.UpdatedAt = now
+	report = Analyse([]*entities.Secret{edited}, now, 365*24*time.Hour)
+	assert.Len(t, report.Entries, 1)
+	assert.Equal(t, entities.IssueOld, report.Entries[0].Issues)
+}
+
+func TestPasswordReportEntry_IssueLabels(t *testing.T) {
+	e := &entities.PasswordReportEntry{Issues: entities.IssueWeak | entities.IssueOld}
+	assert.Equal(t, "слабый пароль, давно не менялся", e.IssueLabels())
+}
+
+func TestReportUseCase_BreachReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secrets := mocks.NewMockReportSecrets(ctrl)
+	breaches := mocks.NewMockBreaches(ctrl)
+	r := NewReport(secrets, breaches)
+
+	mail := &entities.Secret{ID: "mail", Name: "Mail", SecretType: entities.TypePassword}
+	opened := &entities.Secret{
+		ID:         "mail",
+		Name:       "Mail",
+		SecretType: entities.TypePassword,
+		Data:       &entities.Password{Login: "ivan", Password: "123456"},
+	}
+	stored := map[string]*entities.Secret{
+		"mail": mail,
+		"note": {ID: "note", Name: "Note", SecretType: entities.TypeText, Data: "text"},
+	}
+
+	tests := []struct {
+		name      string
+		checkErr  error
+		counts    []uint32
+		wantCount int
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "no index",
+			checkErr: fmt.Errorf("open: %w", os.ErrNotExist),
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorIs(t, err, entities.ErrNoBreachIndex, i...)
+			},
+		},
+		{
+			name:     "check error",
+			checkErr: errors.New("check error"),
+			wantErr:  assert.Error,
+		},
+		{
+			name:      "breached",
+			counts:    []uint32{37359195},
+			wantCount: 1,
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "clean",
+			counts:    []uint32{0},
+			wantCount: 0,
+			wantErr:   assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets.EXPECT().GetSecrets(gomock.Any()).Return(stored, nil).Times(1)
+			secrets.EXPECT().GetSecret(gomock.Any(), "mail").Return(opened, nil).Times(1)
+			breaches.EXPECT().Check([]string{"123456"}).Return(tt.counts, tt.checkErr).Times(1)
+
+			got, err := r.BreachReport(context.Background())
+			if !tt.wantErr(t, err, "BreachReport()") || err != nil {
+				return
+			}
+			assert.Equal(t, 1, got.Total)
+			assert.Len(t, got.Entries, tt.wantCount)
+			if tt.wantCount > 0 {
+				assert.Equal(t, uint32(37359195), got.Entries[0].Breaches)
+				assert.True(t, got.Entries[0].Has(entities.IssueBreached))
+			}
+		})
+	}
+}
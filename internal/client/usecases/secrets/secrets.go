@@ -2,15 +2,40 @@ package secrets
 
 import (
 	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/itohin/gophkeeper/internal/client/entities"
+	errors2 "github.com/itohin/gophkeeper/pkg/errors"
 )
 
 type Client interface {
-	SearchSecrets(ctx context.Context) (map[string]*entities.Secret, error)
+	SearchSecrets(ctx context.Context, filter *entities.SecretsFilter, pageToken string, pageSize uint32) (*entities.SecretsPage, error)
 	GetSecret(ctx context.Context, id string) (*entities.Secret, error)
 	CreateSecret(ctx context.Context, s *entities.Secret) error
-	DeleteSecret(ctx context.Context, s *entities.Secret) error
+	CreateSecrets(ctx context.Context, secrets []*entities.Secret) error
+	DeleteSecret(ctx context.Context, id string) error
+	DeleteSecrets(ctx context.Context, ids []string) error
+	UpdateSecret(ctx context.Context, s *entities.Secret) (uint64, error)
+	GetRevisions(ctx context.Context, secretID string) ([]*entities.Revision, error)
+	RestoreRevision(ctx context.Context, secretID, revisionID string) error
+	GetDeletedSecrets(ctx context.Context) ([]*entities.DeletedSecret, error)
+	RestoreSecret(ctx context.Context, id string) error
+	PurgeSecret(ctx context.Context, id string) error
+	UploadBinary(ctx context.Context, s *entities.Secret, r io.Reader) (string, error)
+	DownloadBinary(ctx context.Context, id string, w io.Writer) error
+	SyncSince(ctx context.Context, cursor uint64, pageSize uint32) (*entities.ChangesPage, error)
+	GetPublicKey(ctx context.Context, email string) (string, []byte, error)
+	ShareSecret(ctx context.Context, secretID, email string, permission uint32, wrappedKey []byte) error
+	RevokeShare(ctx context.Context, secretID, userID string) error
+	GetShares(ctx context.Context, secretID string) ([]*entities.Share, error)
+	CreateFolder(ctx context.Context, name, parentID string) (string, error)
+	GetFolders(ctx context.Context) ([]*entities.Folder, error)
+	DeleteFolder(ctx context.Context, id string) error
+	GetTags(ctx context.Context) ([]string, error)
 }
 
 type Storage interface {
@@ -18,52 +43,489 @@ type Storage interface {
 	SaveSecret(ctx context.Context, secret *entities.Secret) error
 	GetSecrets(ctx context.Context) (map[string]*entities.Secret, error)
 	GetSecret(ctx context.Context, id string) (*entities.Secret, error)
+	DeleteSecret(ctx context.Context, id string) error
+}
+
+type SyncState interface {
+	Load(ctx context.Context, userID string) (*entities.SyncState, error)
+	Save(ctx context.Context, userID string, state *entities.SyncState) error
+}
+
+const (
+	listPageSize = 20
+	syncPageSize = 200
+)
+
+type Cipher interface {
+	Seal(secret *entities.Secret) (*entities.Secret, error)
+	Open(secret *entities.Secret) (*entities.Secret, error)
+	SealStream(secret *entities.Secret, r io.Reader) (io.Reader, error)
+	OpenStream(secret *entities.Secret, w io.Writer) (io.WriteCloser, error)
+	NewItemKey() ([]byte, []byte, error)
+	WrapKeyFor(itemKey, publicKey []byte) ([]byte, error)
+	WrapKeyForOrganization(itemKey []byte, orgID string) ([]byte, error)
+}
+
+type OrganizationKeys interface {
+	LoadOrganizationKeys(ctx context.Context) error
 }
 
 type SecretsUseCase struct {
-	client  Client
-	storage Storage
+	client    Client
+	storage   Storage
+	cipher    Cipher
+	orgKeys   OrganizationKeys
+	syncState SyncState
+	syncMx    sync.Mutex
 }
 
-func NewSecrets(client Client, storage Storage) *SecretsUseCase {
+func NewSecrets(client Client, storage Storage, cipher Cipher, orgKeys OrganizationKeys, syncState SyncState) *SecretsUseCase {
 	return &SecretsUseCase{
-		client:  client,
-		storage: storage,
+		client:    client,
+		storage:   storage,
+		cipher:    cipher,
+		orgKeys:   orgKeys,
+		syncState: syncState,
 	}
 }
 
 func (s *SecretsUseCase) CreateSecret(ctx context.Context, secret *entities.Secret) error {
-	err := s.client.CreateSecret(ctx, secret)
+	err := s.newSecret(ctx, secret)
+	if err != nil {
+		return err
+	}
+	sealed, err := s.seal(secret)
+	if err != nil {
+		return err
+	}
+	err = s.client.CreateSecret(ctx, sealed)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// CreateSecrets saves the secrets in one transaction on the server
+func (s *SecretsUseCase) CreateSecrets(ctx context.Context, secrets []*entities.Secret) error {
+	sealed := make([]*entities.Secret, 0, len(secrets))
+	for _, secret := range secrets {
+		err := s.newSecret(ctx, secret)
+		if err != nil {
+			return err
+		}
+		v, err := s.seal(secret)
+		if err != nil {
+			return err
+		}
+		sealed = append(sealed, v)
+	}
+	return s.client.CreateSecrets(ctx, sealed)
+}
+
 func (s *SecretsUseCase) GetSecrets(ctx context.Context) (map[string]*entities.Secret, error) {
 	return s.storage.GetSecrets(ctx)
 }
 
 func (s *SecretsUseCase) GetSecret(ctx context.Context, id string) (*entities.Secret, error) {
-	return s.storage.GetSecret(ctx, id)
+	secret, err := s.storage.GetSecret(ctx, id)
+	if err == nil && secret.Data != nil {
+		return secret, nil
+	}
+	secret, err = s.fetchSecret(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return secret, s.storage.SaveSecret(ctx, secret)
+}
+
+func (s *SecretsUseCase) GetLatestSecret(ctx context.Context, id string) (*entities.Secret, error) {
+	secret, err := s.fetchSecret(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return secret, s.storage.SaveSecret(ctx, secret)
 }
 
 func (s *SecretsUseCase) SaveSecret(ctx context.Context, secret *entities.Secret) error {
 	return s.storage.SaveSecret(ctx, secret)
 }
 
-func (s *SecretsUseCase) SyncSecrets(ctx context.Context) error {
-	secrets, err := s.client.SearchSecrets(ctx)
+func (s *SecretsUseCase) SearchSecrets(ctx context.Context, filter *entities.SecretsFilter, pageToken string) (*entities.SecretsPage, error) {
+	page, err := s.client.SearchSecrets(ctx, filter, pageToken, listPageSize)
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range page.Secrets {
+		err = s.storage.SaveSecret(ctx, s.withCachedData(ctx, secret))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+func (s *SecretsUseCase) SyncSecrets(ctx context.Context, userID string) error {
+	s.syncMx.Lock()
+	defer s.syncMx.Unlock()
+
+	state, err := s.syncState.Load(ctx, userID)
+	if err != nil {
+		return err
+	}
+	err = s.storage.SaveSecrets(ctx, state.Secrets)
+	if err != nil {
+		return err
+	}
+
+	cursor := state.Cursor
+	for {
+		page, err := s.client.SyncSince(ctx, cursor, syncPageSize)
+		if err != nil {
+			return err
+		}
+		if page.Resync {
+			err = s.fullSync(ctx)
+			if err != nil {
+				return err
+			}
+			cursor = page.Cursor
+			break
+		}
+		for _, change := range page.Changes {
+			if change.Deleted {
+				err = s.storage.DeleteSecret(ctx, change.Secret.ID)
+			} else {
+				err = s.storage.SaveSecret(ctx, s.withCachedData(ctx, change.Secret))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		cursor = page.Cursor
+		if !page.HasMore {
+			break
+		}
+	}
+	return s.saveSyncState(ctx, userID, cursor)
+}
+
+func (s *SecretsUseCase) fullSync(ctx context.Context) error {
+	secrets := make(map[string]*entities.Secret)
+	pageToken := ""
+	for {
+		page, err := s.client.SearchSecrets(ctx, &entities.SecretsFilter{}, pageToken, syncPageSize)
+		if err != nil {
+			return err
+		}
+		for _, secret := range page.Secrets {
+			secrets[secret.ID] = s.withCachedData(ctx, secret)
+		}
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+	stale, err := s.storage.GetSecrets(ctx)
+	if err != nil {
+		return err
+	}
+	for id := range stale {
+		if _, ok := secrets[id]; ok {
+			continue
+		}
+		err = s.storage.DeleteSecret(ctx, id)
+		if err != nil {
+			return err
+		}
+	}
+	return s.storage.SaveSecrets(ctx, secrets)
+}
+
+func (s *SecretsUseCase) saveSyncState(ctx context.Context, userID string, cursor uint64) error {
+	secrets, err := s.storage.GetSecrets(ctx)
 	if err != nil {
 		return err
 	}
-	return s.storage.SaveSecrets(context.Background(), secrets)
+	state := &entities.SyncState{
+		Cursor:  cursor,
+		Secrets: make(map[string]*entities.Secret, len(secrets)),
+	}
+	// only metadata is kept on disk, payloads stay in memory
+	for id, secret := range secrets {
+		meta := *secret
+		meta.Data = nil
+		meta.Notes = ""
+		meta.Fields = nil
+		meta.ItemKey = nil
+		state.Secrets[id] = &meta
+	}
+	return s.syncState.Save(ctx, userID, state)
 }
 
 func (s *SecretsUseCase) DeleteSecret(ctx context.Context, id string) error {
-	secret, err := s.storage.GetSecret(ctx, id)
+	return s.client.DeleteSecret(ctx, id)
+}
+
+// DeleteSecrets moves the secrets to the trash in one transaction on the server
+func (s *SecretsUseCase) DeleteSecrets(ctx context.Context, ids []string) error {
+	return s.client.DeleteSecrets(ctx, ids)
+}
+
+func (s *SecretsUseCase) UpdateSecret(ctx context.Context, secret *entities.Secret) error {
+	sealed, err := s.seal(secret)
+	if err != nil {
+		return err
+	}
+	version, err := s.client.UpdateSecret(ctx, sealed)
+	if err != nil {
+		return err
+	}
+	secret.Version = version
+	return s.storage.SaveSecret(ctx, secret)
+}
+
+func (s *SecretsUseCase) GetRevisions(ctx context.Context, secretID string) ([]*entities.Revision, error) {
+	revisions, err := s.client.GetRevisions(ctx, secretID)
+	if err != nil {
+		return nil, err
+	}
+	for _, revision := range revisions {
+		revision.Secret, err = s.open(ctx, revision.Secret)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return revisions, nil
+}
+
+func (s *SecretsUseCase) RestoreRevision(ctx context.Context, secretID, revisionID string) error {
+	err := s.client.RestoreRevision(ctx, secretID, revisionID)
+	if err != nil {
+		return err
+	}
+	secret, err := s.fetchSecret(ctx, secretID)
+	if err != nil {
+		return err
+	}
+	return s.storage.SaveSecret(ctx, secret)
+}
+
+func (s *SecretsUseCase) GetDeletedSecrets(ctx context.Context) ([]*entities.DeletedSecret, error) {
+	deleted, err := s.client.GetDeletedSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deleted {
+		d.Secret, err = s.open(ctx, d.Secret)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return deleted, nil
+}
+
+func (s *SecretsUseCase) RestoreSecret(ctx context.Context, id string) error {
+	err := s.client.RestoreSecret(ctx, id)
+	if err != nil {
+		return err
+	}
+	secret, err := s.fetchSecret(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.storage.SaveSecret(ctx, secret)
+}
+
+func (s *SecretsUseCase) PurgeSecret(ctx context.Context, id string) error {
+	return s.client.PurgeSecret(ctx, id)
+}
+
+func (s *SecretsUseCase) UploadBinary(ctx context.Context, secret *entities.Secret, r io.Reader) error {
+	if secret.ID == "" {
+		err := s.newSecret(ctx, secret)
+		if err != nil {
+			return err
+		}
+	}
+	meta := *secret
+	meta.Data = nil
+	sealed, err := s.seal(&meta)
+	if err != nil {
+		return err
+	}
+	content, err := s.cipher.SealStream(secret, r)
+	if err != nil {
+		return err
+	}
+	id, err := s.client.UploadBinary(ctx, sealed, content)
+	if err != nil {
+		return err
+	}
+	secret.ID = id
+	fetched, err := s.fetchSecret(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.storage.SaveSecret(ctx, fetched)
+}
+
+func (s *SecretsUseCase) DownloadBinary(ctx context.Context, secret *entities.Secret, w io.Writer) error {
+	content, err := s.cipher.OpenStream(secret, w)
+	if err != nil {
+		return err
+	}
+	err = s.client.DownloadBinary(ctx, secret.ID, content)
+	if err != nil {
+		return err
+	}
+	return content.Close()
+}
+
+func (s *SecretsUseCase) ShareSecret(ctx context.Context, secretID, email string, permission uint32) error {
+	_, publicKey, err := s.client.GetPublicKey(ctx, email)
+	if err != nil {
+		return err
+	}
+	secret, err := s.fetchSecret(ctx, secretID)
+	if err != nil {
+		return err
+	}
+	if len(secret.ItemKey) == 0 {
+		secret, err = s.rekeySecret(ctx, secret)
+		if err != nil {
+			return err
+		}
+	}
+	wrappedKey, err := s.cipher.WrapKeyFor(secret.ItemKey, publicKey)
+	if err != nil {
+		return err
+	}
+	return s.client.ShareSecret(ctx, secretID, email, permission, wrappedKey)
+}
+
+// RevokeShare stops syncing the secret to the user, the item key is not rotated
+// so a copy the user already decrypted stays readable to them
+func (s *SecretsUseCase) RevokeShare(ctx context.Context, secretID, userID string) error {
+	return s.client.RevokeShare(ctx, secretID, userID)
+}
+
+func (s *SecretsUseCase) GetShares(ctx context.Context, secretID string) ([]*entities.Share, error) {
+	return s.client.GetShares(ctx, secretID)
+}
+
+func (s *SecretsUseCase) CreateFolder(ctx context.Context, name, parentID string) error {
+	_, err := s.client.CreateFolder(ctx, name, parentID)
+	return err
+}
+
+func (s *SecretsUseCase) GetFolders(ctx context.Context) ([]*entities.Folder, error) {
+	return s.client.GetFolders(ctx)
+}
+
+func (s *SecretsUseCase) DeleteFolder(ctx context.Context, id string) error {
+	return s.client.DeleteFolder(ctx, id)
+}
+
+func (s *SecretsUseCase) GetTags(ctx context.Context) ([]string, error) {
+	return s.client.GetTags(ctx)
+}
+
+// rekeySecret re-encrypts a secret sealed with the master key under its own
+// item key, so that the key can be handed over to other users
+func (s *SecretsUseCase) rekeySecret(ctx context.Context, secret *entities.Secret) (*entities.Secret, error) {
+	rekeyed := *secret
+	err := s.newItemKey(ctx, &rekeyed)
+	if err != nil {
+		return nil, err
+	}
+	if secret.SecretType != entities.TypeBinary {
+		return &rekeyed, s.UpdateSecret(ctx, &rekeyed)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(s.DownloadBinary(ctx, secret, pw))
+	}()
+	err = s.UploadBinary(ctx, &rekeyed, pr)
+	pr.CloseWithError(err)
+	return &rekeyed, err
+}
+
+// newSecret chooses the id of a new secret on the client, as the encrypted
+// data is bound to it
+func (s *SecretsUseCase) newSecret(ctx context.Context, secret *entities.Secret) error {
+	secret.ID = uuid.NewString()
+	return s.newItemKey(ctx, secret)
+}
+
+// newItemKey seals the item key of a secret in a collection with the key of
+// its organization, so that every member can open it
+func (s *SecretsUseCase) newItemKey(ctx context.Context, secret *entities.Secret) error {
+	itemKey, wrappedKey, err := s.cipher.NewItemKey()
 	if err != nil {
 		return err
 	}
-	return s.client.DeleteSecret(ctx, secret)
+	if secret.CollectionID != "" {
+		wrappedKey, err = s.cipher.WrapKeyForOrganization(itemKey, secret.OrganizationID)
+		if errors.Is(err, entities.ErrOrgKeyMissing) {
+			err = s.orgKeys.LoadOrganizationKeys(ctx)
+			if err != nil {
+				return err
+			}
+			wrappedKey, err = s.cipher.WrapKeyForOrganization(itemKey, secret.OrganizationID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	secret.ItemKey = itemKey
+	secret.WrappedKey = wrappedKey
+	return nil
+}
+
+// seal validates the custom fields, they are encrypted with the data and the
+// server can not check them. A password without the time of its change is new
+// or was just changed
+func (s *SecretsUseCase) seal(secret *entities.Secret) (*entities.Secret, error) {
+	err := entities.ValidateFields(secret.Fields)
+	if err != nil {
+		return nil, errors2.NewDomainError(err)
+	}
+	if p, ok := secret.Data.(*entities.Password); ok && p.ChangedAt.IsZero() {
+		p.ChangedAt = time.Now()
+	}
+	return s.cipher.Seal(secret)
+}
+
+func (s *SecretsUseCase) fetchSecret(ctx context.Context, id string) (*entities.Secret, error) {
+	secret, err := s.client.GetSecret(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(ctx, secret)
+}
+
+// open loads the organization keys once if the secret belongs to an
+// organization the user joined after unlocking the vault
+func (s *SecretsUseCase) open(ctx context.Context, secret *entities.Secret) (*entities.Secret, error) {
+	opened, err := s.cipher.Open(secret)
+	if !errors.Is(err, entities.ErrOrgKeyMissing) {
+		return opened, err
+	}
+	err = s.orgKeys.LoadOrganizationKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.cipher.Open(secret)
+}
+
+func (s *SecretsUseCase) withCachedData(ctx context.Context, secret *entities.Secret) *entities.Secret {
+	cached, err := s.storage.GetSecret(ctx, secret.ID)
+	if err != nil || cached.Data == nil || !cached.UpdatedAt.Equal(secret.UpdatedAt) {
+		return secret
+	}
+	secret.Data = cached.Data
+	secret.Fields = cached.Fields
+	secret.ItemKey = cached.ItemKey
+	return secret
 }
@@ -0,0 +1,319 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const userID = "1955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+
+func TestSecretsUseCase_SyncSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mocks.NewMockSecretsClient(ctrl)
+	storage := mocks.NewMockSecretsCache(ctrl)
+	syncState := mocks.NewMockSyncState(ctrl)
+	s := &SecretsUseCase{client: client, storage: storage, syncState: syncState}
+
+	stored := map[string]*entities.Secret{
+		"first":  {ID: "first", Name: "First"},
+		"second": {ID: "second", Name: "Second"},
+	}
+	changed := &entities.Secret{ID: "third", Name: "Third"}
+	last := &entities.Secret{ID: "fourth", Name: "Fourth"}
+	synced := map[string]*entities.Secret{
+		"first":  {ID: "first", Name: "First", Data: "Lorem ipsum...", Notes: "notes", ItemKey: []byte("key")},
+		"third":  changed,
+		"fourth": last,
+	}
+
+	gomock.InOrder(
+		syncState.EXPECT().Load(gomock.Any(), userID).Return(&entities.SyncState{Cursor: 5, Secrets: stored}, nil),
+		storage.EXPECT().SaveSecrets(gomock.Any(), stored).Return(nil),
+		client.EXPECT().SyncSince(gomock.Any(), uint64(5), uint32(syncPageSize)).Return(&entities.ChangesPage{
+			Changes: []*entities.SecretChange{
+				{Secret: changed},
+				{Secret: &entities.Secret{ID: "second"}, Deleted: true},
+			},
+			Cursor:  7,
+			HasMore: true,
+		}, nil),
+		client.EXPECT().SyncSince(gomock.Any(), uint64(7), uint32(syncPageSize)).Return(&entities.ChangesPage{
+			Changes: []*entities.SecretChange{{Secret: last}},
+			Cursor:  9,
+		}, nil),
+		storage.EXPECT().GetSecrets(gomock.Any()).Return(synced, nil),
+		syncState.EXPECT().Save(gomock.Any(), userID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, state *entities.SyncState) error {
+				assert.Equal(t, uint64(9), state.Cursor)
+				assert.Len(t, state.Secrets, 3)
+				assert.Equal(t, &entities.Secret{ID: "first", Name: "First"}, state.Secrets["first"], "only metadata is kept on disk")
+				return nil
+			},
+		),
+	)
+	storage.EXPECT().GetSecret(gomock.Any(), gomock.Any()).Return(nil, errors.New("not found")).Times(2)
+	storage.EXPECT().SaveSecret(gomock.Any(), changed).Return(nil).Times(1)
+	storage.EXPECT().DeleteSecret(gomock.Any(), "second").Return(nil).Times(1)
+	storage.EXPECT().SaveSecret(gomock.Any(), last).Return(nil).Times(1)
+
+	assert.NoError(t, s.SyncSecrets(context.Background(), userID))
+	// the payloads are stripped only from the saved copy
+	assert.Equal(t, "Lorem ipsum...", synced["first"].Data)
+}
+
+func TestSecretsUseCase_SyncSecretsResync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mocks.NewMockSecretsClient(ctrl)
+	storage := mocks.NewMockSecretsCache(ctrl)
+	syncState := mocks.NewMockSyncState(ctrl)
+	s := &SecretsUseCase{client: client, storage: storage, syncState: syncState}
+
+	stored := map[string]*entities.Secret{
+		"kept":    {ID: "kept"},
+		"removed": {ID: "removed"},
+	}
+	kept := &entities.Secret{ID: "kept", Name: "Kept"}
+	added := &entities.Secret{ID: "added", Name: "Added"}
+	all := map[string]*entities.Secret{"kept": kept, "added": added}
+
+	gomock.InOrder(
+		syncState.EXPECT().Load(gomock.Any(), userID).Return(&entities.SyncState{Cursor: 3, Secrets: stored}, nil),
+		storage.EXPECT().SaveSecrets(gomock.Any(), stored).Return(nil),
+		// the server purged the changes after the cursor
+		client.EXPECT().SyncSince(gomock.Any(), uint64(3), uint32(syncPageSize)).Return(&entities.ChangesPage{Cursor: 12, Resync: true}, nil),
+		client.EXPECT().SearchSecrets(gomock.Any(), &entities.SecretsFilter{}, "", uint32(syncPageSize)).Return(&entities.SecretsPage{
+			Secrets:       []*entities.Secret{kept},
+			NextPageToken: "next",
+		}, nil),
+		client.EXPECT().SearchSecrets(gomock.Any(), &entities.SecretsFilter{}, "next", uint32(syncPageSize)).Return(&entities.SecretsPage{
+			Secrets: []*entities.Secret{added},
+		}, nil),
+		storage.EXPECT().GetSecrets(gomock.Any()).Return(stored, nil),
+		storage.EXPECT().DeleteSecret(gomock.Any(), "removed").Return(nil),
+		storage.EXPECT().SaveSecrets(gomock.Any(), all).Return(nil),
+		storage.EXPECT().GetSecrets(gomock.Any()).Return(all, nil),
+		syncState.EXPECT().Save(gomock.Any(), userID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, state *entities.SyncState) error {
+				assert.Equal(t, uint64(12), state.Cursor)
+				assert.Len(t, state.Secrets, 2)
+				return nil
+			},
+		),
+	)
+	storage.EXPECT().GetSecret(gomock.Any(), gomock.Any()).Return(nil, errors.New("not found")).Times(2)
+
+	assert.NoError(t, s.SyncSecrets(context.Background(), userID))
+}
+
+func TestSecretsUseCase_SyncSecretsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mocks.NewMockSecretsClient(ctrl)
+	storage := mocks.NewMockSecretsCache(ctrl)
+	syncState := mocks.NewMockSyncState(ctrl)
+	s := &SecretsUseCase{client: client, storage: storage, syncState: syncState}
+
+	changed := &entities.Secret{ID: "changed"}
+	syncState.EXPECT().Load(gomock.Any(), userID).Return(&entities.SyncState{Cursor: 5}, nil).Times(1)
+	storage.EXPECT().SaveSecrets(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	gomock.InOrder(
+		client.EXPECT().SyncSince(gomock.Any(), uint64(5), uint32(syncPageSize)).Return(&entities.ChangesPage{
+			Changes: []*entities.SecretChange{{Secret: changed}},
+			Cursor:  6,
+			HasMore: true,
+		}, nil),
+		client.EXPECT().SyncSince(gomock.Any(), uint64(6), uint32(syncPageSize)).Return(nil, errors.New("connection error")),
+	)
+	storage.EXPECT().GetSecret(gomock.Any(), "changed").Return(nil, errors.New("not found")).Times(1)
+	storage.EXPECT().SaveSecret(gomock.Any(), changed).Return(nil).Times(1)
+	// the cursor is not saved, the next sync starts from the saved one again
+	syncState.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	assert.Error(t, s.SyncSecrets(context.Background(), userID))
+}
+
+func TestSecretsUseCase_fullSync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mocks.NewMockSecretsClient(ctrl)
+	storage := mocks.NewMockSecretsCache(ctrl)
+	s := &SecretsUseCase{client: client, storage: storage}
+
+	updatedAt := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+	cached := &entities.Secret{ID: "same", Data: "Lorem ipsum...", ItemKey: []byte("key"), UpdatedAt: updatedAt}
+	same := &entities.Secret{ID: "same", UpdatedAt: updatedAt}
+
+	client.EXPECT().SearchSecrets(gomock.Any(), gomock.Any(), "", uint32(syncPageSize)).Return(&entities.SecretsPage{
+		Secrets: []*entities.Secret{same},
+	}, nil).Times(1)
+	storage.EXPECT().GetSecret(gomock.Any(), "same").Return(cached, nil).Times(1)
+	storage.EXPECT().GetSecrets(gomock.Any()).Return(map[string]*entities.Secret{"same": cached}, nil).Times(1)
+	storage.EXPECT().SaveSecrets(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, secrets map[string]*entities.Secret) error {
+			assert.Equal(t, "Lorem ipsum...", secrets["same"].Data, "unchanged data is not fetched again")
+			assert.Equal(t, []byte("key"), secrets["same"].ItemKey)
+			return nil
+		},
+	).Times(1)
+
+	assert.NoError(t, s.fullSync(context.Background()))
+}
+
+func TestSecretsUseCase_GetSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mocks.NewMockSecretsClient(ctrl)
+	storage := mocks.NewMockSecretsCache(ctrl)
+	cipher := mocks.NewMockCipher(ctrl)
+	s := &SecretsUseCase{client: client, storage: storage, cipher: cipher}
+
+	sealed := &entities.Secret{ID: "text", SecretType: entities.TypeText, Data: entities.Sealed("sealed")}
+	opened := &entities.Secret{ID: "text", SecretType: entities.TypeText, Data: "Lorem ipsum..."}
+
+	tests := []struct {
+		name      string
+		cached    *entities.Secret
+		mockTimes map[string]int
+		errors    map[string]error
+		want      *entities.Secret
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "cached data",
+			cached: opened,
+			mockTimes: map[string]int{
+				"fetch": 0,
+				"save":  0,
+			},
+			errors:  map[string]error{},
+			want:    opened,
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "cached metadata",
+			cached: &entities.Secret{ID: "text", SecretType: entities.TypeText},
+			mockTimes: map[string]int{
+				"fetch": 1,
+				"save":  1,
+			},
+			errors:  map[string]error{},
+			want:    opened,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "not cached",
+			mockTimes: map[string]int{
+				"fetch": 1,
+				"save":  1,
+			},
+			errors: map[string]error{
+				"cache": errors.New("not found"),
+			},
+			want:    opened,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "fetch error",
+			mockTimes: map[string]int{
+				"fetch": 1,
+				"save":  0,
+			},
+			errors: map[string]error{
+				"cache": errors.New("not found"),
+				"fetch": errors.New("connection error"),
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage.EXPECT().GetSecret(gomock.Any(), "text").Return(tt.cached, tt.errors["cache"]).Times(1)
+			client.EXPECT().GetSecret(gomock.Any(), "text").Return(sealed, tt.errors["fetch"]).Times(tt.mockTimes["fetch"])
+			cipher.EXPECT().Open(sealed).Return(opened, nil).Times(tt.mockTimes["save"])
+			storage.EXPECT().SaveSecret(gomock.Any(), opened).Return(nil).Times(tt.mockTimes["save"])
+
+			secret, err := s.GetSecret(context.Background(), "text")
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, secret)
+		})
+	}
+}
+
+func TestSecretsUseCase_withCachedData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := mocks.NewMockSecretsCache(ctrl)
+	s := &SecretsUseCase{storage: storage}
+
+	updatedAt := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+	cached := &entities.Secret{
+		ID:        "text",
+		Data:      "Lorem ipsum...",
+		Fields:    []entities.CustomField{{Name: "Ответ", Value: "Шарик", Type: entities.FieldHidden}},
+		ItemKey:   []byte("key"),
+		UpdatedAt: updatedAt,
+	}
+
+	tests := []struct {
+		name      string
+		cached    *entities.Secret
+		cacheErr  error
+		updatedAt time.Time
+		wantData  interface{}
+	}{
+		{
+			name:      "unchanged secret",
+			cached:    cached,
+			updatedAt: updatedAt,
+			wantData:  "Lorem ipsum...",
+		},
+		{
+			name:      "changed secret",
+			cached:    cached,
+			updatedAt: updatedAt.Add(time.Second),
+			wantData:  nil,
+		},
+		{
+			name:      "cached metadata",
+			cached:    &entities.Secret{ID: "text", UpdatedAt: updatedAt},
+			updatedAt: updatedAt,
+			wantData:  nil,
+		},
+		{
+			name:      "not cached",
+			cacheErr:  errors.New("not found"),
+			updatedAt: updatedAt,
+			wantData:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage.EXPECT().GetSecret(gomock.Any(), "text").Return(tt.cached, tt.cacheErr).Times(1)
+
+			secret := s.withCachedData(context.Background(), &entities.Secret{ID: "text", UpdatedAt: tt.updatedAt})
+			assert.Equal(t, tt.wantData, secret.Data)
+			if tt.wantData == nil {
+				assert.Nil(t, secret.Fields)
+				assert.Nil(t, secret.ItemKey)
+				return
+			}
+			assert.Equal(t, cached.Fields, secret.Fields)
+			assert.Equal(t, cached.ItemKey, secret.ItemKey)
+		})
+	}
+}
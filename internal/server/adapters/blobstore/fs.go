@@ -0,0 +1,282 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/itohin/gophkeeper/internal/server/entities"
+	"github.com/itohin/gophkeeper/pkg/envelope"
+)
+
+const (
+	chunkSize = 1024 * 1024
+	// blobs written recently may not be referenced by a secret yet
+	gcGracePeriod = time.Hour
+)
+
+type DataKeys interface {
+	DataKey(ctx context.Context, userID string) ([]byte, error)
+}
+
+type manifest struct {
+	Size   int64
+	Chunks []string
+}
+
+type FSBlobStore struct {
+	root string
+	keys DataKeys
+}
+
+func NewFSBlobStore(root string, keys DataKeys) (*FSBlobStore, error) {
+	err := os.MkdirAll(root, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob store directory: %v", err)
+	}
+	return &FSBlobStore{root: root, keys: keys}, nil
+}
+
+// Put stores the content as chunks addressed by their hash. The content is
+// the ciphertext of the client, sealed with a random nonce prefix, so chunks
+// are shared only when the same ciphertext is sent again, e.g. by a retried
+// upload. The same file uploaded twice is stored twice.
+func (s *FSBlobStore) Put(ctx context.Context, userID string, r io.Reader) (entities.Blob, error) {
+	key, err := s.keys.DataKey(ctx, userID)
+	if err != nil {
+		return entities.Blob{}, fmt.Errorf("failed to get data key: %v", err)
+	}
+	var m manifest
+	total := sha256.New()
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			total.Write(buf[:n])
+			sum := sha256.Sum256(buf[:n])
+			hash := hex.EncodeToString(sum[:])
+			if err := s.writeChunk(userID, hash, key, buf[:n]); err != nil {
+				return entities.Blob{}, err
+			}
+			m.Chunks = append(m.Chunks, hash)
+			m.Size += int64(n)
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return entities.Blob{}, err
+		}
+	}
+
+	blob := entities.Blob{Ref: hex.EncodeToString(total.Sum(nil)), Size: m.Size}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return entities.Blob{}, fmt.Errorf("failed to marshal blob manifest: %v", err)
+	}
+	err = writeFile(s.manifestPath(userID, blob.Ref), data)
+	if err != nil {
+		return entities.Blob{}, fmt.Errorf("failed to write blob manifest: %v", err)
+	}
+	return blob, nil
+}
+
+func (s *FSBlobStore) Open(ctx context.Context, userID, ref string) (io.ReadCloser, error) {
+	m, err := s.readManifest(userID, ref)
+	if err != nil {
+		return nil, err
+	}
+	key, err := s.keys.DataKey(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data key: %v", err)
+	}
+	return &blobReader{store: s, userID: userID, key: key, chunks: m.Chunks}, nil
+}
+
+func (s *FSBlobStore) Collect(ctx context.Context, userID string, live []string) error {
+	cutoff := time.Now().Add(-gcGracePeriod)
+	keep := make(map[string]struct{}, len(live))
+	for _, ref := range live {
+		keep[ref] = struct{}{}
+	}
+
+	chunks := make(map[string]struct{})
+	err := walkFiles(filepath.Join(s.root, filepath.Base(userID), "blobs"), cutoff, func(path string, info fs.FileInfo) error {
+		ref := filepath.Base(path)
+		if _, ok := keep[ref]; !ok && info.ModTime().Before(cutoff) {
+			return os.Remove(path)
+		}
+		m, err := s.readManifest(userID, ref)
+		if err != nil {
+			return err
+		}
+		for _, hash := range m.Chunks {
+			chunks[hash] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to collect blob manifests: %v", err)
+	}
+
+	err = walkFiles(filepath.Join(s.root, filepath.Base(userID), "chunks"), cutoff, func(path string, info fs.FileInfo) error {
+		if _, ok := chunks[filepath.Base(path)]; !ok && info.ModTime().Before(cutoff) {
+			return os.Remove(path)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to collect blob chunks: %v", err)
+	}
+	return nil
+}
+
+func (s *FSBlobStore) writeChunk(userID, hash string, key, data []byte) error {
+	path := s.chunkPath(userID, hash)
+	now := time.Now()
+	err := os.Chtimes(path, now, now)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to touch blob chunk: %v", err)
+	}
+	sealed, err := envelope.Seal(key, data, chunkAD(userID, hash))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt blob chunk: %v", err)
+	}
+	err = writeFile(path, sealed)
+	if err != nil {
+		return fmt.Errorf("failed to write blob chunk: %v", err)
+	}
+	return nil
+}
+
+func (s *FSBlobStore) readChunk(userID, hash string, key []byte) ([]byte, error) {
+	sealed, err := os.ReadFile(s.chunkPath(userID, hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob chunk: %v", err)
+	}
+	data, err := envelope.Open(key, sealed, chunkAD(userID, hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt blob chunk: %v", err)
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != hash {
+		return nil, fmt.Errorf("blob chunk %s is corrupted", hash)
+	}
+	return data, nil
+}
+
+func (s *FSBlobStore) readManifest(userID, ref string) (manifest, error) {
+	var m manifest
+	data, err := os.ReadFile(s.manifestPath(userID, ref))
+	if err != nil {
+		return m, fmt.Errorf("failed to read blob manifest: %v", err)
+	}
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return m, fmt.Errorf("failed to unmarshal blob manifest: %v", err)
+	}
+	return m, nil
+}
+
+func (s *FSBlobStore) manifestPath(userID, ref string) string {
+	return filepath.Join(s.root, filepath.Base(userID), "blobs", filepath.Base(ref))
+}
+
+func (s *FSBlobStore) chunkPath(userID, hash string) string {
+	return filepath.Join(s.root, filepath.Base(userID), "chunks", hash[:2], hash)
+}
+
+type blobReader struct {
+	store  *FSBlobStore
+	userID string
+	key    []byte
+	chunks []string
+	buf    []byte
+}
+
+func (r *blobReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if len(r.chunks) == 0 {
+			return 0, io.EOF
+		}
+		data, err := r.store.readChunk(r.userID, r.chunks[0], r.key)
+		if err != nil {
+			return 0, err
+		}
+		r.buf = data
+		r.chunks = r.chunks[1:]
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *blobReader) Close() error {
+	r.buf = nil
+	r.chunks = nil
+	return nil
+}
+
+func chunkAD(userID, hash string) []byte {
+	return []byte(userID + "/chunk/" + hash)
+}
+
+func writeFile(path string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func walkFiles(root string, cutoff time.Time, f func(path string, info fs.FileInfo) error) error {
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".tmp-") {
+			if info.ModTime().Before(cutoff) {
+				return os.Remove(path)
+			}
+			return nil
+		}
+		return f(path, info)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,138 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/itohin/gophkeeper/internal/client/adapters/crypto"
+	"github.com/itohin/gophkeeper/internal/client/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticKeys struct {
+	key []byte
+}
+
+func (k *staticKeys) DataKey(ctx context.Context, userID string) ([]byte, error) {
+	return k.key, nil
+}
+
+func newTestStore(t *testing.T) *FSBlobStore {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	s, err := NewFSBlobStore(t.TempDir(), &staticKeys{key: key})
+	require.NoError(t, err)
+	return s
+}
+
+func TestFSBlobStore_PutOpen(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	content := make([]byte, 2*chunkSize+100)
+	_, _ = rand.Read(content)
+
+	blob, err := s.Put(ctx, "user", bytes.NewReader(content))
+	require.NoError(t, err)
+	sum := sha256.Sum256(content)
+	assert.Equal(t, hex.EncodeToString(sum[:]), blob.Ref)
+	assert.Equal(t, int64(len(content)), blob.Size)
+
+	r, err := s.Open(ctx, "user", blob.Ref)
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+
+	_, err = s.Open(ctx, "other", blob.Ref)
+	assert.Error(t, err)
+}
+
+func TestFSBlobStore_PutClientStream(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	c := crypto.NewCipher()
+	_, err := c.NewKeyParams("Master@pass1")
+	require.NoError(t, err)
+	secret := &entities.Secret{ID: "id", SecretType: entities.TypeBinary}
+	content := make([]byte, 2*chunkSize+100)
+	_, _ = rand.Read(content)
+
+	sealed := func() []byte {
+		r, err := c.SealStream(secret, bytes.NewReader(content))
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return data
+	}
+	countChunks := func() int {
+		chunks, _ := filepath.Glob(filepath.Join(s.root, "user", "chunks", "*", "*"))
+		return len(chunks)
+	}
+
+	first := sealed()
+	blob, err := s.Put(ctx, "user", bytes.NewReader(first))
+	require.NoError(t, err)
+	chunks := countChunks()
+
+	retried, err := s.Put(ctx, "user", bytes.NewReader(first))
+	require.NoError(t, err)
+	assert.Equal(t, blob, retried)
+	assert.Equal(t, chunks, countChunks(), "a retried upload reuses the chunks")
+
+	reencrypted, err := s.Put(ctx, "user", bytes.NewReader(sealed()))
+	require.NoError(t, err)
+	assert.NotEqual(t, blob.Ref, reencrypted.Ref)
+	assert.Equal(t, 2*chunks, countChunks(), "the same file sealed again shares no chunks")
+
+	r, err := s.Open(ctx, "user", blob.Ref)
+	require.NoError(t, err)
+	plain := &bytes.Buffer{}
+	w, err := c.OpenStream(secret, plain)
+	require.NoError(t, err)
+	_, err = io.Copy(w, r)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Equal(t, content, plain.Bytes())
+}
+
+func TestFSBlobStore_Collect(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	kept, err := s.Put(ctx, "user", bytes.NewReader([]byte("kept")))
+	require.NoError(t, err)
+	purged, err := s.Put(ctx, "user", bytes.NewReader([]byte("purged")))
+	require.NoError(t, err)
+
+	require.NoError(t, s.Collect(ctx, "user", []string{kept.Ref}))
+	_, err = s.Open(ctx, "user", purged.Ref)
+	assert.NoError(t, err, "recent blobs survive the grace period")
+
+	old := time.Now().Add(-2 * gcGracePeriod)
+	err = filepath.Walk(filepath.Join(s.root, "user"), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		return os.Chtimes(path, old, old)
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Collect(ctx, "user", []string{kept.Ref}))
+	_, err = s.Open(ctx, "user", purged.Ref)
+	assert.Error(t, err)
+	r, err := s.Open(ctx, "user", kept.Ref)
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("kept"), got)
+	chunks, _ := filepath.Glob(filepath.Join(s.root, "user", "chunks", "*", "*"))
+	assert.Len(t, chunks, 1)
+}
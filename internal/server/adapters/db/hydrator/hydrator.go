@@ -7,6 +7,7 @@ import (
 	"github.com/itohin/gophkeeper/internal/server/entities"
 	"github.com/itohin/gophkeeper/pkg/events"
 	pb "github.com/itohin/gophkeeper/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type SecretsHydrator struct {
@@ -18,11 +19,16 @@ func NewSecretsHydrator() *SecretsHydrator {
 
 func (h *SecretsHydrator) FromProto(in *pb.Secret, userID string) (*entities.Secret, error) {
 	secret := &entities.Secret{
-		ID:         in.Id,
-		Name:       in.Name,
-		Notes:      in.Notes,
-		SecretType: in.SecretType,
-		UserID:     userID,
+		ID:           in.Id,
+		Name:         in.Name,
+		Notes:        in.Notes,
+		SecretType:   in.SecretType,
+		UserID:       userID,
+		Version:      int64(in.Version),
+		OwnerKey:     in.WrappedKey,
+		CollectionID: in.CollectionId,
+		FolderID:     &in.FolderId,
+		Tags:         append(make([]string, 0, len(in.Tags)), in.Tags...),
 	}
 	data, err := getProtoSecretData(in)
 	if err != nil {
@@ -38,10 +44,33 @@ func (h *SecretsHydrator) ToProto(in *events.SecretDTO) (*pb.Secret, error) {
 	var b entities.Binary
 	var c entities.Card
 	secret := pb.Secret{
-		Id:         in.ID,
-		Name:       in.Name,
-		SecretType: in.SecretType,
-		Notes:      in.Notes,
+		Id:             in.ID,
+		Name:           in.Name,
+		SecretType:     in.SecretType,
+		Notes:          in.Notes,
+		Size:           uint64(in.Size),
+		Version:        uint64(in.Version),
+		WrappedKey:     in.WrappedKey,
+		Owner:          in.Owner,
+		Permission:     pb.Permission(in.Permission),
+		CollectionId:   in.CollectionID,
+		OrganizationId: in.OrganizationID,
+		FolderId:       in.FolderID,
+		Tags:           in.Tags,
+	}
+	if !in.UpdatedAt.IsZero() {
+		secret.UpdatedAt = timestamppb.New(in.UpdatedAt)
+	}
+	if in.SecretType < entities.TypeText || in.SecretType > entities.TypeSSHKey {
+		return nil, fmt.Errorf("unknown secret type")
+	}
+	if in.Data == nil {
+		return &secret, nil
+	}
+	var sealed entities.Sealed
+	if err := json.Unmarshal(in.Data, &sealed); err == nil && sealed.Sealed != nil {
+		secret.Data = &pb.Secret_Sealed{Sealed: sealed.Sealed}
+		return &secret, nil
 	}
 	switch in.SecretType {
 	case entities.TypeText:
@@ -84,6 +113,44 @@ func (h *SecretsHydrator) ToProto(in *events.SecretDTO) (*pb.Secret, error) {
 	return &secret, nil
 }
 
+func (h *SecretsHydrator) RevisionToProto(in *entities.SecretRevision) (*pb.Revision, error) {
+	secret, err := h.ToProto(&events.SecretDTO{
+		ID:         in.SecretID,
+		Name:       in.Name,
+		SecretType: in.SecretType,
+		Notes:      in.Notes,
+		Data:       in.Data,
+		UserID:     in.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Revision{
+		Id:        in.ID,
+		Secret:    secret,
+		CreatedAt: timestamppb.New(in.CreatedAt),
+	}, nil
+}
+
+func (h *SecretsHydrator) DeletedToProto(in *entities.DeletedSecret) (*pb.DeletedSecret, error) {
+	secret, err := h.ToProto(&events.SecretDTO{
+		ID:         in.ID,
+		Name:       in.Name,
+		SecretType: in.SecretType,
+		Notes:      in.Notes,
+		Data:       in.Data,
+		UserID:     in.UserID,
+		Version:    in.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeletedSecret{
+		Secret:    secret,
+		DeletedAt: timestamppb.New(in.DeletedAt),
+	}, nil
+}
+
 func getProtoSecretData(in *pb.Secret) ([]byte, error) {
 	switch d := in.Data.(type) {
 	case *pb.Secret_Text:
@@ -107,6 +174,10 @@ func getProtoSecretData(in *pb.Secret) ([]byte, error) {
 		return json.Marshal(&entities.Binary{
 			Binary: d.Binary,
 		})
+	case *pb.Secret_Sealed:
+		return json.Marshal(&entities.Sealed{
+			Sealed: d.Sealed,
+		})
 	default:
 		return nil, fmt.Errorf("unknown secret data type")
 	}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/itohin/gophkeeper/internal/server/entities"
+)
+
+func (r *SecretsRepository) CreateFolder(ctx context.Context, folder entities.Folder) error {
+	var parentID *string
+	if folder.ParentID != "" {
+		parentID = &folder.ParentID
+	}
+	query := `INSERT INTO folders (id, user_id, parent_id, name, created_at) VALUES ($1, $2, $3, $4, $5)`
+	_, err := r.db.Conn(ctx).Exec(ctx, query, folder.ID, folder.UserID, parentID, folder.Name, folder.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save folder row: %v", err)
+	}
+	return nil
+}
+
+func (r *SecretsRepository) GetFolders(ctx context.Context, userID string) ([]entities.Folder, error) {
+	folders := make([]entities.Folder, 0)
+	query := `
+		SELECT id, user_id, COALESCE(parent_id::text, ''), name, created_at FROM folders
+		WHERE user_id = $1
+		ORDER BY lower(name), id
+	`
+	rows, err := r.db.Conn(ctx).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query select folders: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var f entities.Folder
+		err = rows.Scan(&f.ID, &f.UserID, &f.ParentID, &f.Name, &f.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan folder row: %v", err)
+		}
+		folders = append(folders, f)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("folders rows error: %v", err)
+	}
+	return folders, nil
+}
+
+func (r *SecretsRepository) GetFolder(ctx context.Context, userID, folderID string) (entities.Folder, error) {
+	var f entities.Folder
+	query := `SELECT id, user_id, COALESCE(parent_id::text, ''), name, created_at FROM folders WHERE id = $1 AND user_id = $2`
+	err := r.db.Conn(ctx).QueryRow(ctx, query, folderID, userID).Scan(&f.ID, &f.UserID, &f.ParentID, &f.Name, &f.CreatedAt)
+	if err != nil {
+		return f, fmt.Errorf("failed to get folder row: %v", err)
+	}
+	return f, nil
+}
+
+// DeleteFolder removes the folder only when it has no subfolders and no live
+// secrets, secrets in the trash are moved to the root
+func (r *SecretsRepository) DeleteFolder(ctx context.Context, userID, folderID string) (bool, error) {
+	query := `
+		WITH deleted AS (
+			DELETE FROM folders WHERE id = $1 AND user_id = $2
+			AND NOT EXISTS (SELECT 1 FROM folders children WHERE children.parent_id = $1)
+			AND NOT EXISTS (SELECT 1 FROM secrets WHERE secrets.folder_id = $1 AND secrets.deleted_at IS NULL)
+			RETURNING id
+		), trashed AS (
+			UPDATE secrets SET folder_id = NULL WHERE folder_id IN (SELECT id FROM deleted)
+		)
+		SELECT count(*) FROM deleted
+	`
+	var deleted int
+	err := r.db.Conn(ctx).QueryRow(ctx, query, folderID, userID).Scan(&deleted)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete folder row: %v", err)
+	}
+	return deleted > 0, nil
+}
+
+func (r *SecretsRepository) GetUserTags(ctx context.Context, userID string) ([]string, error) {
+	tags := make([]string, 0)
+	query := `
+		SELECT DISTINCT unnest(tags) AS tag FROM secrets
+		WHERE ` + accessCondition("$1") + ` AND deleted_at IS NULL
+		ORDER BY tag
+	`
+	rows, err := r.db.Conn(ctx).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query select tags: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tag string
+		err = rows.Scan(&tag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tag row: %v", err)
+		}
+		tags = append(tags, tag)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("tags rows error: %v", err)
+	}
+	return tags, nil
+}
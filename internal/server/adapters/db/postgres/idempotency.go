@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/itohin/gophkeeper/internal/server/entities"
+	"github.com/jackc/pgx/v5"
+)
+
+// ClaimIdempotencyKey binds the key to the secret unless the key was used
+// after since, and returns the secret the key is bound to. A concurrent claim
+// of the same key waits for the first one to commit or roll back. A key bound
+// to another payload returns entities.ErrIdempotencyKeyReused.
+func (r *SecretsRepository) ClaimIdempotencyKey(ctx context.Context, userID, key, secretID, payloadHash string, since time.Time) (string, error) {
+	query := `
+		INSERT INTO idempotency_keys (user_id, key, secret_id, payload_hash, created_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT(user_id, key) DO UPDATE
+		SET secret_id = excluded.secret_id, payload_hash = excluded.payload_hash, created_at = excluded.created_at
+		WHERE idempotency_keys.created_at <= $6
+		RETURNING secret_id
+	`
+	var boundID string
+	err := r.db.Conn(ctx).QueryRow(ctx, query, userID, key, secretID, payloadHash, time.Now(), since).Scan(&boundID)
+	if err == nil {
+		return boundID, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("failed to save idempotency key: %v", err)
+	}
+
+	// a new statement sees the row of the request that claimed the key first
+	query = `SELECT secret_id, payload_hash FROM idempotency_keys WHERE user_id = $1 AND key = $2`
+	var boundHash string
+	err = r.db.Conn(ctx).QueryRow(ctx, query, userID, key).Scan(&boundID, &boundHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to get idempotency key: %v", err)
+	}
+	if boundHash != payloadHash {
+		return "", entities.ErrIdempotencyKeyReused
+	}
+	return boundID, nil
+}
+
+func (r *SecretsRepository) PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE created_at <= $1`
+	tag, err := r.db.Conn(ctx).Exec(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge idempotency keys: %v", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/itohin/gophkeeper/pkg/database"
+	"github.com/itohin/gophkeeper/pkg/envelope"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type cachedKey struct {
+	key       []byte
+	expiresAt time.Time
+}
+
+// keysPool is the part of the pool the data keys are stored through
+type keysPool interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type KeysRepository struct {
+	pool     keysPool
+	keyring  *envelope.Keyring
+	cacheTTL time.Duration
+	mu       sync.RWMutex
+	cache    map[string]cachedKey
+}
+
+func NewKeysRepository(db *database.PgxPoolDB, keyring *envelope.Keyring, cacheTTL time.Duration) *KeysRepository {
+	return &KeysRepository{pool: db.Pool, keyring: keyring, cacheTTL: cacheTTL, cache: make(map[string]cachedKey)}
+}
+
+// DataKey stores a new key through the pool even inside a transaction, the key
+// is cached and must not disappear with a rollback. Cached keys expire, so a
+// running server picks up the wrapping changed by a rotation and does not
+// keep serving keys whose key encryption key was removed from its config
+func (r *KeysRepository) DataKey(ctx context.Context, userID string) ([]byte, error) {
+	r.mu.RLock()
+	cached, ok := r.cache[userID]
+	r.mu.RUnlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.key, nil
+	}
+
+	key, err := r.loadDataKey(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		key, err = r.createDataKey(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cache[userID] = cachedKey{key: key, expiresAt: time.Now().Add(r.cacheTTL)}
+	r.mu.Unlock()
+	return key, nil
+}
+
+func (r *KeysRepository) loadDataKey(ctx context.Context, userID string) ([]byte, error) {
+	var wrapped []byte
+	var kekID string
+	query := `SELECT wrapped_key, kek_id FROM user_keys WHERE user_id = $1`
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&wrapped, &kekID)
+	if err != nil {
+		return nil, err
+	}
+	key, err := r.keyring.Unwrap(kekID, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key with kek %s: %v", kekID, err)
+	}
+	return key, nil
+}
+
+// createDataKey always wraps with the primary key, the retired ones are only
+// used to unwrap
+func (r *KeysRepository) createDataKey(ctx context.Context, userID string) ([]byte, error) {
+	key, err := envelope.NewDataKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %v", err)
+	}
+	kekID := r.keyring.PrimaryID()
+	wrapped, err := r.keyring.Wrap(kekID, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %v", err)
+	}
+	query := `
+		INSERT INTO user_keys (user_id, wrapped_key, kek_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT(user_id) DO NOTHING
+	`
+	tag, err := r.pool.Exec(ctx, query, userID, wrapped, kekID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to save data key: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		// another request created the key concurrently
+		key, err = r.loadDataKey(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get data key: %v", err)
+		}
+	}
+	return key, nil
+}
+
+// Rewrap wraps the data keys of all users with the primary key, afterwards the
+// retired keys can be removed from the config
+func (r *KeysRepository) Rewrap(ctx context.Context) (int64, error) {
+	kekID := r.keyring.PrimaryID()
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	type userKey struct {
+		userID  string
+		kekID   string
+		wrapped []byte
+	}
+	keys := make([]userKey, 0)
+	rows, err := tx.Query(ctx, `SELECT user_id, kek_id, wrapped_key FROM user_keys WHERE kek_id <> $1 FOR UPDATE`, kekID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query select user keys: %v", err)
+	}
+	for rows.Next() {
+		var k userKey
+		if err = rows.Scan(&k.userID, &k.kekID, &k.wrapped); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan user key row: %v", err)
+		}
+		keys = append(keys, k)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("user keys rows error: %v", err)
+	}
+
+	for _, k := range keys {
+		dek, err := r.keyring.Unwrap(k.kekID, k.wrapped)
+		if err != nil {
+			return 0, fmt.Errorf("failed to unwrap data key of user %s with kek %s: %v", k.userID, k.kekID, err)
+		}
+		wrapped, err := r.keyring.Wrap(kekID, dek)
+		if err != nil {
+			return 0, fmt.Errorf("failed to wrap data key of user %s: %v", k.userID, err)
+		}
+		_, err = tx.Exec(
+			ctx, `UPDATE user_keys SET wrapped_key = $1, kek_id = $2, updated_at = $3 WHERE user_id = $4`,
+			wrapped, kekID, time.Now(), k.userID,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update user key row: %v", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return int64(len(keys)), nil
+}
@@ -0,0 +1,264 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itohin/gophkeeper/pkg/envelope"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type userKeyRow struct {
+	wrapped []byte
+	kekID   string
+}
+
+// fakeKeysPool keeps the user_keys table in memory, it answers only the
+// queries of KeysRepository
+type fakeKeysPool struct {
+	keys    map[string]userKeyRow
+	queries int
+}
+
+func newFakeKeysPool() *fakeKeysPool {
+	return &fakeKeysPool{keys: make(map[string]userKeyRow)}
+}
+
+func (p *fakeKeysPool) QueryRow(_ context.Context, _ string, args ...any) pgx.Row {
+	p.queries++
+	k, ok := p.keys[args[0].(string)]
+	return fakeRow{values: []any{k.wrapped, k.kekID}, found: ok}
+}
+
+func (p *fakeKeysPool) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if !strings.Contains(sql, "INSERT INTO user_keys") {
+		return pgconn.CommandTag{}, errors.New("unexpected query")
+	}
+	userID := args[0].(string)
+	if _, ok := p.keys[userID]; ok {
+		return pgconn.NewCommandTag("INSERT 0 0"), nil
+	}
+	p.keys[userID] = userKeyRow{wrapped: args[1].([]byte), kekID: args[2].(string)}
+	return pgconn.NewCommandTag("INSERT 0 1"), nil
+}
+
+func (p *fakeKeysPool) Begin(_ context.Context) (pgx.Tx, error) {
+	return &fakeKeysTx{pool: p, updates: make(map[string]userKeyRow)}, nil
+}
+
+// fakeKeysTx applies the updates only on commit, the methods KeysRepository
+// does not call are left to the embedded nil interface
+type fakeKeysTx struct {
+	pgx.Tx
+	pool    *fakeKeysPool
+	updates map[string]userKeyRow
+}
+
+func (tx *fakeKeysTx) Query(_ context.Context, _ string, args ...any) (pgx.Rows, error) {
+	userIDs := make([]string, 0, len(tx.pool.keys))
+	for userID, k := range tx.pool.keys {
+		if k.kekID != args[0].(string) {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	sort.Strings(userIDs)
+	rows := &fakeRows{}
+	for _, userID := range userIDs {
+		k := tx.pool.keys[userID]
+		rows.values = append(rows.values, []any{userID, k.kekID, k.wrapped})
+	}
+	return rows, nil
+}
+
+func (tx *fakeKeysTx) Exec(_ context.Context, _ string, args ...any) (pgconn.CommandTag, error) {
+	tx.updates[args[3].(string)] = userKeyRow{wrapped: args[0].([]byte), kekID: args[1].(string)}
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func (tx *fakeKeysTx) Commit(_ context.Context) error {
+	for userID, k := range tx.updates {
+		tx.pool.keys[userID] = k
+	}
+	return nil
+}
+
+func (tx *fakeKeysTx) Rollback(_ context.Context) error {
+	return nil
+}
+
+type fakeRow struct {
+	values []any
+	found  bool
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if !r.found {
+		return pgx.ErrNoRows
+	}
+	return scanValues(r.values, dest)
+}
+
+type fakeRows struct {
+	pgx.Rows
+	values [][]any
+	next   int
+}
+
+func (r *fakeRows) Next() bool {
+	r.next++
+	return r.next <= len(r.values)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	return scanValues(r.values[r.next-1], dest)
+}
+
+func (r *fakeRows) Close() {}
+
+func (r *fakeRows) Err() error {
+	return nil
+}
+
+func scanValues(values, dest []any) error {
+	for i, v := range values {
+		switch d := dest[i].(type) {
+		case *string:
+			*d = v.(string)
+		case *[]byte:
+			*d = v.([]byte)
+		default:
+			return errors.New("unexpected scan destination")
+		}
+	}
+	return nil
+}
+
+func newTestKeyring(t *testing.T, primaryID string, keks map[string]string) *envelope.Keyring {
+	keyring, err := envelope.NewKeyring(primaryID, keks[primaryID])
+	require.NoError(t, err)
+	for id, kek := range keks {
+		if id != primaryID {
+			require.NoError(t, keyring.Add(id, kek))
+		}
+	}
+	return keyring
+}
+
+func newTestKEK(t *testing.T) string {
+	key, err := envelope.NewDataKey()
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+const keysUserID = "1955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+
+func TestKeysRepository_DataKey(t *testing.T) {
+	pool := newFakeKeysPool()
+	keks := map[string]string{"new": newTestKEK(t)}
+	r := &KeysRepository{pool: pool, keyring: newTestKeyring(t, "new", keks), cacheTTL: time.Minute, cache: make(map[string]cachedKey)}
+
+	key, err := r.DataKey(context.Background(), keysUserID)
+	require.NoError(t, err)
+	assert.Len(t, key, envelope.KeySize)
+	assert.Equal(t, "new", pool.keys[keysUserID].kekID, "a new key is wrapped with the primary key")
+
+	queries := pool.queries
+	cached, err := r.DataKey(context.Background(), keysUserID)
+	assert.NoError(t, err)
+	assert.Equal(t, key, cached)
+	assert.Equal(t, queries, pool.queries, "a cached key is not loaded")
+
+	r.cache[keysUserID] = cachedKey{key: key, expiresAt: time.Now().Add(-time.Second)}
+	loaded, err := r.DataKey(context.Background(), keysUserID)
+	assert.NoError(t, err)
+	assert.Equal(t, key, loaded)
+	assert.Equal(t, queries+1, pool.queries, "an expired key is loaded again")
+}
+
+func TestKeysRepository_DataKeyRetiredKEK(t *testing.T) {
+	keks := map[string]string{"old": newTestKEK(t), "new": newTestKEK(t)}
+	dek, err := envelope.NewDataKey()
+	require.NoError(t, err)
+	wrapped, err := newTestKeyring(t, "old", keks).Wrap("old", dek)
+	require.NoError(t, err)
+
+	pool := newFakeKeysPool()
+	pool.keys[keysUserID] = userKeyRow{wrapped: wrapped, kekID: "old"}
+
+	r := &KeysRepository{pool: pool, keyring: newTestKeyring(t, "new", keks), cacheTTL: time.Minute, cache: make(map[string]cachedKey)}
+	key, err := r.DataKey(context.Background(), keysUserID)
+	assert.NoError(t, err)
+	assert.Equal(t, dek, key)
+	assert.Equal(t, "old", pool.keys[keysUserID].kekID, "loading does not re-wrap the key")
+
+	withoutOld := map[string]string{"new": keks["new"]}
+	r = &KeysRepository{pool: pool, keyring: newTestKeyring(t, "new", withoutOld), cacheTTL: time.Minute, cache: make(map[string]cachedKey)}
+	_, err = r.DataKey(context.Background(), keysUserID)
+	assert.Error(t, err)
+}
+
+func TestKeysRepository_Rewrap(t *testing.T) {
+	keks := map[string]string{"old": newTestKEK(t), "new": newTestKEK(t)}
+	keyring := newTestKeyring(t, "new", keks)
+
+	pool := newFakeKeysPool()
+	deks := make(map[string][]byte)
+	for userID, kekID := range map[string]string{
+		"1955a7d6-0968-425b-bdb6-fb9a0e4b39e7": "old",
+		"2955a7d6-0968-425b-bdb6-fb9a0e4b39e7": "old",
+		"3955a7d6-0968-425b-bdb6-fb9a0e4b39e7": "new",
+	} {
+		dek, err := envelope.NewDataKey()
+		require.NoError(t, err)
+		wrapped, err := keyring.Wrap(kekID, dek)
+		require.NoError(t, err)
+		deks[userID] = dek
+		pool.keys[userID] = userKeyRow{wrapped: wrapped, kekID: kekID}
+	}
+
+	r := &KeysRepository{pool: pool, keyring: keyring, cacheTTL: time.Minute, cache: make(map[string]cachedKey)}
+	count, err := r.Rewrap(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	// after the rotation the retired key is not needed anymore
+	withoutOld := map[string]string{"new": keks["new"]}
+	r = &KeysRepository{pool: pool, keyring: newTestKeyring(t, "new", withoutOld), cacheTTL: time.Minute, cache: make(map[string]cachedKey)}
+	for userID, dek := range deks {
+		assert.Equal(t, "new", pool.keys[userID].kekID)
+		key, err := r.DataKey(context.Background(), userID)
+		assert.NoError(t, err)
+		assert.Equal(t, dek, key, "the data key does not change")
+	}
+}
+
+func TestKeysRepository_RewrapUnknownKEK(t *testing.T) {
+	keks := map[string]string{"old": newTestKEK(t), "lost": newTestKEK(t), "new": newTestKEK(t)}
+	full := newTestKeyring(t, "new", keks)
+
+	pool := newFakeKeysPool()
+	for userID, kekID := range map[string]string{
+		"1955a7d6-0968-425b-bdb6-fb9a0e4b39e7": "old",
+		"2955a7d6-0968-425b-bdb6-fb9a0e4b39e7": "lost",
+	} {
+		dek, err := envelope.NewDataKey()
+		require.NoError(t, err)
+		wrapped, err := full.Wrap(kekID, dek)
+		require.NoError(t, err)
+		pool.keys[userID] = userKeyRow{wrapped: wrapped, kekID: kekID}
+	}
+
+	withoutLost := map[string]string{"old": keks["old"], "new": keks["new"]}
+	r := &KeysRepository{pool: pool, keyring: newTestKeyring(t, "new", withoutLost), cacheTTL: time.Minute, cache: make(map[string]cachedKey)}
+	_, err := r.Rewrap(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "old", pool.keys["1955a7d6-0968-425b-bdb6-fb9a0e4b39e7"].kekID, "nothing is re-wrapped if one key fails")
+}
@@ -0,0 +1,257 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/itohin/gophkeeper/internal/server/entities"
+	"github.com/itohin/gophkeeper/pkg/database"
+	"github.com/jackc/pgx/v5"
+)
+
+// resyncMember makes the next sync of the user a full one, because the set of
+// organization secrets visible to the user or the permissions on them changed
+const resyncMember = `
+	resync AS (
+		INSERT INTO user_sequences (user_id, seq, purged_seq) SELECT user_id, 1, 1 FROM changed
+		ON CONFLICT(user_id) DO UPDATE SET seq = user_sequences.seq + 1, purged_seq = user_sequences.seq + 1
+	)`
+
+type OrganizationsRepository struct {
+	db *database.PgxPoolDB
+}
+
+func NewOrganizationsRepository(db *database.PgxPoolDB) *OrganizationsRepository {
+	return &OrganizationsRepository{db: db}
+}
+
+func (r *OrganizationsRepository) Create(ctx context.Context, org entities.Organization, owner entities.Member) error {
+	query := `
+		WITH organization AS (
+			INSERT INTO organizations (id, name, created_at) VALUES ($1, $2, $3)
+		)
+		INSERT INTO organization_members (organization_id, user_id, role, wrapped_key, created_at)
+		VALUES ($1, $4, $5, $6, $3)
+	`
+	_, err := r.db.Conn(ctx).Exec(ctx, query, org.ID, org.Name, org.CreatedAt, owner.UserID, owner.Role, owner.WrappedKey)
+	if err != nil {
+		return fmt.Errorf("failed to save organization row: %v", err)
+	}
+	return nil
+}
+
+func (r *OrganizationsRepository) GetUserOrganizations(ctx context.Context, userID string) ([]entities.Organization, error) {
+	organizations := make([]entities.Organization, 0)
+	query := `
+		SELECT organizations.id, organizations.name, organization_members.role, organization_members.wrapped_key, organizations.created_at
+		FROM organization_members JOIN organizations ON organizations.id = organization_members.organization_id
+		WHERE organization_members.user_id = $1
+		ORDER BY organizations.name
+	`
+	rows, err := r.db.Conn(ctx).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query select organizations: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var org entities.Organization
+		err = rows.Scan(&org.ID, &org.Name, &org.Role, &org.WrappedKey, &org.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization row: %v", err)
+		}
+		organizations = append(organizations, org)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("organizations rows error: %v", err)
+	}
+	return organizations, nil
+}
+
+func (r *OrganizationsRepository) GetMember(ctx context.Context, orgID, userID string) (entities.Member, error) {
+	var m entities.Member
+	query := `
+		SELECT organization_members.organization_id, organization_members.user_id, users.email, organization_members.role,
+		    organization_members.wrapped_key, organization_members.created_at
+		FROM organization_members JOIN users ON users.id = organization_members.user_id
+		WHERE organization_members.organization_id = $1 AND organization_members.user_id = $2
+	`
+	err := r.db.Conn(ctx).QueryRow(ctx, query, orgID, userID).Scan(&m.OrganizationID, &m.UserID, &m.Email, &m.Role, &m.WrappedKey, &m.CreatedAt)
+	if err != nil {
+		return m, fmt.Errorf("failed to get member row: %v", err)
+	}
+	return m, nil
+}
+
+func (r *OrganizationsRepository) GetMembers(ctx context.Context, orgID string) ([]entities.Member, error) {
+	members := make([]entities.Member, 0)
+	query := `
+		SELECT organization_members.organization_id, organization_members.user_id, users.email, organization_members.role,
+		    organization_members.created_at
+		FROM organization_members JOIN users ON users.id = organization_members.user_id
+		WHERE organization_members.organization_id = $1
+		ORDER BY organization_members.role, users.email
+	`
+	rows, err := r.db.Conn(ctx).Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query select members: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var m entities.Member
+		err = rows.Scan(&m.OrganizationID, &m.UserID, &m.Email, &m.Role, &m.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan member row: %v", err)
+		}
+		members = append(members, m)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("members rows error: %v", err)
+	}
+	return members, nil
+}
+
+func (r *OrganizationsRepository) SaveInvitation(ctx context.Context, inv entities.Invitation) error {
+	query := `
+		INSERT INTO organization_invitations (
+		    id, organization_id, email, role, wrapped_key, code, invited_by, created_at, expires_at
+		) VALUES (
+		    $1, $2, $3, $4, $5, $6, $7, $8, $9
+		)
+		ON CONFLICT(organization_id, email) DO UPDATE SET
+		    id = $1, role = $4, wrapped_key = $5, code = $6, invited_by = $7, created_at = $8, expires_at = $9
+	`
+	_, err := r.db.Conn(ctx).Exec(
+		ctx, query, inv.ID, inv.OrganizationID, inv.Email, inv.Role, inv.WrappedKey, inv.Code, inv.InvitedBy, inv.CreatedAt, inv.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save invitation row: %v", err)
+	}
+	return nil
+}
+
+func (r *OrganizationsRepository) AcceptInvitation(ctx context.Context, code, userID, email string) (string, error) {
+	var orgID string
+	query := `
+		WITH invitation AS (
+			DELETE FROM organization_invitations WHERE code = $1 AND email = $3 AND expires_at > $4
+			RETURNING organization_id, role, wrapped_key
+		), changed AS (
+			INSERT INTO organization_members (organization_id, user_id, role, wrapped_key, created_at)
+			SELECT organization_id, $2, role, wrapped_key, $4 FROM invitation
+			ON CONFLICT(organization_id, user_id) DO UPDATE SET role = excluded.role, wrapped_key = excluded.wrapped_key
+			RETURNING organization_id, user_id
+		), ` + resyncMember + `
+		SELECT organization_id FROM changed
+	`
+	err := r.db.Conn(ctx).QueryRow(ctx, query, code, userID, email, time.Now()).Scan(&orgID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", entities.ErrInvitationNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to accept invitation: %v", err)
+	}
+	return orgID, nil
+}
+
+func (r *OrganizationsRepository) SetMemberRole(ctx context.Context, orgID, userID string, role uint32) (bool, error) {
+	query := `
+		WITH changed AS (
+			UPDATE organization_members SET role = $3 WHERE organization_id = $1 AND user_id = $2
+			RETURNING user_id
+		), ` + resyncMember + `
+		SELECT count(*) FROM changed
+	`
+	var count int
+	err := r.db.Conn(ctx).QueryRow(ctx, query, orgID, userID, role).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to update member role: %v", err)
+	}
+	return count > 0, nil
+}
+
+func (r *OrganizationsRepository) RemoveMember(ctx context.Context, orgID, userID string) (bool, error) {
+	query := `
+		WITH changed AS (
+			DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2
+			RETURNING user_id
+		), forgotten AS (
+			DELETE FROM organization_changes WHERE user_id IN (SELECT user_id FROM changed) AND secret_id IN (
+				SELECT secrets.id FROM secrets JOIN collections ON collections.id = secrets.collection_id
+				WHERE collections.organization_id = $1
+			)
+		), ` + resyncMember + `
+		SELECT count(*) FROM changed
+	`
+	var count int
+	err := r.db.Conn(ctx).QueryRow(ctx, query, orgID, userID).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete member row: %v", err)
+	}
+	return count > 0, nil
+}
+
+func (r *OrganizationsRepository) CreateCollection(ctx context.Context, c entities.Collection) error {
+	query := `INSERT INTO collections (id, organization_id, name, created_at) VALUES ($1, $2, $3, $4)`
+	_, err := r.db.Conn(ctx).Exec(ctx, query, c.ID, c.OrganizationID, c.Name, c.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save collection row: %v", err)
+	}
+	return nil
+}
+
+func (r *OrganizationsRepository) GetCollections(ctx context.Context, orgID string) ([]entities.Collection, error) {
+	collections := make([]entities.Collection, 0)
+	query := `
+		SELECT collections.id, collections.organization_id, organizations.name, collections.name, collections.created_at
+		FROM collections JOIN organizations ON organizations.id = collections.organization_id
+		WHERE collections.organization_id = $1
+		ORDER BY collections.name
+	`
+	rows, err := r.db.Conn(ctx).Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query select collections: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var c entities.Collection
+		err = rows.Scan(&c.ID, &c.OrganizationID, &c.OrganizationName, &c.Name, &c.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan collection row: %v", err)
+		}
+		collections = append(collections, c)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("collections rows error: %v", err)
+	}
+	return collections, nil
+}
+
+func (r *OrganizationsRepository) GetCollection(ctx context.Context, collectionID string) (entities.Collection, error) {
+	var c entities.Collection
+	query := `
+		SELECT collections.id, collections.organization_id, organizations.name, collections.name, collections.created_at
+		FROM collections JOIN organizations ON organizations.id = collections.organization_id
+		WHERE collections.id = $1
+	`
+	err := r.db.Conn(ctx).QueryRow(ctx, query, collectionID).Scan(&c.ID, &c.OrganizationID, &c.OrganizationName, &c.Name, &c.CreatedAt)
+	if err != nil {
+		return c, fmt.Errorf("failed to get collection row: %v", err)
+	}
+	return c, nil
+}
+
+func (r *OrganizationsRepository) DeleteCollection(ctx context.Context, collectionID string) (bool, error) {
+	query := `
+		DELETE FROM collections WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM secrets WHERE secrets.collection_id = $1)
+	`
+	tag, err := r.db.Conn(ctx).Exec(ctx, query, collectionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete collection row: %v", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -2,36 +2,187 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/itohin/gophkeeper/internal/server/entities"
 	"github.com/itohin/gophkeeper/pkg/database"
+	"github.com/itohin/gophkeeper/pkg/envelope"
 	"github.com/itohin/gophkeeper/pkg/events"
+	"github.com/jackc/pgx/v5"
 )
 
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+const sizeColumn = `COALESCE(blob_size, (SELECT size FROM secret_binaries b WHERE b.secret_id = secrets.id), octet_length(data))`
+
+// purged rows can no longer be reported as deletions, so clients whose cursor
+// is behind them have to resync from scratch
+const purgedSeqUpdate = `
+	purged_shares AS (
+		DELETE FROM shares WHERE secret_id IN (SELECT id FROM purged) RETURNING user_id, seq
+	), purged_members AS (
+		DELETE FROM organization_changes WHERE secret_id IN (SELECT id FROM purged) RETURNING user_id, seq
+	), watermark AS (
+		UPDATE user_sequences u SET purged_seq = GREATEST(u.purged_seq, p.seq)
+		FROM (
+			SELECT user_id, max(seq) AS seq FROM (
+				SELECT user_id, seq FROM purged
+				UNION ALL SELECT user_id, seq FROM purged_shares
+				UNION ALL SELECT user_id, seq FROM purged_members
+			) changes GROUP BY user_id
+		) p
+		WHERE u.user_id = p.user_id
+	)
+`
+
+// collectionRole selects the user's role in the organization the secret's collection belongs to
+func collectionRole(userParam string) string {
+	return fmt.Sprintf(`(
+		SELECT organization_members.role FROM collections
+		JOIN organization_members ON organization_members.organization_id = collections.organization_id
+		WHERE collections.id = secrets.collection_id AND organization_members.user_id = %s
+	)`, userParam)
+}
+
+// accessCondition matches personal secrets owned by or shared with the user
+// and secrets in collections of the user's organizations
+func accessCondition(userParam string) string {
+	return fmt.Sprintf(`(
+		secrets.collection_id IS NULL AND (secrets.user_id = %[1]s OR EXISTS (
+			SELECT 1 FROM shares WHERE shares.secret_id = secrets.id AND shares.user_id = %[1]s AND shares.revoked_at IS NULL
+		))
+		OR secrets.collection_id IS NOT NULL AND %[2]s IS NOT NULL
+	)`, userParam, collectionRole(userParam))
+}
+
+// trashCondition matches deleted personal secrets of the user and deleted
+// secrets in collections of the user's organizations, whoever deleted them
+func trashCondition(userParam string) string {
+	return fmt.Sprintf(`(
+		secrets.collection_id IS NULL AND secrets.user_id = %s
+		OR secrets.collection_id IS NOT NULL AND %s IS NOT NULL
+	)`, userParam, collectionRole(userParam))
+}
+
+// sharingColumns selects the owner label, the user's permission, the item key
+// wrapped for the user, the collection and the organization of the secret
+func sharingColumns(userParam string) string {
+	return fmt.Sprintf(`
+		CASE
+			WHEN secrets.collection_id IS NOT NULL THEN (
+				SELECT organizations.name FROM collections JOIN organizations ON organizations.id = collections.organization_id
+				WHERE collections.id = secrets.collection_id
+			)
+			WHEN secrets.user_id = %[1]s THEN ''
+			ELSE (SELECT email FROM users WHERE users.id = secrets.user_id)
+		END,
+		CASE
+			WHEN secrets.collection_id IS NOT NULL THEN CASE WHEN %[2]s <= %[3]d THEN %[4]d ELSE %[5]d END
+			ELSE COALESCE((
+				SELECT permission FROM shares WHERE shares.secret_id = secrets.id AND shares.user_id = %[1]s AND shares.revoked_at IS NULL
+			), 0)
+		END,
+		CASE WHEN secrets.collection_id IS NOT NULL OR secrets.user_id = %[1]s THEN secrets.owner_key ELSE (
+			SELECT wrapped_key FROM shares WHERE shares.secret_id = secrets.id AND shares.user_id = %[1]s AND shares.revoked_at IS NULL
+		) END,
+		COALESCE(secrets.collection_id::text, ''),
+		COALESCE((SELECT organization_id::text FROM collections WHERE collections.id = secrets.collection_id), '')`,
+		userParam, collectionRole(userParam), entities.RoleMember, entities.PermissionReadWrite, entities.PermissionRead,
+	)
+}
+
+// placementColumns selects the folder of the secret, which only its owner can
+// see, and the tags of the secret
+func placementColumns(userParam string) string {
+	return fmt.Sprintf(`
+		CASE WHEN secrets.collection_id IS NULL AND secrets.user_id = %s THEN COALESCE(secrets.folder_id::text, '') ELSE '' END,
+		secrets.tags`,
+		userParam,
+	)
+}
+
 type SecretsRepository struct {
-	db *database.PgxPoolDB
+	db   *database.PgxPoolDB
+	keys *KeysRepository
 }
 
-func NewSecretsRepository(db *database.PgxPoolDB) *SecretsRepository {
-	return &SecretsRepository{db: db}
+func NewSecretsRepository(db *database.PgxPoolDB, keys *KeysRepository) *SecretsRepository {
+	return &SecretsRepository{db: db, keys: keys}
 }
 
-func (r *SecretsRepository) GetUserSecrets(ctx context.Context, userID string) ([]events.SecretDTO, error) {
-	secrets := make([]events.SecretDTO, 0)
-	query := `SELECT id, user_id, type, name, data, notes FROM secrets WHERE user_id = $1 AND deleted_at IS NULL`
-	rows, err := r.db.Pool.Query(ctx, query, userID)
+func (r *SecretsRepository) SearchUserSecrets(ctx context.Context, userID string, f *entities.SecretsFilter) ([]events.SecretDTO, error) {
+	secrets := make([]events.SecretDTO, 0, f.PageSize)
+	args := []interface{}{userID}
+	conditions := []string{accessCondition("$1"), "deleted_at IS NULL"}
+	// notes are encrypted, only the name can be searched
+	if f.Query != "" {
+		args = append(args, "%"+likeEscaper.Replace(f.Query)+"%")
+		conditions = append(conditions, fmt.Sprintf("name ILIKE $%d", len(args)))
+	}
+	if f.SecretType != 0 {
+		args = append(args, f.SecretType)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+	if f.CollectionID != "" {
+		args = append(args, f.CollectionID)
+		conditions = append(conditions, fmt.Sprintf("collection_id = $%d", len(args)))
+	}
+	if f.FolderID != "" {
+		args = append(args, f.FolderID)
+		conditions = append(conditions, fmt.Sprintf("folder_id = $%d AND secrets.user_id = $1", len(args)))
+	}
+	if f.Tag != "" {
+		args = append(args, f.Tag)
+		conditions = append(conditions, fmt.Sprintf("$%d = ANY(tags)", len(args)))
+	}
+
+	column := "updated_at"
+	if f.IsByName() {
+		column = "lower(name)"
+	}
+	direction, op := "ASC", ">"
+	if f.IsDescending() {
+		direction, op = "DESC", "<"
+	}
+	if f.Cursor != nil {
+		if f.IsByName() {
+			args = append(args, f.Cursor.Name)
+			conditions = append(conditions, fmt.Sprintf("(lower(name), id) %s (lower($%d), $%d)", op, len(args), len(args)+1))
+		} else {
+			args = append(args, f.Cursor.UpdatedAt)
+			conditions = append(conditions, fmt.Sprintf("(updated_at, id) %s ($%d, $%d)", op, len(args), len(args)+1))
+		}
+		args = append(args, f.Cursor.ID)
+	}
+	args = append(args, f.PageSize+1)
+
+	query := fmt.Sprintf(
+		`SELECT id, user_id, type, name, notes, %s, version, %s, %s, updated_at FROM secrets WHERE %s ORDER BY %s %s, id %s LIMIT $%d`,
+		sizeColumn, sharingColumns("$1"), placementColumns("$1"), strings.Join(conditions, " AND "), column, direction, direction, len(args),
+	)
+	rows, err := r.db.Conn(ctx).Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query select secrets: %v", err)
+		return nil, fmt.Errorf("failed to query search secrets: %v", err)
 	}
 	defer rows.Close()
 	for rows.Next() {
 		var secretItem events.SecretDTO
-		err = rows.Scan(&secretItem.ID, &secretItem.UserID, &secretItem.SecretType, &secretItem.Name, &secretItem.Data, &secretItem.Notes)
+		var notes []byte
+		err = rows.Scan(
+			&secretItem.ID, &secretItem.UserID, &secretItem.SecretType, &secretItem.Name, &notes,
+			&secretItem.Size, &secretItem.Version, &secretItem.Owner, &secretItem.Permission, &secretItem.WrappedKey,
+			&secretItem.CollectionID, &secretItem.OrganizationID, &secretItem.FolderID, &secretItem.Tags, &secretItem.UpdatedAt,
+		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan secret row: %v", err)
 		}
+		_, secretItem.Notes, err = r.decrypt(ctx, secretItem.UserID, secretItem.ID, nil, notes)
+		if err != nil {
+			return nil, err
+		}
 		secrets = append(secrets, secretItem)
 	}
 	err = rows.Err()
@@ -43,35 +194,589 @@ func (r *SecretsRepository) GetUserSecrets(ctx context.Context, userID string) (
 
 func (r *SecretsRepository) GetUserSecret(ctx context.Context, userID, secretID string) (events.SecretDTO, error) {
 	var s events.SecretDTO
-	query := `SELECT id, user_id, type, name, data, notes FROM secrets WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
-	err := r.db.Pool.QueryRow(ctx, query, secretID, userID).Scan(&s.ID, &s.UserID, &s.SecretType, &s.Name, &s.Data, &s.Notes)
+	var data, notes []byte
+	query := `
+		SELECT id, user_id, type, name, data, notes, ` + sizeColumn + `, version, ` + sharingColumns("$2") + `, ` + placementColumns("$2") + `, updated_at
+		FROM secrets WHERE id = $1 AND ` + accessCondition("$2") + ` AND deleted_at IS NULL
+	`
+	err := r.db.Conn(ctx).QueryRow(ctx, query, secretID, userID).Scan(
+		&s.ID, &s.UserID, &s.SecretType, &s.Name, &data, &notes, &s.Size, &s.Version,
+		&s.Owner, &s.Permission, &s.WrappedKey, &s.CollectionID, &s.OrganizationID, &s.FolderID, &s.Tags, &s.UpdatedAt,
+	)
 	if err != nil {
 		return s, fmt.Errorf("failed to get secret row: %v", err)
 	}
-	return s, nil
+	s.Data, s.Notes, err = r.decrypt(ctx, s.UserID, s.ID, data, notes)
+	return s, err
 }
 
 func (r *SecretsRepository) Save(ctx context.Context, s entities.Secret) (*events.SecretDTO, error) {
 	var sDTO events.SecretDTO
+	data, ok := s.Data.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("failed to save secret row: unexpected data type %T", s.Data)
+	}
+	encData, encNotes, err := r.encrypt(ctx, s.UserID, s.ID, data, s.Notes)
+	if err != nil {
+		return nil, err
+	}
+	var blobRef *string
+	var blobSize *int64
+	if s.BlobRef != "" {
+		blobRef, blobSize = &s.BlobRef, &s.BlobSize
+	}
+	var ownerKey []byte
+	if len(s.OwnerKey) > 0 {
+		ownerKey = s.OwnerKey
+	}
+	var collectionID *string
+	if s.CollectionID != "" {
+		collectionID = &s.CollectionID
+	}
+	var folderID *string
+	if s.FolderID != nil && *s.FolderID != "" {
+		folderID = s.FolderID
+	}
 	query := `
-		INSERT INTO secrets (
-		    id, user_id, type, name, data, notes, created_at, updated_at, deleted_at
-		) VALUES (
-		    $1, $2, $3, $4, $5, $6, $7, $8, $9
+		WITH next_seq AS (
+			INSERT INTO user_sequences (user_id, seq) VALUES ($2, 1)
+			ON CONFLICT(user_id) DO UPDATE SET seq = user_sequences.seq + 1
+			RETURNING seq
+		), saved AS (
+			INSERT INTO secrets (
+			    id, user_id, type, name, data, notes, encrypted, created_at, updated_at, deleted_at, blob_ref, blob_size, seq, version, owner_key, collection_id,
+			    folder_id, tags
+			) VALUES (
+			    $1, $2, $3, $4, $5, $6, true, $7, $8, $9, $10, $11, (SELECT seq FROM next_seq), 1, $13, $14,
+			    $15, COALESCE($17::varchar[], '{}')
+			)
+			ON CONFLICT(id, user_id) DO UPDATE set
+			    name = $4, data = $5, notes = $6, encrypted = true, updated_at = $8, deleted_at = $9,
+			    blob_ref = COALESCE($10, secrets.blob_ref), blob_size = COALESCE($11, secrets.blob_size),
+			    seq = (SELECT seq FROM next_seq), version = secrets.version + 1, owner_key = COALESCE($13, secrets.owner_key),
+			    folder_id = CASE WHEN $16 THEN $15::uuid ELSE secrets.folder_id END, tags = COALESCE($17, secrets.tags)
+			WHERE secrets.version = $12
+			RETURNING secrets.id, secrets.user_id, secrets.type, secrets.name, secrets.data, secrets.notes, secrets.encrypted,
+			    secrets.updated_at, secrets.deleted_at, secrets.blob_ref, secrets.blob_size, secrets.version, secrets.collection_id,
+			    secrets.folder_id, secrets.tags
+		), revision AS (
+			INSERT INTO secret_revisions (
+			    id, secret_id, user_id, type, name, data, notes, encrypted, blob_ref, blob_size, created_at
+			)
+			SELECT gen_random_uuid(), id, user_id, type, name, data, notes, encrypted, blob_ref, blob_size, $8 FROM saved WHERE deleted_at IS NULL
+		), legacy AS (
+			DELETE FROM secret_binaries WHERE secret_id = $1 AND $10::varchar IS NOT NULL AND EXISTS (SELECT 1 FROM saved)
+		), grantees AS (
+			INSERT INTO user_sequences (user_id, seq)
+			SELECT user_id, 1 FROM shares WHERE secret_id = $1 AND revoked_at IS NULL AND EXISTS (SELECT 1 FROM saved)
+			ON CONFLICT(user_id) DO UPDATE SET seq = user_sequences.seq + 1
+			RETURNING user_id, seq
+		), shared AS (
+			UPDATE shares SET seq = grantees.seq FROM grantees WHERE shares.secret_id = $1 AND shares.user_id = grantees.user_id
+		), members AS (
+			INSERT INTO user_sequences (user_id, seq)
+			SELECT organization_members.user_id, 1 FROM saved
+			JOIN collections ON collections.id = saved.collection_id
+			JOIN organization_members ON organization_members.organization_id = collections.organization_id
+			WHERE organization_members.user_id <> $2
+			ON CONFLICT(user_id) DO UPDATE SET seq = user_sequences.seq + 1
+			RETURNING user_id, seq
+		), member_changes AS (
+			INSERT INTO organization_changes (user_id, secret_id, seq) SELECT user_id, $1, seq FROM members
+			ON CONFLICT(user_id, secret_id) DO UPDATE SET seq = excluded.seq
 		)
-		ON CONFLICT(id, user_id) DO UPDATE set name = $4, data = $5, notes = $6, updated_at = $8, deleted_at = $9
-		RETURNING secrets.id, secrets.user_id, secrets.type, secrets.name, secrets.data, secrets.notes
+		SELECT id, user_id, type, name, COALESCE(blob_size, (SELECT size FROM secret_binaries b WHERE b.secret_id = saved.id), octet_length(data)),
+		    version, COALESCE(collection_id::text, ''), COALESCE(folder_id::text, ''), tags, updated_at
+		FROM saved
 	`
 
-	err := r.db.Pool.QueryRow(
-		ctx, query, s.ID, s.UserID, s.SecretType, s.Name, s.Data, s.Notes, time.Now(), time.Now(), s.DeletedAt,
+	err = r.db.Conn(ctx).QueryRow(
+		ctx, query, s.ID, s.UserID, s.SecretType, s.Name, encData, encNotes, time.Now(), time.Now(), s.DeletedAt, blobRef, blobSize, s.Version, ownerKey, collectionID,
+		folderID, s.FolderID != nil, s.Tags,
 	).Scan(
-		&sDTO.ID, &sDTO.UserID, &sDTO.SecretType, &sDTO.Name, &sDTO.Data, &sDTO.Notes,
+		&sDTO.ID, &sDTO.UserID, &sDTO.SecretType, &sDTO.Name, &sDTO.Size, &sDTO.Version, &sDTO.CollectionID, &sDTO.FolderID, &sDTO.Tags, &sDTO.UpdatedAt,
 	)
 
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entities.ErrVersionConflict
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to save secret row: %v", err)
 	}
+	sDTO.Data = data
+	sDTO.Notes = s.Notes
 
 	return &sDTO, nil
 }
+
+func (r *SecretsRepository) GetSecretRevisions(ctx context.Context, userID, secretID string) ([]entities.SecretRevision, error) {
+	revisions := make([]entities.SecretRevision, 0)
+	query := `
+		SELECT id, secret_id, user_id, type, name, data, notes, COALESCE(blob_ref, ''), COALESCE(blob_size, 0), created_at FROM secret_revisions
+		WHERE secret_id = $1 AND user_id = $2
+		ORDER BY created_at DESC
+	`
+	rows, err := r.db.Conn(ctx).Query(ctx, query, secretID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query select secret revisions: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rev entities.SecretRevision
+		var notes []byte
+		err = rows.Scan(&rev.ID, &rev.SecretID, &rev.UserID, &rev.SecretType, &rev.Name, &rev.Data, &notes, &rev.BlobRef, &rev.BlobSize, &rev.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan secret revision row: %v", err)
+		}
+		rev.Data, rev.Notes, err = r.decrypt(ctx, rev.UserID, rev.SecretID, rev.Data, notes)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("secret revisions rows error: %v", err)
+	}
+	return revisions, nil
+}
+
+func (r *SecretsRepository) GetSecretRevision(ctx context.Context, userID, secretID, revisionID string) (entities.SecretRevision, error) {
+	var rev entities.SecretRevision
+	var notes []byte
+	query := `
+		SELECT id, secret_id, user_id, type, name, data, notes, COALESCE(blob_ref, ''), COALESCE(blob_size, 0), created_at FROM secret_revisions
+		WHERE id = $1 AND secret_id = $2 AND user_id = $3
+	`
+	err := r.db.Conn(ctx).QueryRow(ctx, query, revisionID, secretID, userID).
+		Scan(&rev.ID, &rev.SecretID, &rev.UserID, &rev.SecretType, &rev.Name, &rev.Data, &notes, &rev.BlobRef, &rev.BlobSize, &rev.CreatedAt)
+	if err != nil {
+		return rev, fmt.Errorf("failed to get secret revision row: %v", err)
+	}
+	rev.Data, rev.Notes, err = r.decrypt(ctx, rev.UserID, rev.SecretID, rev.Data, notes)
+	return rev, err
+}
+
+func (r *SecretsRepository) GetUserDeletedSecrets(ctx context.Context, userID string) ([]entities.DeletedSecret, error) {
+	secrets := make([]entities.DeletedSecret, 0)
+	query := `
+		SELECT id, user_id, type, name, data, notes, version, COALESCE(collection_id::text, ''), deleted_at FROM secrets
+		WHERE deleted_at IS NOT NULL AND ` + trashCondition("$1") + `
+		ORDER BY deleted_at DESC
+	`
+	rows, err := r.db.Conn(ctx).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query select deleted secrets: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var s entities.DeletedSecret
+		var notes []byte
+		err = rows.Scan(&s.ID, &s.UserID, &s.SecretType, &s.Name, &s.Data, &notes, &s.Version, &s.CollectionID, &s.DeletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan deleted secret row: %v", err)
+		}
+		s.Data, s.Notes, err = r.decrypt(ctx, s.UserID, s.ID, s.Data, notes)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, s)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("deleted secrets rows error: %v", err)
+	}
+	return secrets, nil
+}
+
+func (r *SecretsRepository) GetUserDeletedSecret(ctx context.Context, userID, secretID string) (entities.DeletedSecret, error) {
+	var s entities.DeletedSecret
+	var notes []byte
+	query := `
+		SELECT id, user_id, type, name, data, notes, version, COALESCE(collection_id::text, ''), deleted_at FROM secrets
+		WHERE id = $1 AND deleted_at IS NOT NULL AND ` + trashCondition("$2") + `
+	`
+	err := r.db.Conn(ctx).QueryRow(ctx, query, secretID, userID).
+		Scan(&s.ID, &s.UserID, &s.SecretType, &s.Name, &s.Data, &notes, &s.Version, &s.CollectionID, &s.DeletedAt)
+	if err != nil {
+		return s, fmt.Errorf("failed to get deleted secret row: %v", err)
+	}
+	s.Data, s.Notes, err = r.decrypt(ctx, s.UserID, s.ID, s.Data, notes)
+	return s, err
+}
+
+func (r *SecretsRepository) GetLegacyBinary(ctx context.Context, userID, secretID string) (entities.BinaryContent, error) {
+	var b entities.BinaryContent
+	query := `SELECT secret_id, user_id, data, size, sha256 FROM secret_binaries WHERE secret_id = $1 AND user_id = $2`
+	err := r.db.Conn(ctx).QueryRow(ctx, query, secretID, userID).Scan(&b.SecretID, &b.UserID, &b.Data, &b.Size, &b.SHA256)
+	if err != nil {
+		return b, fmt.Errorf("failed to get secret binary row: %v", err)
+	}
+	key, err := r.keys.DataKey(ctx, userID)
+	if err != nil {
+		return b, fmt.Errorf("failed to get data key: %v", err)
+	}
+	b.Data, err = envelope.Open(key, b.Data, additionalData(userID, secretID, "binary"))
+	if err != nil {
+		return b, fmt.Errorf("failed to decrypt secret binary: %v", err)
+	}
+	return b, nil
+}
+
+func (r *SecretsRepository) GetSecretBlob(ctx context.Context, userID, secretID string) (entities.Blob, error) {
+	var b entities.Blob
+	query := `SELECT COALESCE(blob_ref, ''), COALESCE(blob_size, 0) FROM secrets WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL`
+	err := r.db.Conn(ctx).QueryRow(ctx, query, secretID, userID).Scan(&b.Ref, &b.Size)
+	if err != nil {
+		return b, fmt.Errorf("failed to get secret blob: %v", err)
+	}
+	return b, nil
+}
+
+func (r *SecretsRepository) GetUserBlobRefs(ctx context.Context, userID string) ([]string, error) {
+	refs := make([]string, 0)
+	query := `
+		SELECT blob_ref FROM secrets WHERE user_id = $1 AND blob_ref IS NOT NULL
+		UNION
+		SELECT blob_ref FROM secret_revisions WHERE user_id = $1 AND blob_ref IS NOT NULL
+	`
+	rows, err := r.db.Conn(ctx).Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query select blob refs: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ref string
+		err = rows.Scan(&ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan blob ref row: %v", err)
+		}
+		refs = append(refs, ref)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("blob refs rows error: %v", err)
+	}
+	return refs, nil
+}
+
+func (r *SecretsRepository) GetChangeSequence(ctx context.Context, userID string) (int64, int64, error) {
+	var seq, purgedSeq int64
+	query := `SELECT seq, purged_seq FROM user_sequences WHERE user_id = $1`
+	err := r.db.Conn(ctx).QueryRow(ctx, query, userID).Scan(&seq, &purgedSeq)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get change sequence row: %v", err)
+	}
+	return seq, purgedSeq, nil
+}
+
+func (r *SecretsRepository) GetChangesSince(ctx context.Context, userID string, cursor int64, limit int) ([]entities.SecretChange, error) {
+	changes := make([]entities.SecretChange, 0, limit)
+	query := `
+		SELECT * FROM (
+			SELECT id, user_id, type, name, notes, ` + sizeColumn + `, version, ` + sharingColumns("$1") + `, ` + placementColumns("$1") + `,
+			    updated_at, deleted_at IS NOT NULL OR collection_id IS NOT NULL AND ` + collectionRole("$1") + ` IS NULL AS deleted, seq
+			FROM secrets
+			WHERE user_id = $1 AND seq > $2
+			UNION ALL
+			SELECT secrets.id, secrets.user_id, type, name, notes, ` + sizeColumn + `, version, ` + sharingColumns("$1") + `, ` + placementColumns("$1") + `,
+			    updated_at, deleted_at IS NOT NULL OR shares.revoked_at IS NOT NULL, shares.seq
+			FROM shares JOIN secrets ON secrets.id = shares.secret_id AND secrets.user_id = shares.owner_id
+			WHERE shares.user_id = $1 AND shares.seq > $2
+			UNION ALL
+			SELECT secrets.id, secrets.user_id, secrets.type, secrets.name, secrets.notes, ` + sizeColumn + `,
+			    secrets.version, ` + sharingColumns("$1") + `, ` + placementColumns("$1") + `,
+			    secrets.updated_at, secrets.deleted_at IS NOT NULL OR ` + collectionRole("$1") + ` IS NULL, organization_changes.seq
+			FROM organization_changes JOIN secrets ON secrets.id = organization_changes.secret_id
+			WHERE organization_changes.user_id = $1 AND organization_changes.seq > $2 AND secrets.user_id <> $1
+		) changes
+		ORDER BY seq
+		LIMIT $3
+	`
+	rows, err := r.db.Conn(ctx).Query(ctx, query, userID, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query select secret changes: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var c entities.SecretChange
+		var notes []byte
+		err = rows.Scan(
+			&c.Secret.ID, &c.Secret.UserID, &c.Secret.SecretType, &c.Secret.Name, &notes,
+			&c.Secret.Size, &c.Secret.Version, &c.Secret.Owner, &c.Secret.Permission, &c.Secret.WrappedKey,
+			&c.Secret.CollectionID, &c.Secret.OrganizationID, &c.Secret.FolderID, &c.Secret.Tags, &c.Secret.UpdatedAt, &c.Deleted, &c.Seq,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan secret change row: %v", err)
+		}
+		_, c.Secret.Notes, err = r.decrypt(ctx, c.Secret.UserID, c.Secret.ID, nil, notes)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("secret changes rows error: %v", err)
+	}
+	return changes, nil
+}
+
+func (r *SecretsRepository) Purge(ctx context.Context, userID, secretID string) error {
+	query := `
+		WITH purged AS (
+			DELETE FROM secrets WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL RETURNING id, user_id, seq
+		), ` + purgedSeqUpdate + `
+		SELECT count(*) FROM purged
+	`
+	_, err := r.db.Conn(ctx).Exec(ctx, query, secretID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to purge secret row: %v", err)
+	}
+	return nil
+}
+
+func (r *SecretsRepository) PurgeDeletedBefore(ctx context.Context, before time.Time) ([]string, error) {
+	userIDs := make([]string, 0)
+	query := `
+		WITH purged AS (
+			DELETE FROM secrets WHERE deleted_at IS NOT NULL AND deleted_at < $1 RETURNING id, user_id, seq
+		), ` + purgedSeqUpdate + `
+		SELECT user_id FROM purged
+	`
+	rows, err := r.db.Conn(ctx).Query(ctx, query, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge deleted secrets: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var userID string
+		err = rows.Scan(&userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan purged secret row: %v", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("purged secrets rows error: %v", err)
+	}
+	return userIDs, nil
+}
+
+func (r *SecretsRepository) SaveShare(ctx context.Context, share entities.Share) error {
+	query := `
+		WITH next_seq AS (
+			INSERT INTO user_sequences (user_id, seq) VALUES ($3, 1)
+			ON CONFLICT(user_id) DO UPDATE SET seq = user_sequences.seq + 1
+			RETURNING seq
+		)
+		INSERT INTO shares (secret_id, owner_id, user_id, permission, wrapped_key, seq, created_at)
+		VALUES ($1, $2, $3, $4, $5, (SELECT seq FROM next_seq), $6)
+		ON CONFLICT(secret_id, user_id) DO UPDATE SET
+		    permission = $4, wrapped_key = $5, seq = (SELECT seq FROM next_seq), created_at = $6, revoked_at = NULL
+	`
+	_, err := r.db.Conn(ctx).Exec(ctx, query, share.SecretID, share.OwnerID, share.UserID, share.Permission, share.WrappedKey, share.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save share row: %v", err)
+	}
+	return nil
+}
+
+func (r *SecretsRepository) RevokeShare(ctx context.Context, secretID, userID string) (bool, error) {
+	query := `
+		WITH active AS (
+			SELECT user_id FROM shares WHERE secret_id = $1 AND user_id = $2 AND revoked_at IS NULL
+		), next_seq AS (
+			INSERT INTO user_sequences (user_id, seq) SELECT user_id, 1 FROM active
+			ON CONFLICT(user_id) DO UPDATE SET seq = user_sequences.seq + 1
+			RETURNING seq
+		)
+		UPDATE shares SET revoked_at = $3, seq = (SELECT seq FROM next_seq)
+		WHERE secret_id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`
+	tag, err := r.db.Conn(ctx).Exec(ctx, query, secretID, userID, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke share row: %v", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *SecretsRepository) GetSecretShares(ctx context.Context, ownerID, secretID string) ([]entities.Share, error) {
+	shares := make([]entities.Share, 0)
+	query := `
+		SELECT shares.secret_id, shares.owner_id, shares.user_id, users.email, shares.permission, shares.created_at
+		FROM shares JOIN users ON users.id = shares.user_id
+		WHERE shares.secret_id = $1 AND shares.owner_id = $2 AND shares.revoked_at IS NULL
+		ORDER BY shares.created_at
+	`
+	rows, err := r.db.Conn(ctx).Query(ctx, query, secretID, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query select shares: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var share entities.Share
+		err = rows.Scan(&share.SecretID, &share.OwnerID, &share.UserID, &share.Email, &share.Permission, &share.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan share row: %v", err)
+		}
+		shares = append(shares, share)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("shares rows error: %v", err)
+	}
+	return shares, nil
+}
+
+func (r *SecretsRepository) encrypt(ctx context.Context, userID, secretID string, data []byte, notes string) ([]byte, []byte, error) {
+	key, err := r.keys.DataKey(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get data key: %v", err)
+	}
+	encData, err := envelope.Seal(key, data, additionalData(userID, secretID, "data"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encrypt secret data: %v", err)
+	}
+	encNotes, err := envelope.Seal(key, []byte(notes), additionalData(userID, secretID, "notes"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encrypt secret notes: %v", err)
+	}
+	return encData, encNotes, nil
+}
+
+func (r *SecretsRepository) decrypt(ctx context.Context, userID, secretID string, data, notes []byte) ([]byte, string, error) {
+	key, err := r.keys.DataKey(ctx, userID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get data key: %v", err)
+	}
+	if data != nil {
+		data, err = envelope.Open(key, data, additionalData(userID, secretID, "data"))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to decrypt secret data: %v", err)
+		}
+	}
+	notes, err = envelope.Open(key, notes, additionalData(userID, secretID, "notes"))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decrypt secret notes: %v", err)
+	}
+	return data, string(notes), nil
+}
+
+// legacyTable describes a table with rows written before the encryption at
+// rest, such rows are marked with encrypted = false
+type legacyTable struct {
+	name      string
+	key       string
+	secretKey string
+	dataField string
+	notes     bool
+}
+
+var legacyTables = []legacyTable{
+	{name: "secrets", key: "id", secretKey: "id", dataField: "data", notes: true},
+	{name: "secret_revisions", key: "id", secretKey: "secret_id", dataField: "data", notes: true},
+	{name: "secret_binaries", key: "secret_id", secretKey: "secret_id", dataField: "binary"},
+}
+
+// EncryptLegacyRows encrypts the rows left unencrypted by the migration to
+// the encryption at rest, the batches are locked with SKIP LOCKED so that
+// several servers can start at once
+func (r *SecretsRepository) EncryptLegacyRows(ctx context.Context, batchSize int) (int64, error) {
+	var total int64
+	for _, t := range legacyTables {
+		for {
+			count, err := r.encryptLegacyBatch(ctx, t, batchSize)
+			if err != nil {
+				return total, err
+			}
+			total += count
+			if count < int64(batchSize) {
+				break
+			}
+		}
+	}
+	return total, nil
+}
+
+func (r *SecretsRepository) encryptLegacyBatch(ctx context.Context, t legacyTable, batchSize int) (int64, error) {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	type legacyRow struct {
+		key      string
+		secretID string
+		userID   string
+		data     []byte
+		notes    []byte
+	}
+	notesColumn := "NULL::bytea"
+	if t.notes {
+		notesColumn = "notes"
+	}
+	query := fmt.Sprintf(
+		`SELECT %s, %s, user_id, data, %s FROM %s WHERE NOT encrypted LIMIT $1 FOR UPDATE SKIP LOCKED`,
+		t.key, t.secretKey, notesColumn, t.name,
+	)
+	legacyRows := make([]legacyRow, 0)
+	rows, err := tx.Query(ctx, query, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query select %s rows: %v", t.name, err)
+	}
+	for rows.Next() {
+		var row legacyRow
+		if err = rows.Scan(&row.key, &row.secretID, &row.userID, &row.data, &row.notes); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan %s row: %v", t.name, err)
+		}
+		legacyRows = append(legacyRows, row)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("%s rows error: %v", t.name, err)
+	}
+
+	for _, row := range legacyRows {
+		key, err := r.keys.DataKey(ctx, row.userID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get data key: %v", err)
+		}
+		data := row.data
+		if data != nil {
+			data, err = envelope.Seal(key, data, additionalData(row.userID, row.secretID, t.dataField))
+			if err != nil {
+				return 0, fmt.Errorf("failed to encrypt %s data: %v", t.name, err)
+			}
+		}
+		update := fmt.Sprintf(`UPDATE %s SET data = $1, encrypted = true WHERE %s = $2`, t.name, t.key)
+		args := []interface{}{data, row.key}
+		if t.notes {
+			notes, err := envelope.Seal(key, row.notes, additionalData(row.userID, row.secretID, "notes"))
+			if err != nil {
+				return 0, fmt.Errorf("failed to encrypt %s notes: %v", t.name, err)
+			}
+			update = fmt.Sprintf(`UPDATE %s SET data = $1, notes = $3, encrypted = true WHERE %s = $2`, t.name, t.key)
+			args = append(args, notes)
+		}
+		_, err = tx.Exec(ctx, update, args...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update %s row: %v", t.name, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return int64(len(legacyRows)), nil
+}
+
+func additionalData(userID, secretID, field string) []byte {
+	return []byte(userID + "/" + secretID + "/" + field)
+}
@@ -27,7 +27,7 @@ func (r *SessionsRepository) Save(ctx context.Context, s entities.Session) error
 		ON CONFLICT(id) DO UPDATE set user_id = $2, fingerprint = $3, expires_at = $4, updated_at = $6
 		RETURNING sessions.id
 	`
-	result, err := r.db.Pool.Exec(
+	result, err := r.db.Conn(ctx).Exec(
 		ctx, query, s.ID, s.UserID, s.FingerPrint, s.ExpiresAt, time.Now(), time.Now(),
 	)
 	if err != nil {
@@ -44,7 +44,7 @@ func (r *SessionsRepository) Save(ctx context.Context, s entities.Session) error
 func (r *SessionsRepository) FindByID(ctx context.Context, id string) (*entities.Session, error) {
 	var session entities.Session
 	query := `SELECT id, user_id, fingerprint, expires_at from sessions where id = $1`
-	err := r.db.Pool.QueryRow(ctx, query, id).Scan(&session.ID, &session.UserID, &session.FingerPrint, &session.ExpiresAt)
+	err := r.db.Conn(ctx).QueryRow(ctx, query, id).Scan(&session.ID, &session.UserID, &session.FingerPrint, &session.ExpiresAt)
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +55,7 @@ func (r *SessionsRepository) FindByID(ctx context.Context, id string) (*entities
 func (r *SessionsRepository) FindByFingerPrint(ctx context.Context, userId, fingerPrint string) (*entities.Session, error) {
 	var session entities.Session
 	query := `SELECT id, user_id, fingerprint, expires_at from sessions where user_id = $1 AND fingerprint $2`
-	err := r.db.Pool.QueryRow(ctx, query, userId, fingerPrint).Scan(&session.ID, &session.UserID, &session.FingerPrint, &session.ExpiresAt)
+	err := r.db.Conn(ctx).QueryRow(ctx, query, userId, fingerPrint).Scan(&session.ID, &session.UserID, &session.FingerPrint, &session.ExpiresAt)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +65,7 @@ func (r *SessionsRepository) FindByFingerPrint(ctx context.Context, userId, fing
 
 func (r *SessionsRepository) DeleteByUserAndFingerPrint(ctx context.Context, userId, fingerPrint string) error {
 	query := `DELETE from sessions where user_id = $1 and fingerprint = $2`
-	_, err := r.db.Pool.Exec(ctx, query, userId, fingerPrint)
+	_, err := r.db.Conn(ctx).Exec(ctx, query, userId, fingerPrint)
 	if err != nil {
 		return err
 	}
@@ -75,7 +75,7 @@ func (r *SessionsRepository) DeleteByUserAndFingerPrint(ctx context.Context, use
 
 func (r *SessionsRepository) DeleteByID(ctx context.Context, sessionID string) error {
 	query := `DELETE from sessions where id = $1`
-	result, err := r.db.Pool.Exec(ctx, query, sessionID)
+	result, err := r.db.Conn(ctx).Exec(ctx, query, sessionID)
 	if err != nil {
 		return err
 	}
@@ -34,7 +34,7 @@ func (r *UsersRepository) Save(ctx context.Context, u entities.User) error {
 		WHERE users.version = $10
 		RETURNING users.id, users.email
 	`
-	result, err := r.db.Pool.Exec(
+	result, err := r.db.Conn(ctx).Exec(
 		ctx, query, u.ID, u.Email, u.Password, u.VerificationCode, u.VerifiedAt, 1, time.Now(), time.Now(), u.Version+1, u.Version,
 	)
 	if err != nil {
@@ -50,7 +50,7 @@ func (r *UsersRepository) Save(ctx context.Context, u entities.User) error {
 func (r *UsersRepository) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
 	var user entities.User
 	query := `SELECT id, email, password, verification_code, verified_at, version from users where email = $1`
-	err := r.db.Pool.QueryRow(ctx, query, email).
+	err := r.db.Conn(ctx).QueryRow(ctx, query, email).
 		Scan(&user.ID, &user.Email, &user.Password, &user.VerificationCode, &user.VerifiedAt, &user.Version)
 	if err != nil {
 		return nil, err
@@ -61,11 +61,61 @@ func (r *UsersRepository) FindByEmail(ctx context.Context, email string) (*entit
 
 func (r *UsersRepository) FindByID(ctx context.Context, id string) (*entities.User, error) {
 	var user entities.User
-	query := `SELECT id, email, created_at from users where id = $1`
-	err := r.db.Pool.QueryRow(ctx, query, id).Scan(&user.ID, &user.Email)
+	query := `SELECT id, email from users where id = $1`
+	err := r.db.Conn(ctx).QueryRow(ctx, query, id).Scan(&user.ID, &user.Email)
 	if err != nil {
 		return nil, err
 	}
 
 	return &user, nil
 }
+
+func (r *UsersRepository) GetKeyParams(ctx context.Context, userID string) (*entities.KeyParams, error) {
+	var params entities.KeyParams
+	query := `SELECT kdf_salt, key_check, public_key, sealed_private_key from users where id = $1`
+	err := r.db.Conn(ctx).QueryRow(ctx, query, userID).Scan(&params.Salt, &params.KeyCheck, &params.PublicKey, &params.SealedPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &params, nil
+}
+
+func (r *UsersRepository) SetKeyParams(ctx context.Context, userID string, params entities.KeyParams) error {
+	query := `
+		UPDATE users SET kdf_salt = $2, key_check = $3, public_key = $4, sealed_private_key = $5, updated_at = $6
+		WHERE id = $1 AND kdf_salt IS NULL
+	`
+	result, err := r.db.Conn(ctx).Exec(ctx, query, userID, params.Salt, params.KeyCheck, params.PublicKey, params.SealedPrivateKey, time.Now())
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() < 1 {
+		return errors.New("failed to update record in database")
+	}
+	return nil
+}
+
+func (r *UsersRepository) SetKeyPair(ctx context.Context, userID string, publicKey, sealedPrivateKey []byte) error {
+	query := `UPDATE users SET public_key = $2, sealed_private_key = $3, updated_at = $4 WHERE id = $1 AND public_key IS NULL`
+	result, err := r.db.Conn(ctx).Exec(ctx, query, userID, publicKey, sealedPrivateKey, time.Now())
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() < 1 {
+		return errors.New("failed to update record in database")
+	}
+	return nil
+}
+
+func (r *UsersRepository) FindPublicKey(ctx context.Context, email string) (string, []byte, error) {
+	var userID string
+	var publicKey []byte
+	query := `SELECT id, public_key from users where email = $1 AND verified_at IS NOT NULL AND public_key IS NOT NULL`
+	err := r.db.Conn(ctx).QueryRow(ctx, query, email).Scan(&userID, &publicKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return userID, publicKey, nil
+}
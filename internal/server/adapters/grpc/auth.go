@@ -15,6 +15,10 @@ type Auth interface {
 	Login(ctx context.Context, email, password, fingerprint string) (*entities.Token, error)
 	Refresh(ctx context.Context, sessionID, fingerprint string) (*entities.Token, error)
 	Logout(ctx context.Context, sessionID string) error
+	GetKeyParams(ctx context.Context, userID string) (*entities.KeyParams, error)
+	SetKeyParams(ctx context.Context, userID string, params *entities.KeyParams) error
+	SetKeyPair(ctx context.Context, userID string, publicKey, sealedPrivateKey []byte) error
+	GetPublicKey(ctx context.Context, email string) (string, []byte, error)
 }
 
 type AuthServer struct {
@@ -82,3 +86,54 @@ func (a *AuthServer) Logout(ctx context.Context, in *pb.LogoutRequest) (*pb.Logo
 	}
 	return &pb.LogoutResponse{}, nil
 }
+
+func (a *AuthServer) GetKeyParams(ctx context.Context, in *pb.GetKeyParamsRequest) (*pb.GetKeyParamsResponse, error) {
+	params, err := a.auth.GetKeyParams(ctx, ctx.Value("user_id").(string))
+	if err != nil {
+		a.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.GetKeyParamsResponse{
+		Params: &pb.KeyParams{
+			Salt:             params.Salt,
+			KeyCheck:         params.KeyCheck,
+			PublicKey:        params.PublicKey,
+			SealedPrivateKey: params.SealedPrivateKey,
+		},
+	}, nil
+}
+
+func (a *AuthServer) SetKeyParams(ctx context.Context, in *pb.SetKeyParamsRequest) (*pb.SetKeyParamsResponse, error) {
+	err := a.auth.SetKeyParams(ctx, ctx.Value("user_id").(string), &entities.KeyParams{
+		Salt:             in.Params.GetSalt(),
+		KeyCheck:         in.Params.GetKeyCheck(),
+		PublicKey:        in.Params.GetPublicKey(),
+		SealedPrivateKey: in.Params.GetSealedPrivateKey(),
+	})
+	if err != nil {
+		a.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.SetKeyParamsResponse{}, nil
+}
+
+func (a *AuthServer) SetKeyPair(ctx context.Context, in *pb.SetKeyPairRequest) (*pb.SetKeyPairResponse, error) {
+	err := a.auth.SetKeyPair(ctx, ctx.Value("user_id").(string), in.PublicKey, in.SealedPrivateKey)
+	if err != nil {
+		a.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.SetKeyPairResponse{}, nil
+}
+
+func (a *AuthServer) GetPublicKey(ctx context.Context, in *pb.GetPublicKeyRequest) (*pb.GetPublicKeyResponse, error) {
+	userID, publicKey, err := a.auth.GetPublicKey(ctx, in.Email)
+	if err != nil {
+		a.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.GetPublicKeyResponse{
+		UserId:    userID,
+		PublicKey: publicKey,
+	}, nil
+}
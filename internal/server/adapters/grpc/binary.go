@@ -0,0 +1,136 @@
+package grpc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"io"
+
+	errors2 "github.com/itohin/gophkeeper/pkg/errors"
+	pb "github.com/itohin/gophkeeper/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const binaryChunkSize = 64 * 1024
+
+func (s *SecretsServer) UploadBinary(stream pb.Secrets_UploadBinaryServer) error {
+	ctx := stream.Context()
+	req, err := stream.Recv()
+	if err != nil {
+		s.log.Error(err)
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	header := req.GetSecret()
+	if header == nil {
+		return status.Error(codes.InvalidArgument, "first message must contain secret")
+	}
+	if header.Data == nil {
+		header.Data = &pb.Secret_Binary{}
+	}
+	secret, err := s.hydrator.FromProto(header, ctx.Value("user_id").(string))
+	if err != nil {
+		s.log.Error(err)
+		return status.Error(codes.Internal, err.Error())
+	}
+	savedSecret, err := s.secrets.SaveBinary(ctx, secret, &uploadReader{stream: stream, hash: sha256.New()})
+	if err != nil {
+		s.log.Error(err)
+		return status.Error(getErrorCode(err), err.Error())
+	}
+	return stream.SendAndClose(&pb.UploadBinaryResponse{
+		Id: savedSecret.ID,
+	})
+}
+
+func (s *SecretsServer) DownloadBinary(in *pb.DownloadBinaryRequest, stream pb.Secrets_DownloadBinaryServer) error {
+	ctx := stream.Context()
+	blob, content, err := s.secrets.GetUserBinary(ctx, ctx.Value("user_id").(string), in.Id)
+	if err != nil {
+		s.log.Error(err)
+		return status.Error(getErrorCode(err), err.Error())
+	}
+	defer content.Close()
+	sum, err := blob.SHA256()
+	if err != nil {
+		s.log.Error(err)
+		return status.Error(codes.Internal, err.Error())
+	}
+	err = stream.Send(&pb.DownloadBinaryResponse{
+		Payload: &pb.DownloadBinaryResponse_Info{
+			Info: &pb.BinaryInfo{
+				Size:   uint64(blob.Size),
+				Sha256: sum,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, binaryChunkSize)
+	for {
+		n, err := io.ReadFull(content, buf)
+		if n > 0 {
+			sendErr := stream.Send(&pb.DownloadBinaryResponse{
+				Payload: &pb.DownloadBinaryResponse_Chunk{
+					Chunk: &pb.BinaryChunk{
+						Data:  buf[:n],
+						Crc32: crc32.ChecksumIEEE(buf[:n]),
+					},
+				},
+			})
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+		if err != nil {
+			s.log.Error(err)
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+}
+
+type uploadReader struct {
+	stream pb.Secrets_UploadBinaryServer
+	hash   hash.Hash
+	buf    []byte
+	done   bool
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		req, err := r.stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return 0, errors2.NewInvalidArgumentError(errors.New("upload was interrupted before checksum"))
+		}
+		if err != nil {
+			return 0, err
+		}
+		switch payload := req.Payload.(type) {
+		case *pb.UploadBinaryRequest_Chunk:
+			if crc32.ChecksumIEEE(payload.Chunk.Data) != payload.Chunk.Crc32 {
+				return 0, errors2.NewInvalidArgumentError(errors.New("chunk checksum mismatch"))
+			}
+			r.hash.Write(payload.Chunk.Data)
+			r.buf = payload.Chunk.Data
+		case *pb.UploadBinaryRequest_Sha256:
+			if !bytes.Equal(r.hash.Sum(nil), payload.Sha256) {
+				return 0, errors2.NewInvalidArgumentError(errors.New("content checksum mismatch"))
+			}
+			r.done = true
+		default:
+			return 0, errors2.NewInvalidArgumentError(errors.New("unexpected upload message"))
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -19,6 +19,8 @@ import (
 type SecretHydrator interface {
 	FromProto(in *pb.Secret, userID string) (*entities.Secret, error)
 	ToProto(in *events.SecretDTO) (*pb.Secret, error)
+	RevisionToProto(in *entities.SecretRevision) (*pb.Revision, error)
+	DeletedToProto(in *entities.DeletedSecret) (*pb.DeletedSecret, error)
 }
 
 type JWTManager interface {
@@ -34,6 +36,7 @@ type Server struct {
 func NewServer(
 	auth Auth,
 	secrets Secrets,
+	organizations Organizations,
 	log logger.Logger,
 	jwtManager JWTManager,
 	hydrator SecretHydrator,
@@ -43,6 +46,9 @@ func NewServer(
 		grpc.ChainUnaryInterceptor(
 			jwt.UnaryServerInterceptor(jwtManager.GetClaims),
 		),
+		grpc.ChainStreamInterceptor(
+			jwt.StreamServerInterceptor(jwtManager.GetClaims),
+		),
 	)
 	pb.RegisterAuthServer(srv, &AuthServer{
 		auth: auth,
@@ -53,6 +59,10 @@ func NewServer(
 		hydrator: hydrator,
 		log:      log,
 	})
+	pb.RegisterOrganizationsServer(srv, &OrganizationsServer{
+		organizations: organizations,
+		log:           log,
+	})
 
 	return &Server{
 		srv: srv,
@@ -83,6 +93,14 @@ func getErrorCode(err error) codes.Code {
 	if errors.As(err, &invalidArgument) {
 		return codes.InvalidArgument
 	}
+	var conflict *errors2.ConflictError
+	if errors.As(err, &conflict) {
+		return codes.Aborted
+	}
+	var permissionDenied *errors2.PermissionDeniedError
+	if errors.As(err, &permissionDenied) {
+		return codes.PermissionDenied
+	}
 
 	return codes.Internal
 }
@@ -18,27 +18,61 @@ func UnaryServerInterceptor(f func(tokenString string) (map[string]interface{},
 			return handler(ctx, req)
 		}
 
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.PermissionDenied, "authorization denied")
-		}
-		jwtString, err := getJWTString(md)
+		authCtx, err := authorize(ctx, f)
 		if err != nil {
-			return nil, status.Error(codes.PermissionDenied, "authorization denied")
+			return nil, err
 		}
 
-		claims, err := f(jwtString)
+		return handler(authCtx, req)
+	}
+}
 
-		if int64(claims["exp"].(float64)) < time.Now().Unix() {
-			return nil, status.Error(codes.PermissionDenied, "authorization denied")
+func StreamServerInterceptor(f func(tokenString string) (map[string]interface{}, error)) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if needToSkip(info.FullMethod) {
+			return handler(srv, ss)
 		}
 
-		authCtx := context.WithValue(ctx, "user_id", claims["sub"])
+		authCtx, err := authorize(ss.Context(), f)
+		if err != nil {
+			return err
+		}
 
-		return handler(authCtx, req)
+		return handler(srv, &authServerStream{ServerStream: ss, ctx: authCtx})
 	}
 }
 
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func authorize(ctx context.Context, f func(tokenString string) (map[string]interface{}, error)) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "authorization denied")
+	}
+	jwtString, err := getJWTString(md)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, "authorization denied")
+	}
+
+	claims, err := f(jwtString)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, "authorization denied")
+	}
+
+	if int64(claims["exp"].(float64)) < time.Now().Unix() {
+		return nil, status.Error(codes.PermissionDenied, "authorization denied")
+	}
+
+	return context.WithValue(ctx, "user_id", claims["sub"]), nil
+}
+
 func getJWTString(md metadata.MD) (string, error) {
 	values := md.Get("Authorization")
 	if len(values[0]) < 1 {
@@ -0,0 +1,163 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/itohin/gophkeeper/internal/server/entities"
+	"github.com/itohin/gophkeeper/pkg/logger"
+	pb "github.com/itohin/gophkeeper/proto"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Organizations interface {
+	CreateOrganization(ctx context.Context, userID, name string, wrappedKey []byte) (string, error)
+	GetUserOrganizations(ctx context.Context, userID string) ([]entities.Organization, error)
+	InviteMember(ctx context.Context, userID string, inv *entities.Invitation) error
+	AcceptInvitation(ctx context.Context, userID, code string) (string, error)
+	GetMembers(ctx context.Context, userID, orgID string) ([]entities.Member, error)
+	SetMemberRole(ctx context.Context, userID, orgID, memberID string, role uint32) error
+	RemoveMember(ctx context.Context, userID, orgID, memberID string) error
+	CreateCollection(ctx context.Context, userID, orgID, name string) (string, error)
+	GetCollections(ctx context.Context, userID, orgID string) ([]entities.Collection, error)
+	DeleteCollection(ctx context.Context, userID, collectionID string) error
+}
+
+type OrganizationsServer struct {
+	pb.UnimplementedOrganizationsServer
+	organizations Organizations
+	log           logger.Logger
+}
+
+func (s *OrganizationsServer) CreateOrganization(ctx context.Context, in *pb.CreateOrganizationRequest) (*pb.CreateOrganizationResponse, error) {
+	id, err := s.organizations.CreateOrganization(ctx, ctx.Value("user_id").(string), in.Name, in.WrappedKey)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.CreateOrganizationResponse{Id: id}, nil
+}
+
+func (s *OrganizationsServer) ListOrganizations(ctx context.Context, in *pb.ListOrganizationsRequest) (*pb.ListOrganizationsResponse, error) {
+	orgs, err := s.organizations.GetUserOrganizations(ctx, ctx.Value("user_id").(string))
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+
+	result := make([]*pb.Organization, 0, len(orgs))
+	for _, v := range orgs {
+		result = append(result, &pb.Organization{
+			Id:         v.ID,
+			Name:       v.Name,
+			Role:       pb.Role(v.Role),
+			WrappedKey: v.WrappedKey,
+		})
+	}
+
+	return &pb.ListOrganizationsResponse{
+		Organizations: result,
+	}, nil
+}
+
+func (s *OrganizationsServer) InviteMember(ctx context.Context, in *pb.InviteMemberRequest) (*pb.InviteMemberResponse, error) {
+	err := s.organizations.InviteMember(ctx, ctx.Value("user_id").(string), &entities.Invitation{
+		OrganizationID: in.OrganizationId,
+		Email:          in.Email,
+		Role:           uint32(in.Role),
+		WrappedKey:     in.WrappedKey,
+	})
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.InviteMemberResponse{}, nil
+}
+
+func (s *OrganizationsServer) AcceptInvitation(ctx context.Context, in *pb.AcceptInvitationRequest) (*pb.AcceptInvitationResponse, error) {
+	orgID, err := s.organizations.AcceptInvitation(ctx, ctx.Value("user_id").(string), in.Code)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.AcceptInvitationResponse{OrganizationId: orgID}, nil
+}
+
+func (s *OrganizationsServer) ListMembers(ctx context.Context, in *pb.ListMembersRequest) (*pb.ListMembersResponse, error) {
+	members, err := s.organizations.GetMembers(ctx, ctx.Value("user_id").(string), in.OrganizationId)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+
+	result := make([]*pb.Member, 0, len(members))
+	for _, v := range members {
+		result = append(result, &pb.Member{
+			UserId:    v.UserID,
+			Email:     v.Email,
+			Role:      pb.Role(v.Role),
+			CreatedAt: timestamppb.New(v.CreatedAt),
+		})
+	}
+
+	return &pb.ListMembersResponse{
+		Members: result,
+	}, nil
+}
+
+func (s *OrganizationsServer) SetMemberRole(ctx context.Context, in *pb.SetMemberRoleRequest) (*pb.SetMemberRoleResponse, error) {
+	err := s.organizations.SetMemberRole(ctx, ctx.Value("user_id").(string), in.OrganizationId, in.UserId, uint32(in.Role))
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.SetMemberRoleResponse{}, nil
+}
+
+func (s *OrganizationsServer) RemoveMember(ctx context.Context, in *pb.RemoveMemberRequest) (*pb.RemoveMemberResponse, error) {
+	err := s.organizations.RemoveMember(ctx, ctx.Value("user_id").(string), in.OrganizationId, in.UserId)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.RemoveMemberResponse{}, nil
+}
+
+func (s *OrganizationsServer) CreateCollection(ctx context.Context, in *pb.CreateCollectionRequest) (*pb.CreateCollectionResponse, error) {
+	id, err := s.organizations.CreateCollection(ctx, ctx.Value("user_id").(string), in.OrganizationId, in.Name)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.CreateCollectionResponse{Id: id}, nil
+}
+
+func (s *OrganizationsServer) ListCollections(ctx context.Context, in *pb.ListCollectionsRequest) (*pb.ListCollectionsResponse, error) {
+	collections, err := s.organizations.GetCollections(ctx, ctx.Value("user_id").(string), in.OrganizationId)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+
+	result := make([]*pb.Collection, 0, len(collections))
+	for _, v := range collections {
+		result = append(result, &pb.Collection{
+			Id:             v.ID,
+			OrganizationId: v.OrganizationID,
+			Name:           v.Name,
+		})
+	}
+
+	return &pb.ListCollectionsResponse{
+		Collections: result,
+	}, nil
+}
+
+func (s *OrganizationsServer) DeleteCollection(ctx context.Context, in *pb.DeleteCollectionRequest) (*pb.DeleteCollectionResponse, error) {
+	err := s.organizations.DeleteCollection(ctx, ctx.Value("user_id").(string), in.Id)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.DeleteCollectionResponse{}, nil
+}
@@ -2,6 +2,9 @@ package grpc
 
 import (
 	"context"
+	"io"
+	"math"
+	"strings"
 
 	"github.com/itohin/gophkeeper/internal/server/entities"
 	"github.com/itohin/gophkeeper/pkg/events"
@@ -9,13 +12,32 @@ import (
 	pb "github.com/itohin/gophkeeper/proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Secrets interface {
 	Save(ctx context.Context, secret *entities.Secret) (*events.SecretDTO, error)
-	GetUserSecrets(ctx context.Context, userID string) ([]events.SecretDTO, error)
+	SearchUserSecrets(ctx context.Context, userID string, filter *entities.SecretsFilter) ([]events.SecretDTO, string, error)
 	GetUserSecret(ctx context.Context, userID, secretID string) (events.SecretDTO, error)
-	DeleteUserSecret(ctx context.Context, secret *entities.Secret) (*events.SecretDTO, error)
+	DeleteUserSecret(ctx context.Context, userID, secretID string) (*events.SecretDTO, error)
+	Update(ctx context.Context, secret *entities.Secret) (*events.SecretDTO, error)
+	GetSecretRevisions(ctx context.Context, userID, secretID string) ([]entities.SecretRevision, error)
+	RestoreSecretRevision(ctx context.Context, userID, secretID, revisionID string) (*events.SecretDTO, error)
+	GetUserDeletedSecrets(ctx context.Context, userID string) ([]entities.DeletedSecret, error)
+	RestoreUserSecret(ctx context.Context, userID, secretID string) (*events.SecretDTO, error)
+	PurgeUserSecret(ctx context.Context, userID, secretID string) error
+	SaveBinary(ctx context.Context, secret *entities.Secret, content io.Reader) (*events.SecretDTO, error)
+	GetUserBinary(ctx context.Context, userID, secretID string) (entities.Blob, io.ReadCloser, error)
+	GetChangesSince(ctx context.Context, userID string, cursor int64, pageSize int) (*entities.ChangesPage, error)
+	Share(ctx context.Context, share *entities.Share) error
+	RevokeShare(ctx context.Context, ownerID, secretID, userID string) error
+	GetSecretShares(ctx context.Context, ownerID, secretID string) ([]entities.Share, error)
+	CreateFolder(ctx context.Context, userID, name, parentID string) (string, error)
+	GetFolders(ctx context.Context, userID string) ([]entities.Folder, error)
+	DeleteFolder(ctx context.Context, userID, folderID string) error
+	GetTags(ctx context.Context, userID string) ([]string, error)
+	SaveBatch(ctx context.Context, secrets []*entities.Secret) ([]*events.SecretDTO, error)
+	DeleteBatch(ctx context.Context, userID string, secretIDs []string) error
 }
 
 type SecretsServer struct {
@@ -26,7 +48,14 @@ type SecretsServer struct {
 }
 
 func (s *SecretsServer) Search(ctx context.Context, in *pb.SearchRequest) (*pb.SearchResponse, error) {
-	userSecrets, err := s.secrets.GetUserSecrets(ctx, ctx.Value("user_id").(string))
+	filter, err := entities.NewSecretsFilter(in.Query, in.SecretType, int(in.Sort), int(in.PageSize), in.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	filter.CollectionID = in.CollectionId
+	filter.FolderID = in.FolderId
+	filter.Tag = strings.ToLower(strings.TrimSpace(in.Tag))
+	userSecrets, nextPageToken, err := s.secrets.SearchUserSecrets(ctx, ctx.Value("user_id").(string), filter)
 	if err != nil {
 		s.log.Error(err)
 		return nil, status.Error(codes.Internal, err.Error())
@@ -44,7 +73,36 @@ func (s *SecretsServer) Search(ctx context.Context, in *pb.SearchRequest) (*pb.S
 	}
 
 	return &pb.SearchResponse{
-		Secrets: secrets,
+		Secrets:       secrets,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (s *SecretsServer) SyncSince(ctx context.Context, in *pb.SyncSinceRequest) (*pb.SyncSinceResponse, error) {
+	if in.Cursor > math.MaxInt64 {
+		return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	page, err := s.secrets.GetChangesSince(ctx, ctx.Value("user_id").(string), int64(in.Cursor), int(in.PageSize))
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+
+	changes := make([]*pb.SecretChange, 0, len(page.Changes))
+	for _, v := range page.Changes {
+		secret, err := s.hydrator.ToProto(&v.Secret)
+		if err != nil {
+			s.log.Error(err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		changes = append(changes, &pb.SecretChange{Secret: secret, Deleted: v.Deleted})
+	}
+
+	return &pb.SyncSinceResponse{
+		Changes: changes,
+		Cursor:  uint64(page.Cursor),
+		HasMore: page.HasMore,
+		Resync:  page.Resync,
 	}, nil
 }
 
@@ -72,6 +130,7 @@ func (s *SecretsServer) Create(ctx context.Context, in *pb.CreateRequest) (*pb.C
 		s.log.Error(err)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	secret.IdempotencyKey = in.IdempotencyKey
 	savedSecret, err := s.secrets.Save(ctx, secret)
 	if err != nil {
 		s.log.Error(err)
@@ -82,16 +141,225 @@ func (s *SecretsServer) Create(ctx context.Context, in *pb.CreateRequest) (*pb.C
 	}, nil
 }
 
+func (s *SecretsServer) BatchCreate(ctx context.Context, in *pb.BatchCreateRequest) (*pb.BatchCreateResponse, error) {
+	secrets := make([]*entities.Secret, 0, len(in.Secrets))
+	for _, v := range in.Secrets {
+		secret, err := s.hydrator.FromProto(v, ctx.Value("user_id").(string))
+		if err != nil {
+			s.log.Error(err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		secrets = append(secrets, secret)
+	}
+	saved, err := s.secrets.SaveBatch(ctx, secrets)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	ids := make([]string, 0, len(saved))
+	for _, v := range saved {
+		ids = append(ids, v.ID)
+	}
+	return &pb.BatchCreateResponse{
+		Ids: ids,
+	}, nil
+}
+
 func (s *SecretsServer) Delete(ctx context.Context, in *pb.DeleteRequest) (*pb.DeleteResponse, error) {
+	_, err := s.secrets.DeleteUserSecret(ctx, ctx.Value("user_id").(string), in.Id)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.DeleteResponse{}, err
+}
+
+func (s *SecretsServer) BatchDelete(ctx context.Context, in *pb.BatchDeleteRequest) (*pb.BatchDeleteResponse, error) {
+	err := s.secrets.DeleteBatch(ctx, ctx.Value("user_id").(string), in.Ids)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.BatchDeleteResponse{}, nil
+}
+
+func (s *SecretsServer) Update(ctx context.Context, in *pb.UpdateRequest) (*pb.UpdateResponse, error) {
 	secret, err := s.hydrator.FromProto(in.Secret, ctx.Value("user_id").(string))
 	if err != nil {
 		s.log.Error(err)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	_, err = s.secrets.DeleteUserSecret(ctx, secret)
+	savedSecret, err := s.secrets.Update(ctx, secret)
 	if err != nil {
 		s.log.Error(err)
 		return nil, status.Error(getErrorCode(err), err.Error())
 	}
-	return &pb.DeleteResponse{}, err
+	return &pb.UpdateResponse{
+		Version: uint64(savedSecret.Version),
+	}, nil
+}
+
+func (s *SecretsServer) ListRevisions(ctx context.Context, in *pb.ListRevisionsRequest) (*pb.ListRevisionsResponse, error) {
+	revisions, err := s.secrets.GetSecretRevisions(ctx, ctx.Value("user_id").(string), in.SecretId)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+
+	result := make([]*pb.Revision, 0, len(revisions))
+	for _, v := range revisions {
+		revision, err := s.hydrator.RevisionToProto(&v)
+		if err != nil {
+			s.log.Error(err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		result = append(result, revision)
+	}
+
+	return &pb.ListRevisionsResponse{
+		Revisions: result,
+	}, nil
+}
+
+func (s *SecretsServer) RestoreRevision(ctx context.Context, in *pb.RestoreRevisionRequest) (*pb.RestoreRevisionResponse, error) {
+	_, err := s.secrets.RestoreSecretRevision(ctx, ctx.Value("user_id").(string), in.SecretId, in.RevisionId)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.RestoreRevisionResponse{}, nil
+}
+
+func (s *SecretsServer) ListDeleted(ctx context.Context, in *pb.ListDeletedRequest) (*pb.ListDeletedResponse, error) {
+	deleted, err := s.secrets.GetUserDeletedSecrets(ctx, ctx.Value("user_id").(string))
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+
+	result := make([]*pb.DeletedSecret, 0, len(deleted))
+	for _, v := range deleted {
+		secret, err := s.hydrator.DeletedToProto(&v)
+		if err != nil {
+			s.log.Error(err)
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		result = append(result, secret)
+	}
+
+	return &pb.ListDeletedResponse{
+		Secrets: result,
+	}, nil
+}
+
+func (s *SecretsServer) Restore(ctx context.Context, in *pb.RestoreRequest) (*pb.RestoreResponse, error) {
+	_, err := s.secrets.RestoreUserSecret(ctx, ctx.Value("user_id").(string), in.Id)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.RestoreResponse{}, nil
+}
+
+func (s *SecretsServer) Purge(ctx context.Context, in *pb.PurgeRequest) (*pb.PurgeResponse, error) {
+	err := s.secrets.PurgeUserSecret(ctx, ctx.Value("user_id").(string), in.Id)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.PurgeResponse{}, nil
+}
+
+func (s *SecretsServer) Share(ctx context.Context, in *pb.ShareRequest) (*pb.ShareResponse, error) {
+	err := s.secrets.Share(ctx, &entities.Share{
+		SecretID:   in.SecretId,
+		OwnerID:    ctx.Value("user_id").(string),
+		Email:      in.Email,
+		Permission: uint32(in.Permission),
+		WrappedKey: in.WrappedKey,
+	})
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.ShareResponse{}, nil
+}
+
+func (s *SecretsServer) RevokeShare(ctx context.Context, in *pb.RevokeShareRequest) (*pb.RevokeShareResponse, error) {
+	err := s.secrets.RevokeShare(ctx, ctx.Value("user_id").(string), in.SecretId, in.UserId)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.RevokeShareResponse{}, nil
+}
+
+func (s *SecretsServer) ListShares(ctx context.Context, in *pb.ListSharesRequest) (*pb.ListSharesResponse, error) {
+	shares, err := s.secrets.GetSecretShares(ctx, ctx.Value("user_id").(string), in.SecretId)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+
+	result := make([]*pb.Share, 0, len(shares))
+	for _, v := range shares {
+		result = append(result, &pb.Share{
+			UserId:     v.UserID,
+			Email:      v.Email,
+			Permission: pb.Permission(v.Permission),
+			CreatedAt:  timestamppb.New(v.CreatedAt),
+		})
+	}
+
+	return &pb.ListSharesResponse{
+		Shares: result,
+	}, nil
+}
+
+func (s *SecretsServer) CreateFolder(ctx context.Context, in *pb.CreateFolderRequest) (*pb.CreateFolderResponse, error) {
+	id, err := s.secrets.CreateFolder(ctx, ctx.Value("user_id").(string), in.Name, in.ParentId)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.CreateFolderResponse{Id: id}, nil
+}
+
+func (s *SecretsServer) ListFolders(ctx context.Context, in *pb.ListFoldersRequest) (*pb.ListFoldersResponse, error) {
+	folders, err := s.secrets.GetFolders(ctx, ctx.Value("user_id").(string))
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+
+	result := make([]*pb.Folder, 0, len(folders))
+	for _, v := range folders {
+		result = append(result, &pb.Folder{
+			Id:       v.ID,
+			ParentId: v.ParentID,
+			Name:     v.Name,
+		})
+	}
+
+	return &pb.ListFoldersResponse{
+		Folders: result,
+	}, nil
+}
+
+func (s *SecretsServer) DeleteFolder(ctx context.Context, in *pb.DeleteFolderRequest) (*pb.DeleteFolderResponse, error) {
+	err := s.secrets.DeleteFolder(ctx, ctx.Value("user_id").(string), in.Id)
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.DeleteFolderResponse{}, nil
+}
+
+func (s *SecretsServer) ListTags(ctx context.Context, in *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	tags, err := s.secrets.GetTags(ctx, ctx.Value("user_id").(string))
+	if err != nil {
+		s.log.Error(err)
+		return nil, status.Error(getErrorCode(err), err.Error())
+	}
+	return &pb.ListTagsResponse{Tags: tags}, nil
 }
@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/itohin/gophkeeper/pkg/logger"
+)
+
+type IdempotencyKeysPurger interface {
+	PurgeExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+}
+
+type IdempotencyKeysCleaner struct {
+	secrets IdempotencyKeysPurger
+	period  time.Duration
+	log     logger.Logger
+}
+
+func NewIdempotencyKeysCleaner(secrets IdempotencyKeysPurger, period time.Duration, l logger.Logger) *IdempotencyKeysCleaner {
+	return &IdempotencyKeysCleaner{
+		secrets: secrets,
+		period:  period,
+		log:     l,
+	}
+}
+
+func (c *IdempotencyKeysCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.period)
+	defer ticker.Stop()
+
+	for {
+		c.purge(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *IdempotencyKeysCleaner) purge(ctx context.Context) {
+	purged, err := c.secrets.PurgeExpiredIdempotencyKeys(ctx)
+	if err != nil {
+		c.log.Error(err)
+		return
+	}
+	if purged > 0 {
+		c.log.Infof("purged %d expired idempotency keys", purged)
+	}
+}
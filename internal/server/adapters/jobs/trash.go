@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/itohin/gophkeeper/pkg/logger"
+)
+
+type TrashPurger interface {
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type TrashCleaner struct {
+	secrets   TrashPurger
+	retention time.Duration
+	period    time.Duration
+	log       logger.Logger
+}
+
+func NewTrashCleaner(secrets TrashPurger, retention, period time.Duration, l logger.Logger) *TrashCleaner {
+	return &TrashCleaner{
+		secrets:   secrets,
+		retention: retention,
+		period:    period,
+		log:       l,
+	}
+}
+
+func (c *TrashCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.period)
+	defer ticker.Stop()
+
+	for {
+		c.purge(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *TrashCleaner) purge(ctx context.Context) {
+	purged, err := c.secrets.PurgeDeletedBefore(ctx, time.Now().Add(-c.retention))
+	if err != nil {
+		c.log.Error(err)
+		return
+	}
+	if purged > 0 {
+		c.log.Infof("purged %d secrets from trash", purged)
+	}
+}
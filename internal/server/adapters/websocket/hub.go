@@ -96,20 +96,25 @@ func (h *Hub) broadcast(s *events.SecretEvent) error {
 	h.mx.Lock()
 	defer h.mx.Unlock()
 
-	userID := s.Secret.UserID
 	message, err := json.Marshal(s)
 	if err != nil {
 		return err
 	}
 
-	devices, ok := h.clients[userID]
-	if !ok {
-		return fmt.Errorf("clients of userID %s not found", userID)
+	recipients := s.Recipients
+	if len(recipients) == 0 {
+		recipients = []string{s.Secret.UserID}
 	}
-
-	for _, c := range devices {
-		if err := wsutil.WriteServerText(c.conn, message); err != nil {
-			return fmt.Errorf("failed to send a message to the client id %s deviceId %s: %v", c.id, c.deviceID, err)
+	for _, userID := range recipients {
+		// offline users catch up through sync on the next connect
+		devices, ok := h.clients[userID]
+		if !ok {
+			continue
+		}
+		for _, c := range devices {
+			if err := wsutil.WriteServerText(c.conn, message); err != nil {
+				return fmt.Errorf("failed to send a message to the client id %s deviceId %s: %v", c.id, c.deviceID, err)
+			}
 		}
 	}
 	return nil
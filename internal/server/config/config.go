@@ -9,19 +9,28 @@ import (
 )
 
 const (
-	DBDsn            = "DbDsn"
-	DBMigrationsPath = "DbMigrationPath"
-	JWTSignature     = "JwtSignature"
-	JWTAccessTTL     = "JwtAccessTtl"
-	JWTRefreshTTL    = "JwtRefreshTtl"
-	WebSocketAddress = "WebSocketAddress"
-	SSLCertPath      = "SSLCertPath"
-	SSLKeyPath       = "SSLKeyPath"
-	MailLogin        = "MailLogin"
-	MailPassword     = "MailPassword"
-	MailHost         = "MailHost"
-	MailPort         = "MailPort"
-	GRPCAddress      = "GrpcAddress"
+	DBDsn              = "DbDsn"
+	DBMigrationsPath   = "DbMigrationPath"
+	JWTSignature       = "JwtSignature"
+	JWTAccessTTL       = "JwtAccessTtl"
+	JWTRefreshTTL      = "JwtRefreshTtl"
+	WebSocketAddress   = "WebSocketAddress"
+	SSLCertPath        = "SSLCertPath"
+	SSLKeyPath         = "SSLKeyPath"
+	MailLogin          = "MailLogin"
+	MailPassword       = "MailPassword"
+	MailHost           = "MailHost"
+	MailPort           = "MailPort"
+	GRPCAddress        = "GrpcAddress"
+	TrashRetention     = "TrashRetention"
+	TrashPurgePeriod   = "TrashPurgePeriod"
+	EncryptionKEK      = "EncryptionKek"
+	EncryptionKEKID    = "EncryptionKekId"
+	EncryptionRetired  = "EncryptionRetiredKeks"
+	EncryptionCacheTTL = "EncryptionKeyCacheTtl"
+	BlobStorePath      = "BlobStorePath"
+	IdempotencyKeyTTL  = "IdempotencyKeyTtl"
+	IdempotencyPurge   = "IdempotencyPurgePeriod"
 )
 
 type DB struct {
@@ -55,13 +64,41 @@ type GRPC struct {
 	Address string
 }
 
+type Trash struct {
+	Retention   time.Duration
+	PurgePeriod time.Duration
+}
+
+// Encryption holds the primary key encryption key, which wraps every new data
+// key, and the retired ones as a comma separated list of id:key pairs, which
+// are only used to unwrap the data keys not yet re-wrapped
+type Encryption struct {
+	KEK         string
+	KEKID       string
+	RetiredKEKs string
+	KeyCacheTTL time.Duration
+}
+
+type BlobStore struct {
+	Path string
+}
+
+type Idempotency struct {
+	KeyTTL      time.Duration
+	PurgePeriod time.Duration
+}
+
 type AppConfig struct {
-	DB        *DB
-	JWT       *JWT
-	WebSocket *WebSocket
-	SSL       *SSL
-	Mail      *Mail
-	GRPC      *GRPC
+	DB          *DB
+	JWT         *JWT
+	WebSocket   *WebSocket
+	SSL         *SSL
+	Mail        *Mail
+	GRPC        *GRPC
+	Trash       *Trash
+	Encryption  *Encryption
+	BlobStore   *BlobStore
+	Idempotency *Idempotency
 }
 
 func ReadConfig() *AppConfig {
@@ -95,6 +132,23 @@ func ReadConfig() *AppConfig {
 		GRPC: &GRPC{
 			Address: viper.GetString(GRPCAddress),
 		},
+		Trash: &Trash{
+			Retention:   viper.GetDuration(TrashRetention),
+			PurgePeriod: viper.GetDuration(TrashPurgePeriod),
+		},
+		Encryption: &Encryption{
+			KEK:         viper.GetString(EncryptionKEK),
+			KEKID:       viper.GetString(EncryptionKEKID),
+			RetiredKEKs: viper.GetString(EncryptionRetired),
+			KeyCacheTTL: viper.GetDuration(EncryptionCacheTTL),
+		},
+		BlobStore: &BlobStore{
+			Path: viper.GetString(BlobStorePath),
+		},
+		Idempotency: &Idempotency{
+			KeyTTL:      viper.GetDuration(IdempotencyKeyTTL),
+			PurgePeriod: viper.GetDuration(IdempotencyPurge),
+		},
 	}
 }
 
@@ -112,6 +166,15 @@ func readEnv() {
 	_ = viper.BindEnv(MailHost, "MAIL_HOST")
 	_ = viper.BindEnv(MailPort, "MAIL_PORT")
 	_ = viper.BindEnv(GRPCAddress, "GRPC_ADDRESS")
+	_ = viper.BindEnv(TrashRetention, "TRASH_RETENTION")
+	_ = viper.BindEnv(TrashPurgePeriod, "TRASH_PURGE_PERIOD")
+	_ = viper.BindEnv(EncryptionKEK, "ENCRYPTION_KEK")
+	_ = viper.BindEnv(EncryptionKEKID, "ENCRYPTION_KEK_ID")
+	_ = viper.BindEnv(EncryptionRetired, "ENCRYPTION_RETIRED_KEKS")
+	_ = viper.BindEnv(EncryptionCacheTTL, "ENCRYPTION_KEY_CACHE_TTL")
+	_ = viper.BindEnv(BlobStorePath, "BLOB_STORE_PATH")
+	_ = viper.BindEnv(IdempotencyKeyTTL, "IDEMPOTENCY_KEY_TTL")
+	_ = viper.BindEnv(IdempotencyPurge, "IDEMPOTENCY_PURGE_PERIOD")
 }
 
 func readFlags() {
@@ -130,6 +193,15 @@ func readFlags() {
 	pflag.String("mail-host", "", "Mail host")
 	pflag.String("mail-port", "", "Mail port")
 	pflag.String("grpc-addr", "", "GRPC server address")
+	pflag.Duration("trash-ret", 720*time.Hour, "How long deleted secrets are kept in trash")
+	pflag.Duration("trash-purge", time.Hour, "How often expired trash is purged")
+	pflag.String("kek", "", "Base64 encoded key encryption key")
+	pflag.String("kek-id", "", "Key encryption key id")
+	pflag.String("retired-keks", "", "Comma separated id:key pairs of key encryption keys used only to unwrap data keys")
+	pflag.Duration("key-cache-ttl", 10*time.Minute, "How long unwrapped data keys are cached")
+	pflag.String("blob-path", "", "Path to binary secrets storage")
+	pflag.Duration("idempotency-ttl", 24*time.Hour, "How long idempotency keys of created secrets are kept")
+	pflag.Duration("idempotency-purge", time.Hour, "How often expired idempotency keys are purged")
 
 	pflag.Parse()
 
@@ -146,6 +218,15 @@ func readFlags() {
 	_ = viper.BindPFlag(MailHost, pflag.Lookup("mail-host"))
 	_ = viper.BindPFlag(MailPort, pflag.Lookup("mail-port"))
 	_ = viper.BindPFlag(GRPCAddress, pflag.Lookup("grpc-addr"))
+	_ = viper.BindPFlag(TrashRetention, pflag.Lookup("trash-ret"))
+	_ = viper.BindPFlag(TrashPurgePeriod, pflag.Lookup("trash-purge"))
+	_ = viper.BindPFlag(EncryptionKEK, pflag.Lookup("kek"))
+	_ = viper.BindPFlag(EncryptionKEKID, pflag.Lookup("kek-id"))
+	_ = viper.BindPFlag(EncryptionRetired, pflag.Lookup("retired-keks"))
+	_ = viper.BindPFlag(EncryptionCacheTTL, pflag.Lookup("key-cache-ttl"))
+	_ = viper.BindPFlag(BlobStorePath, pflag.Lookup("blob-path"))
+	_ = viper.BindPFlag(IdempotencyKeyTTL, pflag.Lookup("idempotency-ttl"))
+	_ = viper.BindPFlag(IdempotencyPurge, pflag.Lookup("idempotency-purge"))
 }
 
 func setDefaults() {
@@ -162,4 +243,13 @@ func setDefaults() {
 	viper.SetDefault(MailHost, "localhost")
 	viper.SetDefault(MailPort, "1025")
 	viper.SetDefault(GRPCAddress, ":3200")
+	viper.SetDefault(TrashRetention, 720*time.Hour)
+	viper.SetDefault(TrashPurgePeriod, time.Hour)
+	viper.SetDefault(EncryptionKEK, "")
+	viper.SetDefault(EncryptionKEKID, "dev")
+	viper.SetDefault(EncryptionRetired, "")
+	viper.SetDefault(EncryptionCacheTTL, 10*time.Minute)
+	viper.SetDefault(BlobStorePath, "data/blobs")
+	viper.SetDefault(IdempotencyKeyTTL, 24*time.Hour)
+	viper.SetDefault(IdempotencyPurge, time.Hour)
 }
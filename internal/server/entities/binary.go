@@ -0,0 +1,9 @@
+package entities
+
+type BinaryContent struct {
+	SecretID string
+	UserID   string
+	Data     []byte
+	Size     int64
+	SHA256   []byte
+}
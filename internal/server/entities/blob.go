@@ -0,0 +1,12 @@
+package entities
+
+import "encoding/hex"
+
+type Blob struct {
+	Ref  string
+	Size int64
+}
+
+func (b Blob) SHA256() ([]byte, error) {
+	return hex.DecodeString(b.Ref)
+}
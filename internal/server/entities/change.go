@@ -0,0 +1,16 @@
+package entities
+
+import "github.com/itohin/gophkeeper/pkg/events"
+
+type SecretChange struct {
+	Secret  events.SecretDTO
+	Deleted bool
+	Seq     int64
+}
+
+type ChangesPage struct {
+	Changes []SecretChange
+	Cursor  int64
+	HasMore bool
+	Resync  bool
+}
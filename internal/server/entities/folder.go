@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	MaxTags      = 20
+	MaxTagLength = 64
+)
+
+type Folder struct {
+	ID        string
+	UserID    string
+	ParentID  string
+	Name      string
+	CreatedAt time.Time
+}
+
+// NormalizeTags trims and lowercases tags, drops empty and repeated ones and
+// sorts the rest, so that tag filters do not depend on how a tag was typed
+func NormalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		if strings.ContainsRune(tag, ',') {
+			return nil, fmt.Errorf("tag %v can not contain commas", tag)
+		}
+		if len([]rune(tag)) > MaxTagLength {
+			return nil, fmt.Errorf("tag %v is longer than %d characters", tag, MaxTagLength)
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > MaxTags {
+		return nil, fmt.Errorf("secret can not have more than %d tags", MaxTags)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tooMany := make([]string, 0, MaxTags+1)
+	for i := 0; i <= MaxTags; i++ {
+		tooMany = append(tooMany, "tag"+strconv.Itoa(i))
+	}
+
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "empty",
+			tags:    nil,
+			want:    []string{},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "trimmed, lowercased, deduplicated and sorted",
+			tags:    []string{" Work ", "банк", "work", "", "Банк"},
+			want:    []string{"work", "банк"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "too long",
+			tags:    []string{strings.Repeat("я", MaxTagLength+1)},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "comma",
+			tags:    []string{"a,b"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "too many",
+			tags:    tooMany,
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeTags(tt.tags)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			if err == nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	RoleOwner = iota
+	RoleAdmin
+	RoleMember
+	RoleViewer
+)
+
+const InvitationTTL = 7 * 24 * time.Hour
+
+var ErrInvitationNotFound = errors.New("invitation not found")
+
+type Organization struct {
+	ID         string
+	Name       string
+	Role       uint32
+	WrappedKey []byte
+	CreatedAt  time.Time
+}
+
+type Member struct {
+	OrganizationID string
+	UserID         string
+	Email          string
+	Role           uint32
+	WrappedKey     []byte
+	CreatedAt      time.Time
+}
+
+type Invitation struct {
+	ID             string
+	OrganizationID string
+	Email          string
+	Role           uint32
+	WrappedKey     []byte
+	Code           string
+	InvitedBy      string
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+}
+
+type Collection struct {
+	ID               string
+	OrganizationID   string
+	OrganizationName string
+	Name             string
+	CreatedAt        time.Time
+}
+
+func IsValidRole(role uint32) bool {
+	return role <= RoleViewer
+}
+
+// CanManage reports whether the role may manage members and collections
+func CanManage(role uint32) bool {
+	return role <= RoleAdmin
+}
+
+// CanWrite reports whether the role may change secrets of the organization
+func CanWrite(role uint32) bool {
+	return role <= RoleMember
+}
+
+// SharePermission maps the role in an organization to the access to its secrets
+func SharePermission(role uint32) uint32 {
+	if CanWrite(role) {
+		return PermissionReadWrite
+	}
+	return PermissionRead
+}
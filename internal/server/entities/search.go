@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	SortNameAsc = iota
+	SortNameDesc
+	SortUpdatedDesc
+	SortUpdatedAsc
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+type SecretsFilter struct {
+	Query        string
+	SecretType   uint32
+	Sort         int
+	PageSize     int
+	Cursor       *Cursor
+	CollectionID string
+	FolderID     string
+	Tag          string
+}
+
+type Cursor struct {
+	Sort      int       `json:"s"`
+	Name      string    `json:"n,omitempty"`
+	UpdatedAt time.Time `json:"u,omitempty"`
+	ID        string    `json:"i"`
+}
+
+func NewSecretsFilter(query string, secretType uint32, sort, pageSize int, pageToken string) (*SecretsFilter, error) {
+	if sort < SortNameAsc || sort > SortUpdatedAsc {
+		return nil, errors.New("unknown sort order")
+	}
+	if secretType > TypeSSHKey {
+		return nil, errors.New("unknown secret type")
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	f := &SecretsFilter{
+		Query:      query,
+		SecretType: secretType,
+		Sort:       sort,
+		PageSize:   pageSize,
+	}
+	if pageToken == "" {
+		return f, nil
+	}
+	cursor, err := DecodeCursor(pageToken)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.Sort != sort {
+		return nil, errors.New("page token does not match sort order")
+	}
+	f.Cursor = cursor
+	return f, nil
+}
+
+func (f *SecretsFilter) IsDescending() bool {
+	return f.Sort == SortNameDesc || f.Sort == SortUpdatedDesc
+}
+
+func (f *SecretsFilter) IsByName() bool {
+	return f.Sort == SortNameAsc || f.Sort == SortNameDesc
+}
+
+func (c *Cursor) Encode() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func DecodeCursor(token string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("malformed page token")
+	}
+	var c Cursor
+	err = json.Unmarshal(b, &c)
+	if err != nil || c.ID == "" {
+		return nil, errors.New("malformed page token")
+	}
+	return &c, nil
+}
@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	c := &Cursor{
+		Sort:      SortUpdatedDesc,
+		UpdatedAt: time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC),
+		ID:        "uuid-001",
+	}
+	token, err := c.Encode()
+	assert.NoError(t, err)
+
+	got, err := DecodeCursor(token)
+	assert.NoError(t, err)
+	assert.Equal(t, c, got)
+}
+
+func TestNewSecretsFilter(t *testing.T) {
+	nameToken, _ := (&Cursor{Sort: SortNameAsc, Name: "first", ID: "uuid-001"}).Encode()
+
+	tests := []struct {
+		name       string
+		secretType uint32
+		sort       int
+		pageSize   int
+		pageToken  string
+		wantSize   int
+		wantCursor bool
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "default page size",
+			wantSize: DefaultPageSize,
+			wantErr:  assert.NoError,
+		},
+		{
+			name:     "page size capped",
+			pageSize: MaxPageSize + 1,
+			wantSize: MaxPageSize,
+			wantErr:  assert.NoError,
+		},
+		{
+			name:       "with page token",
+			pageSize:   10,
+			pageToken:  nameToken,
+			wantSize:   10,
+			wantCursor: true,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:    "unknown sort",
+			sort:    SortUpdatedAsc + 1,
+			wantErr: assert.Error,
+		},
+		{
+			name:       "unknown secret type",
+			secretType: TypeSSHKey + 1,
+			wantErr:    assert.Error,
+		},
+		{
+			name:      "malformed page token",
+			pageToken: "not a token",
+			wantErr:   assert.Error,
+		},
+		{
+			name:      "page token of another sort",
+			sort:      SortUpdatedDesc,
+			pageToken: nameToken,
+			wantErr:   assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewSecretsFilter("", tt.secretType, tt.sort, tt.pageSize, tt.pageToken)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+			assert.Equal(t, tt.wantSize, f.PageSize)
+			assert.Equal(t, tt.wantCursor, f.Cursor != nil)
+		})
+	}
+}
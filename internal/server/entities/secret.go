@@ -2,6 +2,8 @@ package entities
 
 import (
 	"database/sql"
+	"errors"
+	"time"
 )
 
 const (
@@ -9,16 +11,71 @@ const (
 	TypePassword
 	TypeBinary
 	TypeCard
+	TypeTOTP
+	TypeSSHKey
+)
+
+const (
+	// MaxBatchSize limits the number of secrets created or deleted in one batch
+	MaxBatchSize            = 100
+	MaxIdempotencyKeyLength = 64
+)
+
+var (
+	ErrVersionConflict = errors.New("secret version conflict")
+	// ErrIdempotencyKeyReused is returned when a key comes with another payload
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with another payload")
 )
 
 type Secret struct {
+	ID           string
+	Name         string
+	SecretType   uint32
+	Notes        string
+	Data         interface{}
+	UserID       string
+	DeletedAt    sql.NullTime
+	BlobRef      string
+	BlobSize     int64
+	Version      int64
+	OwnerKey     []byte
+	CollectionID string
+	// FolderID is nil when the secret stays in its current folder,
+	// an empty string moves it to the root
+	FolderID *string
+	// Tags is nil when the tags of the secret stay unchanged
+	Tags []string
+	// IdempotencyKey makes a retried create return the secret created first
+	IdempotencyKey string
+}
+
+type SecretRevision struct {
 	ID         string
+	SecretID   string
+	UserID     string
 	Name       string
 	SecretType uint32
 	Notes      string
-	Data       interface{}
-	UserID     string
-	DeletedAt  sql.NullTime
+	Data       []byte
+	BlobRef    string
+	BlobSize   int64
+	CreatedAt  time.Time
+}
+
+type DeletedSecret struct {
+	ID           string
+	UserID       string
+	Name         string
+	SecretType   uint32
+	Notes        string
+	Data         []byte
+	Version      int64
+	CollectionID string
+	DeletedAt    time.Time
+}
+
+type Sealed struct {
+	Sealed []byte
 }
 
 type Text struct {
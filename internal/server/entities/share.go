@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+const (
+	PermissionOwner = iota
+	PermissionRead
+	PermissionReadWrite
+)
+
+type Share struct {
+	SecretID   string
+	OwnerID    string
+	UserID     string
+	Email      string
+	Permission uint32
+	WrappedKey []byte
+	CreatedAt  time.Time
+}
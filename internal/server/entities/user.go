@@ -17,6 +17,17 @@ type User struct {
 	Version          int
 }
 
+type KeyParams struct {
+	Salt             []byte
+	KeyCheck         []byte
+	PublicKey        []byte
+	SealedPrivateKey []byte
+}
+
+func (k *KeyParams) IsEmpty() bool {
+	return len(k.Salt) == 0 && len(k.KeyCheck) == 0
+}
+
 func NewUser(id [16]byte, email, password, otp string) *User {
 	return &User{
 		ID:               id,
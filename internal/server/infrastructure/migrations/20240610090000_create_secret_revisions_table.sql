@@ -0,0 +1,21 @@
+-- +goose Up
+create table if not exists public.secret_revisions
+(
+    id         uuid         not null
+        primary key,
+    secret_id  uuid         not null
+        references public.secrets (id)
+            on delete cascade,
+    user_id    uuid         not null,
+    type       smallint     not null,
+    name       varchar(255) not null,
+    data       jsonb,
+    notes      varchar(255),
+    created_at timestamp    not null
+);
+create index if not exists idx_secret_revisions_secret_id_created_at
+    on public.secret_revisions (secret_id, created_at);
+
+-- +goose Down
+drop index if exists idx_secret_revisions_secret_id_created_at;
+drop table if exists public.secret_revisions;
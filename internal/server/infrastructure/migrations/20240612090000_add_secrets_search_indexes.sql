@@ -0,0 +1,15 @@
+-- +goose Up
+create extension if not exists pg_trgm;
+create index if not exists idx_secrets_user_id_name
+    on public.secrets (user_id, lower(name), id)
+    where deleted_at is null;
+create index if not exists idx_secrets_user_id_updated_at
+    on public.secrets (user_id, updated_at, id)
+    where deleted_at is null;
+create index if not exists idx_secrets_name_trgm
+    on public.secrets using gin (name gin_trgm_ops);
+
+-- +goose Down
+drop index if exists idx_secrets_name_trgm;
+drop index if exists idx_secrets_user_id_updated_at;
+drop index if exists idx_secrets_user_id_name;
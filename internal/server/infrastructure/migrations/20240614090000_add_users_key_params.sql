@@ -0,0 +1,9 @@
+-- +goose Up
+alter table public.users
+    add column if not exists kdf_salt  bytea,
+    add column if not exists key_check bytea;
+
+-- +goose Down
+alter table public.users
+    drop column if exists key_check,
+    drop column if exists kdf_salt;
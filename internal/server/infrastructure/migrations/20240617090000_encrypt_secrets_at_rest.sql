@@ -0,0 +1,34 @@
+-- +goose Up
+create table if not exists public.user_keys
+(
+    user_id     uuid        not null
+        primary key,
+    wrapped_key bytea       not null,
+    kek_id      varchar(64) not null,
+    created_at  timestamp(0),
+    updated_at  timestamp(0)
+);
+create index if not exists idx_user_keys_kek_id
+    on public.user_keys (kek_id);
+
+alter table public.secrets
+    alter column data type bytea using convert_to(data::text, 'UTF8'),
+    alter column notes type bytea using convert_to(notes, 'UTF8'),
+    add column if not exists encrypted boolean not null default false;
+alter table public.secret_revisions
+    alter column data type bytea using convert_to(data::text, 'UTF8'),
+    alter column notes type bytea using convert_to(notes, 'UTF8'),
+    add column if not exists encrypted boolean not null default false;
+
+-- +goose Down
+alter table public.secret_revisions
+    drop column if exists encrypted,
+    alter column notes type varchar(255) using convert_from(notes, 'UTF8'),
+    alter column data type jsonb using convert_from(data, 'UTF8')::jsonb;
+alter table public.secrets
+    drop column if exists encrypted,
+    alter column notes type varchar(255) using convert_from(notes, 'UTF8'),
+    alter column data type jsonb using convert_from(data, 'UTF8')::jsonb;
+
+drop index if exists idx_user_keys_kek_id;
+drop table if exists public.user_keys;
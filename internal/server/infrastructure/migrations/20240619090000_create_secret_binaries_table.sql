@@ -0,0 +1,17 @@
+-- +goose Up
+create table if not exists public.secret_binaries
+(
+    secret_id  uuid      not null
+        primary key
+        references public.secrets (id)
+            on delete cascade,
+    user_id    uuid      not null,
+    data       bytea     not null,
+    size       bigint    not null,
+    sha256     bytea     not null,
+    encrypted  boolean   not null default false,
+    updated_at timestamp not null
+);
+
+-- +goose Down
+drop table if exists public.secret_binaries;
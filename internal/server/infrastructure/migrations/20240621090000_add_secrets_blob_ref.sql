@@ -0,0 +1,23 @@
+-- +goose Up
+alter table public.secrets
+    add column if not exists blob_ref  varchar(64),
+    add column if not exists blob_size bigint;
+alter table public.secret_revisions
+    add column if not exists blob_ref  varchar(64),
+    add column if not exists blob_size bigint;
+create index if not exists idx_secrets_user_id_blob_ref
+    on public.secrets (user_id, blob_ref)
+    where blob_ref is not null;
+create index if not exists idx_secret_revisions_user_id_blob_ref
+    on public.secret_revisions (user_id, blob_ref)
+    where blob_ref is not null;
+
+-- +goose Down
+drop index if exists idx_secret_revisions_user_id_blob_ref;
+drop index if exists idx_secrets_user_id_blob_ref;
+alter table public.secret_revisions
+    drop column if exists blob_size,
+    drop column if exists blob_ref;
+alter table public.secrets
+    drop column if exists blob_size,
+    drop column if exists blob_ref;
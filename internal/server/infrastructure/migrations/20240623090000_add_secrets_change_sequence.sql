@@ -0,0 +1,30 @@
+-- +goose Up
+create table if not exists public.user_sequences
+(
+    user_id    uuid   not null
+        primary key,
+    seq        bigint not null default 0,
+    purged_seq bigint not null default 0
+);
+
+alter table public.secrets
+    add column if not exists seq bigint not null default 0;
+update public.secrets s
+set seq = o.rn
+from (select id, user_id, row_number() over (partition by user_id order by updated_at, id) as rn
+      from public.secrets) o
+where s.id = o.id
+  and s.user_id = o.user_id;
+insert into public.user_sequences (user_id, seq)
+select user_id, max(seq)
+from public.secrets
+group by user_id
+on conflict (user_id) do nothing;
+create index if not exists idx_secrets_user_id_seq
+    on public.secrets (user_id, seq);
+
+-- +goose Down
+drop index if exists idx_secrets_user_id_seq;
+alter table public.secrets
+    drop column if exists seq;
+drop table if exists public.user_sequences;
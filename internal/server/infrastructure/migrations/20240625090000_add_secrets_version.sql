@@ -0,0 +1,7 @@
+-- +goose Up
+alter table public.secrets
+    add column if not exists version bigint not null default 1;
+
+-- +goose Down
+alter table public.secrets
+    drop column if exists version;
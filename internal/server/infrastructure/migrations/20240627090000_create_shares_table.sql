@@ -0,0 +1,30 @@
+-- +goose Up
+alter table public.users
+    add column if not exists public_key         bytea,
+    add column if not exists sealed_private_key bytea;
+alter table public.secrets
+    add column if not exists owner_key bytea;
+
+create table if not exists public.shares
+(
+    secret_id   uuid         not null,
+    owner_id    uuid         not null,
+    user_id     uuid         not null,
+    permission  smallint     not null,
+    wrapped_key bytea        not null,
+    seq         bigint       not null default 0,
+    created_at  timestamp(0),
+    revoked_at  timestamp(0),
+    primary key (secret_id, user_id)
+);
+create index if not exists idx_shares_user_id_seq
+    on public.shares (user_id, seq);
+
+-- +goose Down
+drop index if exists idx_shares_user_id_seq;
+drop table if exists public.shares;
+alter table public.secrets
+    drop column if exists owner_key;
+alter table public.users
+    drop column if exists sealed_private_key,
+    drop column if exists public_key;
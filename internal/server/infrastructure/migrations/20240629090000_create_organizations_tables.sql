@@ -0,0 +1,75 @@
+-- +goose Up
+create table if not exists public.organizations
+(
+    id         uuid         not null
+        primary key,
+    name       varchar(255) not null,
+    created_at timestamp(0)
+);
+
+create table if not exists public.organization_members
+(
+    organization_id uuid     not null,
+    user_id         uuid     not null,
+    role            smallint not null,
+    wrapped_key     bytea    not null,
+    created_at      timestamp(0),
+    primary key (organization_id, user_id)
+);
+create index if not exists idx_organization_members_user_id
+    on public.organization_members (user_id);
+
+create table if not exists public.organization_invitations
+(
+    id              uuid         not null
+        primary key,
+    organization_id uuid         not null,
+    email           varchar(255) not null,
+    role            smallint     not null,
+    wrapped_key     bytea        not null,
+    code            varchar(255) not null,
+    invited_by      uuid         not null,
+    created_at      timestamp(0),
+    expires_at      timestamp(0) not null,
+    constraint organization_invitations_email_unique
+        unique (organization_id, email)
+);
+
+create table if not exists public.collections
+(
+    id              uuid         not null
+        primary key,
+    organization_id uuid         not null,
+    name            varchar(255) not null,
+    created_at      timestamp(0)
+);
+create index if not exists idx_collections_organization_id
+    on public.collections (organization_id);
+
+alter table public.secrets
+    add column if not exists collection_id uuid;
+create index if not exists idx_secrets_collection_id
+    on public.secrets (collection_id);
+
+create table if not exists public.organization_changes
+(
+    user_id   uuid   not null,
+    secret_id uuid   not null,
+    seq       bigint not null,
+    primary key (user_id, secret_id)
+);
+create index if not exists idx_organization_changes_user_id_seq
+    on public.organization_changes (user_id, seq);
+
+-- +goose Down
+drop index if exists idx_organization_changes_user_id_seq;
+drop table if exists public.organization_changes;
+drop index if exists idx_secrets_collection_id;
+alter table public.secrets
+    drop column if exists collection_id;
+drop index if exists idx_collections_organization_id;
+drop table if exists public.collections;
+drop table if exists public.organization_invitations;
+drop index if exists idx_organization_members_user_id;
+drop table if exists public.organization_members;
+drop table if exists public.organizations;
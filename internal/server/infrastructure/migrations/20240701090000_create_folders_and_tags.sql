@@ -0,0 +1,29 @@
+-- +goose Up
+create table if not exists public.folders
+(
+    id         uuid         not null
+        primary key,
+    user_id    uuid         not null,
+    parent_id  uuid,
+    name       varchar(255) not null,
+    created_at timestamp(0)
+);
+create index if not exists idx_folders_user_id
+    on public.folders (user_id);
+
+alter table public.secrets
+    add column if not exists folder_id uuid,
+    add column if not exists tags varchar(64)[] not null default '{}';
+create index if not exists idx_secrets_folder_id
+    on public.secrets (folder_id);
+create index if not exists idx_secrets_tags
+    on public.secrets using gin (tags);
+
+-- +goose Down
+drop index if exists idx_secrets_tags;
+drop index if exists idx_secrets_folder_id;
+alter table public.secrets
+    drop column if exists tags,
+    drop column if exists folder_id;
+drop index if exists idx_folders_user_id;
+drop table if exists public.folders;
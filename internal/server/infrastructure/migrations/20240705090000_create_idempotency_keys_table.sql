@@ -0,0 +1,16 @@
+-- +goose Up
+create table if not exists public.idempotency_keys
+(
+    user_id      uuid         not null,
+    key          varchar(64)  not null,
+    secret_id    uuid         not null,
+    payload_hash varchar(64)  not null,
+    created_at   timestamp(0) not null,
+    primary key (user_id, key)
+);
+create index if not exists idx_idempotency_keys_created_at
+    on public.idempotency_keys (created_at);
+
+-- +goose Down
+drop index if exists idx_idempotency_keys_created_at;
+drop table if exists public.idempotency_keys;
@@ -18,6 +18,10 @@ type UsersStorage interface {
 	Save(ctx context.Context, user entities.User) error
 	FindByEmail(ctx context.Context, email string) (*entities.User, error)
 	FindByID(ctx context.Context, id string) (*entities.User, error)
+	GetKeyParams(ctx context.Context, userID string) (*entities.KeyParams, error)
+	SetKeyParams(ctx context.Context, userID string, params entities.KeyParams) error
+	SetKeyPair(ctx context.Context, userID string, publicKey, sealedPrivateKey []byte) error
+	FindPublicKey(ctx context.Context, email string) (string, []byte, error)
 }
 
 type SessionsStorage interface {
@@ -47,9 +51,14 @@ type JWTManager interface {
 }
 
 type DBTransactionManager interface {
-	Transaction(context.Context, func() error) error
+	Transaction(context.Context, func(context.Context) error) error
 }
 
+const (
+	minSaltSize   = 16
+	publicKeySize = 32
+)
+
 type AuthUseCase struct {
 	hash         PasswordHasher
 	uuid         UUIDGenerator
@@ -176,7 +185,7 @@ func (a *AuthUseCase) Verify(ctx context.Context, email, otp, fingerprint string
 	}
 	session := entities.NewSession(sessionID, user.ID, fingerprint, a.jwt.MakeRefreshExpiration())
 
-	err = a.tx.Transaction(ctx, func() error {
+	err = a.tx.Transaction(ctx, func(ctx context.Context) error {
 		err := a.sessionsRepo.Save(ctx, *session)
 		if err != nil {
 			return err
@@ -234,3 +243,67 @@ func (a *AuthUseCase) Refresh(ctx context.Context, sessionID, fingerprint string
 
 	return entities.NewToken(accessToken, newSession.ID.String()), nil
 }
+
+func (a *AuthUseCase) GetKeyParams(ctx context.Context, userID string) (*entities.KeyParams, error) {
+	return a.usersRepo.GetKeyParams(ctx, userID)
+}
+
+func (a *AuthUseCase) SetKeyParams(ctx context.Context, userID string, params *entities.KeyParams) error {
+	if len(params.Salt) < minSaltSize || len(params.KeyCheck) == 0 {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("invalid key params"),
+		)
+	}
+	if len(params.PublicKey) > 0 && !isValidKeyPair(params.PublicKey, params.SealedPrivateKey) {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("invalid key pair"),
+		)
+	}
+	current, err := a.usersRepo.GetKeyParams(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !current.IsEmpty() {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("key params already set"),
+		)
+	}
+	return a.usersRepo.SetKeyParams(ctx, userID, *params)
+}
+
+func (a *AuthUseCase) SetKeyPair(ctx context.Context, userID string, publicKey, sealedPrivateKey []byte) error {
+	if !isValidKeyPair(publicKey, sealedPrivateKey) {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("invalid key pair"),
+		)
+	}
+	current, err := a.usersRepo.GetKeyParams(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if current.IsEmpty() {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("master password is not set"),
+		)
+	}
+	if len(current.PublicKey) > 0 {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("key pair already set"),
+		)
+	}
+	return a.usersRepo.SetKeyPair(ctx, userID, publicKey, sealedPrivateKey)
+}
+
+func (a *AuthUseCase) GetPublicKey(ctx context.Context, email string) (string, []byte, error) {
+	userID, publicKey, err := a.usersRepo.FindPublicKey(ctx, email)
+	if err != nil {
+		return "", nil, errors.NewInvalidArgumentError(
+			fmt.Errorf("user %s not found or has not set a master password yet", email),
+		)
+	}
+	return userID, publicKey, nil
+}
+
+func isValidKeyPair(publicKey, sealedPrivateKey []byte) bool {
+	return len(publicKey) == publicKeySize && len(sealedPrivateKey) > 0
+}
@@ -758,3 +758,168 @@ func TestAuthUseCase_Refresh(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthUseCase_SetKeyParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepo := mocks.NewMockUsersStorage(ctrl)
+
+	auth := &AuthUseCase{
+		usersRepo: usersRepo,
+	}
+
+	userID := "1955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+	params := entities.KeyParams{
+		Salt:     []byte("0123456789abcdef"),
+		KeyCheck: []byte("key_check"),
+	}
+
+	tests := []struct {
+		name      string
+		params    entities.KeyParams
+		current   *entities.KeyParams
+		mockTimes map[string]int
+		errors    map[string]error
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "short salt",
+			params: entities.KeyParams{Salt: []byte("salt"), KeyCheck: []byte("key_check")},
+			mockTimes: map[string]int{
+				"get": 0,
+				"set": 0,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "get params error",
+			params:  params,
+			current: nil,
+			mockTimes: map[string]int{
+				"get": 1,
+				"set": 0,
+			},
+			errors: map[string]error{
+				"get": errors.New("get params error"),
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "already set",
+			params:  params,
+			current: &params,
+			mockTimes: map[string]int{
+				"get": 1,
+				"set": 0,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "success",
+			params:  params,
+			current: &entities.KeyParams{},
+			mockTimes: map[string]int{
+				"get": 1,
+				"set": 1,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usersRepo.EXPECT().GetKeyParams(gomock.Any(), userID).Return(tt.current, tt.errors["get"]).Times(tt.mockTimes["get"])
+			usersRepo.EXPECT().SetKeyParams(gomock.Any(), userID, tt.params).Return(tt.errors["set"]).Times(tt.mockTimes["set"])
+
+			tt.wantErr(t, auth.SetKeyParams(context.Background(), userID, &tt.params), fmt.Sprintf("SetKeyParams()"))
+		})
+	}
+}
+
+func TestAuthUseCase_SetKeyPair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usersRepo := mocks.NewMockUsersStorage(ctrl)
+
+	auth := &AuthUseCase{
+		usersRepo: usersRepo,
+	}
+
+	userID := "1955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+	publicKey := []byte("0123456789abcdef0123456789abcdef")
+	sealedPrivateKey := []byte("sealed_private_key")
+	params := entities.KeyParams{
+		Salt:     []byte("0123456789abcdef"),
+		KeyCheck: []byte("key_check"),
+	}
+
+	tests := []struct {
+		name      string
+		publicKey []byte
+		current   *entities.KeyParams
+		mockTimes map[string]int
+		errors    map[string]error
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "invalid public key",
+			publicKey: []byte("short"),
+			mockTimes: map[string]int{
+				"get": 0,
+				"set": 0,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.Error,
+		},
+		{
+			name:      "master password not set",
+			publicKey: publicKey,
+			current:   &entities.KeyParams{},
+			mockTimes: map[string]int{
+				"get": 1,
+				"set": 0,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.Error,
+		},
+		{
+			name:      "already set",
+			publicKey: publicKey,
+			current: &entities.KeyParams{
+				Salt:             params.Salt,
+				KeyCheck:         params.KeyCheck,
+				PublicKey:        publicKey,
+				SealedPrivateKey: sealedPrivateKey,
+			},
+			mockTimes: map[string]int{
+				"get": 1,
+				"set": 0,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.Error,
+		},
+		{
+			name:      "success",
+			publicKey: publicKey,
+			current:   &params,
+			mockTimes: map[string]int{
+				"get": 1,
+				"set": 1,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usersRepo.EXPECT().GetKeyParams(gomock.Any(), userID).Return(tt.current, tt.errors["get"]).Times(tt.mockTimes["get"])
+			usersRepo.EXPECT().SetKeyPair(gomock.Any(), userID, tt.publicKey, sealedPrivateKey).Return(tt.errors["set"]).Times(tt.mockTimes["set"])
+
+			tt.wantErr(t, auth.SetKeyPair(context.Background(), userID, tt.publicKey, sealedPrivateKey), fmt.Sprintf("SetKeyPair()"))
+		})
+	}
+}
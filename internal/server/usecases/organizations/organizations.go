@@ -0,0 +1,354 @@
+package organizations
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/itohin/gophkeeper/internal/server/entities"
+	"github.com/itohin/gophkeeper/pkg/errors"
+)
+
+type OrganizationsStorage interface {
+	Create(ctx context.Context, org entities.Organization, owner entities.Member) error
+	GetUserOrganizations(ctx context.Context, userID string) ([]entities.Organization, error)
+	GetMember(ctx context.Context, orgID, userID string) (entities.Member, error)
+	GetMembers(ctx context.Context, orgID string) ([]entities.Member, error)
+	SaveInvitation(ctx context.Context, inv entities.Invitation) error
+	AcceptInvitation(ctx context.Context, code, userID, email string) (string, error)
+	SetMemberRole(ctx context.Context, orgID, userID string, role uint32) (bool, error)
+	RemoveMember(ctx context.Context, orgID, userID string) (bool, error)
+	CreateCollection(ctx context.Context, c entities.Collection) error
+	GetCollections(ctx context.Context, orgID string) ([]entities.Collection, error)
+	GetCollection(ctx context.Context, collectionID string) (entities.Collection, error)
+	DeleteCollection(ctx context.Context, collectionID string) (bool, error)
+}
+
+type UsersStorage interface {
+	FindByEmail(ctx context.Context, email string) (*entities.User, error)
+	FindByID(ctx context.Context, id string) (*entities.User, error)
+}
+
+type Mailer interface {
+	SendMailAsync(to []string, message string)
+}
+
+type OTPGenerator interface {
+	RandomSecret() (string, error)
+}
+
+type UUIDGenerator interface {
+	Generate() ([16]byte, error)
+}
+
+type OrganizationsUseCase struct {
+	uuid   UUIDGenerator
+	otp    OTPGenerator
+	repo   OrganizationsStorage
+	users  UsersStorage
+	mailer Mailer
+}
+
+func NewOrganizationsUseCase(
+	uuid UUIDGenerator,
+	otp OTPGenerator,
+	repo OrganizationsStorage,
+	users UsersStorage,
+	mailer Mailer,
+) *OrganizationsUseCase {
+	return &OrganizationsUseCase{
+		uuid:   uuid,
+		otp:    otp,
+		repo:   repo,
+		users:  users,
+		mailer: mailer,
+	}
+}
+
+func (o *OrganizationsUseCase) CreateOrganization(ctx context.Context, userID, name string, wrappedKey []byte) (string, error) {
+	if name == "" {
+		return "", errors.NewInvalidArgumentError(
+			fmt.Errorf("organization name is required"),
+		)
+	}
+	if len(wrappedKey) == 0 {
+		return "", errors.NewInvalidArgumentError(
+			fmt.Errorf("wrapped organization key is required"),
+		)
+	}
+	orgID, err := o.uuid.Generate()
+	if err != nil {
+		return "", err
+	}
+	org := entities.Organization{
+		ID:        uuid.UUID(orgID).String(),
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+	err = o.repo.Create(ctx, org, entities.Member{
+		OrganizationID: org.ID,
+		UserID:         userID,
+		Role:           entities.RoleOwner,
+		WrappedKey:     wrappedKey,
+	})
+	if err != nil {
+		return "", err
+	}
+	return org.ID, nil
+}
+
+func (o *OrganizationsUseCase) GetUserOrganizations(ctx context.Context, userID string) ([]entities.Organization, error) {
+	return o.repo.GetUserOrganizations(ctx, userID)
+}
+
+func (o *OrganizationsUseCase) InviteMember(ctx context.Context, userID string, inv *entities.Invitation) error {
+	org, err := o.getManagedOrganization(ctx, userID, inv.OrganizationID)
+	if err != nil {
+		return err
+	}
+	err = checkRoleChange(org.Role, inv.Role)
+	if err != nil {
+		return err
+	}
+	if len(inv.WrappedKey) == 0 {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("wrapped organization key is required"),
+		)
+	}
+	invitee, err := o.users.FindByEmail(ctx, inv.Email)
+	if err != nil {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("user with email %v not found", inv.Email),
+		)
+	}
+	_, err = o.repo.GetMember(ctx, org.ID, invitee.ID.String())
+	if err == nil {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("user %v is already a member of the organization", inv.Email),
+		)
+	}
+
+	invitationID, err := o.uuid.Generate()
+	if err != nil {
+		return err
+	}
+	code, err := o.otp.RandomSecret()
+	if err != nil {
+		return err
+	}
+	inv.ID = uuid.UUID(invitationID).String()
+	inv.Code = code
+	inv.InvitedBy = userID
+	inv.CreatedAt = time.Now()
+	inv.ExpiresAt = inv.CreatedAt.Add(entities.InvitationTTL)
+	err = o.repo.SaveInvitation(ctx, *inv)
+	if err != nil {
+		return err
+	}
+
+	o.mailer.SendMailAsync(
+		[]string{inv.Email},
+		"Вас пригласили в организацию "+org.Name+" в сервисе gophkeeper. Для вступления введите пожалуйста код приглашения: "+code,
+	)
+
+	return nil
+}
+
+func (o *OrganizationsUseCase) AcceptInvitation(ctx context.Context, userID, code string) (string, error) {
+	user, err := o.users.FindByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	orgID, err := o.repo.AcceptInvitation(ctx, code, userID, user.Email)
+	if stderrors.Is(err, entities.ErrInvitationNotFound) {
+		return "", errors.NewInvalidArgumentError(
+			fmt.Errorf("invitation not found or expired"),
+		)
+	}
+	if err != nil {
+		return "", err
+	}
+	return orgID, nil
+}
+
+func (o *OrganizationsUseCase) GetMembers(ctx context.Context, userID, orgID string) ([]entities.Member, error) {
+	_, err := o.getOrganization(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return o.repo.GetMembers(ctx, orgID)
+}
+
+func (o *OrganizationsUseCase) SetMemberRole(ctx context.Context, userID, orgID, memberID string, role uint32) error {
+	org, err := o.getManagedOrganization(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if memberID == userID {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("own role can not be changed"),
+		)
+	}
+	member, err := o.getMember(ctx, orgID, memberID)
+	if err != nil {
+		return err
+	}
+	err = checkRoleChange(org.Role, member.Role)
+	if err != nil {
+		return err
+	}
+	err = checkRoleChange(org.Role, role)
+	if err != nil {
+		return err
+	}
+	_, err = o.repo.SetMemberRole(ctx, orgID, memberID, role)
+	return err
+}
+
+// RemoveMember drops the membership and the organization key wrapped for the
+// member. The key itself is not rotated: it is generated and wrapped on the
+// clients, the server can not re-wrap it, so a removed member who kept a copy
+// of the key can still decrypt the secrets they could read before
+func (o *OrganizationsUseCase) RemoveMember(ctx context.Context, userID, orgID, memberID string) error {
+	org, err := o.getOrganization(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	member, err := o.getMember(ctx, orgID, memberID)
+	if err != nil {
+		return err
+	}
+	if member.Role == entities.RoleOwner {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("owner can not leave the organization"),
+		)
+	}
+	// any member may leave, only admins may remove others
+	if memberID != userID {
+		if !entities.CanManage(org.Role) {
+			return errors.NewPermissionDeniedError(
+				fmt.Errorf("organization %v is not managed by user", orgID),
+			)
+		}
+		err = checkRoleChange(org.Role, member.Role)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = o.repo.RemoveMember(ctx, orgID, memberID)
+	return err
+}
+
+func (o *OrganizationsUseCase) CreateCollection(ctx context.Context, userID, orgID, name string) (string, error) {
+	_, err := o.getManagedOrganization(ctx, userID, orgID)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", errors.NewInvalidArgumentError(
+			fmt.Errorf("collection name is required"),
+		)
+	}
+	collectionID, err := o.uuid.Generate()
+	if err != nil {
+		return "", err
+	}
+	collection := entities.Collection{
+		ID:             uuid.UUID(collectionID).String(),
+		OrganizationID: orgID,
+		Name:           name,
+		CreatedAt:      time.Now(),
+	}
+	err = o.repo.CreateCollection(ctx, collection)
+	if err != nil {
+		return "", err
+	}
+	return collection.ID, nil
+}
+
+func (o *OrganizationsUseCase) GetCollections(ctx context.Context, userID, orgID string) ([]entities.Collection, error) {
+	_, err := o.getOrganization(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return o.repo.GetCollections(ctx, orgID)
+}
+
+func (o *OrganizationsUseCase) DeleteCollection(ctx context.Context, userID, collectionID string) error {
+	collection, err := o.repo.GetCollection(ctx, collectionID)
+	if err != nil {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("collection %v not found", collectionID),
+		)
+	}
+	_, err = o.getManagedOrganization(ctx, userID, collection.OrganizationID)
+	if err != nil {
+		return err
+	}
+	deleted, err := o.repo.DeleteCollection(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("collection %v is not empty", collectionID),
+		)
+	}
+	return nil
+}
+
+// getOrganization returns the organization with the user's role in it
+func (o *OrganizationsUseCase) getOrganization(ctx context.Context, userID, orgID string) (entities.Organization, error) {
+	orgs, err := o.repo.GetUserOrganizations(ctx, userID)
+	if err != nil {
+		return entities.Organization{}, err
+	}
+	for _, org := range orgs {
+		if org.ID == orgID {
+			return org, nil
+		}
+	}
+	return entities.Organization{}, errors.NewInvalidArgumentError(
+		fmt.Errorf("organization %v not found", orgID),
+	)
+}
+
+func (o *OrganizationsUseCase) getManagedOrganization(ctx context.Context, userID, orgID string) (entities.Organization, error) {
+	org, err := o.getOrganization(ctx, userID, orgID)
+	if err != nil {
+		return org, err
+	}
+	if !entities.CanManage(org.Role) {
+		return org, errors.NewPermissionDeniedError(
+			fmt.Errorf("organization %v is not managed by user", orgID),
+		)
+	}
+	return org, nil
+}
+
+func (o *OrganizationsUseCase) getMember(ctx context.Context, orgID, memberID string) (entities.Member, error) {
+	member, err := o.repo.GetMember(ctx, orgID, memberID)
+	if err != nil {
+		return member, errors.NewInvalidArgumentError(
+			fmt.Errorf("member %v not found", memberID),
+		)
+	}
+	return member, nil
+}
+
+// checkRoleChange allows only owners to grant, take or change the owner role
+func checkRoleChange(managerRole, role uint32) error {
+	if !entities.IsValidRole(role) {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("unknown role %v", role),
+		)
+	}
+	if role == entities.RoleOwner && managerRole != entities.RoleOwner {
+		return errors.NewPermissionDeniedError(
+			fmt.Errorf("only owners can manage owners"),
+		)
+	}
+	return nil
+}
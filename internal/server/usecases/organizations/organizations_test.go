@@ -0,0 +1,338 @@
+package organizations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/itohin/gophkeeper/internal/server/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationsUseCase_InviteMember(t *testing.T) {
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockOrganizationsStorage(ctrl)
+	users := mocks.NewMockUsersStorage(ctrl)
+	otp := mocks.NewMockOTPGenerator(ctrl)
+	mailer := mocks.NewMockMailer(ctrl)
+	uuid := mocks.NewMockUUIDGenerator(ctrl)
+
+	organizations := &OrganizationsUseCase{
+		uuid:   uuid,
+		otp:    otp,
+		repo:   repo,
+		users:  users,
+		mailer: mailer,
+	}
+
+	userID := "1955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+	orgID := "2955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+	email := "email@mall.ru"
+	otpCode := "otp_code"
+	var inviteeID, invitationID [16]byte
+	copy(inviteeID[:], "3955a7d6-0968-42")
+	copy(invitationID[:], "4955a7d6-0968-42")
+
+	tests := []struct {
+		name      string
+		userRole  uint32
+		role      uint32
+		mockTimes map[string]int
+		errors    map[string]error
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "member can not invite",
+			userRole: entities.RoleMember,
+			role:     entities.RoleViewer,
+			mockTimes: map[string]int{
+				"find_user": 0,
+				"member":    0,
+				"uuid":      0,
+				"otp":       0,
+				"save":      0,
+				"mailer":    0,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.Error,
+		},
+		{
+			name:     "admin can not invite owner",
+			userRole: entities.RoleAdmin,
+			role:     entities.RoleOwner,
+			mockTimes: map[string]int{
+				"find_user": 0,
+				"member":    0,
+				"uuid":      0,
+				"otp":       0,
+				"save":      0,
+				"mailer":    0,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.Error,
+		},
+		{
+			name:     "invitee not registered",
+			userRole: entities.RoleAdmin,
+			role:     entities.RoleMember,
+			mockTimes: map[string]int{
+				"find_user": 1,
+				"member":    0,
+				"uuid":      0,
+				"otp":       0,
+				"save":      0,
+				"mailer":    0,
+			},
+			errors: map[string]error{
+				"find_user": errors.New("user not found"),
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:     "invitee already member",
+			userRole: entities.RoleAdmin,
+			role:     entities.RoleMember,
+			mockTimes: map[string]int{
+				"find_user": 1,
+				"member":    1,
+				"uuid":      0,
+				"otp":       0,
+				"save":      0,
+				"mailer":    0,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.Error,
+		},
+		{
+			name:     "save invitation error",
+			userRole: entities.RoleAdmin,
+			role:     entities.RoleMember,
+			mockTimes: map[string]int{
+				"find_user": 1,
+				"member":    1,
+				"uuid":      1,
+				"otp":       1,
+				"save":      1,
+				"mailer":    0,
+			},
+			errors: map[string]error{
+				"member": errors.New("member not found"),
+				"save":   errors.New("save error"),
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:     "success",
+			userRole: entities.RoleOwner,
+			role:     entities.RoleAdmin,
+			mockTimes: map[string]int{
+				"find_user": 1,
+				"member":    1,
+				"uuid":      1,
+				"otp":       1,
+				"save":      1,
+				"mailer":    1,
+			},
+			errors: map[string]error{
+				"member": errors.New("member not found"),
+			},
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			org := entities.Organization{ID: orgID, Name: "Team", Role: tt.userRole}
+			invitee := entities.User{ID: inviteeID, Email: email}
+
+			repo.EXPECT().GetUserOrganizations(gomock.Any(), userID).Return([]entities.Organization{org}, nil).Times(1)
+			users.EXPECT().FindByEmail(gomock.Any(), email).Return(&invitee, tt.errors["find_user"]).Times(tt.mockTimes["find_user"])
+			repo.EXPECT().GetMember(gomock.Any(), orgID, invitee.ID.String()).Return(entities.Member{}, tt.errors["member"]).Times(tt.mockTimes["member"])
+			uuid.EXPECT().Generate().Return(invitationID, nil).Times(tt.mockTimes["uuid"])
+			otp.EXPECT().RandomSecret().Return(otpCode, nil).Times(tt.mockTimes["otp"])
+			repo.EXPECT().SaveInvitation(gomock.Any(), gomock.Any()).Return(tt.errors["save"]).Times(tt.mockTimes["save"])
+			mailer.EXPECT().SendMailAsync([]string{email}, "Вас пригласили в организацию Team в сервисе gophkeeper. Для вступления введите пожалуйста код приглашения: "+otpCode).Times(tt.mockTimes["mailer"])
+
+			inv := &entities.Invitation{OrganizationID: orgID, Email: email, Role: tt.role, WrappedKey: []byte("wrapped")}
+			tt.wantErr(t, organizations.InviteMember(context.TODO(), userID, inv), fmt.Sprintf("InviteMember()"))
+		})
+	}
+}
+
+func TestOrganizationsUseCase_SetMemberRole(t *testing.T) {
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockOrganizationsStorage(ctrl)
+
+	organizations := &OrganizationsUseCase{
+		repo: repo,
+	}
+
+	userID := "1955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+	memberID := "3955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+	orgID := "2955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+
+	tests := []struct {
+		name       string
+		userRole   uint32
+		memberRole uint32
+		role       uint32
+		memberID   string
+		mockTimes  map[string]int
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "viewer can not change roles",
+			userRole: entities.RoleViewer,
+			role:     entities.RoleMember,
+			memberID: memberID,
+			mockTimes: map[string]int{
+				"member":   0,
+				"set_role": 0,
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:     "own role can not be changed",
+			userRole: entities.RoleAdmin,
+			role:     entities.RoleViewer,
+			memberID: userID,
+			mockTimes: map[string]int{
+				"member":   0,
+				"set_role": 0,
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:       "admin can not demote owner",
+			userRole:   entities.RoleAdmin,
+			memberRole: entities.RoleOwner,
+			role:       entities.RoleMember,
+			memberID:   memberID,
+			mockTimes: map[string]int{
+				"member":   1,
+				"set_role": 0,
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:       "unknown role",
+			userRole:   entities.RoleOwner,
+			memberRole: entities.RoleMember,
+			role:       entities.RoleViewer + 1,
+			memberID:   memberID,
+			mockTimes: map[string]int{
+				"member":   1,
+				"set_role": 0,
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:       "admin promotes viewer",
+			userRole:   entities.RoleAdmin,
+			memberRole: entities.RoleViewer,
+			role:       entities.RoleMember,
+			memberID:   memberID,
+			mockTimes: map[string]int{
+				"member":   1,
+				"set_role": 1,
+			},
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			org := entities.Organization{ID: orgID, Role: tt.userRole}
+
+			repo.EXPECT().GetUserOrganizations(gomock.Any(), userID).Return([]entities.Organization{org}, nil).Times(1)
+			repo.EXPECT().GetMember(gomock.Any(), orgID, tt.memberID).Return(entities.Member{Role: tt.memberRole}, nil).Times(tt.mockTimes["member"])
+			repo.EXPECT().SetMemberRole(gomock.Any(), orgID, tt.memberID, tt.role).Return(true, nil).Times(tt.mockTimes["set_role"])
+
+			tt.wantErr(t, organizations.SetMemberRole(context.TODO(), userID, orgID, tt.memberID, tt.role), fmt.Sprintf("SetMemberRole()"))
+		})
+	}
+}
+
+func TestOrganizationsUseCase_AcceptInvitation(t *testing.T) {
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockOrganizationsStorage(ctrl)
+	users := mocks.NewMockUsersStorage(ctrl)
+
+	organizations := &OrganizationsUseCase{
+		repo:  repo,
+		users: users,
+	}
+
+	userID := "1955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+	orgID := "2955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+	email := "email@mall.ru"
+	code := "otp_code"
+
+	tests := []struct {
+		name      string
+		mockTimes map[string]int
+		errors    map[string]error
+		wantOrgID string
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "accept",
+			mockTimes: map[string]int{
+				"find_user": 1,
+				"accept":    1,
+			},
+			errors:    map[string]error{},
+			wantOrgID: orgID,
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "user lookup error",
+			mockTimes: map[string]int{
+				"find_user": 1,
+				"accept":    0,
+			},
+			errors: map[string]error{
+				"find_user": errors.New("find user error"),
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "invitation not found",
+			mockTimes: map[string]int{
+				"find_user": 1,
+				"accept":    1,
+			},
+			errors: map[string]error{
+				"accept": entities.ErrInvitationNotFound,
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users.EXPECT().FindByID(gomock.Any(), userID).
+				Return(&entities.User{ID: uuid.MustParse(userID), Email: email}, tt.errors["find_user"]).
+				Times(tt.mockTimes["find_user"])
+			repo.EXPECT().AcceptInvitation(gomock.Any(), code, userID, email).
+				Return(orgID, tt.errors["accept"]).
+				Times(tt.mockTimes["accept"])
+
+			got, err := organizations.AcceptInvitation(context.TODO(), userID, code)
+			if !tt.wantErr(t, err, fmt.Sprintf("AcceptInvitation()")) {
+				return
+			}
+			assert.Equal(t, tt.wantOrgID, got)
+		})
+	}
+}
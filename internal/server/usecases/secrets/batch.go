@@ -0,0 +1,116 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/itohin/gophkeeper/internal/server/entities"
+	"github.com/itohin/gophkeeper/pkg/errors"
+	"github.com/itohin/gophkeeper/pkg/events"
+)
+
+// eventBatch collects the secrets of a batch per recipient, so that every
+// user gets a single event for the whole batch
+type eventBatch map[string][]*events.SecretDTO
+
+func (b eventBatch) add(secret *events.SecretDTO, recipients []string) {
+	for _, userID := range recipients {
+		b[userID] = append(b[userID], eventMeta(secret))
+	}
+}
+
+// SaveBatch creates the secrets in one transaction, either all of them are
+// saved or none
+func (s *SecretsUseCase) SaveBatch(ctx context.Context, secrets []*entities.Secret) ([]*events.SecretDTO, error) {
+	err := checkBatchSize(len(secrets))
+	if err != nil {
+		return nil, err
+	}
+	saved := make([]*events.SecretDTO, 0, len(secrets))
+	err = s.tx.Transaction(ctx, func(ctx context.Context) error {
+		for _, secret := range secrets {
+			dto, err := s.create(ctx, secret)
+			if err != nil {
+				return fmt.Errorf("secret %v: %w", secret.Name, err)
+			}
+			saved = append(saved, dto)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	batch := make(eventBatch)
+	for _, dto := range saved {
+		s.fanOut(ctx, dto, batch.add)
+	}
+	s.sendBatch(batch, events.TypeCreated)
+
+	return saved, nil
+}
+
+// DeleteBatch moves the secrets to the trash in one transaction, either all
+// of them are deleted or none
+func (s *SecretsUseCase) DeleteBatch(ctx context.Context, userID string, secretIDs []string) error {
+	err := checkBatchSize(len(secretIDs))
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]struct{}, len(secretIDs))
+	batch := make(eventBatch)
+	var deleted []*events.SecretDTO
+	err = s.tx.Transaction(ctx, func(ctx context.Context) error {
+		for _, secretID := range secretIDs {
+			if _, ok := seen[secretID]; ok {
+				continue
+			}
+			seen[secretID] = struct{}{}
+			dto, revoked, err := s.deleteSecret(ctx, userID, secretID)
+			if err != nil {
+				return err
+			}
+			if revoked {
+				batch.add(dto, []string{userID})
+				continue
+			}
+			deleted = append(deleted, dto)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, dto := range deleted {
+		s.fanOut(ctx, dto, batch.add)
+	}
+	s.sendBatch(batch, events.TypeDeleted)
+
+	return nil
+}
+
+func (s *SecretsUseCase) sendBatch(batch eventBatch, eventType int) {
+	for userID, secrets := range batch {
+		s.eventCh <- &events.SecretEvent{
+			EventType:  eventType,
+			Secrets:    secrets,
+			FetchHint:  eventType != events.TypeDeleted,
+			Recipients: []string{userID},
+		}
+	}
+}
+
+func checkBatchSize(size int) error {
+	if size == 0 {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("batch is empty"),
+		)
+	}
+	if size > entities.MaxBatchSize {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("batch can not have more than %d secrets", entities.MaxBatchSize),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/itohin/gophkeeper/internal/server/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	"github.com/itohin/gophkeeper/pkg/events"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	firstID  = "5955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+	sharedID = "6955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+	thirdID  = "7955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+)
+
+// batchEvents drains the events of a batch into the number of secrets each
+// recipient got, failing on a second event for the same recipient
+func batchEvents(t *testing.T, eventCh chan *events.SecretEvent, eventType int) map[string]int {
+	got := make(map[string]int)
+	for len(eventCh) > 0 {
+		ev := <-eventCh
+		assert.Equal(t, eventType, ev.EventType)
+		assert.Len(t, ev.Recipients, 1)
+		_, ok := got[ev.Recipients[0]]
+		assert.False(t, ok, "one event per recipient")
+		got[ev.Recipients[0]] = len(ev.Secrets)
+	}
+	return got
+}
+
+func TestSecretsUseCase_SaveBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockSecretsStorage(ctrl)
+	users := mocks.NewMockUsersStorage(ctrl)
+	tx := mocks.NewMockDBTransactionManager(ctrl)
+
+	tests := []struct {
+		name       string
+		failID     string
+		mockTimes  map[string]int
+		wantSaved  int
+		wantEvents map[string]int
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "save batch",
+			mockTimes: map[string]int{
+				"save":       3,
+				"shares":     3,
+				"find_owner": 1,
+			},
+			wantSaved:  3,
+			wantEvents: map[string]int{ownerID: 3, recipientID: 1},
+			wantErr:    assert.NoError,
+		},
+		{
+			name:   "third secret fails",
+			failID: thirdID,
+			mockTimes: map[string]int{
+				"save":       3,
+				"shares":     0,
+				"find_owner": 0,
+			},
+			wantEvents: map[string]int{},
+			wantErr:    assert.Error,
+		},
+		{
+			name:   "first secret fails",
+			failID: firstID,
+			mockTimes: map[string]int{
+				"save":       1,
+				"shares":     0,
+				"find_owner": 0,
+			},
+			wantEvents: map[string]int{},
+			wantErr:    assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventCh := make(chan *events.SecretEvent, 4)
+			s := &SecretsUseCase{repo: repo, users: users, tx: tx, eventCh: eventCh}
+
+			tx.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(inTransaction).Times(1)
+			repo.EXPECT().Save(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, secret entities.Secret) (*events.SecretDTO, error) {
+					if secret.ID == tt.failID {
+						return nil, errors.New("db error")
+					}
+					return &events.SecretDTO{ID: secret.ID, UserID: secret.UserID, SecretType: secret.SecretType, Version: 1}, nil
+				}).
+				Times(tt.mockTimes["save"])
+			repo.EXPECT().GetSecretShares(gomock.Any(), ownerID, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, id string) ([]entities.Share, error) {
+					if id != sharedID {
+						return nil, nil
+					}
+					return []entities.Share{{SecretID: sharedID, OwnerID: ownerID, UserID: recipientID, Permission: entities.PermissionRead}}, nil
+				}).
+				Times(tt.mockTimes["shares"])
+			users.EXPECT().FindByID(gomock.Any(), ownerID).
+				Return(&entities.User{ID: uuid.MustParse(ownerID), Email: "owner@mail.ru"}, nil).
+				Times(tt.mockTimes["find_owner"])
+
+			batch := make([]*entities.Secret, 0, 3)
+			for _, id := range []string{firstID, sharedID, thirdID} {
+				batch = append(batch, &entities.Secret{ID: id, UserID: ownerID, SecretType: entities.TypeText, Data: []byte(`{"Text":"text"}`)})
+			}
+			saved, err := s.SaveBatch(context.Background(), batch)
+			tt.wantErr(t, err)
+			assert.Len(t, saved, tt.wantSaved)
+			assert.Equal(t, tt.wantEvents, batchEvents(t, eventCh, events.TypeCreated))
+		})
+	}
+}
+
+func TestSecretsUseCase_DeleteBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockSecretsStorage(ctrl)
+	tx := mocks.NewMockDBTransactionManager(ctrl)
+
+	// recipientID deletes two own secrets and drops the one shared by ownerID
+	current := map[string]events.SecretDTO{
+		firstID:  {ID: firstID, UserID: recipientID, SecretType: entities.TypeText, Version: 1},
+		thirdID:  {ID: thirdID, UserID: recipientID, SecretType: entities.TypeText, Version: 1},
+		sharedID: {ID: sharedID, UserID: ownerID, SecretType: entities.TypeText, Version: 1, Permission: entities.PermissionRead},
+	}
+
+	tests := []struct {
+		name       string
+		secretIDs  []string
+		mockTimes  map[string]int
+		errors     map[string]error
+		wantEvents map[string]int
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "delete batch",
+			secretIDs: []string{firstID, sharedID, thirdID, firstID},
+			mockTimes: map[string]int{
+				"get_secret": 3,
+				"save":       2,
+				"revoke":     1,
+				"shares":     2,
+			},
+			errors:     map[string]error{},
+			wantEvents: map[string]int{recipientID: 3},
+			wantErr:    assert.NoError,
+		},
+		{
+			name:      "last secret fails",
+			secretIDs: []string{firstID, sharedID, thirdID},
+			mockTimes: map[string]int{
+				"get_secret": 3,
+				"save":       2,
+				"revoke":     1,
+				"shares":     0,
+			},
+			errors: map[string]error{
+				thirdID: entities.ErrVersionConflict,
+			},
+			wantEvents: map[string]int{},
+			wantErr:    assertConflict,
+		},
+		{
+			name:      "revoke fails",
+			secretIDs: []string{firstID, sharedID, thirdID},
+			mockTimes: map[string]int{
+				"get_secret": 2,
+				"save":       1,
+				"revoke":     1,
+				"shares":     0,
+			},
+			errors: map[string]error{
+				sharedID: errors.New("db error"),
+			},
+			wantEvents: map[string]int{},
+			wantErr:    assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventCh := make(chan *events.SecretEvent, 4)
+			s := &SecretsUseCase{repo: repo, tx: tx, eventCh: eventCh}
+
+			tx.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(inTransaction).Times(1)
+			repo.EXPECT().GetUserSecret(gomock.Any(), recipientID, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, id string) (events.SecretDTO, error) {
+					return current[id], nil
+				}).
+				Times(tt.mockTimes["get_secret"])
+			repo.EXPECT().Save(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, secret entities.Secret) (*events.SecretDTO, error) {
+					assert.True(t, secret.DeletedAt.Valid)
+					if err := tt.errors[secret.ID]; err != nil {
+						return nil, err
+					}
+					return &events.SecretDTO{ID: secret.ID, UserID: secret.UserID, Version: 2}, nil
+				}).
+				Times(tt.mockTimes["save"])
+			repo.EXPECT().RevokeShare(gomock.Any(), sharedID, recipientID).Return(true, tt.errors[sharedID]).Times(tt.mockTimes["revoke"])
+			repo.EXPECT().GetSecretShares(gomock.Any(), recipientID, gomock.Any()).Return(nil, nil).Times(tt.mockTimes["shares"])
+
+			err := s.DeleteBatch(context.Background(), recipientID, tt.secretIDs)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.wantEvents, batchEvents(t, eventCh, events.TypeDeleted))
+		})
+	}
+}
+
+func Test_checkBatchSize(t *testing.T) {
+	assertInvalidArgument(t, checkBatchSize(0))
+	assert.NoError(t, checkBatchSize(entities.MaxBatchSize))
+	assertInvalidArgument(t, checkBatchSize(entities.MaxBatchSize+1))
+}
@@ -0,0 +1,99 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/itohin/gophkeeper/internal/server/entities"
+	"github.com/itohin/gophkeeper/pkg/errors"
+)
+
+func (s *SecretsUseCase) CreateFolder(ctx context.Context, userID, name, parentID string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.NewInvalidArgumentError(
+			fmt.Errorf("folder name is required"),
+		)
+	}
+	if parentID != "" {
+		_, err := s.repo.GetFolder(ctx, userID, parentID)
+		if err != nil {
+			return "", errors.NewInvalidArgumentError(
+				fmt.Errorf("folder %v not found", parentID),
+			)
+		}
+	}
+	folderID, err := s.uuid.Generate()
+	if err != nil {
+		return "", err
+	}
+	folder := entities.Folder{
+		ID:        uuid.UUID(folderID).String(),
+		UserID:    userID,
+		ParentID:  parentID,
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+	err = s.repo.CreateFolder(ctx, folder)
+	if err != nil {
+		return "", err
+	}
+	return folder.ID, nil
+}
+
+func (s *SecretsUseCase) GetFolders(ctx context.Context, userID string) ([]entities.Folder, error) {
+	return s.repo.GetFolders(ctx, userID)
+}
+
+func (s *SecretsUseCase) DeleteFolder(ctx context.Context, userID, folderID string) error {
+	_, err := s.repo.GetFolder(ctx, userID, folderID)
+	if err != nil {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("folder %v not found", folderID),
+		)
+	}
+	deleted, err := s.repo.DeleteFolder(ctx, userID, folderID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("folder %v is not empty", folderID),
+		)
+	}
+	return nil
+}
+
+func (s *SecretsUseCase) GetTags(ctx context.Context, userID string) ([]string, error) {
+	return s.repo.GetUserTags(ctx, userID)
+}
+
+// checkPlacement normalizes the tags of the secret and lets the owner move it
+// only to their own folders
+func (s *SecretsUseCase) checkPlacement(ctx context.Context, secret *entities.Secret) error {
+	if secret.Tags != nil {
+		tags, err := entities.NormalizeTags(secret.Tags)
+		if err != nil {
+			return errors.NewInvalidArgumentError(err)
+		}
+		secret.Tags = tags
+	}
+	if secret.FolderID == nil || *secret.FolderID == "" {
+		return nil
+	}
+	if secret.CollectionID != "" {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("secrets of organizations can not be put into folders"),
+		)
+	}
+	_, err := s.repo.GetFolder(ctx, secret.UserID, *secret.FolderID)
+	if err != nil {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("folder %v not found", *secret.FolderID),
+		)
+	}
+	return nil
+}
@@ -1,82 +1,879 @@
 package secrets
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/itohin/gophkeeper/internal/server/entities"
+	"github.com/itohin/gophkeeper/pkg/errors"
 	"github.com/itohin/gophkeeper/pkg/events"
 )
 
 type SecretsStorage interface {
 	Save(ctx context.Context, secret entities.Secret) (*events.SecretDTO, error)
-	GetUserSecrets(ctx context.Context, userID string) ([]events.SecretDTO, error)
+	SearchUserSecrets(ctx context.Context, userID string, filter *entities.SecretsFilter) ([]events.SecretDTO, error)
 	GetUserSecret(ctx context.Context, userID, secretID string) (events.SecretDTO, error)
+	GetSecretRevisions(ctx context.Context, userID, secretID string) ([]entities.SecretRevision, error)
+	GetSecretRevision(ctx context.Context, userID, secretID, revisionID string) (entities.SecretRevision, error)
+	GetUserDeletedSecrets(ctx context.Context, userID string) ([]entities.DeletedSecret, error)
+	GetUserDeletedSecret(ctx context.Context, userID, secretID string) (entities.DeletedSecret, error)
+	GetSecretBlob(ctx context.Context, userID, secretID string) (entities.Blob, error)
+	GetLegacyBinary(ctx context.Context, userID, secretID string) (entities.BinaryContent, error)
+	GetUserBlobRefs(ctx context.Context, userID string) ([]string, error)
+	GetChangeSequence(ctx context.Context, userID string) (int64, int64, error)
+	GetChangesSince(ctx context.Context, userID string, cursor int64, limit int) ([]entities.SecretChange, error)
+	Purge(ctx context.Context, userID, secretID string) error
+	PurgeDeletedBefore(ctx context.Context, before time.Time) ([]string, error)
+	ClaimIdempotencyKey(ctx context.Context, userID, key, secretID, payloadHash string, since time.Time) (string, error)
+	PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error)
+	SaveShare(ctx context.Context, share entities.Share) error
+	RevokeShare(ctx context.Context, secretID, userID string) (bool, error)
+	GetSecretShares(ctx context.Context, ownerID, secretID string) ([]entities.Share, error)
+	CreateFolder(ctx context.Context, folder entities.Folder) error
+	GetFolders(ctx context.Context, userID string) ([]entities.Folder, error)
+	GetFolder(ctx context.Context, userID, folderID string) (entities.Folder, error)
+	DeleteFolder(ctx context.Context, userID, folderID string) (bool, error)
+	GetUserTags(ctx context.Context, userID string) ([]string, error)
+}
+
+type UsersStorage interface {
+	FindByID(ctx context.Context, id string) (*entities.User, error)
+	FindPublicKey(ctx context.Context, email string) (string, []byte, error)
+}
+
+type OrganizationsStorage interface {
+	GetCollection(ctx context.Context, collectionID string) (entities.Collection, error)
+	GetMember(ctx context.Context, orgID, userID string) (entities.Member, error)
+	GetMembers(ctx context.Context, orgID string) ([]entities.Member, error)
+}
+
+type BlobStore interface {
+	Put(ctx context.Context, userID string, r io.Reader) (entities.Blob, error)
+	Open(ctx context.Context, userID, ref string) (io.ReadCloser, error)
+	Collect(ctx context.Context, userID string, live []string) error
 }
 type UUIDGenerator interface {
 	Generate() ([16]byte, error)
 }
 
+type DBTransactionManager interface {
+	Transaction(context.Context, func(context.Context) error) error
+}
+
 type SecretsUseCase struct {
 	uuid    UUIDGenerator
 	repo    SecretsStorage
+	users   UsersStorage
+	orgs    OrganizationsStorage
+	blobs   BlobStore
+	tx      DBTransactionManager
+	keyTTL  time.Duration
 	eventCh chan *events.SecretEvent
 }
 
-func NewSecretsUseCase(uuid UUIDGenerator, repo SecretsStorage, eventCh chan *events.SecretEvent) *SecretsUseCase {
+func NewSecretsUseCase(
+	uuid UUIDGenerator,
+	repo SecretsStorage,
+	users UsersStorage,
+	orgs OrganizationsStorage,
+	blobs BlobStore,
+	tx DBTransactionManager,
+	keyTTL time.Duration,
+	eventCh chan *events.SecretEvent,
+) *SecretsUseCase {
 	return &SecretsUseCase{
 		uuid:    uuid,
 		repo:    repo,
+		users:   users,
+		orgs:    orgs,
+		blobs:   blobs,
+		tx:      tx,
+		keyTTL:  keyTTL,
 		eventCh: eventCh,
 	}
 }
 
-func (s *SecretsUseCase) GetUserSecrets(ctx context.Context, userID string) ([]events.SecretDTO, error) {
-	return s.repo.GetUserSecrets(ctx, userID)
+func (s *SecretsUseCase) SearchUserSecrets(ctx context.Context, userID string, filter *entities.SecretsFilter) ([]events.SecretDTO, string, error) {
+	secrets, err := s.repo.SearchUserSecrets(ctx, userID, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(secrets) <= filter.PageSize {
+		return secrets, "", nil
+	}
+
+	secrets = secrets[:filter.PageSize]
+	last := secrets[len(secrets)-1]
+	cursor := &entities.Cursor{Sort: filter.Sort, ID: last.ID}
+	if filter.IsByName() {
+		cursor.Name = last.Name
+	} else {
+		cursor.UpdatedAt = last.UpdatedAt
+	}
+	nextPageToken, err := cursor.Encode()
+	if err != nil {
+		return nil, "", err
+	}
+	return secrets, nextPageToken, nil
+}
+
+func (s *SecretsUseCase) GetChangesSince(ctx context.Context, userID string, cursor int64, pageSize int) (*entities.ChangesPage, error) {
+	if pageSize <= 0 {
+		pageSize = entities.DefaultPageSize
+	}
+	if pageSize > entities.MaxPageSize {
+		pageSize = entities.MaxPageSize
+	}
+	seq, purgedSeq, err := s.repo.GetChangeSequence(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if cursor < purgedSeq || cursor > seq {
+		return &entities.ChangesPage{Cursor: seq, Resync: true}, nil
+	}
+
+	changes, err := s.repo.GetChangesSince(ctx, userID, cursor, pageSize+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &entities.ChangesPage{Cursor: cursor}
+	if len(changes) > pageSize {
+		changes = changes[:pageSize]
+		page.HasMore = true
+	}
+	if len(changes) > 0 {
+		page.Cursor = changes[len(changes)-1].Seq
+	}
+	page.Changes = changes
+	return page, nil
 }
 
 func (s *SecretsUseCase) GetUserSecret(ctx context.Context, userID, secretID string) (events.SecretDTO, error) {
 	return s.repo.GetUserSecret(ctx, userID, secretID)
 }
 
-func (s *SecretsUseCase) DeleteUserSecret(ctx context.Context, secret *entities.Secret) (*events.SecretDTO, error) {
-	secret.DeletedAt = sql.NullTime{
-		Time:  time.Now(),
-		Valid: true,
+func (s *SecretsUseCase) DeleteUserSecret(ctx context.Context, userID, secretID string) (*events.SecretDTO, error) {
+	dto, revoked, err := s.deleteSecret(ctx, userID, secretID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		s.sendEventTo(dto, events.TypeDeleted, []string{userID})
+	} else {
+		s.sendEvent(ctx, dto, events.TypeDeleted)
 	}
-	dto, err := s.repo.Save(ctx, *secret)
 
-	if err == nil {
-		s.sendEvent(dto, events.TypeDeleted)
+	return dto, nil
+}
+
+// deleteSecret moves the secret to the trash, for a recipient of a shared
+// secret it revokes the share instead and reports it with revoked
+func (s *SecretsUseCase) deleteSecret(ctx context.Context, userID, secretID string) (*events.SecretDTO, bool, error) {
+	current, err := s.repo.GetUserSecret(ctx, userID, secretID)
+	if err != nil {
+		return nil, false, errors.NewInvalidArgumentError(
+			fmt.Errorf("secret %v not found", secretID),
+		)
+	}
+	if current.CollectionID != "" {
+		if current.Permission != entities.PermissionReadWrite {
+			return nil, false, errors.NewPermissionDeniedError(
+				fmt.Errorf("secret %v is read-only for user", secretID),
+			)
+		}
+	} else if current.UserID != userID {
+		// a recipient can only drop the secret from their own vault
+		return &current, true, s.revoke(ctx, current.ID, userID)
 	}
+	dto, err := s.repo.Save(ctx, entities.Secret{
+		ID:         current.ID,
+		Name:       current.Name,
+		SecretType: current.SecretType,
+		Notes:      current.Notes,
+		Data:       current.Data,
+		UserID:     current.UserID,
+		DeletedAt: sql.NullTime{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		Version: current.Version,
+	})
+	if err != nil {
+		return nil, false, conflictError(err, secretID)
+	}
+	return dto, false, nil
+}
+
+func (s *SecretsUseCase) Save(ctx context.Context, secret *entities.Secret) (*events.SecretDTO, error) {
+	if secret.IdempotencyKey != "" {
+		return s.saveIdempotent(ctx, secret)
+	}
+	dto, err := s.create(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+	s.sendEvent(ctx, dto, events.TypeCreated)
+
+	return dto, nil
+}
+
+// saveIdempotent creates the secret once per key, a request repeating the key
+// within its TTL gets the secret of the first request, the same key with
+// another payload is rejected
+func (s *SecretsUseCase) saveIdempotent(ctx context.Context, secret *entities.Secret) (*events.SecretDTO, error) {
+	if len(secret.IdempotencyKey) > entities.MaxIdempotencyKeyLength {
+		return nil, errors.NewInvalidArgumentError(
+			fmt.Errorf("idempotency key is longer than %d characters", entities.MaxIdempotencyKeyLength),
+		)
+	}
+	hash, err := payloadHash(secret)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSecretID(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	var dto *events.SecretDTO
+	created := false
+	err = s.tx.Transaction(ctx, func(ctx context.Context) error {
+		boundID, err := s.repo.ClaimIdempotencyKey(ctx, secret.UserID, secret.IdempotencyKey, secret.ID, hash, time.Now().Add(-s.keyTTL))
+		if stderrors.Is(err, entities.ErrIdempotencyKeyReused) {
+			return errors.NewInvalidArgumentError(
+				fmt.Errorf("idempotency key %v was used for another secret", secret.IdempotencyKey),
+			)
+		}
+		if err != nil {
+			return err
+		}
+		// a retry may carry the id of the first request, so the secret is
+		// looked up even if the key is bound to the same id
+		original, err := s.repo.GetUserSecret(ctx, secret.UserID, boundID)
+		if err == nil {
+			dto = &original
+			return nil
+		}
+		if boundID != secret.ID {
+			// the secret may be purged already, the client only needs its id
+			dto = &events.SecretDTO{ID: boundID, UserID: secret.UserID}
+			return nil
+		}
+		dto, err = s.insert(ctx, secret)
+		created = err == nil
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		s.sendEvent(ctx, dto, events.TypeCreated)
+	}
+
+	return dto, nil
+}
 
+// payloadHash identifies the content of a create request, the key is left out
+// as it is not part of the payload
+func payloadHash(secret *entities.Secret) (string, error) {
+	payload := *secret
+	payload.IdempotencyKey = ""
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash payload: %v", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (s *SecretsUseCase) create(ctx context.Context, secret *entities.Secret) (*events.SecretDTO, error) {
+	err := checkSecretID(secret)
+	if err != nil {
+		return nil, err
+	}
+	return s.insert(ctx, secret)
+}
+
+// sealedTypes are the secret types the server never stores in plain text
+var sealedTypes = map[uint32]struct{}{
+	entities.TypeTOTP:   {},
+	entities.TypeSSHKey: {},
+}
+
+// checkSealed rejects plain data of the types that are only accepted
+// encrypted on the client
+func checkSealed(secret *entities.Secret) error {
+	if _, ok := sealedTypes[secret.SecretType]; !ok {
+		return nil
+	}
+	data, _ := secret.Data.([]byte)
+	var sealed entities.Sealed
+	err := json.Unmarshal(data, &sealed)
+	if err != nil || len(sealed.Sealed) == 0 {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("secret type %v is accepted only encrypted", secret.SecretType),
+		)
+	}
+	return nil
+}
+
+// checkSecretID requires the id of a new secret to be chosen by the client,
+// the client binds the encrypted data to it
+func checkSecretID(secret *entities.Secret) error {
+	_, err := uuid.Parse(secret.ID)
+	if err != nil {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("secret id %q is not a valid uuid", secret.ID),
+		)
+	}
+	return nil
+}
+
+// insert saves a new secret whose id is already generated
+func (s *SecretsUseCase) insert(ctx context.Context, secret *entities.Secret) (*events.SecretDTO, error) {
+	err := checkSealed(secret)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkCollectionAccess(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkPlacement(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+	dto, err := s.repo.Save(ctx, *secret)
+	// a secret with the id exists already, its version never matches the new one
+	if stderrors.Is(err, entities.ErrVersionConflict) {
+		return nil, errors.NewConflictError(
+			fmt.Errorf("secret %v already exists", secret.ID),
+		)
+	}
 	return dto, err
 }
 
-func (s *SecretsUseCase) Save(ctx context.Context, secret *entities.Secret) (*events.SecretDTO, error) {
-	var secretID uuid.UUID
+func (s *SecretsUseCase) Update(ctx context.Context, secret *entities.Secret) (*events.SecretDTO, error) {
+	err := checkSealed(secret)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.repo.GetUserSecret(ctx, secret.UserID, secret.ID)
+	if err != nil {
+		return nil, errors.NewInvalidArgumentError(
+			fmt.Errorf("secret %v not found", secret.ID),
+		)
+	}
+	err = checkVersion(current, secret)
+	if err != nil {
+		return nil, err
+	}
+	err = checkWriteAccess(current, secret)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkPlacement(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+	dto, err := s.repo.Save(ctx, *secret)
+	if err != nil {
+		return nil, conflictError(err, secret.ID)
+	}
+	s.sendEvent(ctx, dto, events.TypeUpdated)
+
+	return dto, nil
+}
+
+func (s *SecretsUseCase) SaveBinary(ctx context.Context, secret *entities.Secret, content io.Reader) (*events.SecretDTO, error) {
+	if secret.SecretType != entities.TypeBinary {
+		return nil, errors.NewInvalidArgumentError(
+			fmt.Errorf("secret type %v has no binary content", secret.SecretType),
+		)
+	}
+	eventType := events.TypeUpdated
+	// a new binary has no version yet, all checks are done before the content
+	// is stored
+	if secret.Version == 0 {
+		err := checkSecretID(secret)
+		if err != nil {
+			return nil, err
+		}
+		_, err = s.repo.GetUserSecret(ctx, secret.UserID, secret.ID)
+		if err == nil {
+			return nil, errors.NewConflictError(
+				fmt.Errorf("secret %v already exists", secret.ID),
+			)
+		}
+		eventType = events.TypeCreated
+		err = s.checkCollectionAccess(ctx, secret)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		current, err := s.repo.GetUserSecret(ctx, secret.UserID, secret.ID)
+		if err != nil {
+			return nil, errors.NewInvalidArgumentError(
+				fmt.Errorf("secret %v not found", secret.ID),
+			)
+		}
+		err = checkVersion(current, secret)
+		if err != nil {
+			return nil, err
+		}
+		err = checkWriteAccess(current, secret)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err := s.checkPlacement(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
 
-	secretID, err := s.uuid.Generate()
+	blob, err := s.blobs.Put(ctx, secret.UserID, content)
 	if err != nil {
 		return nil, err
 	}
-	secret.ID = secretID.String()
+	secret.BlobRef = blob.Ref
+	secret.BlobSize = blob.Size
 	dto, err := s.repo.Save(ctx, *secret)
+	if err != nil {
+		// the blob of the failed save is dropped by a later collection, this
+		// one drops the blobs of earlier failed saves
+		_ = s.collectBlobs(ctx, secret.UserID)
+		return nil, conflictError(err, secret.ID)
+	}
+	s.sendEvent(ctx, dto, eventType)
+
+	return dto, nil
+}
+
+func (s *SecretsUseCase) GetUserBinary(ctx context.Context, userID, secretID string) (entities.Blob, io.ReadCloser, error) {
+	var blob entities.Blob
+	current, err := s.repo.GetUserSecret(ctx, userID, secretID)
+	if err != nil {
+		return blob, nil, errors.NewInvalidArgumentError(
+			fmt.Errorf("secret %v not found", secretID),
+		)
+	}
+	// shared binaries are kept in the owner's blob store
+	userID = current.UserID
+	blob, err = s.repo.GetSecretBlob(ctx, userID, secretID)
+	if err != nil {
+		return blob, nil, errors.NewInvalidArgumentError(
+			fmt.Errorf("secret %v not found", secretID),
+		)
+	}
+	if blob.Ref != "" {
+		content, err := s.blobs.Open(ctx, userID, blob.Ref)
+		if err != nil {
+			return blob, nil, err
+		}
+		return blob, content, nil
+	}
+
+	// binaries uploaded before the blob store are kept in the database
+	legacy, err := s.repo.GetLegacyBinary(ctx, userID, secretID)
+	if err != nil {
+		return blob, nil, errors.NewInvalidArgumentError(
+			fmt.Errorf("binary content of secret %v not found", secretID),
+		)
+	}
+	blob = entities.Blob{Ref: hex.EncodeToString(legacy.SHA256), Size: legacy.Size}
+	return blob, io.NopCloser(bytes.NewReader(legacy.Data)), nil
+}
+
+func (s *SecretsUseCase) GetSecretRevisions(ctx context.Context, userID, secretID string) ([]entities.SecretRevision, error) {
+	current, err := s.getOwnSecret(ctx, userID, secretID)
 	if err != nil {
 		return nil, err
 	}
-	s.sendEvent(dto, events.TypeCreated)
+	// revisions of collection secrets are kept with the member who created them
+	return s.repo.GetSecretRevisions(ctx, current.UserID, secretID)
+}
+
+func (s *SecretsUseCase) RestoreSecretRevision(ctx context.Context, userID, secretID, revisionID string) (*events.SecretDTO, error) {
+	current, err := s.getOwnSecret(ctx, userID, secretID)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := s.repo.GetSecretRevision(ctx, current.UserID, secretID, revisionID)
+	if err != nil {
+		return nil, errors.NewInvalidArgumentError(
+			fmt.Errorf("revision %v of secret %v not found", revisionID, secretID),
+		)
+	}
+	dto, err := s.repo.Save(ctx, entities.Secret{
+		ID:         current.ID,
+		Name:       revision.Name,
+		SecretType: current.SecretType,
+		Notes:      revision.Notes,
+		Data:       revision.Data,
+		UserID:     current.UserID,
+		BlobRef:    revision.BlobRef,
+		BlobSize:   revision.BlobSize,
+		Version:    current.Version,
+	})
+	if err != nil {
+		return nil, conflictError(err, secretID)
+	}
+	s.sendEvent(ctx, dto, events.TypeUpdated)
 
 	return dto, nil
 }
 
-func (s *SecretsUseCase) sendEvent(dto *events.SecretDTO, eventType int) {
+func (s *SecretsUseCase) GetUserDeletedSecrets(ctx context.Context, userID string) ([]entities.DeletedSecret, error) {
+	return s.repo.GetUserDeletedSecrets(ctx, userID)
+}
+
+func (s *SecretsUseCase) RestoreUserSecret(ctx context.Context, userID, secretID string) (*events.SecretDTO, error) {
+	deleted, err := s.getDeletedSecret(ctx, userID, secretID)
+	if err != nil {
+		return nil, err
+	}
+	dto, err := s.repo.Save(ctx, entities.Secret{
+		ID:         deleted.ID,
+		Name:       deleted.Name,
+		SecretType: deleted.SecretType,
+		Notes:      deleted.Notes,
+		Data:       deleted.Data,
+		UserID:     deleted.UserID,
+		Version:    deleted.Version,
+	})
+	if err != nil {
+		return nil, conflictError(err, secretID)
+	}
+	s.sendEvent(ctx, dto, events.TypeCreated)
+
+	return dto, nil
+}
+
+func (s *SecretsUseCase) PurgeUserSecret(ctx context.Context, userID, secretID string) error {
+	deleted, err := s.getDeletedSecret(ctx, userID, secretID)
+	if err != nil {
+		return err
+	}
+	err = s.repo.Purge(ctx, deleted.UserID, secretID)
+	if err != nil {
+		return err
+	}
+	return s.collectBlobs(ctx, deleted.UserID)
+}
+
+func (s *SecretsUseCase) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	userIDs, err := s.repo.PurgeDeletedBefore(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+	collected := make(map[string]struct{})
+	for _, userID := range userIDs {
+		if _, ok := collected[userID]; ok {
+			continue
+		}
+		collected[userID] = struct{}{}
+		err = s.collectBlobs(ctx, userID)
+		if err != nil {
+			return int64(len(userIDs)), err
+		}
+	}
+	return int64(len(userIDs)), nil
+}
+
+func (s *SecretsUseCase) PurgeExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	return s.repo.PurgeIdempotencyKeys(ctx, time.Now().Add(-s.keyTTL))
+}
+
+func (s *SecretsUseCase) Share(ctx context.Context, share *entities.Share) error {
+	current, err := s.getOwnSecret(ctx, share.OwnerID, share.SecretID)
+	if err != nil {
+		return err
+	}
+	if current.CollectionID != "" {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("secrets of organizations are shared through membership"),
+		)
+	}
+	if share.Permission != entities.PermissionRead && share.Permission != entities.PermissionReadWrite {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("unknown permission %v", share.Permission),
+		)
+	}
+	if len(share.WrappedKey) == 0 {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("wrapped item key is required"),
+		)
+	}
+	share.UserID, _, err = s.users.FindPublicKey(ctx, share.Email)
+	if err != nil {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("user %v can not receive shared secrets", share.Email),
+		)
+	}
+	if share.UserID == share.OwnerID {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("secret can not be shared with its owner"),
+		)
+	}
+	// the owner is looked up before the write, so a failed lookup does not
+	// leave a share the client was told failed
+	owner, err := s.users.FindByID(ctx, share.OwnerID)
+	if err != nil {
+		return err
+	}
+	share.CreatedAt = time.Now()
+	err = s.repo.SaveShare(ctx, *share)
+	if err != nil {
+		return err
+	}
+	s.sendEventTo(sharedCopy(&current, owner.Email, *share), events.TypeCreated, []string{share.UserID})
+
+	return nil
+}
+
+func (s *SecretsUseCase) RevokeShare(ctx context.Context, ownerID, secretID, userID string) error {
+	current, err := s.getOwnSecret(ctx, ownerID, secretID)
+	if err != nil {
+		return err
+	}
+	err = s.revoke(ctx, current.ID, userID)
+	if err != nil {
+		return err
+	}
+	s.sendEventTo(&current, events.TypeDeleted, []string{userID})
+
+	return nil
+}
+
+func (s *SecretsUseCase) GetSecretShares(ctx context.Context, ownerID, secretID string) ([]entities.Share, error) {
+	_, err := s.getOwnSecret(ctx, ownerID, secretID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetSecretShares(ctx, ownerID, secretID)
+}
+
+func (s *SecretsUseCase) revoke(ctx context.Context, secretID, userID string) error {
+	revoked, err := s.repo.RevokeShare(ctx, secretID, userID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("secret %v is not shared with user %v", secretID, userID),
+		)
+	}
+	return nil
+}
+
+// getOwnSecret returns the secret only if the user owns it or may change the
+// secrets of its collection, shared secrets can not be shared further, rolled
+// back or purged by their recipients
+func (s *SecretsUseCase) getOwnSecret(ctx context.Context, userID, secretID string) (events.SecretDTO, error) {
+	current, err := s.repo.GetUserSecret(ctx, userID, secretID)
+	if err != nil {
+		return current, errors.NewInvalidArgumentError(
+			fmt.Errorf("secret %v not found", secretID),
+		)
+	}
+	if current.CollectionID != "" {
+		// the permission is derived from the user's current role
+		if current.Permission != entities.PermissionReadWrite {
+			return current, errors.NewPermissionDeniedError(
+				fmt.Errorf("secret %v is read-only for user", secretID),
+			)
+		}
+		return current, nil
+	}
+	if current.UserID != userID {
+		return current, errors.NewPermissionDeniedError(
+			fmt.Errorf("secret %v is not owned by user", secretID),
+		)
+	}
+	return current, nil
+}
+
+// getDeletedSecret returns the secret from the trash if the user owns it or
+// currently may change the secrets of its collection
+func (s *SecretsUseCase) getDeletedSecret(ctx context.Context, userID, secretID string) (entities.DeletedSecret, error) {
+	deleted, err := s.repo.GetUserDeletedSecret(ctx, userID, secretID)
+	if err != nil {
+		return deleted, errors.NewInvalidArgumentError(
+			fmt.Errorf("secret %v not found in trash", secretID),
+		)
+	}
+	if deleted.CollectionID != "" {
+		err = s.checkCollectionRole(ctx, deleted.CollectionID, userID)
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// checkCollectionAccess allows to add secrets to a collection only to
+// members of its organization that may change its secrets
+func (s *SecretsUseCase) checkCollectionAccess(ctx context.Context, secret *entities.Secret) error {
+	if secret.CollectionID == "" {
+		return nil
+	}
+	return s.checkCollectionRole(ctx, secret.CollectionID, secret.UserID)
+}
+
+func (s *SecretsUseCase) checkCollectionRole(ctx context.Context, collectionID, userID string) error {
+	collection, err := s.orgs.GetCollection(ctx, collectionID)
+	if err != nil {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("collection %v not found", collectionID),
+		)
+	}
+	member, err := s.orgs.GetMember(ctx, collection.OrganizationID, userID)
+	if err != nil {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("collection %v not found", collectionID),
+		)
+	}
+	if !entities.CanWrite(member.Role) {
+		return errors.NewPermissionDeniedError(
+			fmt.Errorf("collection %v is read-only for user", collectionID),
+		)
+	}
+	return nil
+}
+
+func (s *SecretsUseCase) collectBlobs(ctx context.Context, userID string) error {
+	live, err := s.repo.GetUserBlobRefs(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.blobs.Collect(ctx, userID, live)
+}
+
+func checkVersion(current events.SecretDTO, secret *entities.Secret) error {
+	if current.SecretType != secret.SecretType {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("secret type can not be changed"),
+		)
+	}
+	if secret.Version == 0 {
+		return errors.NewInvalidArgumentError(
+			fmt.Errorf("expected version of secret %v is required", secret.ID),
+		)
+	}
+	if current.Version != secret.Version {
+		return errors.NewConflictError(
+			fmt.Errorf("secret %v was changed on another device", secret.ID),
+		)
+	}
+	return nil
+}
+
+// checkWriteAccess rejects writes of read-only recipients and members and
+// redirects writes of other recipients and members to the owner's copy
+func checkWriteAccess(current events.SecretDTO, secret *entities.Secret) error {
+	if current.UserID == secret.UserID && current.CollectionID == "" {
+		return nil
+	}
+	if current.Permission != entities.PermissionReadWrite {
+		return errors.NewPermissionDeniedError(
+			fmt.Errorf("secret %v is shared read-only", secret.ID),
+		)
+	}
+	secret.UserID = current.UserID
+	secret.OwnerKey = nil
+	// folders are personal, only the owner of a secret can move it
+	secret.FolderID = nil
+	return nil
+}
+
+func conflictError(err error, secretID string) error {
+	if stderrors.Is(err, entities.ErrVersionConflict) {
+		return errors.NewConflictError(
+			fmt.Errorf("secret %v was changed on another device", secretID),
+		)
+	}
+	return err
+}
+
+func (s *SecretsUseCase) sendEvent(ctx context.Context, dto *events.SecretDTO, eventType int) {
+	s.fanOut(ctx, dto, func(secret *events.SecretDTO, recipients []string) {
+		s.sendEventTo(secret, eventType, recipients)
+	})
+}
+
+// fanOut passes to send the copy of the secret its recipients should see
+func (s *SecretsUseCase) fanOut(ctx context.Context, dto *events.SecretDTO, send func(secret *events.SecretDTO, recipients []string)) {
+	if dto.CollectionID != "" {
+		s.fanOutOrganization(ctx, dto, send)
+		return
+	}
+	send(dto, []string{dto.UserID})
+
+	// recipients that miss the notification catch up on their next sync
+	shares, err := s.repo.GetSecretShares(ctx, dto.UserID, dto.ID)
+	if err != nil || len(shares) == 0 {
+		return
+	}
+	owner, err := s.users.FindByID(ctx, dto.UserID)
+	if err != nil {
+		return
+	}
+	for _, share := range shares {
+		send(sharedCopy(dto, owner.Email, share), []string{share.UserID})
+	}
+}
+
+func (s *SecretsUseCase) fanOutOrganization(ctx context.Context, dto *events.SecretDTO, send func(secret *events.SecretDTO, recipients []string)) {
+	collection, err := s.orgs.GetCollection(ctx, dto.CollectionID)
+	if err != nil {
+		return
+	}
+	dto.OrganizationID = collection.OrganizationID
+	dto.Owner = collection.OrganizationName
+	dto.FolderID = ""
+	members, err := s.orgs.GetMembers(ctx, collection.OrganizationID)
+	if err != nil {
+		return
+	}
+	for _, member := range members {
+		shared := *dto
+		shared.Permission = entities.SharePermission(member.Role)
+		send(&shared, []string{member.UserID})
+	}
+}
+
+func sharedCopy(dto *events.SecretDTO, ownerEmail string, share entities.Share) *events.SecretDTO {
+	shared := *dto
+	shared.Owner = ownerEmail
+	shared.Permission = share.Permission
+	shared.FolderID = ""
+	return &shared
+}
+
+func (s *SecretsUseCase) sendEventTo(dto *events.SecretDTO, eventType int, recipients []string) {
 	ev := &events.SecretEvent{
-		EventType: eventType,
-		Secret:    dto,
+		EventType:  eventType,
+		Secret:     eventMeta(dto),
+		FetchHint:  eventType != events.TypeDeleted,
+		Recipients: recipients,
 	}
 
 	s.eventCh <- ev
 }
+
+func eventMeta(dto *events.SecretDTO) *events.SecretDTO {
+	meta := *dto
+	meta.Data = nil
+	// item keys are wrapped per user, recipients fetch their own copy
+	meta.WrappedKey = nil
+	return &meta
+}
@@ -0,0 +1,791 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/itohin/gophkeeper/internal/server/entities"
+	"github.com/itohin/gophkeeper/mocks"
+	pkgerrors "github.com/itohin/gophkeeper/pkg/errors"
+	"github.com/itohin/gophkeeper/pkg/events"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ownerID     = "1955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+	recipientID = "2955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+	secretID    = "3955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+)
+
+// inTransaction runs the function of a mocked transaction, its error stands
+// for the rollback
+func inTransaction(ctx context.Context, f func(context.Context) error) error {
+	return f(ctx)
+}
+
+func assertConflict(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+	var conflict *pkgerrors.ConflictError
+	return assert.ErrorAs(t, err, &conflict, msgAndArgs...)
+}
+
+func assertInvalidArgument(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+	var invalid *pkgerrors.InvalidArgumentError
+	return assert.ErrorAs(t, err, &invalid, msgAndArgs...)
+}
+
+func TestSecretsUseCase_Share(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockSecretsStorage(ctrl)
+	users := mocks.NewMockUsersStorage(ctrl)
+
+	current := events.SecretDTO{ID: secretID, UserID: ownerID, Name: "note", SecretType: entities.TypeText, Version: 1}
+
+	tests := []struct {
+		name      string
+		email     string
+		mockTimes map[string]int
+		errors    map[string]error
+		wantEvent bool
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:  "share",
+			email: "friend@mail.ru",
+			mockTimes: map[string]int{
+				"get_secret": 1,
+				"public_key": 1,
+				"find_owner": 1,
+				"save_share": 1,
+			},
+			errors:    map[string]error{},
+			wantEvent: true,
+			wantErr:   assert.NoError,
+		},
+		{
+			name:  "unknown recipient",
+			email: "nobody@mail.ru",
+			mockTimes: map[string]int{
+				"get_secret": 1,
+				"public_key": 1,
+				"find_owner": 0,
+				"save_share": 0,
+			},
+			errors: map[string]error{
+				"public_key": errors.New("not found"),
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:  "owner lookup error does not save the share",
+			email: "friend@mail.ru",
+			mockTimes: map[string]int{
+				"get_secret": 1,
+				"public_key": 1,
+				"find_owner": 1,
+				"save_share": 0,
+			},
+			errors: map[string]error{
+				"find_owner": errors.New("db error"),
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:  "save error",
+			email: "friend@mail.ru",
+			mockTimes: map[string]int{
+				"get_secret": 1,
+				"public_key": 1,
+				"find_owner": 1,
+				"save_share": 1,
+			},
+			errors: map[string]error{
+				"save_share": errors.New("db error"),
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventCh := make(chan *events.SecretEvent, 1)
+			s := &SecretsUseCase{repo: repo, users: users, eventCh: eventCh}
+
+			repo.EXPECT().GetUserSecret(gomock.Any(), ownerID, secretID).Return(current, nil).Times(tt.mockTimes["get_secret"])
+			users.EXPECT().FindPublicKey(gomock.Any(), tt.email).Return(recipientID, []byte("key"), tt.errors["public_key"]).Times(tt.mockTimes["public_key"])
+			users.EXPECT().FindByID(gomock.Any(), ownerID).
+				Return(&entities.User{ID: uuid.MustParse(ownerID), Email: "owner@mail.ru"}, tt.errors["find_owner"]).
+				Times(tt.mockTimes["find_owner"])
+			repo.EXPECT().SaveShare(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, share entities.Share) error {
+					assert.Equal(t, recipientID, share.UserID)
+					assert.Equal(t, uint32(entities.PermissionRead), share.Permission)
+					return tt.errors["save_share"]
+				}).
+				Times(tt.mockTimes["save_share"])
+
+			err := s.Share(context.Background(), &entities.Share{
+				SecretID:   secretID,
+				OwnerID:    ownerID,
+				Email:      tt.email,
+				Permission: entities.PermissionRead,
+				WrappedKey: []byte("wrapped"),
+			})
+			tt.wantErr(t, err)
+
+			if !tt.wantEvent {
+				assert.Len(t, eventCh, 0)
+				return
+			}
+			ev := <-eventCh
+			assert.Equal(t, events.TypeCreated, ev.EventType)
+			assert.Equal(t, []string{recipientID}, ev.Recipients)
+			assert.Equal(t, "owner@mail.ru", ev.Secret.Owner)
+			assert.Equal(t, uint32(entities.PermissionRead), ev.Secret.Permission)
+		})
+	}
+}
+
+func TestSecretsUseCase_sendEventToShares(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockSecretsStorage(ctrl)
+	users := mocks.NewMockUsersStorage(ctrl)
+	eventCh := make(chan *events.SecretEvent, 2)
+	s := &SecretsUseCase{repo: repo, users: users, eventCh: eventCh}
+
+	repo.EXPECT().GetSecretShares(gomock.Any(), ownerID, secretID).Return([]entities.Share{
+		{SecretID: secretID, OwnerID: ownerID, UserID: recipientID, Permission: entities.PermissionReadWrite},
+	}, nil).Times(1)
+	users.EXPECT().FindByID(gomock.Any(), ownerID).Return(&entities.User{ID: uuid.MustParse(ownerID), Email: "owner@mail.ru"}, nil).Times(1)
+
+	s.sendEvent(context.Background(), &events.SecretDTO{ID: secretID, UserID: ownerID, FolderID: "folder"}, events.TypeUpdated)
+
+	own := <-eventCh
+	assert.Equal(t, []string{ownerID}, own.Recipients)
+	assert.Equal(t, "folder", own.Secret.FolderID)
+	shared := <-eventCh
+	assert.Equal(t, []string{recipientID}, shared.Recipients)
+	assert.Equal(t, "owner@mail.ru", shared.Secret.Owner)
+	assert.Equal(t, uint32(entities.PermissionReadWrite), shared.Secret.Permission)
+	assert.Equal(t, "", shared.Secret.FolderID)
+}
+
+func TestSecretsUseCase_saveIdempotent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockSecretsStorage(ctrl)
+	tx := mocks.NewMockDBTransactionManager(ctrl)
+
+	const firstID = "4955a7d6-0968-425b-bdb6-fb9a0e4b39e7"
+	keyTTL := time.Hour
+	original := events.SecretDTO{ID: firstID, UserID: ownerID, Name: "note", SecretType: entities.TypeText, Version: 1}
+	created := events.SecretDTO{ID: secretID, UserID: ownerID, Name: "note", SecretType: entities.TypeText, Version: 1}
+
+	tests := []struct {
+		name      string
+		boundID   string
+		mockTimes map[string]int
+		errors    map[string]error
+		want      *events.SecretDTO
+		wantEvent bool
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "retry returns the original secret",
+			boundID: firstID,
+			mockTimes: map[string]int{
+				"get_secret": 1,
+				"save":       0,
+			},
+			errors:  map[string]error{},
+			want:    &original,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "retry of a purged secret returns its id",
+			boundID: firstID,
+			mockTimes: map[string]int{
+				"get_secret": 1,
+				"save":       0,
+			},
+			errors: map[string]error{
+				"get_secret": errors.New("not found"),
+			},
+			want:    &events.SecretDTO{ID: firstID, UserID: ownerID},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "same key with another payload",
+			mockTimes: map[string]int{
+				"get_secret": 0,
+				"save":       0,
+			},
+			errors: map[string]error{
+				"claim": entities.ErrIdempotencyKeyReused,
+			},
+			wantErr: assert.Error,
+		},
+		{
+			// the repository rebinds a key older than the ttl to the new secret
+			name:    "expired key is claimed again",
+			boundID: secretID,
+			mockTimes: map[string]int{
+				"get_secret": 1,
+				"save":       1,
+			},
+			errors: map[string]error{
+				"get_secret": errors.New("not found"),
+			},
+			want:      &created,
+			wantEvent: true,
+			wantErr:   assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventCh := make(chan *events.SecretEvent, 1)
+			s := &SecretsUseCase{repo: repo, tx: tx, eventCh: eventCh, keyTTL: keyTTL}
+			secret := &entities.Secret{
+				ID:             secretID,
+				UserID:         ownerID,
+				Name:           "note",
+				SecretType:     entities.TypeText,
+				Data:           []byte(`{"Text":"text"}`),
+				IdempotencyKey: "key",
+			}
+			hash, err := payloadHash(secret)
+			assert.NoError(t, err)
+
+			tx.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(inTransaction).Times(1)
+			repo.EXPECT().ClaimIdempotencyKey(gomock.Any(), ownerID, "key", secretID, hash, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _, _, _ string, since time.Time) (string, error) {
+					assert.WithinDuration(t, time.Now().Add(-keyTTL), since, time.Minute)
+					return tt.boundID, tt.errors["claim"]
+				}).
+				Times(1)
+			repo.EXPECT().GetUserSecret(gomock.Any(), ownerID, tt.boundID).
+				Return(original, tt.errors["get_secret"]).
+				Times(tt.mockTimes["get_secret"])
+			repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(&created, nil).Times(tt.mockTimes["save"])
+			repo.EXPECT().GetSecretShares(gomock.Any(), ownerID, secretID).Return(nil, nil).AnyTimes()
+
+			dto, err := s.saveIdempotent(context.Background(), secret)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, dto)
+
+			if !tt.wantEvent {
+				assert.Len(t, eventCh, 0)
+				return
+			}
+			ev := <-eventCh
+			assert.Equal(t, events.TypeCreated, ev.EventType)
+			assert.Equal(t, secretID, ev.Secret.ID)
+		})
+	}
+}
+
+func TestSecretsUseCase_create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockSecretsStorage(ctrl)
+	saved := events.SecretDTO{ID: secretID, UserID: ownerID, SecretType: entities.TypeText, Version: 1}
+
+	tests := []struct {
+		name      string
+		id        string
+		mockTimes map[string]int
+		errors    map[string]error
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "create",
+			id:   secretID,
+			mockTimes: map[string]int{
+				"save": 1,
+			},
+			errors:  map[string]error{},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "existing id",
+			id:   secretID,
+			mockTimes: map[string]int{
+				"save": 1,
+			},
+			errors: map[string]error{
+				"save": entities.ErrVersionConflict,
+			},
+			wantErr: assertConflict,
+		},
+		{
+			name: "save error",
+			id:   secretID,
+			mockTimes: map[string]int{
+				"save": 1,
+			},
+			errors: map[string]error{
+				"save": errors.New("db error"),
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "id is not a uuid",
+			id:   "note",
+			mockTimes: map[string]int{
+				"save": 0,
+			},
+			errors:  map[string]error{},
+			wantErr: assertInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SecretsUseCase{repo: repo}
+
+			repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(&saved, tt.errors["save"]).Times(tt.mockTimes["save"])
+
+			_, err := s.create(context.Background(), &entities.Secret{
+				ID:         tt.id,
+				UserID:     ownerID,
+				SecretType: entities.TypeText,
+				Data:       []byte(`{"Text":"text"}`),
+			})
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestSecretsUseCase_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockSecretsStorage(ctrl)
+	current := events.SecretDTO{ID: secretID, UserID: ownerID, SecretType: entities.TypeText, Version: 2}
+	saved := events.SecretDTO{ID: secretID, UserID: ownerID, SecretType: entities.TypeText, Version: 3}
+
+	tests := []struct {
+		name      string
+		version   int64
+		mockTimes map[string]int
+		errors    map[string]error
+		wantEvent bool
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "update",
+			version: 2,
+			mockTimes: map[string]int{
+				"get_secret": 1,
+				"save":       1,
+			},
+			errors:    map[string]error{},
+			wantEvent: true,
+			wantErr:   assert.NoError,
+		},
+		{
+			name:    "stale version",
+			version: 1,
+			mockTimes: map[string]int{
+				"get_secret": 1,
+				"save":       0,
+			},
+			errors:  map[string]error{},
+			wantErr: assertConflict,
+		},
+		{
+			name:    "changed between the check and the save",
+			version: 2,
+			mockTimes: map[string]int{
+				"get_secret": 1,
+				"save":       1,
+			},
+			errors: map[string]error{
+				"save": entities.ErrVersionConflict,
+			},
+			wantErr: assertConflict,
+		},
+		{
+			name:    "secret not found",
+			version: 2,
+			mockTimes: map[string]int{
+				"get_secret": 1,
+				"save":       0,
+			},
+			errors: map[string]error{
+				"get_secret": errors.New("not found"),
+			},
+			wantErr: assertInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventCh := make(chan *events.SecretEvent, 1)
+			s := &SecretsUseCase{repo: repo, eventCh: eventCh}
+
+			repo.EXPECT().GetUserSecret(gomock.Any(), ownerID, secretID).Return(current, tt.errors["get_secret"]).Times(tt.mockTimes["get_secret"])
+			repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(&saved, tt.errors["save"]).Times(tt.mockTimes["save"])
+			repo.EXPECT().GetSecretShares(gomock.Any(), ownerID, secretID).Return(nil, nil).AnyTimes()
+
+			_, err := s.Update(context.Background(), &entities.Secret{
+				ID:         secretID,
+				UserID:     ownerID,
+				SecretType: entities.TypeText,
+				Data:       []byte(`{"Text":"text"}`),
+				Version:    tt.version,
+			})
+			tt.wantErr(t, err)
+
+			if !tt.wantEvent {
+				assert.Len(t, eventCh, 0)
+				return
+			}
+			ev := <-eventCh
+			assert.Equal(t, events.TypeUpdated, ev.EventType)
+			assert.Equal(t, int64(3), ev.Secret.Version)
+		})
+	}
+}
+
+func TestSecretsUseCase_SaveBinary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockSecretsStorage(ctrl)
+	blobs := mocks.NewMockBlobStore(ctrl)
+	current := events.SecretDTO{ID: secretID, UserID: ownerID, SecretType: entities.TypeBinary, Version: 2}
+	saved := events.SecretDTO{ID: secretID, UserID: ownerID, SecretType: entities.TypeBinary, Version: 3}
+
+	tests := []struct {
+		name       string
+		id         string
+		secretType uint32
+		version    int64
+		mockTimes  map[string]int
+		errors     map[string]error
+		wantEvent  int
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "new binary",
+			id:         secretID,
+			secretType: entities.TypeBinary,
+			mockTimes: map[string]int{
+				"get_secret": 1,
+				"put":        1,
+				"save":       1,
+			},
+			errors: map[string]error{
+				"get_secret": errors.New("not found"),
+			},
+			wantEvent: events.TypeCreated,
+			wantErr:   assert.NoError,
+		},
+		{
+			name:       "new binary with an existing id",
+			id:         secretID,
+			secretType: entities.TypeBinary,
+			mockTimes: map[string]int{
+				"get_secret": 1,
+			},
+			errors:  map[string]error{},
+			wantErr: assertConflict,
+		},
+		{
+			name:       "new binary with an invalid id",
+			id:         "binary",
+			secretType: entities.TypeBinary,
+			mockTimes:  map[string]int{},
+			errors:     map[string]error{},
+			wantErr:    assertInvalidArgument,
+		},
+		{
+			name:       "update",
+			id:         secretID,
+			secretType: entities.TypeBinary,
+			version:    2,
+			mockTimes: map[string]int{
+				"get_secret": 1,
+				"put":        1,
+				"save":       1,
+			},
+			errors:    map[string]error{},
+			wantEvent: events.TypeUpdated,
+			wantErr:   assert.NoError,
+		},
+		{
+			name:       "stale version is rejected before the content is stored",
+			id:         secretID,
+			secretType: entities.TypeBinary,
+			version:    1,
+			mockTimes: map[string]int{
+				"get_secret": 1,
+			},
+			errors:  map[string]error{},
+			wantErr: assertConflict,
+		},
+		{
+			name:       "not a binary",
+			id:         secretID,
+			secretType: entities.TypeText,
+			version:    2,
+			mockTimes:  map[string]int{},
+			errors:     map[string]error{},
+			wantErr:    assertInvalidArgument,
+		},
+		{
+			name:       "failed save collects blobs",
+			id:         secretID,
+			secretType: entities.TypeBinary,
+			version:    2,
+			mockTimes: map[string]int{
+				"get_secret": 1,
+				"put":        1,
+				"save":       1,
+				"collect":    1,
+			},
+			errors: map[string]error{
+				"save": entities.ErrVersionConflict,
+			},
+			wantErr: assertConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventCh := make(chan *events.SecretEvent, 1)
+			s := &SecretsUseCase{repo: repo, blobs: blobs, eventCh: eventCh}
+
+			repo.EXPECT().GetUserSecret(gomock.Any(), ownerID, tt.id).Return(current, tt.errors["get_secret"]).Times(tt.mockTimes["get_secret"])
+			blobs.EXPECT().Put(gomock.Any(), ownerID, gomock.Any()).Return(entities.Blob{Ref: "ref", Size: 7}, nil).Times(tt.mockTimes["put"])
+			repo.EXPECT().Save(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, secret entities.Secret) (*events.SecretDTO, error) {
+					assert.Equal(t, "ref", secret.BlobRef)
+					assert.Equal(t, int64(7), secret.BlobSize)
+					if err := tt.errors["save"]; err != nil {
+						return nil, err
+					}
+					return &saved, nil
+				}).
+				Times(tt.mockTimes["save"])
+			repo.EXPECT().GetUserBlobRefs(gomock.Any(), ownerID).Return([]string{}, nil).Times(tt.mockTimes["collect"])
+			blobs.EXPECT().Collect(gomock.Any(), ownerID, []string{}).Return(nil).Times(tt.mockTimes["collect"])
+			repo.EXPECT().GetSecretShares(gomock.Any(), ownerID, secretID).Return(nil, nil).AnyTimes()
+
+			_, err := s.SaveBinary(context.Background(), &entities.Secret{
+				ID:         tt.id,
+				UserID:     ownerID,
+				SecretType: tt.secretType,
+				Data:       []byte(`{"Sealed":"c2VhbGVk"}`),
+				Version:    tt.version,
+			}, strings.NewReader("content"))
+			tt.wantErr(t, err)
+
+			if tt.wantEvent == 0 {
+				assert.Len(t, eventCh, 0)
+				return
+			}
+			ev := <-eventCh
+			assert.Equal(t, tt.wantEvent, ev.EventType)
+		})
+	}
+}
+
+func TestSecretsUseCase_GetChangesSince(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockSecretsStorage(ctrl)
+	s := &SecretsUseCase{repo: repo}
+
+	changes := []entities.SecretChange{
+		{Secret: events.SecretDTO{ID: firstID}, Seq: 11},
+		{Secret: events.SecretDTO{ID: sharedID}, Deleted: true, Seq: 12},
+		{Secret: events.SecretDTO{ID: thirdID}, Seq: 14},
+	}
+
+	tests := []struct {
+		name        string
+		cursor      int64
+		pageSize    int
+		changes     []entities.SecretChange
+		wantLimit   int
+		wantChanges int
+		want        *entities.ChangesPage
+	}{
+		{
+			name:        "last page",
+			cursor:      10,
+			pageSize:    3,
+			changes:     changes,
+			wantLimit:   4,
+			wantChanges: 3,
+			want:        &entities.ChangesPage{Cursor: 14},
+		},
+		{
+			name:        "more pages",
+			cursor:      10,
+			pageSize:    2,
+			changes:     changes,
+			wantLimit:   3,
+			wantChanges: 2,
+			want:        &entities.ChangesPage{Cursor: 12, HasMore: true},
+		},
+		{
+			name:        "no changes",
+			cursor:      20,
+			pageSize:    2,
+			changes:     []entities.SecretChange{},
+			wantLimit:   3,
+			wantChanges: 0,
+			want:        &entities.ChangesPage{Cursor: 20},
+		},
+		{
+			name:        "default page size",
+			cursor:      10,
+			changes:     changes,
+			wantLimit:   entities.DefaultPageSize + 1,
+			wantChanges: 3,
+			want:        &entities.ChangesPage{Cursor: 14},
+		},
+		{
+			name:      "cursor before purged changes",
+			cursor:    4,
+			pageSize:  2,
+			wantLimit: 0,
+			want:      &entities.ChangesPage{Cursor: 20, Resync: true},
+		},
+		{
+			name:      "cursor ahead of the server",
+			cursor:    21,
+			pageSize:  2,
+			wantLimit: 0,
+			want:      &entities.ChangesPage{Cursor: 20, Resync: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.EXPECT().GetChangeSequence(gomock.Any(), ownerID).Return(int64(20), int64(5), nil).Times(1)
+			if tt.wantLimit > 0 {
+				repo.EXPECT().GetChangesSince(gomock.Any(), ownerID, tt.cursor, tt.wantLimit).Return(tt.changes, nil).Times(1)
+			}
+
+			page, err := s.GetChangesSince(context.Background(), ownerID, tt.cursor, tt.pageSize)
+			assert.NoError(t, err)
+			assert.Len(t, page.Changes, tt.wantChanges)
+			page.Changes = nil
+			assert.Equal(t, tt.want, page)
+		})
+	}
+}
+
+func Test_checkVersion(t *testing.T) {
+	current := events.SecretDTO{ID: secretID, SecretType: entities.TypeText, Version: 2}
+	tests := []struct {
+		name    string
+		secret  *entities.Secret
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "same version",
+			secret:  &entities.Secret{ID: secretID, SecretType: entities.TypeText, Version: 2},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "stale version",
+			secret:  &entities.Secret{ID: secretID, SecretType: entities.TypeText, Version: 1},
+			wantErr: assertConflict,
+		},
+		{
+			name:    "no version",
+			secret:  &entities.Secret{ID: secretID, SecretType: entities.TypeText},
+			wantErr: assertInvalidArgument,
+		},
+		{
+			name:    "another type",
+			secret:  &entities.Secret{ID: secretID, SecretType: entities.TypeCard, Version: 2},
+			wantErr: assertInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, checkVersion(current, tt.secret))
+		})
+	}
+}
+
+func Test_conflictError(t *testing.T) {
+	err := conflictError(entities.ErrVersionConflict, secretID)
+	assertConflict(t, err)
+	assert.Contains(t, err.Error(), secretID)
+
+	other := errors.New("db error")
+	assert.Equal(t, other, conflictError(other, secretID))
+}
+
+func Test_payloadHash(t *testing.T) {
+	secret := &entities.Secret{ID: secretID, SecretType: entities.TypeText, Data: []byte(`{"Text":"text"}`), IdempotencyKey: "first"}
+	first, err := payloadHash(secret)
+	assert.NoError(t, err)
+
+	secret.IdempotencyKey = "second"
+	second, err := payloadHash(secret)
+	assert.NoError(t, err)
+	assert.Equal(t, first, second, "the key is not part of the payload")
+
+	secret.Data = []byte(`{"Text":"other"}`)
+	other, err := payloadHash(secret)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, other)
+}
+
+func Test_checkSealed(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  *entities.Secret
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "sealed ssh key",
+			secret:  &entities.Secret{SecretType: entities.TypeSSHKey, Data: []byte(`{"Sealed":"c2VhbGVk"}`)},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "plain ssh key",
+			secret:  &entities.Secret{SecretType: entities.TypeSSHKey, Data: []byte(`{"PrivateKey":"key"}`)},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "ssh key without data",
+			secret:  &entities.Secret{SecretType: entities.TypeSSHKey},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "plain totp",
+			secret:  &entities.Secret{SecretType: entities.TypeTOTP, Data: []byte(`{"Secret":"JBSWY3DPEHPK3PXP"}`)},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "sealed totp",
+			secret:  &entities.Secret{SecretType: entities.TypeTOTP, Data: []byte(`{"Sealed":"c2VhbGVk"}`)},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "plain text",
+			secret:  &entities.Secret{SecretType: entities.TypeText, Data: []byte(`{"Text":"text"}`)},
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, checkSealed(tt.secret))
+		})
+	}
+}
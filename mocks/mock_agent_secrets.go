@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/itohin/gophkeeper/internal/client/adapters/sshagent (interfaces: Secrets)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+// MockAgentSecrets is a mock of Secrets interface.
+type MockAgentSecrets struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgentSecretsMockRecorder
+}
+
+// MockAgentSecretsMockRecorder is the mock recorder for MockAgentSecrets.
+type MockAgentSecretsMockRecorder struct {
+	mock *MockAgentSecrets
+}
+
+// NewMockAgentSecrets creates a new mock instance.
+func NewMockAgentSecrets(ctrl *gomock.Controller) *MockAgentSecrets {
+	mock := &MockAgentSecrets{ctrl: ctrl}
+	mock.recorder = &MockAgentSecretsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgentSecrets) EXPECT() *MockAgentSecretsMockRecorder {
+	return m.recorder
+}
+
+// GetSecret mocks base method.
+func (m *MockAgentSecrets) GetSecret(arg0 context.Context, arg1 string) (*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret.
+func (mr *MockAgentSecretsMockRecorder) GetSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockAgentSecrets)(nil).GetSecret), arg0, arg1)
+}
+
+// GetSecrets mocks base method.
+func (m *MockAgentSecrets) GetSecrets(arg0 context.Context) (map[string]*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecrets", arg0)
+	ret0, _ := ret[0].(map[string]*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecrets indicates an expected call of GetSecrets.
+func (mr *MockAgentSecretsMockRecorder) GetSecrets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecrets", reflect.TypeOf((*MockAgentSecrets)(nil).GetSecrets), arg0)
+}
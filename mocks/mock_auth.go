@@ -34,6 +34,35 @@ func (m *MockAuth) EXPECT() *MockAuthMockRecorder {
 	return m.recorder
 }
 
+// CreateMasterPassword mocks base method.
+func (m *MockAuth) CreateMasterPassword(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMasterPassword", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMasterPassword indicates an expected call of CreateMasterPassword.
+func (mr *MockAuthMockRecorder) CreateMasterPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMasterPassword", reflect.TypeOf((*MockAuth)(nil).CreateMasterPassword), arg0, arg1)
+}
+
+// HasMasterPassword mocks base method.
+func (m *MockAuth) HasMasterPassword(arg0 context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasMasterPassword", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasMasterPassword indicates an expected call of HasMasterPassword.
+func (mr *MockAuthMockRecorder) HasMasterPassword(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasMasterPassword", reflect.TypeOf((*MockAuth)(nil).HasMasterPassword), arg0)
+}
+
 // Login mocks base method.
 func (m *MockAuth) Login(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -76,6 +105,20 @@ func (mr *MockAuthMockRecorder) Register(arg0, arg1, arg2 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuth)(nil).Register), arg0, arg1, arg2)
 }
 
+// Unlock mocks base method.
+func (m *MockAuth) Unlock(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockAuthMockRecorder) Unlock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockAuth)(nil).Unlock), arg0, arg1)
+}
+
 // Verify mocks base method.
 func (m *MockAuth) Verify(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/itohin/gophkeeper/internal/client/adapters/cli (interfaces: Backup)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+// MockBackup is a mock of Backup interface.
+type MockBackup struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupMockRecorder
+}
+
+// MockBackupMockRecorder is the mock recorder for MockBackup.
+type MockBackupMockRecorder struct {
+	mock *MockBackup
+}
+
+// NewMockBackup creates a new mock instance.
+func NewMockBackup(ctrl *gomock.Controller) *MockBackup {
+	mock := &MockBackup{ctrl: ctrl}
+	mock.recorder = &MockBackupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackup) EXPECT() *MockBackupMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockBackup) Export(arg0 context.Context, arg1 io.Writer, arg2, arg3 string) (*entities.BackupResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entities.BackupResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockBackupMockRecorder) Export(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockBackup)(nil).Export), arg0, arg1, arg2, arg3)
+}
+
+// Restore mocks base method.
+func (m *MockBackup) Restore(arg0 context.Context, arg1 io.Reader, arg2 string, arg3 func(int, int)) (*entities.BackupResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entities.BackupResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBackupMockRecorder) Restore(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBackup)(nil).Restore), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/itohin/gophkeeper/internal/client/usecases/backup (interfaces: Secrets,Archiver)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+// MockBackupSecrets is a mock of Secrets interface.
+type MockBackupSecrets struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupSecretsMockRecorder
+}
+
+// MockBackupSecretsMockRecorder is the mock recorder for MockBackupSecrets.
+type MockBackupSecretsMockRecorder struct {
+	mock *MockBackupSecrets
+}
+
+// NewMockBackupSecrets creates a new mock instance.
+func NewMockBackupSecrets(ctrl *gomock.Controller) *MockBackupSecrets {
+	mock := &MockBackupSecrets{ctrl: ctrl}
+	mock.recorder = &MockBackupSecretsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackupSecrets) EXPECT() *MockBackupSecretsMockRecorder {
+	return m.recorder
+}
+
+// CreateSecret mocks base method.
+func (m *MockBackupSecrets) CreateSecret(arg0 context.Context, arg1 *entities.Secret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSecret indicates an expected call of CreateSecret.
+func (mr *MockBackupSecretsMockRecorder) CreateSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockBackupSecrets)(nil).CreateSecret), arg0, arg1)
+}
+
+// DownloadBinary mocks base method.
+func (m *MockBackupSecrets) DownloadBinary(arg0 context.Context, arg1 *entities.Secret, arg2 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadBinary", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DownloadBinary indicates an expected call of DownloadBinary.
+func (mr *MockBackupSecretsMockRecorder) DownloadBinary(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadBinary", reflect.TypeOf((*MockBackupSecrets)(nil).DownloadBinary), arg0, arg1, arg2)
+}
+
+// GetSecret mocks base method.
+func (m *MockBackupSecrets) GetSecret(arg0 context.Context, arg1 string) (*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret.
+func (mr *MockBackupSecretsMockRecorder) GetSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockBackupSecrets)(nil).GetSecret), arg0, arg1)
+}
+
+// GetSecrets mocks base method.
+func (m *MockBackupSecrets) GetSecrets(arg0 context.Context) (map[string]*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecrets", arg0)
+	ret0, _ := ret[0].(map[string]*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecrets indicates an expected call of GetSecrets.
+func (mr *MockBackupSecretsMockRecorder) GetSecrets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecrets", reflect.TypeOf((*MockBackupSecrets)(nil).GetSecrets), arg0)
+}
+
+// UploadBinary mocks base method.
+func (m *MockBackupSecrets) UploadBinary(arg0 context.Context, arg1 *entities.Secret, arg2 io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadBinary", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadBinary indicates an expected call of UploadBinary.
+func (mr *MockBackupSecretsMockRecorder) UploadBinary(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadBinary", reflect.TypeOf((*MockBackupSecrets)(nil).UploadBinary), arg0, arg1, arg2)
+}
+
+// MockArchiver is a mock of Archiver interface.
+type MockArchiver struct {
+	ctrl     *gomock.Controller
+	recorder *MockArchiverMockRecorder
+}
+
+// MockArchiverMockRecorder is the mock recorder for MockArchiver.
+type MockArchiverMockRecorder struct {
+	mock *MockArchiver
+}
+
+// NewMockArchiver creates a new mock instance.
+func NewMockArchiver(ctrl *gomock.Controller) *MockArchiver {
+	mock := &MockArchiver{ctrl: ctrl}
+	mock.recorder = &MockArchiverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArchiver) EXPECT() *MockArchiverMockRecorder {
+	return m.recorder
+}
+
+// OpenArchive mocks base method.
+func (m *MockArchiver) OpenArchive(arg0 string, arg1 io.Reader) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenArchive", arg0, arg1)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenArchive indicates an expected call of OpenArchive.
+func (mr *MockArchiverMockRecorder) OpenArchive(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenArchive", reflect.TypeOf((*MockArchiver)(nil).OpenArchive), arg0, arg1)
+}
+
+// SealArchive mocks base method.
+func (m *MockArchiver) SealArchive(arg0 string, arg1 io.Reader) (io.Reader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SealArchive", arg0, arg1)
+	ret0, _ := ret[0].(io.Reader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SealArchive indicates an expected call of SealArchive.
+func (mr *MockArchiverMockRecorder) SealArchive(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SealArchive", reflect.TypeOf((*MockArchiver)(nil).SealArchive), arg0, arg1)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/itohin/gophkeeper/internal/client/usecases/report (interfaces: Breaches)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBreaches is a mock of Breaches interface.
+type MockBreaches struct {
+	ctrl     *gomock.Controller
+	recorder *MockBreachesMockRecorder
+}
+
+// MockBreachesMockRecorder is the mock recorder for MockBreaches.
+type MockBreachesMockRecorder struct {
+	mock *MockBreaches
+}
+
+// NewMockBreaches creates a new mock instance.
+func NewMockBreaches(ctrl *gomock.Controller) *MockBreaches {
+	mock := &MockBreaches{ctrl: ctrl}
+	mock.recorder = &MockBreachesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBreaches) EXPECT() *MockBreachesMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockBreaches) Check(arg0 []string) ([]uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", arg0)
+	ret0, _ := ret[0].([]uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockBreachesMockRecorder) Check(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockBreaches)(nil).Check), arg0)
+}
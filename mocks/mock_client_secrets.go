@@ -0,0 +1,685 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/itohin/gophkeeper/internal/client/usecases/secrets (interfaces: Client,Storage,SyncState,Cipher,OrganizationKeys)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+// MockSecretsClient is a mock of Client interface.
+type MockSecretsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretsClientMockRecorder
+}
+
+// MockSecretsClientMockRecorder is the mock recorder for MockSecretsClient.
+type MockSecretsClientMockRecorder struct {
+	mock *MockSecretsClient
+}
+
+// NewMockSecretsClient creates a new mock instance.
+func NewMockSecretsClient(ctrl *gomock.Controller) *MockSecretsClient {
+	mock := &MockSecretsClient{ctrl: ctrl}
+	mock.recorder = &MockSecretsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretsClient) EXPECT() *MockSecretsClientMockRecorder {
+	return m.recorder
+}
+
+// CreateFolder mocks base method.
+func (m *MockSecretsClient) CreateFolder(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockSecretsClientMockRecorder) CreateFolder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockSecretsClient)(nil).CreateFolder), arg0, arg1, arg2)
+}
+
+// CreateSecret mocks base method.
+func (m *MockSecretsClient) CreateSecret(arg0 context.Context, arg1 *entities.Secret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSecret indicates an expected call of CreateSecret.
+func (mr *MockSecretsClientMockRecorder) CreateSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockSecretsClient)(nil).CreateSecret), arg0, arg1)
+}
+
+// CreateSecrets mocks base method.
+func (m *MockSecretsClient) CreateSecrets(arg0 context.Context, arg1 []*entities.Secret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecrets", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSecrets indicates an expected call of CreateSecrets.
+func (mr *MockSecretsClientMockRecorder) CreateSecrets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecrets", reflect.TypeOf((*MockSecretsClient)(nil).CreateSecrets), arg0, arg1)
+}
+
+// DeleteFolder mocks base method.
+func (m *MockSecretsClient) DeleteFolder(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFolder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFolder indicates an expected call of DeleteFolder.
+func (mr *MockSecretsClientMockRecorder) DeleteFolder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockSecretsClient)(nil).DeleteFolder), arg0, arg1)
+}
+
+// DeleteSecret mocks base method.
+func (m *MockSecretsClient) DeleteSecret(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret.
+func (mr *MockSecretsClientMockRecorder) DeleteSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockSecretsClient)(nil).DeleteSecret), arg0, arg1)
+}
+
+// DeleteSecrets mocks base method.
+func (m *MockSecretsClient) DeleteSecrets(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecrets", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecrets indicates an expected call of DeleteSecrets.
+func (mr *MockSecretsClientMockRecorder) DeleteSecrets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecrets", reflect.TypeOf((*MockSecretsClient)(nil).DeleteSecrets), arg0, arg1)
+}
+
+// DownloadBinary mocks base method.
+func (m *MockSecretsClient) DownloadBinary(arg0 context.Context, arg1 string, arg2 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadBinary", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DownloadBinary indicates an expected call of DownloadBinary.
+func (mr *MockSecretsClientMockRecorder) DownloadBinary(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadBinary", reflect.TypeOf((*MockSecretsClient)(nil).DownloadBinary), arg0, arg1, arg2)
+}
+
+// GetDeletedSecrets mocks base method.
+func (m *MockSecretsClient) GetDeletedSecrets(arg0 context.Context) ([]*entities.DeletedSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedSecrets", arg0)
+	ret0, _ := ret[0].([]*entities.DeletedSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedSecrets indicates an expected call of GetDeletedSecrets.
+func (mr *MockSecretsClientMockRecorder) GetDeletedSecrets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedSecrets", reflect.TypeOf((*MockSecretsClient)(nil).GetDeletedSecrets), arg0)
+}
+
+// GetFolders mocks base method.
+func (m *MockSecretsClient) GetFolders(arg0 context.Context) ([]*entities.Folder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFolders", arg0)
+	ret0, _ := ret[0].([]*entities.Folder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFolders indicates an expected call of GetFolders.
+func (mr *MockSecretsClientMockRecorder) GetFolders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolders", reflect.TypeOf((*MockSecretsClient)(nil).GetFolders), arg0)
+}
+
+// GetPublicKey mocks base method.
+func (m *MockSecretsClient) GetPublicKey(arg0 context.Context, arg1 string) (string, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicKey", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPublicKey indicates an expected call of GetPublicKey.
+func (mr *MockSecretsClientMockRecorder) GetPublicKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicKey", reflect.TypeOf((*MockSecretsClient)(nil).GetPublicKey), arg0, arg1)
+}
+
+// GetRevisions mocks base method.
+func (m *MockSecretsClient) GetRevisions(arg0 context.Context, arg1 string) ([]*entities.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]*entities.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockSecretsClientMockRecorder) GetRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockSecretsClient)(nil).GetRevisions), arg0, arg1)
+}
+
+// GetSecret mocks base method.
+func (m *MockSecretsClient) GetSecret(arg0 context.Context, arg1 string) (*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret.
+func (mr *MockSecretsClientMockRecorder) GetSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockSecretsClient)(nil).GetSecret), arg0, arg1)
+}
+
+// GetShares mocks base method.
+func (m *MockSecretsClient) GetShares(arg0 context.Context, arg1 string) ([]*entities.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShares", arg0, arg1)
+	ret0, _ := ret[0].([]*entities.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShares indicates an expected call of GetShares.
+func (mr *MockSecretsClientMockRecorder) GetShares(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShares", reflect.TypeOf((*MockSecretsClient)(nil).GetShares), arg0, arg1)
+}
+
+// GetTags mocks base method.
+func (m *MockSecretsClient) GetTags(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockSecretsClientMockRecorder) GetTags(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockSecretsClient)(nil).GetTags), arg0)
+}
+
+// PurgeSecret mocks base method.
+func (m *MockSecretsClient) PurgeSecret(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeSecret indicates an expected call of PurgeSecret.
+func (mr *MockSecretsClientMockRecorder) PurgeSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeSecret", reflect.TypeOf((*MockSecretsClient)(nil).PurgeSecret), arg0, arg1)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockSecretsClient) RestoreRevision(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockSecretsClientMockRecorder) RestoreRevision(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockSecretsClient)(nil).RestoreRevision), arg0, arg1, arg2)
+}
+
+// RestoreSecret mocks base method.
+func (m *MockSecretsClient) RestoreSecret(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreSecret indicates an expected call of RestoreSecret.
+func (mr *MockSecretsClientMockRecorder) RestoreSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSecret", reflect.TypeOf((*MockSecretsClient)(nil).RestoreSecret), arg0, arg1)
+}
+
+// RevokeShare mocks base method.
+func (m *MockSecretsClient) RevokeShare(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShare", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockSecretsClientMockRecorder) RevokeShare(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockSecretsClient)(nil).RevokeShare), arg0, arg1, arg2)
+}
+
+// SearchSecrets mocks base method.
+func (m *MockSecretsClient) SearchSecrets(arg0 context.Context, arg1 *entities.SecretsFilter, arg2 string, arg3 uint32) (*entities.SecretsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchSecrets", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entities.SecretsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchSecrets indicates an expected call of SearchSecrets.
+func (mr *MockSecretsClientMockRecorder) SearchSecrets(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSecrets", reflect.TypeOf((*MockSecretsClient)(nil).SearchSecrets), arg0, arg1, arg2, arg3)
+}
+
+// ShareSecret mocks base method.
+func (m *MockSecretsClient) ShareSecret(arg0 context.Context, arg1, arg2 string, arg3 uint32, arg4 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareSecret", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShareSecret indicates an expected call of ShareSecret.
+func (mr *MockSecretsClientMockRecorder) ShareSecret(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareSecret", reflect.TypeOf((*MockSecretsClient)(nil).ShareSecret), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SyncSince mocks base method.
+func (m *MockSecretsClient) SyncSince(arg0 context.Context, arg1 uint64, arg2 uint32) (*entities.ChangesPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncSince", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.ChangesPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncSince indicates an expected call of SyncSince.
+func (mr *MockSecretsClientMockRecorder) SyncSince(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncSince", reflect.TypeOf((*MockSecretsClient)(nil).SyncSince), arg0, arg1, arg2)
+}
+
+// UpdateSecret mocks base method.
+func (m *MockSecretsClient) UpdateSecret(arg0 context.Context, arg1 *entities.Secret) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecret", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecret indicates an expected call of UpdateSecret.
+func (mr *MockSecretsClientMockRecorder) UpdateSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockSecretsClient)(nil).UpdateSecret), arg0, arg1)
+}
+
+// UploadBinary mocks base method.
+func (m *MockSecretsClient) UploadBinary(arg0 context.Context, arg1 *entities.Secret, arg2 io.Reader) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadBinary", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadBinary indicates an expected call of UploadBinary.
+func (mr *MockSecretsClientMockRecorder) UploadBinary(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadBinary", reflect.TypeOf((*MockSecretsClient)(nil).UploadBinary), arg0, arg1, arg2)
+}
+
+// MockSecretsCache is a mock of Storage interface.
+type MockSecretsCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretsCacheMockRecorder
+}
+
+// MockSecretsCacheMockRecorder is the mock recorder for MockSecretsCache.
+type MockSecretsCacheMockRecorder struct {
+	mock *MockSecretsCache
+}
+
+// NewMockSecretsCache creates a new mock instance.
+func NewMockSecretsCache(ctrl *gomock.Controller) *MockSecretsCache {
+	mock := &MockSecretsCache{ctrl: ctrl}
+	mock.recorder = &MockSecretsCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretsCache) EXPECT() *MockSecretsCacheMockRecorder {
+	return m.recorder
+}
+
+// DeleteSecret mocks base method.
+func (m *MockSecretsCache) DeleteSecret(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret.
+func (mr *MockSecretsCacheMockRecorder) DeleteSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockSecretsCache)(nil).DeleteSecret), arg0, arg1)
+}
+
+// GetSecret mocks base method.
+func (m *MockSecretsCache) GetSecret(arg0 context.Context, arg1 string) (*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret.
+func (mr *MockSecretsCacheMockRecorder) GetSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockSecretsCache)(nil).GetSecret), arg0, arg1)
+}
+
+// GetSecrets mocks base method.
+func (m *MockSecretsCache) GetSecrets(arg0 context.Context) (map[string]*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecrets", arg0)
+	ret0, _ := ret[0].(map[string]*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecrets indicates an expected call of GetSecrets.
+func (mr *MockSecretsCacheMockRecorder) GetSecrets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecrets", reflect.TypeOf((*MockSecretsCache)(nil).GetSecrets), arg0)
+}
+
+// SaveSecret mocks base method.
+func (m *MockSecretsCache) SaveSecret(arg0 context.Context, arg1 *entities.Secret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSecret indicates an expected call of SaveSecret.
+func (mr *MockSecretsCacheMockRecorder) SaveSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSecret", reflect.TypeOf((*MockSecretsCache)(nil).SaveSecret), arg0, arg1)
+}
+
+// SaveSecrets mocks base method.
+func (m *MockSecretsCache) SaveSecrets(arg0 context.Context, arg1 map[string]*entities.Secret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSecrets", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSecrets indicates an expected call of SaveSecrets.
+func (mr *MockSecretsCacheMockRecorder) SaveSecrets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSecrets", reflect.TypeOf((*MockSecretsCache)(nil).SaveSecrets), arg0, arg1)
+}
+
+// MockSyncState is a mock of SyncState interface.
+type MockSyncState struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncStateMockRecorder
+}
+
+// MockSyncStateMockRecorder is the mock recorder for MockSyncState.
+type MockSyncStateMockRecorder struct {
+	mock *MockSyncState
+}
+
+// NewMockSyncState creates a new mock instance.
+func NewMockSyncState(ctrl *gomock.Controller) *MockSyncState {
+	mock := &MockSyncState{ctrl: ctrl}
+	mock.recorder = &MockSyncStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncState) EXPECT() *MockSyncStateMockRecorder {
+	return m.recorder
+}
+
+// Load mocks base method.
+func (m *MockSyncState) Load(arg0 context.Context, arg1 string) (*entities.SyncState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load", arg0, arg1)
+	ret0, _ := ret[0].(*entities.SyncState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Load indicates an expected call of Load.
+func (mr *MockSyncStateMockRecorder) Load(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockSyncState)(nil).Load), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockSyncState) Save(arg0 context.Context, arg1 string, arg2 *entities.SyncState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSyncStateMockRecorder) Save(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSyncState)(nil).Save), arg0, arg1, arg2)
+}
+
+// MockCipher is a mock of Cipher interface.
+type MockCipher struct {
+	ctrl     *gomock.Controller
+	recorder *MockCipherMockRecorder
+}
+
+// MockCipherMockRecorder is the mock recorder for MockCipher.
+type MockCipherMockRecorder struct {
+	mock *MockCipher
+}
+
+// NewMockCipher creates a new mock instance.
+func NewMockCipher(ctrl *gomock.Controller) *MockCipher {
+	mock := &MockCipher{ctrl: ctrl}
+	mock.recorder = &MockCipherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCipher) EXPECT() *MockCipherMockRecorder {
+	return m.recorder
+}
+
+// NewItemKey mocks base method.
+func (m *MockCipher) NewItemKey() ([]byte, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewItemKey")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// NewItemKey indicates an expected call of NewItemKey.
+func (mr *MockCipherMockRecorder) NewItemKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewItemKey", reflect.TypeOf((*MockCipher)(nil).NewItemKey))
+}
+
+// Open mocks base method.
+func (m *MockCipher) Open(arg0 *entities.Secret) (*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", arg0)
+	ret0, _ := ret[0].(*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockCipherMockRecorder) Open(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockCipher)(nil).Open), arg0)
+}
+
+// OpenStream mocks base method.
+func (m *MockCipher) OpenStream(arg0 *entities.Secret, arg1 io.Writer) (io.WriteCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenStream", arg0, arg1)
+	ret0, _ := ret[0].(io.WriteCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenStream indicates an expected call of OpenStream.
+func (mr *MockCipherMockRecorder) OpenStream(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenStream", reflect.TypeOf((*MockCipher)(nil).OpenStream), arg0, arg1)
+}
+
+// Seal mocks base method.
+func (m *MockCipher) Seal(arg0 *entities.Secret) (*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seal", arg0)
+	ret0, _ := ret[0].(*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Seal indicates an expected call of Seal.
+func (mr *MockCipherMockRecorder) Seal(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seal", reflect.TypeOf((*MockCipher)(nil).Seal), arg0)
+}
+
+// SealStream mocks base method.
+func (m *MockCipher) SealStream(arg0 *entities.Secret, arg1 io.Reader) (io.Reader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SealStream", arg0, arg1)
+	ret0, _ := ret[0].(io.Reader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SealStream indicates an expected call of SealStream.
+func (mr *MockCipherMockRecorder) SealStream(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SealStream", reflect.TypeOf((*MockCipher)(nil).SealStream), arg0, arg1)
+}
+
+// WrapKeyFor mocks base method.
+func (m *MockCipher) WrapKeyFor(arg0, arg1 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WrapKeyFor", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WrapKeyFor indicates an expected call of WrapKeyFor.
+func (mr *MockCipherMockRecorder) WrapKeyFor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WrapKeyFor", reflect.TypeOf((*MockCipher)(nil).WrapKeyFor), arg0, arg1)
+}
+
+// WrapKeyForOrganization mocks base method.
+func (m *MockCipher) WrapKeyForOrganization(arg0 []byte, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WrapKeyForOrganization", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WrapKeyForOrganization indicates an expected call of WrapKeyForOrganization.
+func (mr *MockCipherMockRecorder) WrapKeyForOrganization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WrapKeyForOrganization", reflect.TypeOf((*MockCipher)(nil).WrapKeyForOrganization), arg0, arg1)
+}
+
+// MockOrganizationKeys is a mock of OrganizationKeys interface.
+type MockOrganizationKeys struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationKeysMockRecorder
+}
+
+// MockOrganizationKeysMockRecorder is the mock recorder for MockOrganizationKeys.
+type MockOrganizationKeysMockRecorder struct {
+	mock *MockOrganizationKeys
+}
+
+// NewMockOrganizationKeys creates a new mock instance.
+func NewMockOrganizationKeys(ctrl *gomock.Controller) *MockOrganizationKeys {
+	mock := &MockOrganizationKeys{ctrl: ctrl}
+	mock.recorder = &MockOrganizationKeysMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationKeys) EXPECT() *MockOrganizationKeysMockRecorder {
+	return m.recorder
+}
+
+// LoadOrganizationKeys mocks base method.
+func (m *MockOrganizationKeys) LoadOrganizationKeys(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOrganizationKeys", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadOrganizationKeys indicates an expected call of LoadOrganizationKeys.
+func (mr *MockOrganizationKeysMockRecorder) LoadOrganizationKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOrganizationKeys", reflect.TypeOf((*MockOrganizationKeys)(nil).LoadOrganizationKeys), arg0)
+}
@@ -35,7 +35,7 @@ func (m *MockDBTransactionManager) EXPECT() *MockDBTransactionManagerMockRecorde
 }
 
 // Transaction mocks base method.
-func (m *MockDBTransactionManager) Transaction(arg0 context.Context, arg1 func() error) error {
+func (m *MockDBTransactionManager) Transaction(arg0 context.Context, arg1 func(context.Context) error) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Transaction", arg0, arg1)
 	ret0, _ := ret[0].(error)
@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/itohin/gophkeeper/internal/client/usecases/imports (interfaces: Secrets,Parser)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+// MockImportSecrets is a mock of Secrets interface.
+type MockImportSecrets struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportSecretsMockRecorder
+}
+
+// MockImportSecretsMockRecorder is the mock recorder for MockImportSecrets.
+type MockImportSecretsMockRecorder struct {
+	mock *MockImportSecrets
+}
+
+// NewMockImportSecrets creates a new mock instance.
+func NewMockImportSecrets(ctrl *gomock.Controller) *MockImportSecrets {
+	mock := &MockImportSecrets{ctrl: ctrl}
+	mock.recorder = &MockImportSecretsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportSecrets) EXPECT() *MockImportSecretsMockRecorder {
+	return m.recorder
+}
+
+// CreateSecrets mocks base method.
+func (m *MockImportSecrets) CreateSecrets(arg0 context.Context, arg1 []*entities.Secret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecrets", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSecrets indicates an expected call of CreateSecrets.
+func (mr *MockImportSecretsMockRecorder) CreateSecrets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecrets", reflect.TypeOf((*MockImportSecrets)(nil).CreateSecrets), arg0, arg1)
+}
+
+// GetSecrets mocks base method.
+func (m *MockImportSecrets) GetSecrets(arg0 context.Context) (map[string]*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecrets", arg0)
+	ret0, _ := ret[0].(map[string]*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecrets indicates an expected call of GetSecrets.
+func (mr *MockImportSecretsMockRecorder) GetSecrets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecrets", reflect.TypeOf((*MockImportSecrets)(nil).GetSecrets), arg0)
+}
+
+// UploadBinary mocks base method.
+func (m *MockImportSecrets) UploadBinary(arg0 context.Context, arg1 *entities.Secret, arg2 io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadBinary", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadBinary indicates an expected call of UploadBinary.
+func (mr *MockImportSecretsMockRecorder) UploadBinary(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadBinary", reflect.TypeOf((*MockImportSecrets)(nil).UploadBinary), arg0, arg1, arg2)
+}
+
+// MockImportParser is a mock of Parser interface.
+type MockImportParser struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportParserMockRecorder
+}
+
+// MockImportParserMockRecorder is the mock recorder for MockImportParser.
+type MockImportParserMockRecorder struct {
+	mock *MockImportParser
+}
+
+// NewMockImportParser creates a new mock instance.
+func NewMockImportParser(ctrl *gomock.Controller) *MockImportParser {
+	mock := &MockImportParser{ctrl: ctrl}
+	mock.recorder = &MockImportParserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportParser) EXPECT() *MockImportParserMockRecorder {
+	return m.recorder
+}
+
+// Parse mocks base method.
+func (m *MockImportParser) Parse(arg0 string, arg1 io.Reader) ([]*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", arg0, arg1)
+	ret0, _ := ret[0].([]*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockImportParserMockRecorder) Parse(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockImportParser)(nil).Parse), arg0, arg1)
+}
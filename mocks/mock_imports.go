@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/itohin/gophkeeper/internal/client/adapters/cli (interfaces: Imports)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+// MockImports is a mock of Imports interface.
+type MockImports struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportsMockRecorder
+}
+
+// MockImportsMockRecorder is the mock recorder for MockImports.
+type MockImportsMockRecorder struct {
+	mock *MockImports
+}
+
+// NewMockImports creates a new mock instance.
+func NewMockImports(ctrl *gomock.Controller) *MockImports {
+	mock := &MockImports{ctrl: ctrl}
+	mock.recorder = &MockImportsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImports) EXPECT() *MockImportsMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockImports) Import(arg0 context.Context, arg1 []*entities.ImportItem, arg2 bool, arg3 func(int, int)) (*entities.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entities.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockImportsMockRecorder) Import(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockImports)(nil).Import), arg0, arg1, arg2, arg3)
+}
+
+// Preview mocks base method.
+func (m *MockImports) Preview(arg0 context.Context, arg1 string, arg2 io.Reader) ([]*entities.ImportItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entities.ImportItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockImportsMockRecorder) Preview(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockImports)(nil).Preview), arg0, arg1, arg2)
+}
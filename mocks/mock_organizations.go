@@ -0,0 +1,179 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/itohin/gophkeeper/internal/client/adapters/cli (interfaces: Organizations)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+// MockOrganizations is a mock of Organizations interface.
+type MockOrganizations struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationsMockRecorder
+}
+
+// MockOrganizationsMockRecorder is the mock recorder for MockOrganizations.
+type MockOrganizationsMockRecorder struct {
+	mock *MockOrganizations
+}
+
+// NewMockOrganizations creates a new mock instance.
+func NewMockOrganizations(ctrl *gomock.Controller) *MockOrganizations {
+	mock := &MockOrganizations{ctrl: ctrl}
+	mock.recorder = &MockOrganizationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizations) EXPECT() *MockOrganizationsMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockOrganizations) AcceptInvitation(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockOrganizationsMockRecorder) AcceptInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockOrganizations)(nil).AcceptInvitation), arg0, arg1)
+}
+
+// CreateCollection mocks base method.
+func (m *MockOrganizations) CreateCollection(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockOrganizationsMockRecorder) CreateCollection(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockOrganizations)(nil).CreateCollection), arg0, arg1, arg2)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockOrganizations) CreateOrganization(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockOrganizationsMockRecorder) CreateOrganization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockOrganizations)(nil).CreateOrganization), arg0, arg1)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockOrganizations) DeleteCollection(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockOrganizationsMockRecorder) DeleteCollection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockOrganizations)(nil).DeleteCollection), arg0, arg1)
+}
+
+// GetCollections mocks base method.
+func (m *MockOrganizations) GetCollections(arg0 context.Context, arg1 string) ([]*entities.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollections", arg0, arg1)
+	ret0, _ := ret[0].([]*entities.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollections indicates an expected call of GetCollections.
+func (mr *MockOrganizationsMockRecorder) GetCollections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollections", reflect.TypeOf((*MockOrganizations)(nil).GetCollections), arg0, arg1)
+}
+
+// GetMembers mocks base method.
+func (m *MockOrganizations) GetMembers(arg0 context.Context, arg1 string) ([]*entities.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", arg0, arg1)
+	ret0, _ := ret[0].([]*entities.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockOrganizationsMockRecorder) GetMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockOrganizations)(nil).GetMembers), arg0, arg1)
+}
+
+// GetOrganizations mocks base method.
+func (m *MockOrganizations) GetOrganizations(arg0 context.Context) ([]*entities.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizations", arg0)
+	ret0, _ := ret[0].([]*entities.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizations indicates an expected call of GetOrganizations.
+func (mr *MockOrganizationsMockRecorder) GetOrganizations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizations", reflect.TypeOf((*MockOrganizations)(nil).GetOrganizations), arg0)
+}
+
+// InviteMember mocks base method.
+func (m *MockOrganizations) InviteMember(arg0 context.Context, arg1, arg2 string, arg3 uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteMember", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InviteMember indicates an expected call of InviteMember.
+func (mr *MockOrganizationsMockRecorder) InviteMember(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteMember", reflect.TypeOf((*MockOrganizations)(nil).InviteMember), arg0, arg1, arg2, arg3)
+}
+
+// RemoveMember mocks base method.
+func (m *MockOrganizations) RemoveMember(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockOrganizationsMockRecorder) RemoveMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrganizations)(nil).RemoveMember), arg0, arg1, arg2)
+}
+
+// SetMemberRole mocks base method.
+func (m *MockOrganizations) SetMemberRole(arg0 context.Context, arg1, arg2 string, arg3 uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMemberRole", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMemberRole indicates an expected call of SetMemberRole.
+func (mr *MockOrganizationsMockRecorder) SetMemberRole(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMemberRole", reflect.TypeOf((*MockOrganizations)(nil).SetMemberRole), arg0, arg1, arg2, arg3)
+}
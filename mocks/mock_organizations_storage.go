@@ -0,0 +1,213 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/itohin/gophkeeper/internal/server/usecases/organizations (interfaces: OrganizationsStorage)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/itohin/gophkeeper/internal/server/entities"
+)
+
+// MockOrganizationsStorage is a mock of OrganizationsStorage interface.
+type MockOrganizationsStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationsStorageMockRecorder
+}
+
+// MockOrganizationsStorageMockRecorder is the mock recorder for MockOrganizationsStorage.
+type MockOrganizationsStorageMockRecorder struct {
+	mock *MockOrganizationsStorage
+}
+
+// NewMockOrganizationsStorage creates a new mock instance.
+func NewMockOrganizationsStorage(ctrl *gomock.Controller) *MockOrganizationsStorage {
+	mock := &MockOrganizationsStorage{ctrl: ctrl}
+	mock.recorder = &MockOrganizationsStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationsStorage) EXPECT() *MockOrganizationsStorageMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockOrganizationsStorage) AcceptInvitation(arg0 context.Context, arg1, arg2, arg3 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockOrganizationsStorageMockRecorder) AcceptInvitation(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockOrganizationsStorage)(nil).AcceptInvitation), arg0, arg1, arg2, arg3)
+}
+
+// Create mocks base method.
+func (m *MockOrganizationsStorage) Create(arg0 context.Context, arg1 entities.Organization, arg2 entities.Member) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrganizationsStorageMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrganizationsStorage)(nil).Create), arg0, arg1, arg2)
+}
+
+// CreateCollection mocks base method.
+func (m *MockOrganizationsStorage) CreateCollection(arg0 context.Context, arg1 entities.Collection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCollection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCollection indicates an expected call of CreateCollection.
+func (mr *MockOrganizationsStorageMockRecorder) CreateCollection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCollection", reflect.TypeOf((*MockOrganizationsStorage)(nil).CreateCollection), arg0, arg1)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockOrganizationsStorage) DeleteCollection(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockOrganizationsStorageMockRecorder) DeleteCollection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockOrganizationsStorage)(nil).DeleteCollection), arg0, arg1)
+}
+
+// GetCollection mocks base method.
+func (m *MockOrganizationsStorage) GetCollection(arg0 context.Context, arg1 string) (entities.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollection", arg0, arg1)
+	ret0, _ := ret[0].(entities.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollection indicates an expected call of GetCollection.
+func (mr *MockOrganizationsStorageMockRecorder) GetCollection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollection", reflect.TypeOf((*MockOrganizationsStorage)(nil).GetCollection), arg0, arg1)
+}
+
+// GetCollections mocks base method.
+func (m *MockOrganizationsStorage) GetCollections(arg0 context.Context, arg1 string) ([]entities.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollections", arg0, arg1)
+	ret0, _ := ret[0].([]entities.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollections indicates an expected call of GetCollections.
+func (mr *MockOrganizationsStorageMockRecorder) GetCollections(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollections", reflect.TypeOf((*MockOrganizationsStorage)(nil).GetCollections), arg0, arg1)
+}
+
+// GetMember mocks base method.
+func (m *MockOrganizationsStorage) GetMember(arg0 context.Context, arg1, arg2 string) (entities.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entities.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockOrganizationsStorageMockRecorder) GetMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockOrganizationsStorage)(nil).GetMember), arg0, arg1, arg2)
+}
+
+// GetMembers mocks base method.
+func (m *MockOrganizationsStorage) GetMembers(arg0 context.Context, arg1 string) ([]entities.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", arg0, arg1)
+	ret0, _ := ret[0].([]entities.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockOrganizationsStorageMockRecorder) GetMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockOrganizationsStorage)(nil).GetMembers), arg0, arg1)
+}
+
+// GetUserOrganizations mocks base method.
+func (m *MockOrganizationsStorage) GetUserOrganizations(arg0 context.Context, arg1 string) ([]entities.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrganizations", arg0, arg1)
+	ret0, _ := ret[0].([]entities.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrganizations indicates an expected call of GetUserOrganizations.
+func (mr *MockOrganizationsStorageMockRecorder) GetUserOrganizations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrganizations", reflect.TypeOf((*MockOrganizationsStorage)(nil).GetUserOrganizations), arg0, arg1)
+}
+
+// RemoveMember mocks base method.
+func (m *MockOrganizationsStorage) RemoveMember(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockOrganizationsStorageMockRecorder) RemoveMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrganizationsStorage)(nil).RemoveMember), arg0, arg1, arg2)
+}
+
+// SaveInvitation mocks base method.
+func (m *MockOrganizationsStorage) SaveInvitation(arg0 context.Context, arg1 entities.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInvitation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveInvitation indicates an expected call of SaveInvitation.
+func (mr *MockOrganizationsStorageMockRecorder) SaveInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInvitation", reflect.TypeOf((*MockOrganizationsStorage)(nil).SaveInvitation), arg0, arg1)
+}
+
+// SetMemberRole mocks base method.
+func (m *MockOrganizationsStorage) SetMemberRole(arg0 context.Context, arg1, arg2 string, arg3 uint32) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMemberRole", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMemberRole indicates an expected call of SetMemberRole.
+func (mr *MockOrganizationsStorageMockRecorder) SetMemberRole(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMemberRole", reflect.TypeOf((*MockOrganizationsStorage)(nil).SetMemberRole), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/itohin/gophkeeper/internal/client/adapters/cli (interfaces: Report)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+// MockReport is a mock of Report interface.
+type MockReport struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportMockRecorder
+}
+
+// MockReportMockRecorder is the mock recorder for MockReport.
+type MockReportMockRecorder struct {
+	mock *MockReport
+}
+
+// NewMockReport creates a new mock instance.
+func NewMockReport(ctrl *gomock.Controller) *MockReport {
+	mock := &MockReport{ctrl: ctrl}
+	mock.recorder = &MockReportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReport) EXPECT() *MockReportMockRecorder {
+	return m.recorder
+}
+
+// BreachReport mocks base method.
+func (m *MockReport) BreachReport(arg0 context.Context) (*entities.PasswordReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BreachReport", arg0)
+	ret0, _ := ret[0].(*entities.PasswordReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BreachReport indicates an expected call of BreachReport.
+func (mr *MockReportMockRecorder) BreachReport(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BreachReport", reflect.TypeOf((*MockReport)(nil).BreachReport), arg0)
+}
+
+// PasswordReport mocks base method.
+func (m *MockReport) PasswordReport(arg0 context.Context, arg1 time.Duration) (*entities.PasswordReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordReport", arg0, arg1)
+	ret0, _ := ret[0].(*entities.PasswordReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PasswordReport indicates an expected call of PasswordReport.
+func (mr *MockReportMockRecorder) PasswordReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordReport", reflect.TypeOf((*MockReport)(nil).PasswordReport), arg0, arg1)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/itohin/gophkeeper/internal/client/usecases/report (interfaces: Secrets)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+// MockReportSecrets is a mock of Secrets interface.
+type MockReportSecrets struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportSecretsMockRecorder
+}
+
+// MockReportSecretsMockRecorder is the mock recorder for MockReportSecrets.
+type MockReportSecretsMockRecorder struct {
+	mock *MockReportSecrets
+}
+
+// NewMockReportSecrets creates a new mock instance.
+func NewMockReportSecrets(ctrl *gomock.Controller) *MockReportSecrets {
+	mock := &MockReportSecrets{ctrl: ctrl}
+	mock.recorder = &MockReportSecretsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportSecrets) EXPECT() *MockReportSecretsMockRecorder {
+	return m.recorder
+}
+
+// GetSecret mocks base method.
+func (m *MockReportSecrets) GetSecret(arg0 context.Context, arg1 string) (*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret.
+func (mr *MockReportSecretsMockRecorder) GetSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockReportSecrets)(nil).GetSecret), arg0, arg1)
+}
+
+// GetSecrets mocks base method.
+func (m *MockReportSecrets) GetSecrets(arg0 context.Context) (map[string]*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecrets", arg0)
+	ret0, _ := ret[0].(map[string]*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecrets indicates an expected call of GetSecrets.
+func (mr *MockReportSecretsMockRecorder) GetSecrets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecrets", reflect.TypeOf((*MockReportSecrets)(nil).GetSecrets), arg0)
+}
@@ -0,0 +1,188 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/itohin/gophkeeper/internal/client/adapters/script (interfaces: Auth,Secrets)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/itohin/gophkeeper/internal/client/entities"
+)
+
+// MockScriptAuth is a mock of Auth interface.
+type MockScriptAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MockScriptAuthMockRecorder
+}
+
+// MockScriptAuthMockRecorder is the mock recorder for MockScriptAuth.
+type MockScriptAuthMockRecorder struct {
+	mock *MockScriptAuth
+}
+
+// NewMockScriptAuth creates a new mock instance.
+func NewMockScriptAuth(ctrl *gomock.Controller) *MockScriptAuth {
+	mock := &MockScriptAuth{ctrl: ctrl}
+	mock.recorder = &MockScriptAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScriptAuth) EXPECT() *MockScriptAuthMockRecorder {
+	return m.recorder
+}
+
+// HasMasterPassword mocks base method.
+func (m *MockScriptAuth) HasMasterPassword(arg0 context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasMasterPassword", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasMasterPassword indicates an expected call of HasMasterPassword.
+func (mr *MockScriptAuthMockRecorder) HasMasterPassword(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasMasterPassword", reflect.TypeOf((*MockScriptAuth)(nil).HasMasterPassword), arg0)
+}
+
+// Login mocks base method.
+func (m *MockScriptAuth) Login(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockScriptAuthMockRecorder) Login(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockScriptAuth)(nil).Login), arg0, arg1, arg2)
+}
+
+// Logout mocks base method.
+func (m *MockScriptAuth) Logout(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockScriptAuthMockRecorder) Logout(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockScriptAuth)(nil).Logout), arg0)
+}
+
+// Unlock mocks base method.
+func (m *MockScriptAuth) Unlock(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockScriptAuthMockRecorder) Unlock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockScriptAuth)(nil).Unlock), arg0, arg1)
+}
+
+// MockScriptSecrets is a mock of Secrets interface.
+type MockScriptSecrets struct {
+	ctrl     *gomock.Controller
+	recorder *MockScriptSecretsMockRecorder
+}
+
+// MockScriptSecretsMockRecorder is the mock recorder for MockScriptSecrets.
+type MockScriptSecretsMockRecorder struct {
+	mock *MockScriptSecrets
+}
+
+// NewMockScriptSecrets creates a new mock instance.
+func NewMockScriptSecrets(ctrl *gomock.Controller) *MockScriptSecrets {
+	mock := &MockScriptSecrets{ctrl: ctrl}
+	mock.recorder = &MockScriptSecretsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScriptSecrets) EXPECT() *MockScriptSecretsMockRecorder {
+	return m.recorder
+}
+
+// CreateSecret mocks base method.
+func (m *MockScriptSecrets) CreateSecret(arg0 context.Context, arg1 *entities.Secret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSecret indicates an expected call of CreateSecret.
+func (mr *MockScriptSecretsMockRecorder) CreateSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockScriptSecrets)(nil).CreateSecret), arg0, arg1)
+}
+
+// DeleteSecrets mocks base method.
+func (m *MockScriptSecrets) DeleteSecrets(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecrets", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecrets indicates an expected call of DeleteSecrets.
+func (mr *MockScriptSecretsMockRecorder) DeleteSecrets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecrets", reflect.TypeOf((*MockScriptSecrets)(nil).DeleteSecrets), arg0, arg1)
+}
+
+// GetSecret mocks base method.
+func (m *MockScriptSecrets) GetSecret(arg0 context.Context, arg1 string) (*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret.
+func (mr *MockScriptSecretsMockRecorder) GetSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockScriptSecrets)(nil).GetSecret), arg0, arg1)
+}
+
+// GetSecrets mocks base method.
+func (m *MockScriptSecrets) GetSecrets(arg0 context.Context) (map[string]*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecrets", arg0)
+	ret0, _ := ret[0].(map[string]*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecrets indicates an expected call of GetSecrets.
+func (mr *MockScriptSecretsMockRecorder) GetSecrets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecrets", reflect.TypeOf((*MockScriptSecrets)(nil).GetSecrets), arg0)
+}
+
+// SyncSecrets mocks base method.
+func (m *MockScriptSecrets) SyncSecrets(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncSecrets", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncSecrets indicates an expected call of SyncSecrets.
+func (mr *MockScriptSecretsMockRecorder) SyncSecrets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncSecrets", reflect.TypeOf((*MockScriptSecrets)(nil).SyncSecrets), arg0, arg1)
+}
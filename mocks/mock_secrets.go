@@ -6,6 +6,7 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,20 @@ func (m *MockSecrets) EXPECT() *MockSecretsMockRecorder {
 	return m.recorder
 }
 
+// CreateFolder mocks base method.
+func (m *MockSecrets) CreateFolder(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockSecretsMockRecorder) CreateFolder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockSecrets)(nil).CreateFolder), arg0, arg1, arg2)
+}
+
 // CreateSecret mocks base method.
 func (m *MockSecrets) CreateSecret(arg0 context.Context, arg1 *entities.Secret) error {
 	m.ctrl.T.Helper()
@@ -49,6 +64,20 @@ func (mr *MockSecretsMockRecorder) CreateSecret(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockSecrets)(nil).CreateSecret), arg0, arg1)
 }
 
+// DeleteFolder mocks base method.
+func (m *MockSecrets) DeleteFolder(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFolder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFolder indicates an expected call of DeleteFolder.
+func (mr *MockSecretsMockRecorder) DeleteFolder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockSecrets)(nil).DeleteFolder), arg0, arg1)
+}
+
 // DeleteSecret mocks base method.
 func (m *MockSecrets) DeleteSecret(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -63,6 +92,94 @@ func (mr *MockSecretsMockRecorder) DeleteSecret(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockSecrets)(nil).DeleteSecret), arg0, arg1)
 }
 
+// DeleteSecrets mocks base method.
+func (m *MockSecrets) DeleteSecrets(arg0 context.Context, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecrets", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecrets indicates an expected call of DeleteSecrets.
+func (mr *MockSecretsMockRecorder) DeleteSecrets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecrets", reflect.TypeOf((*MockSecrets)(nil).DeleteSecrets), arg0, arg1)
+}
+
+// DownloadBinary mocks base method.
+func (m *MockSecrets) DownloadBinary(arg0 context.Context, arg1 *entities.Secret, arg2 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadBinary", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DownloadBinary indicates an expected call of DownloadBinary.
+func (mr *MockSecretsMockRecorder) DownloadBinary(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadBinary", reflect.TypeOf((*MockSecrets)(nil).DownloadBinary), arg0, arg1, arg2)
+}
+
+// GetDeletedSecrets mocks base method.
+func (m *MockSecrets) GetDeletedSecrets(arg0 context.Context) ([]*entities.DeletedSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedSecrets", arg0)
+	ret0, _ := ret[0].([]*entities.DeletedSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedSecrets indicates an expected call of GetDeletedSecrets.
+func (mr *MockSecretsMockRecorder) GetDeletedSecrets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedSecrets", reflect.TypeOf((*MockSecrets)(nil).GetDeletedSecrets), arg0)
+}
+
+// GetFolders mocks base method.
+func (m *MockSecrets) GetFolders(arg0 context.Context) ([]*entities.Folder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFolders", arg0)
+	ret0, _ := ret[0].([]*entities.Folder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFolders indicates an expected call of GetFolders.
+func (mr *MockSecretsMockRecorder) GetFolders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolders", reflect.TypeOf((*MockSecrets)(nil).GetFolders), arg0)
+}
+
+// GetLatestSecret mocks base method.
+func (m *MockSecrets) GetLatestSecret(arg0 context.Context, arg1 string) (*entities.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestSecret", arg0, arg1)
+	ret0, _ := ret[0].(*entities.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestSecret indicates an expected call of GetLatestSecret.
+func (mr *MockSecretsMockRecorder) GetLatestSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestSecret", reflect.TypeOf((*MockSecrets)(nil).GetLatestSecret), arg0, arg1)
+}
+
+// GetRevisions mocks base method.
+func (m *MockSecrets) GetRevisions(arg0 context.Context, arg1 string) ([]*entities.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]*entities.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockSecretsMockRecorder) GetRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockSecrets)(nil).GetRevisions), arg0, arg1)
+}
+
 // GetSecret mocks base method.
 func (m *MockSecrets) GetSecret(arg0 context.Context, arg1 string) (*entities.Secret, error) {
 	m.ctrl.T.Helper()
@@ -92,3 +209,146 @@ func (mr *MockSecretsMockRecorder) GetSecrets(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecrets", reflect.TypeOf((*MockSecrets)(nil).GetSecrets), arg0)
 }
+
+// GetShares mocks base method.
+func (m *MockSecrets) GetShares(arg0 context.Context, arg1 string) ([]*entities.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShares", arg0, arg1)
+	ret0, _ := ret[0].([]*entities.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShares indicates an expected call of GetShares.
+func (mr *MockSecretsMockRecorder) GetShares(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShares", reflect.TypeOf((*MockSecrets)(nil).GetShares), arg0, arg1)
+}
+
+// GetTags mocks base method.
+func (m *MockSecrets) GetTags(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockSecretsMockRecorder) GetTags(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockSecrets)(nil).GetTags), arg0)
+}
+
+// PurgeSecret mocks base method.
+func (m *MockSecrets) PurgeSecret(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeSecret indicates an expected call of PurgeSecret.
+func (mr *MockSecretsMockRecorder) PurgeSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeSecret", reflect.TypeOf((*MockSecrets)(nil).PurgeSecret), arg0, arg1)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockSecrets) RestoreRevision(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockSecretsMockRecorder) RestoreRevision(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockSecrets)(nil).RestoreRevision), arg0, arg1, arg2)
+}
+
+// RestoreSecret mocks base method.
+func (m *MockSecrets) RestoreSecret(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreSecret indicates an expected call of RestoreSecret.
+func (mr *MockSecretsMockRecorder) RestoreSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSecret", reflect.TypeOf((*MockSecrets)(nil).RestoreSecret), arg0, arg1)
+}
+
+// RevokeShare mocks base method.
+func (m *MockSecrets) RevokeShare(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShare", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockSecretsMockRecorder) RevokeShare(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockSecrets)(nil).RevokeShare), arg0, arg1, arg2)
+}
+
+// SearchSecrets mocks base method.
+func (m *MockSecrets) SearchSecrets(arg0 context.Context, arg1 *entities.SecretsFilter, arg2 string) (*entities.SecretsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchSecrets", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entities.SecretsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchSecrets indicates an expected call of SearchSecrets.
+func (mr *MockSecretsMockRecorder) SearchSecrets(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSecrets", reflect.TypeOf((*MockSecrets)(nil).SearchSecrets), arg0, arg1, arg2)
+}
+
+// ShareSecret mocks base method.
+func (m *MockSecrets) ShareSecret(arg0 context.Context, arg1, arg2 string, arg3 uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareSecret", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShareSecret indicates an expected call of ShareSecret.
+func (mr *MockSecretsMockRecorder) ShareSecret(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareSecret", reflect.TypeOf((*MockSecrets)(nil).ShareSecret), arg0, arg1, arg2, arg3)
+}
+
+// UpdateSecret mocks base method.
+func (m *MockSecrets) UpdateSecret(arg0 context.Context, arg1 *entities.Secret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSecret indicates an expected call of UpdateSecret.
+func (mr *MockSecretsMockRecorder) UpdateSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockSecrets)(nil).UpdateSecret), arg0, arg1)
+}
+
+// UploadBinary mocks base method.
+func (m *MockSecrets) UploadBinary(arg0 context.Context, arg1 *entities.Secret, arg2 io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadBinary", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadBinary indicates an expected call of UploadBinary.
+func (mr *MockSecretsMockRecorder) UploadBinary(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadBinary", reflect.TypeOf((*MockSecrets)(nil).UploadBinary), arg0, arg1, arg2)
+}
@@ -0,0 +1,396 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/itohin/gophkeeper/internal/server/usecases/secrets (interfaces: SecretsStorage)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/itohin/gophkeeper/internal/server/entities"
+	events "github.com/itohin/gophkeeper/pkg/events"
+)
+
+// MockSecretsStorage is a mock of SecretsStorage interface.
+type MockSecretsStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretsStorageMockRecorder
+}
+
+// MockSecretsStorageMockRecorder is the mock recorder for MockSecretsStorage.
+type MockSecretsStorageMockRecorder struct {
+	mock *MockSecretsStorage
+}
+
+// NewMockSecretsStorage creates a new mock instance.
+func NewMockSecretsStorage(ctrl *gomock.Controller) *MockSecretsStorage {
+	mock := &MockSecretsStorage{ctrl: ctrl}
+	mock.recorder = &MockSecretsStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretsStorage) EXPECT() *MockSecretsStorageMockRecorder {
+	return m.recorder
+}
+
+// ClaimIdempotencyKey mocks base method.
+func (m *MockSecretsStorage) ClaimIdempotencyKey(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimIdempotencyKey", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimIdempotencyKey indicates an expected call of ClaimIdempotencyKey.
+func (mr *MockSecretsStorageMockRecorder) ClaimIdempotencyKey(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimIdempotencyKey", reflect.TypeOf((*MockSecretsStorage)(nil).ClaimIdempotencyKey), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// CreateFolder mocks base method.
+func (m *MockSecretsStorage) CreateFolder(arg0 context.Context, arg1 entities.Folder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockSecretsStorageMockRecorder) CreateFolder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockSecretsStorage)(nil).CreateFolder), arg0, arg1)
+}
+
+// DeleteFolder mocks base method.
+func (m *MockSecretsStorage) DeleteFolder(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFolder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFolder indicates an expected call of DeleteFolder.
+func (mr *MockSecretsStorageMockRecorder) DeleteFolder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockSecretsStorage)(nil).DeleteFolder), arg0, arg1, arg2)
+}
+
+// GetChangeSequence mocks base method.
+func (m *MockSecretsStorage) GetChangeSequence(arg0 context.Context, arg1 string) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangeSequence", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetChangeSequence indicates an expected call of GetChangeSequence.
+func (mr *MockSecretsStorageMockRecorder) GetChangeSequence(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeSequence", reflect.TypeOf((*MockSecretsStorage)(nil).GetChangeSequence), arg0, arg1)
+}
+
+// GetChangesSince mocks base method.
+func (m *MockSecretsStorage) GetChangesSince(arg0 context.Context, arg1 string, arg2 int64, arg3 int) ([]entities.SecretChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangesSince", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]entities.SecretChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChangesSince indicates an expected call of GetChangesSince.
+func (mr *MockSecretsStorageMockRecorder) GetChangesSince(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangesSince", reflect.TypeOf((*MockSecretsStorage)(nil).GetChangesSince), arg0, arg1, arg2, arg3)
+}
+
+// GetFolder mocks base method.
+func (m *MockSecretsStorage) GetFolder(arg0 context.Context, arg1, arg2 string) (entities.Folder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFolder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entities.Folder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFolder indicates an expected call of GetFolder.
+func (mr *MockSecretsStorageMockRecorder) GetFolder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolder", reflect.TypeOf((*MockSecretsStorage)(nil).GetFolder), arg0, arg1, arg2)
+}
+
+// GetFolders mocks base method.
+func (m *MockSecretsStorage) GetFolders(arg0 context.Context, arg1 string) ([]entities.Folder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFolders", arg0, arg1)
+	ret0, _ := ret[0].([]entities.Folder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFolders indicates an expected call of GetFolders.
+func (mr *MockSecretsStorageMockRecorder) GetFolders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolders", reflect.TypeOf((*MockSecretsStorage)(nil).GetFolders), arg0, arg1)
+}
+
+// GetLegacyBinary mocks base method.
+func (m *MockSecretsStorage) GetLegacyBinary(arg0 context.Context, arg1, arg2 string) (entities.BinaryContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLegacyBinary", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entities.BinaryContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLegacyBinary indicates an expected call of GetLegacyBinary.
+func (mr *MockSecretsStorageMockRecorder) GetLegacyBinary(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLegacyBinary", reflect.TypeOf((*MockSecretsStorage)(nil).GetLegacyBinary), arg0, arg1, arg2)
+}
+
+// GetSecretBlob mocks base method.
+func (m *MockSecretsStorage) GetSecretBlob(arg0 context.Context, arg1, arg2 string) (entities.Blob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretBlob", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entities.Blob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretBlob indicates an expected call of GetSecretBlob.
+func (mr *MockSecretsStorageMockRecorder) GetSecretBlob(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretBlob", reflect.TypeOf((*MockSecretsStorage)(nil).GetSecretBlob), arg0, arg1, arg2)
+}
+
+// GetSecretRevision mocks base method.
+func (m *MockSecretsStorage) GetSecretRevision(arg0 context.Context, arg1, arg2, arg3 string) (entities.SecretRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretRevision", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(entities.SecretRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretRevision indicates an expected call of GetSecretRevision.
+func (mr *MockSecretsStorageMockRecorder) GetSecretRevision(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretRevision", reflect.TypeOf((*MockSecretsStorage)(nil).GetSecretRevision), arg0, arg1, arg2, arg3)
+}
+
+// GetSecretRevisions mocks base method.
+func (m *MockSecretsStorage) GetSecretRevisions(arg0 context.Context, arg1, arg2 string) ([]entities.SecretRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretRevisions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]entities.SecretRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretRevisions indicates an expected call of GetSecretRevisions.
+func (mr *MockSecretsStorageMockRecorder) GetSecretRevisions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretRevisions", reflect.TypeOf((*MockSecretsStorage)(nil).GetSecretRevisions), arg0, arg1, arg2)
+}
+
+// GetSecretShares mocks base method.
+func (m *MockSecretsStorage) GetSecretShares(arg0 context.Context, arg1, arg2 string) ([]entities.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretShares", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]entities.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretShares indicates an expected call of GetSecretShares.
+func (mr *MockSecretsStorageMockRecorder) GetSecretShares(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretShares", reflect.TypeOf((*MockSecretsStorage)(nil).GetSecretShares), arg0, arg1, arg2)
+}
+
+// GetUserBlobRefs mocks base method.
+func (m *MockSecretsStorage) GetUserBlobRefs(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserBlobRefs", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserBlobRefs indicates an expected call of GetUserBlobRefs.
+func (mr *MockSecretsStorageMockRecorder) GetUserBlobRefs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBlobRefs", reflect.TypeOf((*MockSecretsStorage)(nil).GetUserBlobRefs), arg0, arg1)
+}
+
+// GetUserDeletedSecret mocks base method.
+func (m *MockSecretsStorage) GetUserDeletedSecret(arg0 context.Context, arg1, arg2 string) (entities.DeletedSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDeletedSecret", arg0, arg1, arg2)
+	ret0, _ := ret[0].(entities.DeletedSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDeletedSecret indicates an expected call of GetUserDeletedSecret.
+func (mr *MockSecretsStorageMockRecorder) GetUserDeletedSecret(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDeletedSecret", reflect.TypeOf((*MockSecretsStorage)(nil).GetUserDeletedSecret), arg0, arg1, arg2)
+}
+
+// GetUserDeletedSecrets mocks base method.
+func (m *MockSecretsStorage) GetUserDeletedSecrets(arg0 context.Context, arg1 string) ([]entities.DeletedSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDeletedSecrets", arg0, arg1)
+	ret0, _ := ret[0].([]entities.DeletedSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDeletedSecrets indicates an expected call of GetUserDeletedSecrets.
+func (mr *MockSecretsStorageMockRecorder) GetUserDeletedSecrets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDeletedSecrets", reflect.TypeOf((*MockSecretsStorage)(nil).GetUserDeletedSecrets), arg0, arg1)
+}
+
+// GetUserSecret mocks base method.
+func (m *MockSecretsStorage) GetUserSecret(arg0 context.Context, arg1, arg2 string) (events.SecretDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSecret", arg0, arg1, arg2)
+	ret0, _ := ret[0].(events.SecretDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSecret indicates an expected call of GetUserSecret.
+func (mr *MockSecretsStorageMockRecorder) GetUserSecret(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSecret", reflect.TypeOf((*MockSecretsStorage)(nil).GetUserSecret), arg0, arg1, arg2)
+}
+
+// GetUserTags mocks base method.
+func (m *MockSecretsStorage) GetUserTags(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTags", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTags indicates an expected call of GetUserTags.
+func (mr *MockSecretsStorageMockRecorder) GetUserTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTags", reflect.TypeOf((*MockSecretsStorage)(nil).GetUserTags), arg0, arg1)
+}
+
+// Purge mocks base method.
+func (m *MockSecretsStorage) Purge(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockSecretsStorageMockRecorder) Purge(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockSecretsStorage)(nil).Purge), arg0, arg1, arg2)
+}
+
+// PurgeDeletedBefore mocks base method.
+func (m *MockSecretsStorage) PurgeDeletedBefore(arg0 context.Context, arg1 time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBefore", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBefore indicates an expected call of PurgeDeletedBefore.
+func (mr *MockSecretsStorageMockRecorder) PurgeDeletedBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBefore", reflect.TypeOf((*MockSecretsStorage)(nil).PurgeDeletedBefore), arg0, arg1)
+}
+
+// PurgeIdempotencyKeys mocks base method.
+func (m *MockSecretsStorage) PurgeIdempotencyKeys(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeIdempotencyKeys", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeIdempotencyKeys indicates an expected call of PurgeIdempotencyKeys.
+func (mr *MockSecretsStorageMockRecorder) PurgeIdempotencyKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeIdempotencyKeys", reflect.TypeOf((*MockSecretsStorage)(nil).PurgeIdempotencyKeys), arg0, arg1)
+}
+
+// RevokeShare mocks base method.
+func (m *MockSecretsStorage) RevokeShare(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShare", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockSecretsStorageMockRecorder) RevokeShare(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockSecretsStorage)(nil).RevokeShare), arg0, arg1, arg2)
+}
+
+// Save mocks base method.
+func (m *MockSecretsStorage) Save(arg0 context.Context, arg1 entities.Secret) (*events.SecretDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(*events.SecretDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSecretsStorageMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSecretsStorage)(nil).Save), arg0, arg1)
+}
+
+// SaveShare mocks base method.
+func (m *MockSecretsStorage) SaveShare(arg0 context.Context, arg1 entities.Share) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveShare", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveShare indicates an expected call of SaveShare.
+func (mr *MockSecretsStorageMockRecorder) SaveShare(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveShare", reflect.TypeOf((*MockSecretsStorage)(nil).SaveShare), arg0, arg1)
+}
+
+// SearchUserSecrets mocks base method.
+func (m *MockSecretsStorage) SearchUserSecrets(arg0 context.Context, arg1 string, arg2 *entities.SecretsFilter) ([]events.SecretDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUserSecrets", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]events.SecretDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchUserSecrets indicates an expected call of SearchUserSecrets.
+func (mr *MockSecretsStorageMockRecorder) SearchUserSecrets(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUserSecrets", reflect.TypeOf((*MockSecretsStorage)(nil).SearchUserSecrets), arg0, arg1, arg2)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/itohin/gophkeeper/internal/client/adapters/cli (interfaces: SSHAgent)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSSHAgent is a mock of SSHAgent interface.
+type MockSSHAgent struct {
+	ctrl     *gomock.Controller
+	recorder *MockSSHAgentMockRecorder
+}
+
+// MockSSHAgentMockRecorder is the mock recorder for MockSSHAgent.
+type MockSSHAgentMockRecorder struct {
+	mock *MockSSHAgent
+}
+
+// NewMockSSHAgent creates a new mock instance.
+func NewMockSSHAgent(ctrl *gomock.Controller) *MockSSHAgent {
+	mock := &MockSSHAgent{ctrl: ctrl}
+	mock.recorder = &MockSSHAgentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSSHAgent) EXPECT() *MockSSHAgentMockRecorder {
+	return m.recorder
+}
+
+// Serve mocks base method.
+func (m *MockSSHAgent) Serve(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Serve", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Serve indicates an expected call of Serve.
+func (mr *MockSSHAgentMockRecorder) Serve(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Serve", reflect.TypeOf((*MockSSHAgent)(nil).Serve), arg0, arg1)
+}
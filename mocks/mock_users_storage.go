@@ -65,6 +65,37 @@ func (mr *MockUsersStorageMockRecorder) FindByID(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUsersStorage)(nil).FindByID), arg0, arg1)
 }
 
+// FindPublicKey mocks base method.
+func (m *MockUsersStorage) FindPublicKey(arg0 context.Context, arg1 string) (string, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPublicKey", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPublicKey indicates an expected call of FindPublicKey.
+func (mr *MockUsersStorageMockRecorder) FindPublicKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPublicKey", reflect.TypeOf((*MockUsersStorage)(nil).FindPublicKey), arg0, arg1)
+}
+
+// GetKeyParams mocks base method.
+func (m *MockUsersStorage) GetKeyParams(arg0 context.Context, arg1 string) (*entities.KeyParams, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyParams", arg0, arg1)
+	ret0, _ := ret[0].(*entities.KeyParams)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeyParams indicates an expected call of GetKeyParams.
+func (mr *MockUsersStorageMockRecorder) GetKeyParams(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyParams", reflect.TypeOf((*MockUsersStorage)(nil).GetKeyParams), arg0, arg1)
+}
+
 // Save mocks base method.
 func (m *MockUsersStorage) Save(arg0 context.Context, arg1 entities.User) error {
 	m.ctrl.T.Helper()
@@ -78,3 +109,31 @@ func (mr *MockUsersStorageMockRecorder) Save(arg0, arg1 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUsersStorage)(nil).Save), arg0, arg1)
 }
+
+// SetKeyPair mocks base method.
+func (m *MockUsersStorage) SetKeyPair(arg0 context.Context, arg1 string, arg2, arg3 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKeyPair", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKeyPair indicates an expected call of SetKeyPair.
+func (mr *MockUsersStorageMockRecorder) SetKeyPair(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeyPair", reflect.TypeOf((*MockUsersStorage)(nil).SetKeyPair), arg0, arg1, arg2, arg3)
+}
+
+// SetKeyParams mocks base method.
+func (m *MockUsersStorage) SetKeyParams(arg0 context.Context, arg1 string, arg2 entities.KeyParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKeyParams", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKeyParams indicates an expected call of SetKeyParams.
+func (mr *MockUsersStorageMockRecorder) SetKeyParams(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeyParams", reflect.TypeOf((*MockUsersStorage)(nil).SetKeyParams), arg0, arg1, arg2)
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
@@ -15,6 +17,13 @@ type PgxPoolDB struct {
 	Pool *pgxpool.Pool
 }
 
+// Querier is implemented by both the pool and a transaction
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
 func NewPgxPoolDB(ctx context.Context, dsn, migrationsPath string) (*PgxPoolDB, error) {
 	db := &PgxPoolDB{}
 	if dsn == "" {
@@ -38,6 +47,15 @@ func NewPgxPoolDB(ctx context.Context, dsn, migrationsPath string) (*PgxPoolDB,
 	return db, nil
 }
 
+// Conn returns the transaction started by PgxPoolTransaction if ctx carries
+// one and the pool otherwise
+func (db *PgxPoolDB) Conn(ctx context.Context) Querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return db.Pool
+}
+
 func migrate(pool *pgxpool.Pool, migrationsPath string) error {
 	migrationsPath, err := filepath.Abs(migrationsPath)
 	if err != nil {
@@ -3,9 +3,12 @@ package database
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+type txKey struct{}
+
 type PgxPoolTransaction struct {
 	db *pgxpool.Pool
 }
@@ -14,7 +17,13 @@ func NewPgxTransaction(db *pgxpool.Pool) *PgxPoolTransaction {
 	return &PgxPoolTransaction{db: db}
 }
 
-func (t *PgxPoolTransaction) Transaction(ctx context.Context, f func() error) error {
+// Transaction runs f with a context that carries the transaction, queries
+// made through PgxPoolDB.Conn with this context are part of it. A nested call
+// joins the outer transaction.
+func (t *PgxPoolTransaction) Transaction(ctx context.Context, f func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return f(ctx)
+	}
 	tx, err := t.db.Begin(ctx)
 	if err != nil {
 		return err
@@ -22,7 +31,7 @@ func (t *PgxPoolTransaction) Transaction(ctx context.Context, f func() error) er
 
 	defer tx.Rollback(ctx)
 
-	err = f()
+	err = f(context.WithValue(ctx, txKey{}, tx))
 	if err != nil {
 		return err
 	}
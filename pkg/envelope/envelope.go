@@ -0,0 +1,123 @@
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+const KeySize = 32
+
+var ErrUnknownKEK = errors.New("envelope: unknown key encryption key")
+
+type Keyring struct {
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+func NewKeyring(primaryID, primaryKey string) (*Keyring, error) {
+	k := &Keyring{keys: make(map[string]cipher.AEAD)}
+	if err := k.Add(primaryID, primaryKey); err != nil {
+		return nil, err
+	}
+	k.primary = primaryID
+	return k, nil
+}
+
+func (k *Keyring) Add(id, key string) error {
+	if id == "" {
+		return errors.New("envelope: empty key encryption key id")
+	}
+	if key == "" {
+		return fmt.Errorf("envelope: key encryption key %s is not set", id)
+	}
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return fmt.Errorf("envelope: failed to decode key encryption key %s: %v", id, err)
+	}
+	if len(raw) != KeySize {
+		return fmt.Errorf("envelope: key encryption key %s must be %d bytes", id, KeySize)
+	}
+	aead, err := newAEAD(raw)
+	if err != nil {
+		return err
+	}
+	k.keys[id] = aead
+	return nil
+}
+
+func (k *Keyring) PrimaryID() string {
+	return k.primary
+}
+
+func (k *Keyring) Has(id string) bool {
+	_, ok := k.keys[id]
+	return ok
+}
+
+func (k *Keyring) Wrap(kekID string, dek []byte) ([]byte, error) {
+	aead, ok := k.keys[kekID]
+	if !ok {
+		return nil, ErrUnknownKEK
+	}
+	return seal(aead, dek, []byte(kekID))
+}
+
+func (k *Keyring) Unwrap(kekID string, wrapped []byte) ([]byte, error) {
+	aead, ok := k.keys[kekID]
+	if !ok {
+		return nil, ErrUnknownKEK
+	}
+	return open(aead, wrapped, []byte(kekID))
+}
+
+func NewDataKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func Seal(key, plaintext, ad []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return seal(aead, plaintext, ad)
+}
+
+func Open(key, ciphertext, ad []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return open(aead, ciphertext, ad)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, plaintext, ad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, ad), nil
+}
+
+func open(aead cipher.AEAD, ciphertext, ad []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("envelope: ciphertext too short")
+	}
+	nonce, ct := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, ct, ad)
+}
@@ -0,0 +1,126 @@
+package envelope
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKEK(t *testing.T) string {
+	key, err := NewDataKey()
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func TestKeyring_Add(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		key     string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "key",
+			id:      "primary",
+			key:     newKEK(t),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "empty key",
+			id:      "primary",
+			key:     "",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "empty id",
+			id:      "",
+			key:     newKEK(t),
+			wantErr: assert.Error,
+		},
+		{
+			name:    "not base64",
+			id:      "primary",
+			key:     "not base64!",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "short key",
+			id:      "primary",
+			key:     base64.StdEncoding.EncodeToString([]byte("short key")),
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeyring(tt.id, tt.key)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestKeyring_WrapUnwrap(t *testing.T) {
+	kek := newKEK(t)
+	keyring, err := NewKeyring("primary", kek)
+	require.NoError(t, err)
+	require.NoError(t, keyring.Add("retired", newKEK(t)))
+	assert.Equal(t, "primary", keyring.PrimaryID())
+	assert.True(t, keyring.Has("retired"))
+	assert.False(t, keyring.Has("unknown"))
+
+	dek, err := NewDataKey()
+	require.NoError(t, err)
+	for _, id := range []string{"primary", "retired"} {
+		wrapped, err := keyring.Wrap(id, dek)
+		require.NoError(t, err)
+		unwrapped, err := keyring.Unwrap(id, wrapped)
+		assert.NoError(t, err)
+		assert.Equal(t, dek, unwrapped)
+	}
+
+	wrapped, err := keyring.Wrap("primary", dek)
+	require.NoError(t, err)
+	_, err = keyring.Unwrap("retired", wrapped)
+	assert.Error(t, err)
+	_, err = keyring.Unwrap("unknown", wrapped)
+	assert.ErrorIs(t, err, ErrUnknownKEK)
+	_, err = keyring.Wrap("unknown", dek)
+	assert.ErrorIs(t, err, ErrUnknownKEK)
+
+	// the id is bound to the wrapped key, the same key under another id does
+	// not unwrap it
+	renamed, err := NewKeyring("renamed", kek)
+	require.NoError(t, err)
+	_, err = renamed.Unwrap("renamed", wrapped)
+	assert.Error(t, err)
+}
+
+func TestSealOpen(t *testing.T) {
+	key, err := NewDataKey()
+	require.NoError(t, err)
+
+	sealed, err := Seal(key, []byte("Lorem ipsum..."), []byte("ad"))
+	require.NoError(t, err)
+	opened, err := Open(key, sealed, []byte("ad"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("Lorem ipsum..."), opened)
+
+	_, err = Open(key, sealed, []byte("another ad"))
+	assert.Error(t, err)
+
+	other, err := NewDataKey()
+	require.NoError(t, err)
+	_, err = Open(other, sealed, []byte("ad"))
+	assert.Error(t, err)
+
+	sealed[len(sealed)-1] ^= 1
+	_, err = Open(key, sealed, []byte("ad"))
+	assert.Error(t, err)
+
+	_, err = Open(key, []byte("short"), nil)
+	assert.Error(t, err)
+
+	_, err = Seal([]byte("short key"), []byte("Lorem ipsum..."), nil)
+	assert.Error(t, err)
+}
@@ -14,6 +14,18 @@ func (i *InvalidArgumentError) Error() string {
 	return fmt.Sprintf("%v", i.Err)
 }
 
+type ConflictError struct {
+	Err error
+}
+
+func NewConflictError(err error) error {
+	return &ConflictError{Err: err}
+}
+
+func (i *ConflictError) Error() string {
+	return fmt.Sprintf("%v", i.Err)
+}
+
 type DomainError struct {
 	Err error
 }
@@ -26,6 +38,10 @@ func (i *DomainError) Error() string {
 	return fmt.Sprintf("%v", i.Err)
 }
 
+func (i *DomainError) Unwrap() error {
+	return i.Err
+}
+
 type AuthError struct {
 	Err error
 }
@@ -37,3 +53,15 @@ func NewAuthError(err error) error {
 func (i *AuthError) Error() string {
 	return fmt.Sprintf("%v", i.Err)
 }
+
+type PermissionDeniedError struct {
+	Err error
+}
+
+func NewPermissionDeniedError(err error) error {
+	return &PermissionDeniedError{Err: err}
+}
+
+func (i *PermissionDeniedError) Error() string {
+	return fmt.Sprintf("%v", i.Err)
+}
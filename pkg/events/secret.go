@@ -1,21 +1,38 @@
 package events
 
+import "time"
+
 const (
 	TypeCreated = iota + 1
 	TypeUpdated
 	TypeDeleted
 )
 
+// SecretEvent carries a single secret or, for batch operations, all the
+// secrets of the batch in Secrets
 type SecretEvent struct {
-	EventType int
-	Secret    *SecretDTO
+	EventType  int
+	Secret     *SecretDTO
+	Secrets    []*SecretDTO `json:",omitempty"`
+	FetchHint  bool
+	Recipients []string `json:"-"`
 }
 
 type SecretDTO struct {
-	ID         string
-	Name       string
-	SecretType uint32
-	Notes      string
-	Data       []byte
-	UserID     string
+	ID             string
+	Name           string
+	SecretType     uint32
+	Notes          string
+	Data           []byte
+	UserID         string
+	Size           int64
+	Version        int64
+	Owner          string
+	Permission     uint32
+	WrappedKey     []byte
+	CollectionID   string
+	OrganizationID string
+	FolderID       string
+	Tags           []string
+	UpdatedAt      time.Time
 }
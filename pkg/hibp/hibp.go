@@ -0,0 +1,266 @@
+package hibp
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The index is the magic header followed by records of a SHA-1 hash and
+// a big-endian occurrence count, sorted by hash
+const (
+	prefixLength = 5
+	countSize    = 4
+	recordSize   = sha1.Size + countSize
+)
+
+var magic = []byte("GKHIBP01")
+
+var (
+	ErrNotSorted = errors.New("hibp: corpus is not sorted by hash")
+	ErrBadIndex  = errors.New("hibp: not a breach index")
+)
+
+// BuildIndex converts a corpus into an index at dst and returns the number of
+// hashes. src is either a directory of range files named by the hash prefix,
+// as the k-anonymity API serves them (ABCDE or ABCDE.txt with "SUFFIX:COUNT"
+// lines), or a single file of "HASH:COUNT" lines ordered by hash
+func BuildIndex(src, dst string) (int64, error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		return 0, err
+	}
+	files := []string{src}
+	prefixes := []string{""}
+	if info.IsDir() {
+		files, prefixes, err = rangeFiles(src)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	tmp := dst + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp)
+	w := bufio.NewWriterSize(out, 1<<20)
+	_, err = w.Write(magic)
+	if err != nil {
+		out.Close()
+		return 0, err
+	}
+
+	var last []byte
+	var total int64
+	for i, file := range files {
+		n, err := appendRecords(w, file, prefixes[i], &last)
+		total += n
+		if err != nil {
+			out.Close()
+			return 0, err
+		}
+	}
+	err = w.Flush()
+	if err != nil {
+		out.Close()
+		return 0, err
+	}
+	err = out.Close()
+	if err != nil {
+		return 0, err
+	}
+	return total, os.Rename(tmp, dst)
+}
+
+func rangeFiles(dir string) ([]string, []string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	files := make([]string, 0, len(entries))
+	prefixes := make([]string, 0, len(entries))
+	for _, e := range entries {
+		prefix := strings.ToUpper(strings.TrimSuffix(e.Name(), ".txt"))
+		if e.IsDir() || !isHex(prefix, prefixLength) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, e.Name()))
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Sort(byPrefix{files, prefixes})
+	return files, prefixes, nil
+}
+
+func appendRecords(w io.Writer, file, prefix string, last *[]byte) (int64, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var n int64
+	record := make([]byte, recordSize)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		hash, count, ok := strings.Cut(text, ":")
+		hash = prefix + hash
+		if !ok || !isHex(hash, sha1.Size*2) {
+			return n, fmt.Errorf("hibp: %s:%d: malformed line %q", file, line, text)
+		}
+		c, err := strconv.ParseUint(count, 10, 32)
+		if err != nil {
+			return n, fmt.Errorf("hibp: %s:%d: malformed count: %v", file, line, err)
+		}
+		// the API pads responses with zero counts
+		if c == 0 {
+			continue
+		}
+		_, err = hex.Decode(record[:sha1.Size], []byte(hash))
+		if err != nil {
+			return n, err
+		}
+		if *last != nil && bytes.Compare(record[:sha1.Size], *last) <= 0 {
+			return n, fmt.Errorf("%w: %s:%d", ErrNotSorted, file, line)
+		}
+		binary.BigEndian.PutUint32(record[sha1.Size:], uint32(c))
+		_, err = w.Write(record)
+		if err != nil {
+			return n, err
+		}
+		*last = append((*last)[:0], record[:sha1.Size]...)
+		n++
+	}
+	return n, scanner.Err()
+}
+
+// Index looks hashes up with a binary search over the file, so only a few
+// pages of it are read per password
+type Index struct {
+	f *os.File
+	n int64
+}
+
+func Open(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	header := make([]byte, len(magic))
+	_, err = io.ReadFull(f, header)
+	size := info.Size() - int64(len(magic))
+	if err != nil || !bytes.Equal(header, magic) || size%recordSize != 0 {
+		f.Close()
+		return nil, ErrBadIndex
+	}
+	return &Index{f: f, n: size / recordSize}, nil
+}
+
+// Len is the number of hashes in the index
+func (i *Index) Len() int64 {
+	return i.n
+}
+
+// Count returns how many times the password was seen in breaches
+func (i *Index) Count(password string) (uint32, error) {
+	hash := sha1.Sum([]byte(password))
+	return i.Lookup(hash)
+}
+
+func (i *Index) Lookup(hash [sha1.Size]byte) (uint32, error) {
+	record := make([]byte, recordSize)
+	lo, hi := int64(0), i.n
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		_, err := i.f.ReadAt(record, int64(len(magic))+mid*recordSize)
+		if err != nil {
+			return 0, err
+		}
+		switch bytes.Compare(record[:sha1.Size], hash[:]) {
+		case 0:
+			return binary.BigEndian.Uint32(record[sha1.Size:]), nil
+		case -1:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+	return 0, nil
+}
+
+func (i *Index) Close() error {
+	return i.f.Close()
+}
+
+// Checker opens the index for every batch, so a rebuilt index is picked up
+// without restarting the client
+type Checker struct {
+	path string
+}
+
+func NewChecker(path string) *Checker {
+	return &Checker{
+		path: path,
+	}
+}
+
+// Check returns the breach counts in the order of passwords
+func (c *Checker) Check(passwords []string) ([]uint32, error) {
+	index, err := Open(c.path)
+	if err != nil {
+		return nil, err
+	}
+	defer index.Close()
+	counts := make([]uint32, len(passwords))
+	for k, p := range passwords {
+		counts[k], err = index.Count(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return counts, nil
+}
+
+func isHex(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
+type byPrefix struct {
+	files    []string
+	prefixes []string
+}
+
+func (b byPrefix) Len() int           { return len(b.files) }
+func (b byPrefix) Less(i, j int) bool { return b.prefixes[i] < b.prefixes[j] }
+func (b byPrefix) Swap(i, j int) {
+	b.files[i], b.files[j] = b.files[j], b.files[i]
+	b.prefixes[i], b.prefixes[j] = b.prefixes[j], b.prefixes[i]
+}
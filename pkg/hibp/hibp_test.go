@@ -0,0 +1,111 @@
+package hibp
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hash(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func TestBuildIndex_rangeFiles(t *testing.T) {
+	dir := t.TempDir()
+	corpus := filepath.Join(dir, "ranges")
+	require.NoError(t, os.Mkdir(corpus, 0700))
+
+	// password -> 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	require.NoError(t, os.WriteFile(filepath.Join(corpus, "5BAA6.txt"), []byte(
+		"003D68EB55068C33ACE09247EE4C639306B:3\r\n"+
+			"1E4C9B93F3F0682250B6CF8331B7EE68FD8:9659365\r\n"+
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:0\r\n",
+	), 0600))
+	// 123456 -> 7C4A8D09CA3762AF61E59520943DC26494F8941B
+	require.NoError(t, os.WriteFile(filepath.Join(corpus, "7C4A8"), []byte(
+		"D09CA3762AF61E59520943DC26494F8941B:37359195\n",
+	), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(corpus, "README"), []byte("not a range"), 0600))
+
+	dst := filepath.Join(dir, "breaches.idx")
+	n, err := BuildIndex(corpus, dst)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	index, err := Open(dst)
+	require.NoError(t, err)
+	defer index.Close()
+	assert.Equal(t, int64(3), index.Len())
+
+	tests := []struct {
+		password string
+		want     uint32
+	}{
+		{"password", 9659365},
+		{"123456", 37359195},
+		{"Xv7#pQ2!mZ9$kL4w", 0},
+	}
+	for _, tt := range tests {
+		got, err := index.Count(tt.password)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt.password)
+	}
+
+	counts, err := NewChecker(dst).Check([]string{"123456", "Xv7#pQ2!mZ9$kL4w"})
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{37359195, 0}, counts)
+}
+
+func TestBuildIndex_singleFile(t *testing.T) {
+	dir := t.TempDir()
+	passwords := []string{"qwerty", "letmein", "dragon", "monkey", "sunshine", "iloveyou", "trustno1"}
+	hashes := make([]string, 0, len(passwords))
+	for _, p := range passwords {
+		hashes = append(hashes, hash(p)+":1")
+	}
+
+	unsorted := filepath.Join(dir, "unsorted.txt")
+	require.NoError(t, os.WriteFile(unsorted, []byte(strings.Join(hashes, "\n")), 0600))
+	_, err := BuildIndex(unsorted, filepath.Join(dir, "unsorted.idx"))
+	assert.ErrorIs(t, err, ErrNotSorted)
+	_, err = os.Stat(filepath.Join(dir, "unsorted.idx"))
+	assert.True(t, os.IsNotExist(err))
+
+	sorted := filepath.Join(dir, "sorted.txt")
+	sort.Strings(hashes)
+	require.NoError(t, os.WriteFile(sorted, []byte(strings.Join(hashes, "\n")), 0600))
+	dst := filepath.Join(dir, "sorted.idx")
+	_, err = BuildIndex(sorted, dst)
+	require.NoError(t, err)
+
+	index, err := Open(dst)
+	require.NoError(t, err)
+	defer index.Close()
+	for _, p := range passwords {
+		got, err := index.Count(p)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), got, p)
+	}
+	got, err := index.Count("correct horse battery staple")
+	require.NoError(t, err)
+	assert.Zero(t, got)
+}
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Open(filepath.Join(dir, "missing.idx"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	bad := filepath.Join(dir, "bad.idx")
+	require.NoError(t, os.WriteFile(bad, []byte("5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:1"), 0600))
+	_, err = Open(bad)
+	assert.ErrorIs(t, err, ErrBadIndex)
+}
@@ -0,0 +1,163 @@
+package otp
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xlzd/gotp"
+)
+
+const (
+	AlgorithmSHA1 = iota
+	AlgorithmSHA256
+	AlgorithmSHA512
+)
+
+const (
+	DefaultDigits = 6
+	DefaultPeriod = 30
+	MinDigits     = 6
+	MaxDigits     = 8
+	MaxPeriod     = 300
+)
+
+var ErrInvalidSecret = errors.New("некорректный секрет, ожидается строка в base32")
+
+type TOTP struct {
+	Secret    string
+	Issuer    string
+	Account   string
+	Digits    uint32
+	Period    uint32
+	Algorithm uint32
+}
+
+// NormalizeSecret strips spaces and padding authenticator apps use to group the key
+func NormalizeSecret(secret string) string {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	return strings.TrimRight(secret, "=")
+}
+
+func ValidateSecret(secret string) error {
+	secret = NormalizeSecret(secret)
+	if secret == "" || !gotp.IsSecretValid(secret) {
+		return ErrInvalidSecret
+	}
+	return nil
+}
+
+func (t *TOTP) Validate() error {
+	if err := ValidateSecret(t.Secret); err != nil {
+		return err
+	}
+	if t.Digits < MinDigits || t.Digits > MaxDigits {
+		return fmt.Errorf("количество цифр кода должно быть от %d до %d", MinDigits, MaxDigits)
+	}
+	if t.Period == 0 || t.Period > MaxPeriod {
+		return fmt.Errorf("период действия кода должен быть от 1 до %d секунд", MaxPeriod)
+	}
+	if t.Algorithm > AlgorithmSHA512 {
+		return errors.New("неизвестный алгоритм")
+	}
+	return nil
+}
+
+// Code returns the code valid at the moment and the time left until it changes
+func (t *TOTP) Code(now time.Time) (string, time.Duration, error) {
+	if err := t.Validate(); err != nil {
+		return "", 0, err
+	}
+	gen := gotp.NewTOTP(NormalizeSecret(t.Secret), int(t.Digits), int(t.Period), hasher(t.Algorithm))
+	period := int64(t.Period)
+	left := period - now.Unix()%period
+	return gen.At(now.Unix()), time.Duration(left) * time.Second, nil
+}
+
+// ParseURI reads otpauth://totp/Issuer:account?secret=...&issuer=... URIs
+// exported by authenticator apps and QR codes
+func ParseURI(uri string) (*TOTP, error) {
+	u, err := url.Parse(strings.TrimSpace(uri))
+	if err != nil {
+		return nil, fmt.Errorf("некорректная ссылка: %v", err)
+	}
+	if u.Scheme != "otpauth" {
+		return nil, errors.New("ожидается ссылка вида otpauth://totp/...")
+	}
+	if u.Host != "totp" {
+		return nil, fmt.Errorf("неподдерживаемый тип одноразовых паролей: %s", u.Host)
+	}
+	q := u.Query()
+	t := &TOTP{
+		Secret: NormalizeSecret(q.Get("secret")),
+		Issuer: q.Get("issuer"),
+		Digits: DefaultDigits,
+		Period: DefaultPeriod,
+	}
+	label := strings.TrimPrefix(u.Path, "/")
+	if issuer, account, ok := strings.Cut(label, ":"); ok {
+		if t.Issuer == "" {
+			t.Issuer = strings.TrimSpace(issuer)
+		}
+		label = account
+	}
+	t.Account = strings.TrimSpace(label)
+	if v := q.Get("digits"); v != "" {
+		d, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("некорректное количество цифр: %s", v)
+		}
+		t.Digits = uint32(d)
+	}
+	if v := q.Get("period"); v != "" {
+		p, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("некорректный период: %s", v)
+		}
+		t.Period = uint32(p)
+	}
+	if v := q.Get("algorithm"); v != "" {
+		switch strings.ToUpper(v) {
+		case "SHA1":
+			t.Algorithm = AlgorithmSHA1
+		case "SHA256":
+			t.Algorithm = AlgorithmSHA256
+		case "SHA512":
+			t.Algorithm = AlgorithmSHA512
+		default:
+			return nil, fmt.Errorf("неизвестный алгоритм: %s", v)
+		}
+	}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func AlgorithmName(algorithm uint32) string {
+	switch algorithm {
+	case AlgorithmSHA256:
+		return "SHA256"
+	case AlgorithmSHA512:
+		return "SHA512"
+	default:
+		return "SHA1"
+	}
+}
+
+func hasher(algorithm uint32) *gotp.Hasher {
+	switch algorithm {
+	case AlgorithmSHA256:
+		return &gotp.Hasher{HashName: "sha256", Digest: sha256.New}
+	case AlgorithmSHA512:
+		return &gotp.Hasher{HashName: "sha512", Digest: sha512.New}
+	default:
+		return &gotp.Hasher{HashName: "sha1", Digest: sha1.New}
+	}
+}
@@ -0,0 +1,130 @@
+package otp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOTP_Code(t *testing.T) {
+	tests := []struct {
+		name     string
+		totp     TOTP
+		now      time.Time
+		wantCode string
+		wantLeft time.Duration
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "rfc 6238 sha1",
+			totp:     TOTP{Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", Digits: 8, Period: 30},
+			now:      time.Unix(59, 0),
+			wantCode: "94287082",
+			wantLeft: time.Second,
+			wantErr:  assert.NoError,
+		},
+		{
+			name:     "rfc 6238 sha256",
+			totp:     TOTP{Secret: "gezd gnbv gy3t qojq gezd gnbv gy3t qojq gezd gnbv gy3t qojq geza====", Digits: 8, Period: 30, Algorithm: AlgorithmSHA256},
+			now:      time.Unix(1111111109, 0),
+			wantCode: "68084774",
+			wantLeft: time.Second,
+			wantErr:  assert.NoError,
+		},
+		{
+			name:     "six digits",
+			totp:     TOTP{Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", Digits: 6, Period: 30},
+			now:      time.Unix(1111111111, 0),
+			wantCode: "050471",
+			wantLeft: 29 * time.Second,
+			wantErr:  assert.NoError,
+		},
+		{
+			name:    "invalid secret",
+			totp:    TOTP{Secret: "not base32!", Digits: 6, Period: 30},
+			now:     time.Unix(59, 0),
+			wantErr: assert.Error,
+		},
+		{
+			name:    "invalid digits",
+			totp:    TOTP{Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", Digits: 10, Period: 30},
+			now:     time.Unix(59, 0),
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, left, err := tt.totp.Code(tt.now)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.wantLeft, left)
+		})
+	}
+}
+
+func TestParseURI(t *testing.T) {
+	tests := []struct {
+		name    string
+		uri     string
+		want    *TOTP
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "defaults",
+			uri:  "otpauth://totp/GitHub:john%40zzz.com?secret=JBSWY3DPEHPK3PXP",
+			want: &TOTP{
+				Secret:  "JBSWY3DPEHPK3PXP",
+				Issuer:  "GitHub",
+				Account: "john@zzz.com",
+				Digits:  DefaultDigits,
+				Period:  DefaultPeriod,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "all params",
+			uri:  "otpauth://totp/john?secret=jbswy3dpehpk3pxp&issuer=Example&digits=8&period=60&algorithm=SHA512",
+			want: &TOTP{
+				Secret:    "JBSWY3DPEHPK3PXP",
+				Issuer:    "Example",
+				Account:   "john",
+				Digits:    8,
+				Period:    60,
+				Algorithm: AlgorithmSHA512,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "hotp",
+			uri:     "otpauth://hotp/john?secret=JBSWY3DPEHPK3PXP&counter=1",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "wrong scheme",
+			uri:     "https://zzz.com/?secret=JBSWY3DPEHPK3PXP",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "missing secret",
+			uri:     "otpauth://totp/john",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "unknown algorithm",
+			uri:     "otpauth://totp/john?secret=JBSWY3DPEHPK3PXP&algorithm=MD5",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseURI(tt.uri)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
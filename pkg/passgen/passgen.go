@@ -0,0 +1,246 @@
+package passgen
+
+import (
+	"bufio"
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	LowerChars   = "abcdefghijklmnopqrstuvwxyz"
+	UpperChars   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	DigitChars   = "0123456789"
+	SymbolChars  = "!@#$%^&*()-_=+[]{};:,.<>?/~"
+	vowels       = "aeiou"
+	consonants   = "bcdfghjkmnprstvz"
+	ambiguous    = "Il1O0"
+	DefaultWords = 6
+	MinWords     = 3
+	MaxWords     = 20
+	MinLength    = 8
+	MaxLength    = 128
+)
+
+// eff_large_wordlist.txt is the EFF large wordlist for passphrases,
+// https://www.eff.org/dice, licensed under CC BY 3.0
+//
+//go:embed eff_large_wordlist.txt
+var wordlistFile string
+
+var wordlist = sync.OnceValue(func() []string {
+	words := make([]string, 0, 7776)
+	scanner := bufio.NewScanner(strings.NewReader(wordlistFile))
+	for scanner.Scan() {
+		_, word, ok := strings.Cut(scanner.Text(), "\t")
+		if ok {
+			words = append(words, word)
+		}
+	}
+	return words
+})
+
+var ErrEmptyPolicy = errors.New("выберите хотя бы один набор символов")
+
+// Policy describes the character classes of a random password
+type Policy struct {
+	Length           int
+	Lower            bool
+	Upper            bool
+	Digits           bool
+	Symbols          bool
+	ExcludeAmbiguous bool
+}
+
+func DefaultPolicy() Policy {
+	return Policy{Length: 20, Lower: true, Upper: true, Digits: true, Symbols: true}
+}
+
+func (p Policy) classes() []string {
+	classes := make([]string, 0, 4)
+	for _, c := range []struct {
+		on    bool
+		chars string
+	}{
+		{p.Lower, LowerChars},
+		{p.Upper, UpperChars},
+		{p.Digits, DigitChars},
+		{p.Symbols, SymbolChars},
+	} {
+		if !c.on {
+			continue
+		}
+		chars := c.chars
+		if p.ExcludeAmbiguous {
+			chars = strings.Map(func(r rune) rune {
+				if strings.ContainsRune(ambiguous, r) {
+					return -1
+				}
+				return r
+			}, chars)
+		}
+		classes = append(classes, chars)
+	}
+	return classes
+}
+
+func (p Policy) Validate() error {
+	if p.Length < MinLength || p.Length > MaxLength {
+		return fmt.Errorf("длина пароля должна быть от %d до %d символов", MinLength, MaxLength)
+	}
+	if len(p.classes()) == 0 {
+		return ErrEmptyPolicy
+	}
+	return nil
+}
+
+// Random returns a password with at least one character of every class of the policy
+func Random(p Policy) (string, error) {
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+	classes := p.classes()
+	pool := strings.Join(classes, "")
+	result := make([]byte, 0, p.Length)
+	for _, class := range classes {
+		c, err := pick(class)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, c)
+	}
+	for len(result) < p.Length {
+		c, err := pick(pool)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, c)
+	}
+	err := shuffle(result)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
+
+// Pronounceable alternates consonants and vowels, so the password is easy
+// to read out and type
+func Pronounceable(length int) (string, error) {
+	if length < MinLength || length > MaxLength {
+		return "", fmt.Errorf("длина пароля должна быть от %d до %d символов", MinLength, MaxLength)
+	}
+	result := make([]byte, length)
+	for i := range result {
+		chars := consonants
+		if i%2 == 1 {
+			chars = vowels
+		}
+		c, err := pick(chars)
+		if err != nil {
+			return "", err
+		}
+		result[i] = c
+	}
+	return string(result), nil
+}
+
+// Passphrase picks words from the EFF large wordlist
+func Passphrase(words int, separator string) (string, error) {
+	if words < MinWords || words > MaxWords {
+		return "", fmt.Errorf("количество слов должно быть от %d до %d", MinWords, MaxWords)
+	}
+	list := wordlist()
+	result := make([]string, words)
+	for i := range result {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(list))))
+		if err != nil {
+			return "", err
+		}
+		result[i] = list[n.Int64()]
+	}
+	return strings.Join(result, separator), nil
+}
+
+func RandomEntropy(p Policy) float64 {
+	return float64(p.Length) * math.Log2(float64(len(strings.Join(p.classes(), ""))))
+}
+
+func PronounceableEntropy(length int) float64 {
+	pairs := float64(length / 2)
+	bits := pairs * (math.Log2(float64(len(consonants))) + math.Log2(float64(len(vowels))))
+	if length%2 == 1 {
+		bits += math.Log2(float64(len(consonants)))
+	}
+	return bits
+}
+
+func PassphraseEntropy(words int) float64 {
+	return float64(words) * math.Log2(float64(len(wordlist())))
+}
+
+// Estimate rates a password of unknown origin by its length and the character
+// classes it uses, it is an upper bound for human-made passwords
+func Estimate(password string) float64 {
+	var lower, upper, digit, symbol, other bool
+	length := 0
+	for _, r := range password {
+		length++
+		switch {
+		case r < unicode.MaxASCII && unicode.IsLower(r):
+			lower = true
+		case r < unicode.MaxASCII && unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+	pool := 0
+	for _, c := range []struct {
+		used bool
+		size int
+	}{
+		{lower, len(LowerChars)},
+		{upper, len(UpperChars)},
+		{digit, len(DigitChars)},
+		{symbol, 33},
+		{other, 100},
+	} {
+		if c.used {
+			pool += c.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+	return float64(length) * math.Log2(float64(pool))
+}
+
+func pick(chars string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, err
+	}
+	return chars[n.Int64()], nil
+}
+
+func shuffle(b []byte) error {
+	for i := len(b) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return err
+		}
+		j := n.Int64()
+		b[i], b[j] = b[j], b[i]
+	}
+	return nil
+}
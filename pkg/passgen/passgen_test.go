@@ -0,0 +1,115 @@
+package passgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandom(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  Policy
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "default",
+			policy:  DefaultPolicy(),
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "digits only without ambiguous",
+			policy:  Policy{Length: 12, Digits: true, ExcludeAmbiguous: true},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "too short",
+			policy:  Policy{Length: 4, Lower: true},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "no classes",
+			policy:  Policy{Length: 12},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Random(tt.policy)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+			assert.Len(t, got, tt.policy.Length)
+			for _, class := range tt.policy.classes() {
+				assert.True(t, strings.ContainsAny(got, class), "missing class %q in %q", class, got)
+			}
+			if tt.policy.ExcludeAmbiguous {
+				assert.False(t, strings.ContainsAny(got, ambiguous))
+			}
+		})
+	}
+}
+
+func TestPronounceable(t *testing.T) {
+	got, err := Pronounceable(11)
+	require.NoError(t, err)
+	assert.Len(t, got, 11)
+	for i, c := range got {
+		if i%2 == 1 {
+			assert.Contains(t, vowels, string(c))
+		} else {
+			assert.Contains(t, consonants, string(c))
+		}
+	}
+
+	_, err = Pronounceable(MaxLength + 1)
+	assert.Error(t, err)
+}
+
+func TestPassphrase(t *testing.T) {
+	assert.Len(t, wordlist(), 7776)
+
+	got, err := Passphrase(5, "-")
+	require.NoError(t, err)
+	words := strings.Split(got, "-")
+	assert.Len(t, words, 5)
+	for _, w := range words {
+		assert.Contains(t, wordlist(), w)
+	}
+
+	_, err = Passphrase(2, "-")
+	assert.Error(t, err)
+}
+
+func TestEntropy(t *testing.T) {
+	assert.InDelta(t, 77.5, PassphraseEntropy(6), 0.1)
+	assert.InDelta(t, 47.6, RandomEntropy(Policy{Length: 8, Lower: true, Upper: true, Digits: true}), 0.1)
+	assert.InDelta(t, 25.3, PronounceableEntropy(8), 0.1)
+
+	assert.Equal(t, 0.0, Estimate(""))
+	assert.InDelta(t, 37.6, Estimate("password"), 0.1)
+	assert.Greater(t, Estimate("Tr0ub4dor&3"), Estimate("troubadour3"))
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		password string
+		want     int
+	}{
+		{"password", ScoreVeryWeak},
+		{"P@ssw0rd!", ScoreVeryWeak},
+		{"qwerty123", ScoreVeryWeak},
+		{"aaaaaaaaaaaa", ScoreVeryWeak},
+		{"abcdefgh12", ScoreVeryWeak},
+		{"gh7Kq2", ScoreWeak},
+		{"Tr0ub4dor&3", ScoreVeryStrong},
+		{"correct-horse-battery-staple", ScoreVeryStrong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			assert.Equal(t, tt.want, Score(tt.password))
+		})
+	}
+}
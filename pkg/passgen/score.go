@@ -0,0 +1,157 @@
+package passgen
+
+import (
+	_ "embed"
+	"math"
+	"strings"
+	"sync"
+)
+
+const (
+	ScoreVeryWeak = iota
+	ScoreWeak
+	ScoreFair
+	ScoreStrong
+	ScoreVeryStrong
+)
+
+// minPatternLength is the length from which repeats, sequences and keyboard
+// walks are treated as a single guess
+const minPatternLength = 3
+
+var keyboardRows = []string{
+	"1234567890-=",
+	"qwertyuiop[]",
+	"asdfghjkl;'",
+	"zxcvbnm,./",
+	"йцукенгшщзхъ",
+	"фывапролджэ",
+	"ячсмитьбю",
+}
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+var commonPasswords = sync.OnceValue(func() map[string]struct{} {
+	result := make(map[string]struct{})
+	for _, p := range strings.Fields(commonPasswordsFile) {
+		result[p] = struct{}{}
+	}
+	return result
+})
+
+var leet = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s")
+
+// Score rates a password from ScoreVeryWeak to ScoreVeryStrong in the spirit
+// of zxcvbn: common passwords are rejected, repeats, sequences and keyboard
+// walks are worth much less than the same number of random characters
+func Score(password string) int {
+	if isCommon(password) {
+		return ScoreVeryWeak
+	}
+	bits := PatternEntropy(password)
+	switch {
+	case bits < 28:
+		return ScoreVeryWeak
+	case bits < 36:
+		return ScoreWeak
+	case bits < 50:
+		return ScoreFair
+	case bits < 64:
+		return ScoreStrong
+	default:
+		return ScoreVeryStrong
+	}
+}
+
+// PatternEntropy is Estimate with every repeat, sequence or keyboard walk
+// counted as a single character plus its length
+func PatternEntropy(password string) float64 {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return 0
+	}
+	charBits := Estimate(password) / float64(len(runes))
+	bits := 0.0
+	for i := 0; i < len(runes); {
+		n := patternLength(runes[i:])
+		if n >= minPatternLength {
+			bits += charBits + math.Log2(float64(n))
+			i += n
+			continue
+		}
+		bits += charBits
+		i++
+	}
+	return bits
+}
+
+func isCommon(password string) bool {
+	p := strings.ToLower(password)
+	common := commonPasswords()
+	for _, candidate := range []string{
+		p,
+		strings.TrimRight(p, "0123456789!@#$%^&*.?"),
+		leet.Replace(p),
+		strings.TrimRight(leet.Replace(p), "!.?"),
+	} {
+		if _, ok := common[candidate]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func patternLength(runes []rune) int {
+	best := 1
+	for _, n := range []int{repeatLength(runes), sequenceLength(runes), keyboardLength(runes)} {
+		if n > best {
+			best = n
+		}
+	}
+	return best
+}
+
+func repeatLength(runes []rune) int {
+	n := 1
+	for n < len(runes) && runes[n] == runes[0] {
+		n++
+	}
+	return n
+}
+
+func sequenceLength(runes []rune) int {
+	if len(runes) < 2 {
+		return 1
+	}
+	step := runes[1] - runes[0]
+	if step != 1 && step != -1 {
+		return 1
+	}
+	n := 2
+	for n < len(runes) && runes[n]-runes[n-1] == step {
+		n++
+	}
+	return n
+}
+
+func keyboardLength(runes []rune) int {
+	best := 1
+	lower := []rune(strings.ToLower(string(runes)))
+	for _, row := range keyboardRows {
+		keys := []rune(row)
+		for start := range keys {
+			if keys[start] != lower[0] {
+				continue
+			}
+			n := 1
+			for n < len(lower) && start+n < len(keys) && lower[n] == keys[start+n] {
+				n++
+			}
+			if n > best {
+				best = n
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,109 @@
+package sshkey
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	TypeEd25519 = "ed25519"
+	TypeRSA     = "rsa"
+
+	RSABits = 3072
+)
+
+var ErrWrongPassphrase = errors.New("неверная парольная фраза ключа")
+
+type Key struct {
+	PrivateKey string
+	PublicKey  string
+}
+
+// Generate creates a key pair in the OpenSSH format, the private key is
+// encrypted when a passphrase is given
+func Generate(keyType, comment, passphrase string) (*Key, error) {
+	var private crypto.PrivateKey
+	var err error
+	switch keyType {
+	case TypeEd25519:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	case TypeRSA:
+		private, err = rsa.GenerateKey(rand.Reader, RSABits)
+	default:
+		return nil, fmt.Errorf("unknown key type %s", keyType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %v", err)
+	}
+	var block *pem.Block
+	if passphrase == "" {
+		block, err = ssh.MarshalPrivateKey(private, comment)
+	} else {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(private, comment, []byte(passphrase))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key: %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(private)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{
+		PrivateKey: string(pem.EncodeToMemory(block)),
+		PublicKey:  AuthorizedKey(signer.PublicKey(), comment),
+	}, nil
+}
+
+// ParsePrivateKey reads a PEM encoded private key of any type supported by ssh,
+// the passphrase is only used when the key is encrypted
+func ParsePrivateKey(privateKey, passphrase string) (crypto.PrivateKey, error) {
+	key, err := ssh.ParseRawPrivateKey([]byte(privateKey))
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		if passphrase == "" {
+			return nil, ErrWrongPassphrase
+		}
+		key, err = ssh.ParseRawPrivateKeyWithPassphrase([]byte(privateKey), []byte(passphrase))
+		if errors.Is(err, x509.IncorrectPasswordError) {
+			return nil, ErrWrongPassphrase
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("некорректный приватный ключ: %v", err)
+	}
+	return key, nil
+}
+
+func NewSigner(privateKey, passphrase string) (ssh.Signer, error) {
+	key, err := ParsePrivateKey(privateKey, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return ssh.NewSignerFromKey(key)
+}
+
+// AuthorizedKey formats the key the way it is written to authorized_keys
+func AuthorizedKey(key ssh.PublicKey, comment string) string {
+	line := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+	if comment != "" {
+		line += " " + comment
+	}
+	return line
+}
+
+func Fingerprint(publicKey string) string {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return ""
+	}
+	return ssh.FingerprintSHA256(key)
+}
@@ -0,0 +1,70 @@
+package sshkey
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name       string
+		keyType    string
+		passphrase string
+		wantPrefix string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "ed25519",
+			keyType:    TypeEd25519,
+			wantPrefix: "ssh-ed25519 ",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "rsa with passphrase",
+			keyType:    TypeRSA,
+			passphrase: "secret phrase",
+			wantPrefix: "ssh-rsa ",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:    "unknown type",
+			keyType: "dsa",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := Generate(tt.keyType, "john@zzz.com", tt.passphrase)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+			assert.True(t, strings.HasPrefix(key.PublicKey, tt.wantPrefix))
+			assert.True(t, strings.HasSuffix(key.PublicKey, " john@zzz.com"))
+			assert.True(t, strings.HasPrefix(Fingerprint(key.PublicKey), "SHA256:"))
+
+			signer, err := NewSigner(key.PrivateKey, tt.passphrase)
+			require.NoError(t, err)
+			assert.Equal(t, key.PublicKey, AuthorizedKey(signer.PublicKey(), "john@zzz.com"))
+		})
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key, err := Generate(TypeEd25519, "", "secret phrase")
+	require.NoError(t, err)
+
+	_, err = ParsePrivateKey(key.PrivateKey, "")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+
+	_, err = ParsePrivateKey(key.PrivateKey, "wrong")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+
+	_, err = ParsePrivateKey(key.PrivateKey, "secret phrase")
+	assert.NoError(t, err)
+
+	_, err = ParsePrivateKey("not a key", "")
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/mail"
+	"net/url"
 	"time"
 	"unicode"
 )
@@ -49,6 +50,32 @@ func ValidateStringLength(minLength, maxLength int) func(string) error {
 	}
 }
 
+func ValidateURL() func(string) error {
+	return func(input string) error {
+		if input == "" {
+			return nil
+		}
+		_, err := url.ParseRequestURI(input)
+		if err != nil {
+			return fmt.Errorf("некорректная ссылка: %s", input)
+		}
+		return nil
+	}
+}
+
+func ValidateDate(layout string) func(string) error {
+	return func(input string) error {
+		if input == "" {
+			return nil
+		}
+		_, err := time.Parse(layout, input)
+		if err != nil {
+			return fmt.Errorf("некорректная дата: %s", input)
+		}
+		return nil
+	}
+}
+
 func ValidateCardExpiration() func(string) error {
 	return func(input string) error {
 		inputLen := len(input)
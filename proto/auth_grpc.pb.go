@@ -27,6 +27,10 @@ type AuthClient interface {
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResponse, error)
 	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
+	GetKeyParams(ctx context.Context, in *GetKeyParamsRequest, opts ...grpc.CallOption) (*GetKeyParamsResponse, error)
+	SetKeyParams(ctx context.Context, in *SetKeyParamsRequest, opts ...grpc.CallOption) (*SetKeyParamsResponse, error)
+	SetKeyPair(ctx context.Context, in *SetKeyPairRequest, opts ...grpc.CallOption) (*SetKeyPairResponse, error)
+	GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error)
 }
 
 type authClient struct {
@@ -82,6 +86,42 @@ func (c *authClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *authClient) GetKeyParams(ctx context.Context, in *GetKeyParamsRequest, opts ...grpc.CallOption) (*GetKeyParamsResponse, error) {
+	out := new(GetKeyParamsResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Auth/GetKeyParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) SetKeyParams(ctx context.Context, in *SetKeyParamsRequest, opts ...grpc.CallOption) (*SetKeyParamsResponse, error) {
+	out := new(SetKeyParamsResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Auth/SetKeyParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) SetKeyPair(ctx context.Context, in *SetKeyPairRequest, opts ...grpc.CallOption) (*SetKeyPairResponse, error) {
+	out := new(SetKeyPairResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Auth/SetKeyPair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error) {
+	out := new(GetPublicKeyResponse)
+	err := c.cc.Invoke(ctx, "/gophkeeper.Auth/GetPublicKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServer is the server API for Auth service.
 // All implementations must embed UnimplementedAuthServer
 // for forward compatibility
@@ -91,6 +131,10 @@ type AuthServer interface {
 	Login(context.Context, *LoginRequest) (*LoginResponse, error)
 	Refresh(context.Context, *RefreshRequest) (*RefreshResponse, error)
 	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	GetKeyParams(context.Context, *GetKeyParamsRequest) (*GetKeyParamsResponse, error)
+	SetKeyParams(context.Context, *SetKeyParamsRequest) (*SetKeyParamsResponse, error)
+	SetKeyPair(context.Context, *SetKeyPairRequest) (*SetKeyPairResponse, error)
+	GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error)
 	mustEmbedUnimplementedAuthServer()
 }
 
@@ -113,6 +157,18 @@ func (UnimplementedAuthServer) Refresh(context.Context, *RefreshRequest) (*Refre
 func (UnimplementedAuthServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
 }
+func (UnimplementedAuthServer) GetKeyParams(context.Context, *GetKeyParamsRequest) (*GetKeyParamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeyParams not implemented")
+}
+func (UnimplementedAuthServer) SetKeyParams(context.Context, *SetKeyParamsRequest) (*SetKeyParamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKeyParams not implemented")
+}
+func (UnimplementedAuthServer) SetKeyPair(context.Context, *SetKeyPairRequest) (*SetKeyPairResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKeyPair not implemented")
+}
+func (UnimplementedAuthServer) GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKey not implemented")
+}
 func (UnimplementedAuthServer) mustEmbedUnimplementedAuthServer() {}
 
 // UnsafeAuthServer may be embedded to opt out of forward compatibility for this service.
@@ -216,6 +272,78 @@ func _Auth_Logout_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Auth_GetKeyParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).GetKeyParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Auth/GetKeyParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).GetKeyParams(ctx, req.(*GetKeyParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_SetKeyParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKeyParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).SetKeyParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Auth/SetKeyParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).SetKeyParams(ctx, req.(*SetKeyParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_SetKeyPair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKeyPairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).SetKeyPair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Auth/SetKeyPair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).SetKeyPair(ctx, req.(*SetKeyPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gophkeeper.Auth/GetPublicKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).GetPublicKey(ctx, req.(*GetPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Auth_ServiceDesc is the grpc.ServiceDesc for Auth service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -243,6 +371,22 @@ var Auth_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Logout",
 			Handler:    _Auth_Logout_Handler,
 		},
+		{
+			MethodName: "GetKeyParams",
+			Handler:    _Auth_GetKeyParams_Handler,
+		},
+		{
+			MethodName: "SetKeyParams",
+			Handler:    _Auth_SetKeyParams_Handler,
+		},
+		{
+			MethodName: "SetKeyPair",
+			Handler:    _Auth_SetKeyPair_Handler,
+		},
+		{
+			MethodName: "GetPublicKey",
+			Handler:    _Auth_GetPublicKey_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/auth.proto",